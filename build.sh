@@ -16,12 +16,14 @@ function print_usage {
     echo -e "${YELLOW}Usage:${NC} $0 [command]"
     echo ""
     echo "Commands:"
-    echo "  all       Build both emulator and assembler (default)"
+    echo "  all       Build the emulator, assembler and g8b tool (default)"
     echo "  emulator  Build only the emulator"
     echo "  assembler Build only the assembler"
+    echo "  g8b       Build only the g8b tool"
     echo "  clean     Remove build artifacts"
     echo "  release   Build optimized release binaries"
     echo "  bench     Run benchmarks"
+    echo "  generate  Regenerate instruction tables from src/cpu/*.isa"
     echo "  test      Run tests"
     echo "  profile   Build with profiling enabled"
     echo "  help      Show this help message"
@@ -53,7 +55,7 @@ CMD=${1:-all}
 
 case "$CMD" in
     all)
-        echo -e "${GREEN}Building emulator and assembler...${NC}"
+        echo -e "${GREEN}Building emulator, assembler and g8b...${NC}"
         make all
         echo -e "${GREEN}Build successful! Binaries are in the bin/ directory.${NC}"
         ;;
@@ -67,6 +69,11 @@ case "$CMD" in
         make bin/assembler
         echo -e "${GREEN}Build successful! Binary is at bin/assembler.${NC}"
         ;;
+    g8b)
+        echo -e "${GREEN}Building g8b...${NC}"
+        make bin/g8b
+        echo -e "${GREEN}Build successful! Binary is at bin/g8b.${NC}"
+        ;;
     clean)
         echo -e "${GREEN}Cleaning build artifacts...${NC}"
         make clean
@@ -81,6 +88,10 @@ case "$CMD" in
         echo -e "${GREEN}Running benchmarks...${NC}"
         make bench
         ;;
+    generate)
+        echo -e "${GREEN}Regenerating instruction tables...${NC}"
+        make generate
+        ;;
     test)
         echo -e "${GREEN}Running tests...${NC}"
         make test
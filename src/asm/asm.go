@@ -0,0 +1,523 @@
+// Package asm assembles source code for the emulated CPUs into program images.
+package asm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// Options control how a program is assembled
+type Options struct {
+	Filename     string            // Name of the source in diagnostics; INCLUDE and INCBIN resolve relative to it
+	CPU          string            // CPU type (default: 8008)
+	Syntax       string            // Mnemonic dialect (default: the CPU's original mnemonics)
+	StartAddress uint16            // Address the program is assembled for and starts at
+	IncludePaths []string          // Directories searched for INCLUDE and INCBIN files
+	Defines      map[string]string // Predefined symbols (NAME -> expression, empty for 1)
+}
+
+// assembler holds the state of one assembly
+type assembler struct {
+	loader       *SourceLoader
+	cpuType      string
+	syntax       *cpu.Syntax
+	instructions map[byte]cpu.Instruction
+	prefixes     cpu.PrefixTables
+	set          *instructionSet
+	defines      map[string]int64
+	labels       *labelTable
+	refs         map[string][]SourceLine // Lines referring to each symbol
+	referencing  *SourceLine             // Line whose symbol references are being recorded
+	wide         map[*Statement]bool     // 6502 and 6800 statements sized with a 16-bit address in the first pass
+	diags        []Diagnostic
+}
+
+// Assemble assembles the source read from src. The program is nil when the
+// diagnostics contain errors.
+func Assemble(src io.Reader, opts Options) (*Program, []Diagnostic) {
+	if opts.Filename == "" {
+		opts.Filename = "<source>"
+	}
+	a, err := newAssembler(opts)
+	if err != nil {
+		return nil, []Diagnostic{asDiagnostic(err, opts.Filename)}
+	}
+	lines, err := a.loader.LoadReader(opts.Filename, src)
+	if err != nil {
+		return nil, []Diagnostic{asDiagnostic(err, opts.Filename)}
+	}
+	return a.assemble(lines, opts.StartAddress)
+}
+
+// AssembleFile assembles the source file at path; opts.Filename is set to path
+func AssembleFile(path string, opts Options) (*Program, []Diagnostic) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, []Diagnostic{{Message: err.Error()}}
+	}
+	defer file.Close()
+	opts.Filename = path
+	return Assemble(file, opts)
+}
+
+// newAssembler prepares the instruction set and predefined symbols
+func newAssembler(opts Options) (*assembler, error) {
+	a := &assembler{
+		loader:  NewSourceLoader(opts.IncludePaths),
+		cpuType: opts.CPU,
+		labels:  newLabelTable(),
+		refs:    make(map[string][]SourceLine),
+		wide:    make(map[*Statement]bool),
+	}
+	if a.cpuType == "" {
+		a.cpuType = "8008"
+	}
+
+	// Get CPU-specific instructions and the dialect they are written in
+	var syntaxes []*cpu.Syntax
+	switch a.cpuType {
+	case "8008":
+		a.instructions = cpu.Intel8008Instructions
+		syntaxes = cpu.Intel8008Syntaxes
+	case "8080":
+		a.instructions = cpu.Intel8080Instructions
+		syntaxes = cpu.Intel8080Syntaxes
+	case "8085":
+		a.instructions = cpu.Intel8085Instructions
+		syntaxes = cpu.Intel8085Syntaxes
+	case "6502":
+		a.instructions = cpu.MOS6502Instructions
+		syntaxes = cpu.MOS6502Syntaxes
+	case "6800":
+		a.instructions = cpu.Motorola6800Instructions
+		syntaxes = cpu.Motorola6800Syntaxes
+	case "1802":
+		a.instructions = cpu.RCA1802Instructions
+		syntaxes = cpu.RCA1802Syntaxes
+	case "z80":
+		a.instructions = cpu.Z80Instructions
+		a.prefixes = cpu.Z80PrefixTables
+		syntaxes = cpu.Z80Syntaxes
+	case "4004":
+		a.instructions = cpu.Intel4004Instructions
+		syntaxes = cpu.Intel4004Syntaxes
+	case "4040":
+		a.instructions = cpu.Intel4040Instructions
+		syntaxes = cpu.Intel4040Syntaxes
+	default:
+		return nil, fmt.Errorf("unsupported CPU type: %s (available: 8008, 8080, 8085, 6502, 6800, 1802, z80, 4004, 4040)", a.cpuType)
+	}
+
+	a.syntax = syntaxes[0]
+	if opts.Syntax != "" {
+		var err error
+		if a.syntax, err = cpu.FindSyntax(syntaxes, opts.Syntax); err != nil {
+			return nil, err
+		}
+	}
+	a.set = newInstructionSet(a.syntax, a.instructions, a.prefixes)
+
+	defines, err := parseDefines(opts.Defines)
+	if err != nil {
+		return nil, fmt.Errorf("invalid define %v", err)
+	}
+	a.defines = defines
+	return a, nil
+}
+
+// resolve finds the value of a symbol; defines take precedence over labels
+func (a *assembler) resolve(name string) (int64, bool) {
+	if value, ok := a.defines[name]; ok {
+		return value, true
+	}
+	if addr, ok := a.labels.Lookup(name); ok {
+		return int64(addr), true
+	}
+	return 0, false
+}
+
+// lookup resolves a symbol and records the reference for the current line
+func (a *assembler) lookup(name string) (int64, bool) {
+	value, ok := a.resolve(name)
+	if ok && a.referencing != nil {
+		if qualified := a.labels.Qualify(name); qualified != "" {
+			refs := a.refs[qualified]
+			if n := len(refs); n == 0 || refs[n-1] != *a.referencing {
+				a.refs[qualified] = append(refs, *a.referencing)
+			}
+		}
+	}
+	return value, ok
+}
+
+// isLabel reports whether a symbol is a program address
+func (a *assembler) isLabel(name string) bool {
+	if _, ok := a.defines[name]; ok {
+		return false
+	}
+	_, ok := a.labels.Lookup(name)
+	return ok
+}
+
+// errorf records an error at a source line
+func (a *assembler) errorf(source SourceLine, format string, args ...interface{}) {
+	a.diags = append(a.diags, errorAt(source, format, args...))
+}
+
+// statementSize returns the number of bytes a statement emits
+func (a *assembler) statementSize(stmt *Statement) (uint16, error) {
+	switch stmt.Op {
+	case "", "ORG", "INCLUDE":
+		return 0, nil
+	case "INCBIN":
+		data, err := a.incbinData(stmt)
+		return uint16(len(data)), err
+	}
+	if a.cpuType == "6502" {
+		if enc, _, err := a.match6502(stmt, nil); err == nil {
+			return uint16(enc.Instruction.Size), nil
+		}
+		return 0, nil
+	}
+	if a.cpuType == "6800" {
+		if enc, _, err := a.match6800(stmt, nil); err == nil {
+			return uint16(enc.Instruction.Size), nil
+		}
+		return 0, nil
+	}
+	if a.cpuType == "z80" {
+		if enc, err := a.matchZ80(stmt, nil); err == nil {
+			return uint16(enc.Instruction.Size), nil
+		}
+		return 0, nil
+	}
+	if enc, _, err := a.set.Match(stmt, nil); err == nil {
+		return uint16(enc.Instruction.Size), nil
+	}
+	return 0, nil
+}
+
+// assemble performs the two-pass assembly process
+func (a *assembler) assemble(lines []SourceLine, startAddress uint16) (*Program, []Diagnostic) {
+	// Parse every line into a statement
+	statements := make([]*Statement, len(lines))
+	for i, source := range lines {
+		stmt, err := parseLine(source)
+		if err != nil {
+			a.errorf(source, "%v", err)
+			stmt = &Statement{Source: source}
+		}
+		statements[i] = stmt
+	}
+
+	// First pass: collect labels and decide which lines conditional assembly keeps
+	active := make([]bool, len(statements))
+	var conditionals conditionalStack
+	currentAddress := startAddress
+	for i, stmt := range statements {
+		if isConditional(stmt.Op) {
+			if stmt.Label != "" {
+				a.errorf(stmt.Source, "label %s not allowed on %s", stmt.Label, stmt.Op)
+			}
+			a.referencing = &stmt.Source
+			if err := conditionals.Process(stmt, a.lookup); err != nil {
+				a.errorf(stmt.Source, "%v", err)
+			}
+			a.referencing = nil
+			continue
+		}
+		if !conditionals.Active() {
+			continue
+		}
+		active[i] = true
+
+		if stmt.Op == "ORG" {
+			addr, err := a.orgAddress(stmt)
+			if err != nil {
+				a.errorf(stmt.Source, "%v", err)
+			}
+			currentAddress = addr
+		}
+
+		if stmt.Label != "" {
+			if _, ok := a.defines[stmt.Label]; ok {
+				a.errorf(stmt.Source, "label %s conflicts with a defined symbol", stmt.Label)
+			} else if _, err := a.labels.Define(stmt.Source, stmt.Label, currentAddress); err != nil {
+				a.errorf(stmt.Source, "%v", err)
+			}
+		}
+
+		size, err := a.statementSize(stmt)
+		if err != nil {
+			a.errorf(stmt.Source, "%v", err)
+		}
+		currentAddress += size
+	}
+	if err := conditionals.Close(); err != nil {
+		a.diags = append(a.diags, asDiagnostic(err, ""))
+	}
+
+	// Addresses are unreliable after errors in the first pass
+	if HasErrors(a.diags) {
+		return nil, a.diags
+	}
+
+	// Second pass: generate code, starting again at the start address
+	out := newEmitter(startAddress, a.syntax.BigEndian)
+	a.labels.Rewind()
+	program := &Program{Symbols: make(map[string]*Symbol)}
+
+	for i, stmt := range statements {
+		conditional := isConditional(stmt.Op)
+		if active[i] {
+			a.referencing = &stmt.Source
+			if err := a.generate(stmt, out); err != nil {
+				a.errorf(stmt.Source, "%v", err)
+			}
+			a.referencing = nil
+		}
+
+		data := out.Emitted()
+		line := Line{Source: stmt.Source, Bytes: data, Skipped: !active[i] && !conditional}
+		if active[i] && (len(data) > 0 || stmt.Label != "" || stmt.Op == "ORG") {
+			line.HasAddr = true
+			line.Addr = out.addr - uint16(len(data))
+		}
+		program.Lines = append(program.Lines, line)
+	}
+	if HasErrors(a.diags) {
+		return nil, a.diags
+	}
+
+	img, err := out.Image()
+	if err != nil {
+		a.diags = append(a.diags, Diagnostic{Message: err.Error()})
+		return nil, a.diags
+	}
+	program.Image = img
+
+	// Symbols with their cross-references
+	for name, value := range a.defines {
+		program.Symbols[name] = &Symbol{Name: name, Value: value, Refs: a.refs[name]}
+	}
+	for name, addr := range a.labels.addrs {
+		program.Symbols[name] = &Symbol{Name: name, Value: int64(addr), IsLabel: true,
+			Source: a.labels.sources[name], Refs: a.refs[name]}
+	}
+	return program, a.diags
+}
+
+// generate emits the code for one statement during the second pass
+func (a *assembler) generate(stmt *Statement, out *emitter) error {
+	if stmt.Label != "" {
+		a.labels.Visit(stmt.Label)
+	}
+
+	switch stmt.Op {
+	case "", "INCLUDE":
+		return nil
+	case "ORG":
+		addr, err := a.orgAddress(stmt)
+		out.Org(addr)
+		return err
+	case "INCBIN":
+		data, err := a.incbinData(stmt)
+		out.Emit(data...)
+		return err
+	}
+
+	if !a.set.Has(stmt.Op) {
+		return fmt.Errorf("Unknown mnemonic: %s", stmt.Op)
+	}
+	switch a.cpuType {
+	case "6502":
+		return a.encode6502(stmt, out)
+	case "6800":
+		return a.encode6800(stmt, out)
+	case "z80":
+		return a.encodeZ80(stmt, out)
+	case "4004", "4040":
+		return a.encode4004(stmt, out)
+	case "1802":
+		return a.encode1802(stmt, out)
+	}
+	enc, operands, err := a.set.Match(stmt, a.lookup)
+	if err != nil {
+		return err
+	}
+
+	out.Emit(enc.Opcode)
+
+	switch a.cpuType {
+	case "8008", "8080", "8085":
+		return a.encodeIntelOperand(stmt, enc.Instruction, operands, out)
+	}
+	return nil
+}
+
+// orgAddress evaluates the operand of an ORG directive
+func (a *assembler) orgAddress(stmt *Statement) (uint16, error) {
+	if len(stmt.Operands) != 1 || stmt.Operands[0].IsString || stmt.Operands[0].Immediate {
+		return 0, fmt.Errorf("ORG: expected an address")
+	}
+	addr, err := stmt.Operands[0].Expr.Eval(a.lookup)
+	if err != nil {
+		return 0, fmt.Errorf("ORG: %v", err)
+	}
+	if addr < 0 || addr > 0xFFFF {
+		return 0, fmt.Errorf("ORG: address out of range: %s", stmt.Operands[0].Text)
+	}
+	return uint16(addr), nil
+}
+
+// incbinData returns the bytes included by an INCBIN "file"[, offset[, length]] directive
+func (a *assembler) incbinData(stmt *Statement) ([]byte, error) {
+	if len(stmt.Operands) == 0 || !stmt.Operands[0].IsString {
+		return nil, fmt.Errorf("INCBIN: expected quoted file name")
+	}
+	if len(stmt.Operands) > 3 {
+		return nil, fmt.Errorf("INCBIN: too many arguments")
+	}
+
+	name := stmt.Operands[0].Str
+	data, err := a.loader.ReadBinary(name, stmt.Source.File)
+	if err != nil {
+		return nil, fmt.Errorf("INCBIN: %v", err)
+	}
+
+	// Optional offset and length follow the file name
+	var values []int64
+	for _, operand := range stmt.Operands[1:] {
+		if operand.IsString || operand.Immediate {
+			return nil, fmt.Errorf("INCBIN: invalid argument %s", operand.Text)
+		}
+		value, err := operand.Expr.Eval(a.lookup)
+		if err != nil {
+			return nil, fmt.Errorf("INCBIN: %v", err)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("INCBIN: negative argument %s", operand.Text)
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return data, nil
+	}
+
+	offset := values[0]
+	if offset > int64(len(data)) {
+		return nil, fmt.Errorf("INCBIN: offset %d is beyond the end of %s (%d bytes)", offset, name, len(data))
+	}
+	end := int64(len(data))
+	if len(values) == 2 {
+		end = offset + values[1]
+		if end > int64(len(data)) {
+			return nil, fmt.Errorf("INCBIN: offset %d and length %d exceed %s (%d bytes)", offset, values[1], name, len(data))
+		}
+	}
+	return data[offset:end], nil
+}
+
+// encodeIntelOperand encodes the data or address operand of an 8008 or 8080 instruction;
+// operands are those left after the fixed operands of the instruction's form
+func (a *assembler) encodeIntelOperand(stmt *Statement, instruction cpu.Instruction, operands []Operand, out *emitter) error {
+	mnemonic := stmt.Op
+	if instruction.Size == 1 {
+		if len(operands) > 0 {
+			return fmt.Errorf("%s takes no operand, got %s", mnemonic, operands[0].Text)
+		}
+		return nil
+	}
+	if len(operands) != 1 {
+		return fmt.Errorf("%s takes exactly one data or address operand", mnemonic)
+	}
+	operand := operands[0]
+	if operand.IsString {
+		return fmt.Errorf("%s does not take a string operand", mnemonic)
+	}
+	if operand.Index != "" {
+		return fmt.Errorf("%s does not take an indexed operand: %s", mnemonic, operand.Text)
+	}
+
+	switch instruction.Mode {
+	case cpu.Absolute:
+		// Handle absolute instructions
+		if operand.Immediate {
+			return fmt.Errorf("%s takes an address, not an immediate value: %s", mnemonic, operand.Text)
+		}
+		address, err := operand.Expr.Eval(a.lookup)
+		if err != nil {
+			return fmt.Errorf("Unknown label or address: %s (%v)", operand.Text, err)
+		}
+		if address < 0 || address > 0xFFFF {
+			return fmt.Errorf("Address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.EmitWord(uint16(address), relocationOf(operand.Expr, a.isLabel))
+	case cpu.Immediate:
+		// Handle immediate instructions; '#' is optional in dialects that do not write it
+		if !operand.Immediate && a.syntax.ImmediatePrefix != "" {
+			return fmt.Errorf("Invalid immediate value format for %s: %s", mnemonic, operand.Text)
+		}
+		value, err := operand.Expr.Eval(a.lookup)
+		if err != nil {
+			return fmt.Errorf("Invalid immediate value for %s: %v", mnemonic, err)
+		}
+		if value < -0x80 || value > 0xFF {
+			return fmt.Errorf("%s only loads 8 bits, got %s", mnemonic, operand.Text)
+		}
+		out.Relocate(out.addr, 1, relocationOf(operand.Expr, a.isLabel))
+		out.Emit(byte(value))
+	case cpu.Immediate16:
+		// 16-bit data, such as the value LXI loads into a register pair
+		if !operand.Immediate && a.syntax.ImmediatePrefix != "" {
+			return fmt.Errorf("Invalid immediate value format for %s: %s", mnemonic, operand.Text)
+		}
+		value, err := operand.Expr.Eval(a.lookup)
+		if err != nil {
+			return fmt.Errorf("Invalid immediate value for %s: %v", mnemonic, err)
+		}
+		if value < -0x8000 || value > 0xFFFF {
+			return fmt.Errorf("%s only loads 16 bits, got %s", mnemonic, operand.Text)
+		}
+		out.EmitWord(uint16(value), relocationOf(operand.Expr, a.isLabel))
+	default:
+		return fmt.Errorf("Error: can't assembly %s: %s", mnemonic, operand.Text)
+	}
+	return nil
+}
+
+// parseDefines evaluates define values; an empty value defines the symbol as 1
+func parseDefines(raw map[string]string) (map[string]int64, error) {
+	defines := make(map[string]int64)
+	lookup := func(name string) (int64, bool) {
+		value, ok := defines[name]
+		return value, ok
+	}
+
+	// Evaluate in name order so the result does not depend on map iteration
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("empty symbol name")
+		}
+		if raw[name] == "" {
+			defines[name] = 1
+			continue
+		}
+		value, err := evalExpr(raw[name], lookup)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		defines[name] = value
+	}
+	return defines, nil
+}
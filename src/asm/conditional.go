@@ -0,0 +1,107 @@
+package asm
+
+import (
+	"fmt"
+)
+
+// conditionalFrame is one open IF/IFDEF/IFNDEF block
+type conditionalFrame struct {
+	source       SourceLine // Line that opened the block
+	parentActive bool       // Whether the enclosing block is being assembled
+	taken        bool       // Whether the IF branch was selected
+	inElse       bool       // Whether ELSE has been seen
+}
+
+// conditionalStack tracks nested conditional assembly blocks
+type conditionalStack struct {
+	frames []conditionalFrame
+}
+
+// Active reports whether lines at the current nesting level are assembled
+func (s *conditionalStack) Active() bool {
+	if len(s.frames) == 0 {
+		return true
+	}
+	frame := s.frames[len(s.frames)-1]
+	return frame.parentActive && frame.taken != frame.inElse
+}
+
+// isConditional reports whether an upper-case directive belongs to conditional assembly
+func isConditional(directive string) bool {
+	switch directive {
+	case "IF", "IFDEF", "IFNDEF", "ELSE", "ENDIF":
+		return true
+	}
+	return false
+}
+
+// Process handles a conditional directive; lookup is only consulted inside active blocks
+func (s *conditionalStack) Process(stmt *Statement, lookup SymbolLookup) error {
+	switch stmt.Op {
+	case "IF", "IFDEF", "IFNDEF":
+		frame := conditionalFrame{source: stmt.Source, parentActive: s.Active()}
+		if frame.parentActive {
+			taken, err := evalCondition(stmt, lookup)
+			if err != nil {
+				// The block is still opened so that its ELSE and ENDIF match it;
+				// neither branch is assembled
+				frame.parentActive = false
+				s.frames = append(s.frames, frame)
+				return fmt.Errorf("%s: %v", stmt.Op, err)
+			}
+			frame.taken = taken
+		}
+		s.frames = append(s.frames, frame)
+	case "ELSE", "ENDIF":
+		if len(stmt.Operands) > 0 {
+			return fmt.Errorf("%s takes no operand", stmt.Op)
+		}
+		if len(s.frames) == 0 {
+			return fmt.Errorf("%s without IF", stmt.Op)
+		}
+		if stmt.Op == "ENDIF" {
+			s.frames = s.frames[:len(s.frames)-1]
+			return nil
+		}
+		frame := &s.frames[len(s.frames)-1]
+		if frame.inElse {
+			return fmt.Errorf("duplicate ELSE for IF at %s", frame.source)
+		}
+		frame.inElse = true
+	}
+	return nil
+}
+
+// Close checks that every conditional block has been terminated
+func (s *conditionalStack) Close() error {
+	if len(s.frames) > 0 {
+		return Diagnostic{Source: s.frames[len(s.frames)-1].source, Message: "IF without ENDIF"}
+	}
+	return nil
+}
+
+// evalCondition evaluates the operand of an IF, IFDEF or IFNDEF directive
+func evalCondition(stmt *Statement, lookup SymbolLookup) (bool, error) {
+	if len(stmt.Operands) != 1 || stmt.Operands[0].IsString || stmt.Operands[0].Immediate {
+		return false, fmt.Errorf("expected a single expression")
+	}
+	expr := stmt.Operands[0].Expr
+
+	if stmt.Op == "IF" {
+		value, err := expr.Eval(lookup)
+		if err != nil {
+			return false, err
+		}
+		return value != 0, nil
+	}
+
+	symbol, ok := expr.(*SymbolExpr)
+	if !ok {
+		return false, fmt.Errorf("expected a symbol name, got %s", stmt.Operands[0].Text)
+	}
+	_, defined := lookup(symbol.Name)
+	if stmt.Op == "IFNDEF" {
+		return !defined, nil
+	}
+	return defined, nil
+}
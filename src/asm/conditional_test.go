@@ -0,0 +1,21 @@
+package asm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFailedConditionReportsOneError(t *testing.T) {
+	source := strings.Join([]string{
+		"IF BAR + 1",
+		"    LAI #1",
+		"ELSE",
+		"    LAI #2",
+		"ENDIF",
+		"    HLT",
+	}, "\n")
+	_, diags := Assemble(strings.NewReader(source), Options{})
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "BAR") {
+		t.Errorf("got diagnostics %v, want only the undefined symbol BAR", diags)
+	}
+}
@@ -0,0 +1,61 @@
+package asm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Severity tells whether a diagnostic stops the program from being assembled
+type Severity int
+
+const (
+	SeverityError   Severity = iota // The program cannot be assembled
+	SeverityWarning                 // The program is assembled but may not do what was intended
+)
+
+// Diagnostic is a problem found in the source
+type Diagnostic struct {
+	Source   SourceLine // Where the problem was found; Num is 0 when it concerns a whole file
+	Severity Severity
+	Message  string
+}
+
+// String returns the diagnostic as "file:line: message"
+func (d Diagnostic) String() string {
+	switch {
+	case d.Source.File == "":
+		return d.Message
+	case d.Source.Num == 0:
+		return fmt.Sprintf("%s: %s", d.Source.File, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Source, d.Message)
+}
+
+// Error lets a diagnostic be returned as an error
+func (d Diagnostic) Error() string {
+	return d.String()
+}
+
+// HasErrors reports whether any of the diagnostics is an error
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// errorAt creates an error diagnostic for a source line
+func errorAt(source SourceLine, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{Source: source, Message: fmt.Sprintf(format, args...)}
+}
+
+// asDiagnostic converts an error to a diagnostic, keeping the location of diagnostics
+func asDiagnostic(err error, file string) Diagnostic {
+	var d Diagnostic
+	if errors.As(err, &d) {
+		return d
+	}
+	return Diagnostic{Source: SourceLine{File: file}, Message: err.Error()}
+}
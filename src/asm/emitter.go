@@ -0,0 +1,135 @@
+package asm
+
+import (
+	"fmt"
+
+	"github.com/lukasz-gorgol/g8b/src/image"
+)
+
+// Relocation kinds of an expression value, see relocationOf
+const (
+	relocNone     = -1 // Value depends on the load address in a way a relocation cannot express
+	relocAbsolute = 0  // Value does not depend on where the program is loaded
+	relocAddress  = 1  // Value is a program address that moves with the program
+)
+
+// emitter collects the bytes produced by the second pass into segments
+type emitter struct {
+	img    *image.Image
+	addr   uint16 // Address of the next byte
+	recent []byte // Bytes emitted since the last call to Emitted
+}
+
+// newEmitter creates an emitter that starts a relocatable image at startAddress;
+// bigEndian selects the byte order of 16-bit words
+func newEmitter(startAddress uint16, bigEndian bool) *emitter {
+	return &emitter{
+		img:  &image.Image{Entry: startAddress, Relocatable: true, BigEndian: bigEndian},
+		addr: startAddress,
+	}
+}
+
+// Org moves the output to a new address, starting a new segment
+func (e *emitter) Org(addr uint16) {
+	e.addr = addr
+}
+
+// Emit appends bytes at the current address
+func (e *emitter) Emit(data ...byte) {
+	segments := e.img.Segments
+	if n := len(segments); n == 0 || segments[n-1].End() != int(e.addr) {
+		e.img.Segments = append(e.img.Segments, image.Segment{Addr: e.addr})
+	}
+	current := &e.img.Segments[len(e.img.Segments)-1]
+	current.Data = append(current.Data, data...)
+	e.recent = append(e.recent, data...)
+	e.addr += uint16(len(data))
+}
+
+// Emitted returns the bytes emitted since the previous call
+func (e *emitter) Emitted() []byte {
+	data := e.recent
+	e.recent = nil
+	return data
+}
+
+// EmitWord appends a 16-bit value in the image's byte order, recording a relocation for program addresses
+func (e *emitter) EmitWord(value uint16, reloc int) {
+	e.Relocate(e.addr, 2, reloc)
+	if e.img.BigEndian {
+		e.Emit(byte(value>>8), byte(value&0xFF))
+		return
+	}
+	e.Emit(byte(value&0xFF), byte(value>>8))
+}
+
+// Relocate records how a field of size bytes at addr depends on the load address
+func (e *emitter) Relocate(addr uint16, size int, reloc int) {
+	switch {
+	case reloc == relocAbsolute:
+	case reloc == relocAddress && size == 2:
+		e.img.Relocations = append(e.img.Relocations, addr)
+	default:
+		e.img.Relocatable = false
+	}
+}
+
+// Image returns the assembled image with its segments sorted
+func (e *emitter) Image() (*image.Image, error) {
+	if err := e.img.Sort(); err != nil {
+		return nil, err
+	}
+	return e.img, nil
+}
+
+// relocationOf classifies how an expression depends on the load address
+func relocationOf(expr Expr, isLabel func(name string) bool) int {
+	coeff, linear := addressCoefficient(expr, isLabel)
+	switch {
+	case linear && coeff == 0:
+		return relocAbsolute
+	case linear && coeff == 1:
+		return relocAddress
+	}
+	return relocNone
+}
+
+// addressCoefficient counts how many times program addresses enter a value.
+// Constants and differences of labels give 0 and an address plus or minus a
+// constant gives 1; linear is false when the value depends on an address in a
+// way that is not a plain sum, such as label>>8.
+func addressCoefficient(expr Expr, isLabel func(name string) bool) (int, bool) {
+	switch e := expr.(type) {
+	case *NumberExpr, *DefinedExpr:
+		return 0, true
+	case *SymbolExpr:
+		if isLabel(e.Name) {
+			return 1, true
+		}
+		return 0, true
+	case *UnaryExpr:
+		coeff, linear := addressCoefficient(e.Operand, isLabel)
+		switch {
+		case !linear:
+			return 0, false
+		case e.Op == "+":
+			return coeff, true
+		case e.Op == "-":
+			return -coeff, true
+		}
+		return 0, coeff == 0
+	case *BinaryExpr:
+		left, leftLinear := addressCoefficient(e.Left, isLabel)
+		right, rightLinear := addressCoefficient(e.Right, isLabel)
+		switch {
+		case !leftLinear || !rightLinear:
+			return 0, false
+		case e.Op == "+":
+			return left + right, true
+		case e.Op == "-":
+			return left - right, true
+		}
+		return 0, left == 0 && right == 0
+	}
+	panic(fmt.Sprintf("unknown expression type %T", expr))
+}
@@ -0,0 +1,269 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SymbolLookup resolves a symbol name to its value
+type SymbolLookup func(name string) (int64, bool)
+
+// Expr is a node of an expression tree
+type Expr interface {
+	// Eval computes the value of the expression
+	Eval(lookup SymbolLookup) (int64, error)
+}
+
+// NumberExpr is a numeric literal
+type NumberExpr struct {
+	Value int64
+}
+
+// SymbolExpr is a reference to a label or defined symbol
+type SymbolExpr struct {
+	Name string
+}
+
+// DefinedExpr is the defined(NAME) test, 1 if the symbol exists and 0 otherwise
+type DefinedExpr struct {
+	Name string
+}
+
+// UnaryExpr applies a prefix operator to an operand
+type UnaryExpr struct {
+	Op      string
+	Operand Expr
+}
+
+// BinaryExpr applies an infix operator to two operands
+type BinaryExpr struct {
+	Op          string
+	Left, Right Expr
+}
+
+func (e *NumberExpr) Eval(lookup SymbolLookup) (int64, error) {
+	return e.Value, nil
+}
+
+func (e *SymbolExpr) Eval(lookup SymbolLookup) (int64, error) {
+	value, ok := lookup(e.Name)
+	if !ok {
+		return 0, fmt.Errorf("undefined symbol %q", e.Name)
+	}
+	return value, nil
+}
+
+func (e *DefinedExpr) Eval(lookup SymbolLookup) (int64, error) {
+	_, ok := lookup(e.Name)
+	return boolValue(ok), nil
+}
+
+func (e *UnaryExpr) Eval(lookup SymbolLookup) (int64, error) {
+	value, err := e.Operand.Eval(lookup)
+	if err != nil {
+		return 0, err
+	}
+	switch e.Op {
+	case "-":
+		return -value, nil
+	case "~":
+		return ^value, nil
+	case "!":
+		return boolValue(value == 0), nil
+	case "<":
+		return value & 0xFF, nil
+	case ">":
+		return value >> 8 & 0xFF, nil
+	}
+	return value, nil
+}
+
+func (e *BinaryExpr) Eval(lookup SymbolLookup) (int64, error) {
+	left, err := e.Left.Eval(lookup)
+	if err != nil {
+		return 0, err
+	}
+	// The right side of && and || is only evaluated when the left side does not decide
+	// the result, so that "defined(FOO) && FOO > 2" works when FOO is undefined
+	if e.Op == "&&" && left == 0 || e.Op == "||" && left != 0 {
+		return boolValue(left != 0), nil
+	}
+	right, err := e.Right.Eval(lookup)
+	if err != nil {
+		return 0, err
+	}
+	return applyBinary(e.Op, left, right)
+}
+
+// evalExpr parses and evaluates an expression given as text, such as a -D value
+func evalExpr(text string, lookup SymbolLookup) (int64, error) {
+	tokens, err := lexLine(text)
+	if err != nil {
+		return 0, err
+	}
+	p := &tokenParser{tokens: tokens}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	if !p.done() {
+		return 0, fmt.Errorf("unexpected %s in expression", p.peek())
+	}
+	return expr.Eval(lookup)
+}
+
+// binaryPrecedence lists binary operators from lowest to highest precedence
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"|"},
+	{"^"},
+	{"&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"<<", ">>"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseExpr parses an expression starting at the current token
+func (p *tokenParser) parseExpr() (Expr, error) {
+	return p.parseBinary(0)
+}
+
+// parseBinary parses binary operators at the given precedence level and above
+func (p *tokenParser) parseBinary(level int) (Expr, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.peek().Kind == TokenOperator && contains(binaryPrecedence[level], p.peek().Text) {
+		op := p.next().Text
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses prefix operators, parentheses, numbers and symbols
+func (p *tokenParser) parseUnary() (Expr, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	token := p.next()
+	switch {
+	case token.Kind == TokenNumber:
+		return &NumberExpr{Value: token.Value}, nil
+	case token.Kind == TokenIdent && strings.EqualFold(token.Text, "defined"):
+		// defined(NAME) or defined NAME
+		paren := !p.done() && p.peek().Is("(")
+		if paren {
+			p.next()
+		}
+		if p.done() || p.peek().Kind != TokenIdent {
+			return nil, fmt.Errorf("missing symbol name after defined")
+		}
+		name := p.next().Text
+		if paren {
+			if p.done() || !p.peek().Is(")") {
+				return nil, fmt.Errorf("missing ')' after defined")
+			}
+			p.next()
+		}
+		return &DefinedExpr{Name: name}, nil
+	case token.Kind == TokenIdent:
+		return &SymbolExpr{Name: token.Text}, nil
+	case token.Is("("):
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || !p.peek().Is(")") {
+			return nil, fmt.Errorf("missing ')' in expression")
+		}
+		p.next()
+		return expr, nil
+	case token.Is("-"), token.Is("+"), token.Is("~"), token.Is("!"), token.Is("<"), token.Is(">"):
+		// Prefix < and > take the low and high byte of a value, as in LDA #<table
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Op: token.Text, Operand: operand}, nil
+	}
+	return nil, fmt.Errorf("unexpected %s in expression", token)
+}
+
+// applyBinary applies a binary operator to two values
+func applyBinary(op string, left, right int64) (int64, error) {
+	switch op {
+	case "||":
+		return boolValue(left != 0 || right != 0), nil
+	case "&&":
+		return boolValue(left != 0 && right != 0), nil
+	case "|":
+		return left | right, nil
+	case "^":
+		return left ^ right, nil
+	case "&":
+		return left & right, nil
+	case "==":
+		return boolValue(left == right), nil
+	case "!=":
+		return boolValue(left != right), nil
+	case "<":
+		return boolValue(left < right), nil
+	case "<=":
+		return boolValue(left <= right), nil
+	case ">":
+		return boolValue(left > right), nil
+	case ">=":
+		return boolValue(left >= right), nil
+	case "<<":
+		return left << uint(right), nil
+	case ">>":
+		return left >> uint(right), nil
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/", "%":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		if op == "/" {
+			return left / right, nil
+		}
+		return left % right, nil
+	}
+	return 0, fmt.Errorf("unknown operator %q", op)
+}
+
+// boolValue converts a boolean to 1 or 0
+func boolValue(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
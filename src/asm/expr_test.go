@@ -0,0 +1,33 @@
+package asm
+
+import "testing"
+
+func TestLogicalOperatorsShortCircuit(t *testing.T) {
+	lookup := func(name string) (int64, bool) {
+		if name == "SET" {
+			return 3, true
+		}
+		return 0, false
+	}
+
+	tests := []struct {
+		text string
+		want int64
+	}{
+		{"defined(FOO) && FOO > 2", 0},
+		{"!defined(FOO) || FOO > 2", 1},
+		{"defined(SET) && SET > 2", 1},
+		{"0 && 1 / 0", 0},
+		{"1 || 1 / 0", 1},
+	}
+	for _, test := range tests {
+		got, err := evalExpr(test.text, lookup)
+		if err != nil || got != test.want {
+			t.Errorf("%s = %d, %v; want %d", test.text, got, err, test.want)
+		}
+	}
+
+	if _, err := evalExpr("defined(SET) && FOO", lookup); err == nil {
+		t.Error("expected an error when the right side is needed and undefined")
+	}
+}
@@ -0,0 +1,140 @@
+package asm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// maxListedForms limits the forms listed when operands do not match
+const maxListedForms = 8
+
+// encoding is one way of writing an instruction in the selected syntax
+type encoding struct {
+	Prefix      uint16 // Prefix bytes before the opcode, 0 for none
+	Opcode      byte
+	Form        cpu.Form
+	Instruction cpu.Instruction
+}
+
+// instructionSet finds the encoding of a statement in one assembly dialect
+type instructionSet struct {
+	syntax    *cpu.Syntax
+	mnemonics map[string][]encoding // Encodings by mnemonic, in opcode order
+}
+
+// newInstructionSet indexes the instructions of a CPU and its prefix tables by their mnemonics in the given syntax
+func newInstructionSet(syntax *cpu.Syntax, instructions map[byte]cpu.Instruction, prefixes cpu.PrefixTables) *instructionSet {
+	set := &instructionSet{syntax: syntax, mnemonics: make(map[string][]encoding)}
+	set.add(0, instructions, syntax.Forms)
+	for prefix, table := range prefixes {
+		set.add(prefix, table, syntax.PrefixForms[prefix])
+	}
+
+	// Unprefixed encodings come first, then documented ones, and opcode order
+	// makes the choice between equivalent encodings deterministic
+	for _, encodings := range set.mnemonics {
+		sort.Slice(encodings, func(i, j int) bool {
+			a, b := encodings[i], encodings[j]
+			if a.Prefix != b.Prefix {
+				return a.Prefix < b.Prefix
+			}
+			if undocumented(a) != undocumented(b) {
+				return undocumented(b)
+			}
+			return a.Opcode < b.Opcode
+		})
+	}
+	return set
+}
+
+// add indexes one instruction table, reached through the given prefix
+func (s *instructionSet) add(prefix uint16, instructions map[byte]cpu.Instruction, forms map[byte]cpu.Form) {
+	for opcode, instruction := range instructions {
+		form, ok := forms[opcode]
+		if !ok {
+			continue
+		}
+		s.mnemonics[form.Mnemonic] = append(s.mnemonics[form.Mnemonic], encoding{prefix, opcode, form, instruction})
+	}
+}
+
+// undocumented reports whether an encoding is one the manufacturer does not document
+func undocumented(enc encoding) bool {
+	return strings.Contains(enc.Instruction.Description, "undocumented")
+}
+
+// Has reports whether the mnemonic exists in the syntax
+func (s *instructionSet) Has(mnemonic string) bool {
+	_, ok := s.mnemonics[mnemonic]
+	return ok
+}
+
+// Match finds the encoding whose fixed operands start the statement's operands and
+// returns it with the operands that remain. Register names must match exactly and
+// numbers, such as port and restart numbers, are compared with the operand's value.
+// Without lookup, as in the first pass, numbers match any value so that the
+// statement's size can be found before all symbols are known.
+func (s *instructionSet) Match(stmt *Statement, lookup SymbolLookup) (encoding, []Operand, error) {
+	encodings, ok := s.mnemonics[stmt.Op]
+	if !ok {
+		return encoding{}, nil, fmt.Errorf("unknown mnemonic %s", stmt.Op)
+	}
+
+	for _, enc := range encodings {
+		if len(stmt.Operands) < len(enc.Form.Operands) {
+			continue
+		}
+		matched := true
+		for i, fixed := range enc.Form.Operands {
+			operand := stmt.Operands[i]
+			if !cpu.IsNumber(fixed) {
+				matched = isName(operand, fixed)
+			} else if operand.Immediate || operand.IsString {
+				matched = false
+			} else if lookup != nil {
+				value, err := operand.Expr.Eval(lookup)
+				if err != nil {
+					return encoding{}, nil, fmt.Errorf("%s: %v", stmt.Op, err)
+				}
+				matched = fmt.Sprint(value) == fixed
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched {
+			return enc, stmt.Operands[len(enc.Form.Operands):], nil
+		}
+	}
+
+	// Show what the mnemonic accepts
+	var forms []string
+	seen := make(map[string]bool)
+	for _, enc := range encodings {
+		if form := enc.Form.String(); !seen[form] {
+			seen[form] = true
+			forms = append(forms, form)
+		}
+	}
+	if len(forms) > maxListedForms {
+		forms = append(forms[:maxListedForms], "...")
+	}
+	if len(stmt.Operands) == 0 {
+		return encoding{}, nil, fmt.Errorf("%s needs operands (expected %s)", stmt.Op, strings.Join(forms, ", "))
+	}
+	var operands []string
+	for _, operand := range stmt.Operands {
+		operands = append(operands, operand.Text)
+	}
+	return encoding{}, nil, fmt.Errorf("%s does not take operands %s (expected %s)",
+		stmt.Op, strings.Join(operands, ","), strings.Join(forms, ", "))
+}
+
+// isName reports whether an operand is the plain name, ignoring case
+func isName(operand Operand, name string) bool {
+	symbol, ok := operand.Expr.(*SymbolExpr)
+	return ok && !operand.Immediate && strings.EqualFold(symbol.Name, name)
+}
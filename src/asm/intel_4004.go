@@ -0,0 +1,74 @@
+package asm
+
+import (
+	"fmt"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// encode4004 emits a 4004 or 4040 instruction. JUN and JMS put the high 4 bits
+// of their 12-bit address in the low nibble of the opcode, and JCN and ISZ
+// jump within the 256-byte page of the next instruction.
+func (a *assembler) encode4004(stmt *Statement, out *emitter) error {
+	enc, operands, err := a.set.Match(stmt, a.lookup)
+	if err != nil {
+		return err
+	}
+	mnemonic := stmt.Op
+	instruction := enc.Instruction
+	if instruction.Size == 1 {
+		if len(operands) > 0 {
+			return fmt.Errorf("%s takes no operand, got %s", mnemonic, operands[0].Text)
+		}
+		out.Emit(enc.Opcode)
+		return nil
+	}
+	if len(operands) != 1 {
+		return fmt.Errorf("%s takes exactly one data or address operand", mnemonic)
+	}
+	operand := operands[0]
+	if operand.IsString || operand.Indirect || operand.Index != "" {
+		return fmt.Errorf("%s does not take operand %s", mnemonic, operand.Text)
+	}
+	if operand.Immediate && instruction.Mode != cpu.Immediate {
+		return fmt.Errorf("%s takes an address, not an immediate value: %s", mnemonic, operand.Text)
+	}
+	value, err := operand.Expr.Eval(a.lookup)
+	if err != nil {
+		return fmt.Errorf("Unknown label or address: %s (%v)", operand.Text, err)
+	}
+
+	// The 8-bit fields cannot hold a relocation, so a program using
+	// addresses in them is not relocatable
+	start := out.addr
+	reloc := relocationOf(operand.Expr, a.isLabel)
+	switch instruction.Mode {
+	case cpu.Immediate:
+		if value < -0x80 || value > 0xFF {
+			return fmt.Errorf("%s only loads 8 bits, got %s", mnemonic, operand.Text)
+		}
+		out.Emit(enc.Opcode)
+	case cpu.Absolute12:
+		// The 4040's second ROM bank is reached by its 12-bit address after DB1
+		limit := int64(cpu.Intel4004ROMSize - 1)
+		if a.cpuType == "4040" {
+			limit = cpu.Intel4040ROMSize - 1
+		}
+		if value < 0 || value > limit {
+			return fmt.Errorf("Address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.Emit(enc.Opcode&0xF0 | byte(value>>8)&0x0F)
+	case cpu.Page:
+		page := (start&0x1000 | (start+2)&0x0FFF) &^ 0xFF
+		if value < int64(page) || value > int64(page)+0xFF {
+			return fmt.Errorf("Jump target out of range for %s: %s is not in the page of the next instruction ($%04X-$%04X)",
+				mnemonic, operand.Text, page, page+0xFF)
+		}
+		out.Emit(enc.Opcode)
+	default:
+		return fmt.Errorf("Error: can't assembly %s: %s", mnemonic, operand.Text)
+	}
+	out.Relocate(out.addr, 1, reloc)
+	out.Emit(byte(value))
+	return nil
+}
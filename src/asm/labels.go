@@ -0,0 +1,124 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// labelTable holds the labels of a program and resolves local and anonymous references.
+//
+// Global labels are plain names. Local labels start with a dot and belong to the
+// closest preceding global label, so ".loop" after "delay:" is stored as "delay.loop".
+// Anonymous labels are written as "-" or "+" and referenced as "-", "--", ... for the
+// nearest preceding ones and "+", "++", ... for the nearest following ones.
+type labelTable struct {
+	addrs    map[string]uint16     // Label addresses by fully qualified name
+	sources  map[string]SourceLine // Lines that defined each label
+	scope    string                // Last global label, qualifies local labels
+	backward []uint16              // Addresses of "-" labels in source order
+	forward  []uint16              // Addresses of "+" labels in source order
+	backSeen int                   // Number of "-" labels passed in the current pass
+	fwdSeen  int                   // Number of "+" labels passed in the current pass
+}
+
+// newLabelTable creates an empty label table
+func newLabelTable() *labelTable {
+	return &labelTable{
+		addrs:   make(map[string]uint16),
+		sources: make(map[string]SourceLine),
+	}
+}
+
+// Define records a label during the first pass and reports duplicates
+func (t *labelTable) Define(source SourceLine, name string, addr uint16) (string, error) {
+	switch name {
+	case "-":
+		t.backward = append(t.backward, addr)
+		t.backSeen++
+		return name, nil
+	case "+":
+		t.forward = append(t.forward, addr)
+		t.fwdSeen++
+		return name, nil
+	}
+
+	qualified, err := t.enterScope(name)
+	if err != nil {
+		return "", err
+	}
+	if previous, exists := t.sources[qualified]; exists {
+		return "", fmt.Errorf("duplicate label %s (previously defined at %s)", qualified, previous)
+	}
+	t.addrs[qualified] = addr
+	t.sources[qualified] = source
+	return qualified, nil
+}
+
+// Visit tracks scope and anonymous label positions for a label seen during the second pass
+func (t *labelTable) Visit(name string) {
+	switch name {
+	case "-":
+		t.backSeen++
+	case "+":
+		t.fwdSeen++
+	default:
+		t.enterScope(name)
+	}
+}
+
+// Rewind resets the position-dependent state before the next pass
+func (t *labelTable) Rewind() {
+	t.scope = ""
+	t.backSeen = 0
+	t.fwdSeen = 0
+}
+
+// enterScope qualifies a label name and makes global labels the current scope
+func (t *labelTable) enterScope(name string) (string, error) {
+	if name == "" || name == "." {
+		return "", fmt.Errorf("empty label name")
+	}
+	if strings.HasPrefix(name, ".") {
+		if t.scope == "" {
+			return "", fmt.Errorf("local label %s has no preceding global label", name)
+		}
+		return t.scope + name, nil
+	}
+	t.scope = name
+	return name, nil
+}
+
+// Qualify returns the full name a reference means at the current position, or "" for anonymous references
+func (t *labelTable) Qualify(name string) string {
+	if strings.Trim(name, "-") == "" || strings.Trim(name, "+") == "" {
+		return ""
+	}
+	if strings.HasPrefix(name, ".") {
+		return t.scope + name
+	}
+	return name
+}
+
+// Lookup resolves a label reference at the current position
+func (t *labelTable) Lookup(name string) (uint16, bool) {
+	if count := strings.Count(name, "-"); count > 0 && count == len(name) {
+		index := t.backSeen - count
+		if index < 0 {
+			return 0, false
+		}
+		return t.backward[index], true
+	}
+	if count := strings.Count(name, "+"); count > 0 && count == len(name) {
+		index := t.fwdSeen + count - 1
+		if index >= len(t.forward) {
+			return 0, false
+		}
+		return t.forward[index], true
+	}
+
+	if strings.HasPrefix(name, ".") {
+		name = t.scope + name
+	}
+	addr, ok := t.addrs[name]
+	return addr, ok
+}
@@ -0,0 +1,188 @@
+package asm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TokenKind identifies the type of a lexical token
+type TokenKind int
+
+const (
+	TokenIdent    TokenKind = iota // Mnemonic, directive, label or symbol name
+	TokenNumber                    // Numeric literal, including character literals
+	TokenString                    // Double-quoted string literal
+	TokenOperator                  // Operator or punctuation such as , : # ( ) + <<
+)
+
+// Token is a single lexical element of a source line
+type Token struct {
+	Kind  TokenKind // Kind of token
+	Text  string    // Source text (for strings, the unquoted value)
+	Value int64     // Numeric value of number tokens
+	Col   int       // Column of the token in the line (1-based)
+}
+
+// String returns a readable form of the token for error messages
+func (t Token) String() string {
+	if t.Kind == TokenString {
+		return strconv.Quote(t.Text)
+	}
+	return t.Text
+}
+
+// Is reports whether the token is the given operator
+func (t Token) Is(op string) bool {
+	return t.Kind == TokenOperator && t.Text == op
+}
+
+// operators lists the multi- and single-character operators, longest first
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=", "<<", ">>",
+	"+", "-", "*", "/", "%", "&", "|", "^", "~", "!", "<", ">", "(", ")", ",", ":", "#",
+}
+
+// lexLine splits a source line into tokens, dropping the trailing comment
+func lexLine(text string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(text); {
+		ch := rune(text[i])
+		start := i
+
+		switch {
+		case ch == ';':
+			// Comment runs to the end of the line
+			return tokens, nil
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '"':
+			value, n, err := lexString(text[i:])
+			if err != nil {
+				return nil, fmt.Errorf("column %d: %v", start+1, err)
+			}
+			tokens = append(tokens, Token{Kind: TokenString, Text: value, Col: start + 1})
+			i += n
+		case ch == '\'':
+			value, n, err := lexChar(text[i:])
+			if err != nil {
+				return nil, fmt.Errorf("column %d: %v", start+1, err)
+			}
+			tokens = append(tokens, Token{Kind: TokenNumber, Text: text[i : i+n], Value: value, Col: start + 1})
+			i += n
+		case ch == '$' || unicode.IsDigit(ch):
+			i++
+			for i < len(text) && isIdentChar(rune(text[i])) {
+				i++
+			}
+			value, err := parseNumber(text[start:i])
+			if err != nil {
+				return nil, fmt.Errorf("column %d: invalid number %q", start+1, text[start:i])
+			}
+			tokens = append(tokens, Token{Kind: TokenNumber, Text: text[start:i], Value: int64(value), Col: start + 1})
+		case isIdentChar(ch):
+			for i < len(text) && isIdentChar(rune(text[i])) {
+				i++
+			}
+			if i < len(text) && text[i] == '\'' && strings.EqualFold(text[start:i], "AF") {
+				i++ // The Z80's alternate register pair AF'
+			}
+			tokens = append(tokens, Token{Kind: TokenIdent, Text: text[start:i], Col: start + 1})
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(text[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("column %d: unexpected character %q", start+1, ch)
+			}
+			tokens = append(tokens, Token{Kind: TokenOperator, Text: op, Col: start + 1})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+// lexString reads a double-quoted string with C-style escapes, returning its value and length
+func lexString(text string) (string, int, error) {
+	var value strings.Builder
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			return value.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(text) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			i++
+			ch, err := unescape(text[i])
+			if err != nil {
+				return "", 0, err
+			}
+			value.WriteByte(ch)
+		default:
+			value.WriteByte(text[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+// lexChar reads a single-quoted character literal, returning its value and length
+func lexChar(text string) (int64, int, error) {
+	if len(text) >= 4 && text[1] == '\\' && text[3] == '\'' {
+		ch, err := unescape(text[2])
+		return int64(ch), 4, err
+	}
+	if len(text) >= 3 && text[1] != '\\' && text[2] == '\'' {
+		return int64(text[1]), 3, nil
+	}
+	return 0, 0, fmt.Errorf("invalid character literal")
+}
+
+// unescape returns the character for a backslash escape
+func unescape(ch byte) (byte, error) {
+	switch ch {
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '0':
+		return 0, nil
+	case '\\', '"', '\'':
+		return ch, nil
+	}
+	return 0, fmt.Errorf("unknown escape sequence \\%c", ch)
+}
+
+// parseNumber parses a number: $hex, 0x hex, 0b binary, 0o octal, decimal, or in Intel
+// notation a suffixed 0FFH hex or 377Q / 377O octal number
+func parseNumber(s string) (uint64, error) {
+	upper := strings.ToUpper(s)
+	switch {
+	case strings.HasPrefix(s, "$"):
+		return strconv.ParseUint(s[1:], 16, 32)
+	case strings.HasPrefix(upper, "0X"):
+		return strconv.ParseUint(s[2:], 16, 32)
+	case strings.HasSuffix(upper, "H"):
+		return strconv.ParseUint(s[:len(s)-1], 16, 32)
+	case strings.HasPrefix(upper, "0B"):
+		return strconv.ParseUint(s[2:], 2, 32)
+	case strings.HasPrefix(upper, "0O"):
+		return strconv.ParseUint(s[2:], 8, 32)
+	case strings.HasSuffix(upper, "Q"), strings.HasSuffix(upper, "O"):
+		return strconv.ParseUint(s[:len(s)-1], 8, 32)
+	default:
+		return strconv.ParseUint(s, 10, 32)
+	}
+}
+
+// isIdentChar reports whether ch can appear in a symbol name or number
+func isIdentChar(ch rune) bool {
+	return ch == '_' || ch == '.' || unicode.IsLetter(ch) || unicode.IsDigit(ch)
+}
@@ -0,0 +1,119 @@
+package asm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listingBytesPerRow is the number of emitted bytes shown on one listing row
+const listingBytesPerRow = 4
+
+// WriteListing writes a listing of the program: every source line with its address and
+// bytes, then a cross-reference of the symbols. The title names the assembled source.
+func WriteListing(w io.Writer, title string, program *Program) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "Listing of %s\n\n", title)
+	fmt.Fprintf(bw, "%-6s %-4s  %-11s   %s\n", "Line", "Addr", "Code", "Source")
+	for _, line := range program.Lines {
+		writeListingLine(bw, line)
+	}
+
+	fmt.Fprintf(bw, "\nSymbols\n\n")
+	fmt.Fprintf(bw, "%-20s %-5s  %-20s %s\n", "Name", "Value", "Defined", "References")
+	names := make([]string, 0, len(program.Symbols))
+	for name := range program.Symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		symbol := program.Symbols[name]
+		defined := "predefined"
+		if symbol.IsLabel {
+			defined = symbol.Source.String()
+		}
+		refs := make([]string, len(symbol.Refs))
+		for i, ref := range symbol.Refs {
+			refs[i] = ref.String()
+		}
+		row := fmt.Sprintf("%-20s $%04X  %-20s %s", name, uint16(symbol.Value), defined, strings.Join(refs, " "))
+		fmt.Fprintln(bw, strings.TrimRight(row, " "))
+	}
+
+	return bw.Flush()
+}
+
+// writeListingLine writes a source line; bytes that do not fit on its row continue on following rows.
+// Included lines are marked with a plus and lines skipped by conditional assembly with a minus.
+func writeListingLine(w io.Writer, line Line) {
+	marker := " "
+	switch {
+	case line.Skipped:
+		marker = "-"
+	case line.Source.Depth > 0:
+		marker = "+"
+	}
+
+	addr := "    "
+	if line.HasAddr {
+		addr = fmt.Sprintf("%04X", line.Addr)
+	}
+	first := line.Bytes
+	if len(first) > listingBytesPerRow {
+		first = first[:listingBytesPerRow]
+	}
+	row := fmt.Sprintf("%5d%s %s  %-11s   %s", line.Source.Num, marker, addr, hexBytes(first), line.Source.Text)
+	fmt.Fprintln(w, strings.TrimRight(row, " "))
+
+	for offset := listingBytesPerRow; offset < len(line.Bytes); offset += listingBytesPerRow {
+		end := offset + listingBytesPerRow
+		if end > len(line.Bytes) {
+			end = len(line.Bytes)
+		}
+		fmt.Fprintf(w, "%6s %04X  %s\n", "", line.Addr+uint16(offset), hexBytes(line.Bytes[offset:end]))
+	}
+}
+
+// hexBytes formats bytes as space-separated hex pairs
+func hexBytes(data []byte) string {
+	parts := make([]string, len(data))
+	for i, b := range data {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ReadListingSymbols reads the symbol table at the end of a listing written by WriteListing,
+// so that tools working on the assembled code can refer to its labels by name
+func ReadListingSymbols(r io.Reader) (map[string]uint16, error) {
+	symbols := make(map[string]uint16)
+	scanner := bufio.NewScanner(r)
+	inTable := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !inTable {
+			inTable = line == "Symbols"
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == "Name" {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimPrefix(fields[1], "$"), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of symbol %s: %s", fields[0], fields[1])
+		}
+		symbols[fields[0]] = uint16(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !inTable {
+		return nil, fmt.Errorf("no symbol table found in the listing")
+	}
+	return symbols, nil
+}
@@ -0,0 +1,54 @@
+package asm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadListingSymbolsReadsWhatWriteListingWrote(t *testing.T) {
+	source := strings.Join([]string{
+		"start:",
+		"    LAI #COUNT",
+		"    CAL a_label_longer_than_the_name_column",
+		"    HLT",
+		"a_label_longer_than_the_name_column:",
+		"    RET",
+	}, "\n")
+	program, diags := Assemble(strings.NewReader(source), Options{
+		StartAddress: 0x8000,
+		Defines:      map[string]string{"COUNT": "5"},
+	})
+	if HasErrors(diags) {
+		t.Fatalf("assembly failed: %v", diags)
+	}
+
+	var listing bytes.Buffer
+	if err := WriteListing(&listing, "test.asm", program); err != nil {
+		t.Fatal(err)
+	}
+	symbols, err := ReadListingSymbols(&listing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]uint16{
+		"start":                               0x8000,
+		"a_label_longer_than_the_name_column": 0x8006,
+		"COUNT":                               5,
+	}
+	if len(symbols) != len(want) {
+		t.Errorf("got %d symbols %v, want %v", len(symbols), symbols, want)
+	}
+	for name, value := range want {
+		if got, ok := symbols[name]; !ok || got != value {
+			t.Errorf("symbol %s = $%04X (found %v), want $%04X", name, got, ok, value)
+		}
+	}
+}
+
+func TestReadListingSymbolsWithoutSymbolTable(t *testing.T) {
+	if _, err := ReadListingSymbols(strings.NewReader("Listing of test.asm\n")); err == nil {
+		t.Error("expected an error for a listing without a symbol table")
+	}
+}
@@ -0,0 +1,177 @@
+package asm
+
+import (
+	"fmt"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// match6502 finds the 6502 encoding for the addressing mode the statement's operands are written in:
+//
+//	(none) or A      implied or accumulator
+//	#value           immediate
+//	addr             zero page, absolute or relative for branches
+//	addr,X  addr,Y   zero page or absolute indexed
+//	(addr)           indirect, JMP only
+//	(zp,X)  (zp),Y   indexed indirect and indirect indexed
+//
+// Zero page is chosen when the address is known to be below $100 when the
+// statement is first sized; addresses of later labels get the absolute form
+// in both passes so that the program's layout does not change.
+func (a *assembler) match6502(stmt *Statement, lookup SymbolLookup) (encoding, *Operand, error) {
+	modes, operand, err := a.operandModes6502(stmt, lookup)
+	if err != nil {
+		return encoding{}, nil, err
+	}
+	for _, mode := range modes {
+		for _, enc := range a.set.mnemonics[stmt.Op] {
+			if enc.Instruction.Mode == mode {
+				return enc, operand, nil
+			}
+		}
+	}
+	return encoding{}, nil, fmt.Errorf("%s does not support %s addressing", stmt.Op, modeName6502(modes[len(modes)-1]))
+}
+
+// operandModes6502 returns the addressing modes the statement's operands can
+// mean, preferred first, and the operand holding the value or address
+func (a *assembler) operandModes6502(stmt *Statement, lookup SymbolLookup) ([]cpu.AddressingMode, *Operand, error) {
+	operands := stmt.Operands
+	switch {
+	case len(operands) == 0:
+		return []cpu.AddressingMode{cpu.Implied, cpu.Accumulator}, nil, nil
+	case len(operands) > 2:
+		return nil, nil, fmt.Errorf("%s takes at most two operands", stmt.Op)
+	}
+	operand := &operands[0]
+	if operand.IsString {
+		return nil, nil, fmt.Errorf("%s does not take a string operand", stmt.Op)
+	}
+
+	// Second operand: the index register of addr,X, addr,Y and (zp),Y
+	index := ""
+	if len(operands) == 2 {
+		switch {
+		case isName(operands[1], "X"):
+			index = "X"
+		case isName(operands[1], "Y"):
+			index = "Y"
+		default:
+			return nil, nil, fmt.Errorf("%s: expected index register X or Y, got %s", stmt.Op, operands[1].Text)
+		}
+		if operand.Immediate || operand.Index != "" {
+			return nil, nil, fmt.Errorf("%s: %s cannot be indexed", stmt.Op, operand.Text)
+		}
+	}
+
+	switch {
+	case operand.Immediate:
+		return []cpu.AddressingMode{cpu.Immediate}, operand, nil
+	case operand.Index == "X":
+		return []cpu.AddressingMode{cpu.IndexedIndirect}, operand, nil
+	case operand.Index != "":
+		return nil, nil, fmt.Errorf("%s: only X can index inside parentheses, got %s", stmt.Op, operand.Text)
+	case operand.Indirect && index == "Y":
+		return []cpu.AddressingMode{cpu.IndirectIndexed}, operand, nil
+	case operand.Indirect && index == "":
+		return []cpu.AddressingMode{cpu.Indirect}, operand, nil
+	case operand.Indirect:
+		return nil, nil, fmt.Errorf("%s: ($zp),X is not a 6502 addressing mode, use ($zp,X)", stmt.Op)
+	case index == "" && isName(*operand, "A"):
+		return []cpu.AddressingMode{cpu.Accumulator}, nil, nil
+	}
+
+	absolute := map[string][]cpu.AddressingMode{
+		"":  {cpu.Relative, cpu.Absolute},
+		"X": {cpu.AbsoluteX},
+		"Y": {cpu.AbsoluteY},
+	}[index]
+	if !a.zeroPage6502(stmt, operand, lookup) {
+		return absolute, operand, nil
+	}
+	zeroPage := map[string]cpu.AddressingMode{"": cpu.ZeroPage, "X": cpu.ZeroPageX, "Y": cpu.ZeroPageY}[index]
+	return append([]cpu.AddressingMode{zeroPage}, absolute...), operand, nil
+}
+
+// zeroPage6502 reports whether an address operand fits the zero page. The
+// first pass records operands it cannot evaluate yet, and the second pass
+// keeps them absolute.
+func (a *assembler) zeroPage6502(stmt *Statement, operand *Operand, lookup SymbolLookup) bool {
+	if a.wide[stmt] {
+		return false
+	}
+	if lookup == nil {
+		lookup = a.resolve
+	}
+	value, err := operand.Expr.Eval(lookup)
+	if err != nil {
+		a.wide[stmt] = true
+		return false
+	}
+	return value >= 0 && value <= 0xFF
+}
+
+// encode6502 emits a 6502 instruction with its operand
+func (a *assembler) encode6502(stmt *Statement, out *emitter) error {
+	enc, operand, err := a.match6502(stmt, a.lookup)
+	if err != nil {
+		return err
+	}
+	out.Emit(enc.Opcode)
+	if operand == nil {
+		return nil
+	}
+
+	mnemonic := stmt.Op
+	value, err := operand.Expr.Eval(a.lookup)
+	if err != nil {
+		return fmt.Errorf("Unknown label or address: %s (%v)", operand.Text, err)
+	}
+	switch enc.Instruction.Mode {
+	case cpu.Immediate:
+		if value < -0x80 || value > 0xFF {
+			return fmt.Errorf("%s only loads 8 bits, got %s", mnemonic, operand.Text)
+		}
+		out.Relocate(out.addr, 1, relocationOf(operand.Expr, a.isLabel))
+		out.Emit(byte(value))
+	case cpu.ZeroPage, cpu.ZeroPageX, cpu.ZeroPageY, cpu.IndexedIndirect, cpu.IndirectIndexed:
+		if value < 0 || value > 0xFF {
+			return fmt.Errorf("Zero page address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.Emit(byte(value))
+	case cpu.Absolute, cpu.AbsoluteX, cpu.AbsoluteY, cpu.Indirect:
+		if value < 0 || value > 0xFFFF {
+			return fmt.Errorf("Address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.EmitWord(uint16(value), relocationOf(operand.Expr, a.isLabel))
+	case cpu.Relative:
+		// The offset counts from the address after the branch
+		offset := value - int64(out.addr) - 1
+		if offset < -0x80 || offset > 0x7F {
+			return fmt.Errorf("Branch target out of range for %s: %s is %d bytes away", mnemonic, operand.Text, offset)
+		}
+		out.Emit(byte(offset))
+	}
+	return nil
+}
+
+// modeName6502 describes an addressing mode in error messages
+func modeName6502(mode cpu.AddressingMode) string {
+	switch mode {
+	case cpu.Implied, cpu.Accumulator:
+		return "implied"
+	case cpu.Immediate:
+		return "immediate"
+	case cpu.AbsoluteX:
+		return "addr,X"
+	case cpu.AbsoluteY:
+		return "addr,Y"
+	case cpu.Indirect:
+		return "(addr)"
+	case cpu.IndexedIndirect:
+		return "(zp,X)"
+	case cpu.IndirectIndexed:
+		return "(zp),Y"
+	}
+	return "address"
+}
@@ -0,0 +1,134 @@
+package asm
+
+import (
+	"fmt"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// match6800 finds the 6800 encoding for the addressing mode the statement's operands are written in:
+//
+//	(none)           inherent, including the accumulator forms such as NEGA
+//	#value           immediate, 16 bits for CPX, LDS and LDX
+//	addr             direct, extended or relative for branches
+//	offset,X         indexed by X with an unsigned 8-bit offset; write 0,X for X itself
+//
+// Direct addressing is chosen as the 6502's zero page is: when the address is
+// known to be below $100 when the statement is first sized.
+func (a *assembler) match6800(stmt *Statement, lookup SymbolLookup) (encoding, *Operand, error) {
+	modes, operand, err := a.operandModes6800(stmt, lookup)
+	if err != nil {
+		return encoding{}, nil, err
+	}
+	for _, mode := range modes {
+		for _, enc := range a.set.mnemonics[stmt.Op] {
+			if enc.Instruction.Mode == mode {
+				return enc, operand, nil
+			}
+		}
+	}
+	return encoding{}, nil, fmt.Errorf("%s does not support %s addressing", stmt.Op, modeName6800(modes[len(modes)-1]))
+}
+
+// operandModes6800 returns the addressing modes the statement's operands can
+// mean, preferred first, and the operand holding the value or address
+func (a *assembler) operandModes6800(stmt *Statement, lookup SymbolLookup) ([]cpu.AddressingMode, *Operand, error) {
+	operands := stmt.Operands
+	switch {
+	case len(operands) == 0:
+		return []cpu.AddressingMode{cpu.Implied}, nil, nil
+	case len(operands) > 2:
+		return nil, nil, fmt.Errorf("%s takes at most two operands", stmt.Op)
+	}
+	operand := &operands[0]
+	switch {
+	case operand.IsString:
+		return nil, nil, fmt.Errorf("%s does not take a string operand", stmt.Op)
+	case operand.Indirect:
+		return nil, nil, fmt.Errorf("%s: the 6800 has no indirect addressing, got %s", stmt.Op, operand.Text)
+	}
+
+	if len(operands) == 2 {
+		if !isName(operands[1], "X") {
+			return nil, nil, fmt.Errorf("%s: expected index register X, got %s", stmt.Op, operands[1].Text)
+		}
+		if operand.Immediate {
+			return nil, nil, fmt.Errorf("%s: %s cannot be indexed", stmt.Op, operand.Text)
+		}
+		return []cpu.AddressingMode{cpu.IndexedOffset}, operand, nil
+	}
+	if operand.Immediate {
+		return []cpu.AddressingMode{cpu.Immediate, cpu.Immediate16}, operand, nil
+	}
+	if !a.zeroPage6502(stmt, operand, lookup) {
+		return []cpu.AddressingMode{cpu.Relative, cpu.Absolute}, operand, nil
+	}
+	return []cpu.AddressingMode{cpu.Relative, cpu.ZeroPage, cpu.Absolute}, operand, nil
+}
+
+// encode6800 emits a 6800 instruction with its operand
+func (a *assembler) encode6800(stmt *Statement, out *emitter) error {
+	enc, operand, err := a.match6800(stmt, a.lookup)
+	if err != nil {
+		return err
+	}
+	out.Emit(enc.Opcode)
+	if operand == nil {
+		return nil
+	}
+
+	mnemonic := stmt.Op
+	value, err := operand.Expr.Eval(a.lookup)
+	if err != nil {
+		return fmt.Errorf("Unknown label or address: %s (%v)", operand.Text, err)
+	}
+	switch enc.Instruction.Mode {
+	case cpu.Immediate:
+		if value < -0x80 || value > 0xFF {
+			return fmt.Errorf("%s only loads 8 bits, got %s", mnemonic, operand.Text)
+		}
+		out.Relocate(out.addr, 1, relocationOf(operand.Expr, a.isLabel))
+		out.Emit(byte(value))
+	case cpu.Immediate16:
+		if value < -0x8000 || value > 0xFFFF {
+			return fmt.Errorf("%s only loads 16 bits, got %s", mnemonic, operand.Text)
+		}
+		out.EmitWord(uint16(value), relocationOf(operand.Expr, a.isLabel))
+	case cpu.ZeroPage:
+		if value < 0 || value > 0xFF {
+			return fmt.Errorf("Direct address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.Emit(byte(value))
+	case cpu.IndexedOffset:
+		if value < 0 || value > 0xFF {
+			return fmt.Errorf("Index offset out of range for %s: %s is not 0-255", mnemonic, operand.Text)
+		}
+		out.Emit(byte(value))
+	case cpu.Absolute:
+		if value < 0 || value > 0xFFFF {
+			return fmt.Errorf("Address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.EmitWord(uint16(value), relocationOf(operand.Expr, a.isLabel))
+	case cpu.Relative:
+		// The offset counts from the address after the branch
+		offset := value - int64(out.addr) - 1
+		if offset < -0x80 || offset > 0x7F {
+			return fmt.Errorf("Branch target out of range for %s: %s is %d bytes away", mnemonic, operand.Text, offset)
+		}
+		out.Emit(byte(offset))
+	}
+	return nil
+}
+
+// modeName6800 describes an addressing mode in error messages
+func modeName6800(mode cpu.AddressingMode) string {
+	switch mode {
+	case cpu.Implied:
+		return "inherent"
+	case cpu.Immediate, cpu.Immediate16:
+		return "immediate"
+	case cpu.IndexedOffset:
+		return "offset,X"
+	}
+	return "address"
+}
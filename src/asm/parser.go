@@ -0,0 +1,210 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Statement is the parsed form of one source line
+type Statement struct {
+	Source   SourceLine // Line the statement was parsed from
+	Label    string     // Label defined on the line, empty if none
+	Op       string     // Mnemonic or directive in upper case, empty for label-only lines
+	Operands []Operand  // Comma-separated operands
+}
+
+// Operand is a single operand of an instruction or directive
+type Operand struct {
+	Text      string // Source text of the operand, used in messages
+	Immediate bool   // The operand was prefixed with '#'
+	Indirect  bool   // The operand is wrapped in parentheses, as in ($10) or ($10,X)
+	Index     string // Index register inside the parentheses, "X" for ($10,X)
+	Expr      Expr   // Value of the operand; nil for string operands
+	Str       string // Value of a string operand
+	IsString  bool   // The operand is a string literal
+}
+
+// tokenParser walks the tokens of a single line
+type tokenParser struct {
+	tokens []Token
+	pos    int
+}
+
+func (p *tokenParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *tokenParser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *tokenParser) next() Token {
+	token := p.tokens[p.pos]
+	p.pos++
+	return token
+}
+
+// parseLine parses a source line into a statement; empty and comment-only lines give an empty statement
+func parseLine(source SourceLine) (*Statement, error) {
+	tokens, err := lexLine(source.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &Statement{Source: source}
+	p := &tokenParser{tokens: tokens}
+	if p.done() {
+		return stmt, nil
+	}
+
+	// Optional label: "name:", "-", "+", "-:" or "+:"
+	first := p.peek()
+	switch {
+	case first.Kind == TokenIdent && len(tokens) > 1 && tokens[1].Is(":"):
+		stmt.Label = first.Text
+		p.pos += 2
+	case first.Is("-") || first.Is("+"):
+		if len(tokens) == 1 {
+			stmt.Label = first.Text
+			p.pos++
+		} else if tokens[1].Is(":") {
+			stmt.Label = first.Text
+			p.pos += 2
+		}
+	}
+	if p.done() {
+		return stmt, nil
+	}
+
+	// Mnemonic or directive
+	op := p.next()
+	if op.Kind != TokenIdent {
+		return nil, fmt.Errorf("expected mnemonic or directive, got %s", op)
+	}
+	stmt.Op = strings.ToUpper(op.Text)
+
+	// Operands separated by top-level commas
+	for !p.done() {
+		start := p.pos
+		depth := 0
+		for !p.done() {
+			token := p.peek()
+			if token.Is(",") && depth == 0 {
+				break
+			}
+			if token.Is("(") {
+				depth++
+			} else if token.Is(")") {
+				depth--
+			}
+			p.next()
+		}
+
+		operand, err := parseOperand(source.Text, tokens[start:p.pos])
+		if err != nil {
+			return nil, err
+		}
+		stmt.Operands = append(stmt.Operands, operand)
+
+		if !p.done() {
+			p.next() // Skip the comma
+			if p.done() {
+				return nil, fmt.Errorf("missing operand after ','")
+			}
+		}
+	}
+
+	return stmt, nil
+}
+
+// parseOperand parses the tokens of a single operand
+func parseOperand(line string, tokens []Token) (Operand, error) {
+	if len(tokens) == 0 {
+		return Operand{}, fmt.Errorf("empty operand")
+	}
+
+	last := tokens[len(tokens)-1]
+	operand := Operand{Text: strings.TrimSpace(line[tokens[0].Col-1 : last.Col-1+len(last.Text)])}
+	if last.Kind == TokenString {
+		operand.Text = strings.TrimSpace(line[tokens[0].Col-1:])
+		if end := strings.LastIndex(operand.Text, "\""); end != -1 {
+			operand.Text = operand.Text[:end+1]
+		}
+	}
+
+	if tokens[0].Is("#") {
+		operand.Immediate = true
+		tokens = tokens[1:]
+		if len(tokens) == 0 {
+			return Operand{}, fmt.Errorf("missing value after '#'")
+		}
+	}
+
+	// String literal
+	if tokens[0].Kind == TokenString {
+		if len(tokens) > 1 {
+			return Operand{}, fmt.Errorf("unexpected %s after string", tokens[1])
+		}
+		operand.IsString = true
+		operand.Str = tokens[0].Text
+		return operand, nil
+	}
+
+	// Anonymous label reference: a run of '-' or '+'
+	if name, ok := anonymousReference(tokens); ok {
+		operand.Expr = &SymbolExpr{Name: name}
+		return operand, nil
+	}
+
+	// Indirect operand: "(expr)" or "(expr,X)"
+	if !operand.Immediate && wrapped(tokens) {
+		operand.Indirect = true
+		inner := tokens[1 : len(tokens)-1]
+		if n := len(inner); n > 2 && inner[n-2].Is(",") && inner[n-1].Kind == TokenIdent {
+			operand.Index = strings.ToUpper(inner[n-1].Text)
+			tokens = inner[:n-2]
+		}
+	}
+
+	p := &tokenParser{tokens: tokens}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return Operand{}, err
+	}
+	if !p.done() {
+		return Operand{}, fmt.Errorf("unexpected %s in operand %s", p.peek(), operand.Text)
+	}
+	operand.Expr = expr
+	return operand, nil
+}
+
+// wrapped reports whether the tokens are enclosed in one pair of parentheses
+func wrapped(tokens []Token) bool {
+	if len(tokens) < 3 || !tokens[0].Is("(") {
+		return false
+	}
+	depth := 0
+	for i, token := range tokens {
+		if token.Is("(") {
+			depth++
+		} else if token.Is(")") {
+			depth--
+			if depth == 0 {
+				return i == len(tokens)-1
+			}
+		}
+	}
+	return false
+}
+
+// anonymousReference recognizes operands such as "-", "--" or "++"
+func anonymousReference(tokens []Token) (string, bool) {
+	var name strings.Builder
+	for _, token := range tokens {
+		if !token.Is(tokens[0].Text) || !(token.Is("-") || token.Is("+")) {
+			return "", false
+		}
+		name.WriteString(token.Text)
+	}
+	return name.String(), true
+}
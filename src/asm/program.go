@@ -0,0 +1,60 @@
+package asm
+
+import (
+	"github.com/lukasz-gorgol/g8b/src/image"
+)
+
+// Program is an assembled program together with its symbols and source map
+type Program struct {
+	*image.Image                    // Segments, entry point and relocations
+	Symbols      map[string]*Symbol // Labels and predefined symbols by name; local labels as "global.local"
+	Lines        []Line             // Source map: every source line, in order, with the code it produced
+}
+
+// Symbol is a label or a symbol defined in the options
+type Symbol struct {
+	Name    string
+	Value   int64
+	IsLabel bool         // Label in the source rather than a predefined symbol
+	Source  SourceLine   // Line that defined the label
+	Refs    []SourceLine // Lines referring to the symbol, in source order
+}
+
+// Line is one source line with the address and bytes it produced
+type Line struct {
+	Source  SourceLine
+	Addr    uint16 // Address of the first emitted byte
+	Bytes   []byte // Bytes emitted for the line
+	HasAddr bool   // Whether Addr is meaningful (the line emitted code, has a label or set the address)
+	Skipped bool   // Line was excluded by conditional assembly
+}
+
+// Label returns the address of a label
+func (p *Program) Label(name string) (uint16, bool) {
+	symbol, ok := p.Symbols[name]
+	if !ok || !symbol.IsLabel {
+		return 0, false
+	}
+	return uint16(symbol.Value), true
+}
+
+// LineAt returns the source line whose code contains addr
+func (p *Program) LineAt(addr uint16) (Line, bool) {
+	for _, line := range p.Lines {
+		if len(line.Bytes) > 0 && addr >= line.Addr && int(addr) < int(line.Addr)+len(line.Bytes) {
+			return line, true
+		}
+	}
+	return Line{}, false
+}
+
+// Evaluate evaluates an expression such as "buffer+2" with the program's symbols
+func (p *Program) Evaluate(text string) (int64, error) {
+	return evalExpr(text, func(name string) (int64, bool) {
+		symbol, ok := p.Symbols[name]
+		if !ok {
+			return 0, false
+		}
+		return symbol.Value, true
+	})
+}
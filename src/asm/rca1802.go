@@ -0,0 +1,62 @@
+package asm
+
+import (
+	"fmt"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// encode1802 emits an 1802 instruction. Short branches jump within the
+// 256-byte page of their operand byte; long branches take a 16-bit address,
+// high byte first.
+func (a *assembler) encode1802(stmt *Statement, out *emitter) error {
+	enc, operands, err := a.set.Match(stmt, a.lookup)
+	if err != nil {
+		return err
+	}
+	mnemonic := stmt.Op
+	instruction := enc.Instruction
+	out.Emit(enc.Opcode)
+	if instruction.Size == 1 {
+		if len(operands) > 0 {
+			return fmt.Errorf("%s takes no operand, got %s", mnemonic, operands[0].Text)
+		}
+		return nil
+	}
+	if len(operands) != 1 {
+		return fmt.Errorf("%s takes exactly one data or address operand", mnemonic)
+	}
+	operand := operands[0]
+	if operand.IsString || operand.Immediate || operand.Indirect || operand.Index != "" {
+		return fmt.Errorf("%s does not take operand %s", mnemonic, operand.Text)
+	}
+	value, err := operand.Expr.Eval(a.lookup)
+	if err != nil {
+		return fmt.Errorf("Unknown label or address: %s (%v)", operand.Text, err)
+	}
+
+	reloc := relocationOf(operand.Expr, a.isLabel)
+	switch instruction.Mode {
+	case cpu.Immediate:
+		if value < -0x80 || value > 0xFF {
+			return fmt.Errorf("%s only loads 8 bits, got %s", mnemonic, operand.Text)
+		}
+	case cpu.OperandPage:
+		// The 8-bit field cannot hold a relocation, so a program with short
+		// branches is not relocatable
+		page := out.addr &^ 0xFF
+		if value < int64(page) || value > int64(page)+0xFF {
+			return fmt.Errorf("Branch target out of range for %s: %s is not in the page of the operand ($%04X-$%04X)",
+				mnemonic, operand.Text, page, page+0xFF)
+		}
+	case cpu.Absolute:
+		if value < 0 || value > 0xFFFF {
+			return fmt.Errorf("Address out of range for %s: %s", mnemonic, operand.Text)
+		}
+		out.EmitWord(uint16(value), reloc)
+		return nil
+	}
+	out.Relocate(out.addr, 1, reloc)
+	out.Emit(byte(value))
+	return nil
+}
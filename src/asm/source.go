@@ -0,0 +1,158 @@
+package asm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SourceLine is a single line of assembler source together with its origin
+type SourceLine struct {
+	File  string // Path of the file the line was read from
+	Num   int    // Line number within the file (1-based)
+	Text  string // Raw text of the line
+	Depth int    // Include nesting level, 0 for the main source file
+}
+
+// String returns the "file:line" location of the source line
+func (l SourceLine) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Num)
+}
+
+// SourceLoader reads assembler sources and expands INCLUDE directives
+type SourceLoader struct {
+	IncludePaths []string          // Extra directories searched for INCLUDE and INCBIN files
+	stack        []string          // Absolute paths of the files currently being included
+	binaries     map[string][]byte // Cache of files read by INCBIN
+}
+
+// NewSourceLoader creates a new source loader with the given include paths
+func NewSourceLoader(includePaths []string) *SourceLoader {
+	return &SourceLoader{
+		IncludePaths: includePaths,
+		binaries:     make(map[string][]byte),
+	}
+}
+
+// Load reads the given file and returns its lines with all INCLUDE directives expanded.
+// Each INCLUDE line is kept, directly followed by the lines of the included file.
+func (l *SourceLoader) Load(path string) ([]SourceLine, error) {
+	return l.load(path, nil)
+}
+
+// LoadReader reads source from r like Load; name is used in messages and to resolve includes
+func (l *SourceLoader) LoadReader(name string, r io.Reader) ([]SourceLine, error) {
+	return l.read(name, r, nil)
+}
+
+// load opens a file and reads it; from is the including line, if any
+func (l *SourceLoader) load(path string, from *SourceLine) ([]SourceLine, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if from != nil {
+			return nil, errorAt(*from, "%v", err)
+		}
+		return nil, err
+	}
+	defer file.Close()
+	return l.read(path, file, from)
+}
+
+// read reads source lines, recursively expanding includes
+func (l *SourceLoader) read(path string, r io.Reader, from *SourceLine) ([]SourceLine, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Detect include cycles
+	for i, p := range l.stack {
+		if p == absPath {
+			chain := append(append([]string{}, l.stack[i:]...), absPath)
+			return nil, errorAt(*from, "include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	l.stack = append(l.stack, absPath)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	var lines []SourceLine
+	scanner := bufio.NewScanner(r)
+	num := 0
+	for scanner.Scan() {
+		num++
+		line := SourceLine{File: path, Num: num, Text: scanner.Text(), Depth: len(l.stack) - 1}
+
+		// Lines that do not parse are passed through; the assembler reports the error
+		stmt, err := parseLine(line)
+		if err != nil || stmt.Op != "INCLUDE" {
+			lines = append(lines, line)
+			continue
+		}
+
+		// Expand INCLUDE "file.asm"
+		if stmt.Label != "" || len(stmt.Operands) != 1 || !stmt.Operands[0].IsString {
+			return nil, errorAt(line, "INCLUDE: expected a single quoted file name")
+		}
+		name := stmt.Operands[0].Str
+		resolved, err := l.Resolve(name, path)
+		if err != nil {
+			return nil, errorAt(line, "INCLUDE: %v", err)
+		}
+		included, err := l.load(resolved, &line)
+		if err != nil {
+			return nil, err
+		}
+
+		// The directive stays in place for the listing, followed by the included lines
+		lines = append(lines, line)
+		lines = append(lines, included...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, Diagnostic{Source: SourceLine{File: path}, Message: err.Error()}
+	}
+
+	return lines, nil
+}
+
+// Resolve finds an included file, first relative to the including file and then in the include paths
+func (l *SourceLoader) Resolve(name, from string) (string, error) {
+	if filepath.IsAbs(name) {
+		if _, err := os.Stat(name); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+
+	candidates := []string{filepath.Join(filepath.Dir(from), name)}
+	for _, dir := range l.IncludePaths {
+		candidates = append(candidates, filepath.Join(dir, name))
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("file not found: %s", name)
+}
+
+// ReadBinary reads a file for INCBIN, resolving it like an include
+func (l *SourceLoader) ReadBinary(name, from string) ([]byte, error) {
+	resolved, err := l.Resolve(name, from)
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := l.binaries[resolved]; ok {
+		return data, nil
+	}
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return nil, err
+	}
+	l.binaries[resolved] = data
+	return data, nil
+}
@@ -0,0 +1,235 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// z80Names are the register and condition names a Z80 operand can be; they
+// cannot be used as labels in data and address operands
+var z80Names = map[string]bool{
+	"A": true, "B": true, "C": true, "D": true, "E": true, "H": true, "L": true, "F": true, "I": true, "R": true,
+	"AF": true, "AF'": true, "BC": true, "DE": true, "HL": true, "SP": true, "IX": true, "IY": true,
+	"IXH": true, "IXL": true, "IYH": true, "IYL": true,
+	"NZ": true, "Z": true, "NC": true, "PO": true, "PE": true, "P": true, "M": true,
+}
+
+// matchZ80 finds the Z80 encoding whose form the statement's operands are
+// written in. Each operand must match the form's operand in the same place:
+//
+//	A  HL  NZ  AF'      register and condition names
+//	(HL)  (C)  (SP)     registers in parentheses
+//	(IX+d)  (IY+d)      index register with a displacement, which may be left out
+//	n  nn  e            data, address or relative jump target
+//	(n)  (nn)           port or memory address in parentheses
+//	0-7  00H-38H        numbers encoded in the opcode, such as bit and restart numbers
+//
+// Without lookup, as in the first pass, numbers match any value.
+func (a *assembler) matchZ80(stmt *Statement, lookup SymbolLookup) (encoding, error) {
+	for _, enc := range a.set.mnemonics[stmt.Op] {
+		if len(stmt.Operands) != len(enc.Form.Operands) {
+			continue
+		}
+		matched := true
+		for i, pattern := range enc.Form.Operands {
+			ok, err := matchZ80Operand(stmt.Operands[i], pattern, lookup)
+			if err != nil {
+				return encoding{}, fmt.Errorf("%s: %v", stmt.Op, err)
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return enc, nil
+		}
+	}
+
+	// Show what the mnemonic accepts
+	forms := z80Forms(a.set.mnemonics[stmt.Op])
+	if len(stmt.Operands) == 0 {
+		return encoding{}, fmt.Errorf("%s needs operands (expected %s)", stmt.Op, forms)
+	}
+	var operands []string
+	for _, operand := range stmt.Operands {
+		operands = append(operands, operand.Text)
+	}
+	return encoding{}, fmt.Errorf("%s does not take operands %s (expected %s)", stmt.Op, strings.Join(operands, ","), forms)
+}
+
+// z80Forms lists the distinct forms of a mnemonic for error messages
+func z80Forms(encodings []encoding) string {
+	var forms []string
+	seen := make(map[string]bool)
+	for _, enc := range encodings {
+		if form := enc.Form.String(); !seen[form] {
+			seen[form] = true
+			forms = append(forms, form)
+		}
+	}
+	if len(forms) > maxListedForms {
+		forms = append(forms[:maxListedForms], "...")
+	}
+	return strings.Join(forms, ", ")
+}
+
+// matchZ80Operand reports whether an operand is written as a form's operand pattern
+func matchZ80Operand(operand Operand, pattern string, lookup SymbolLookup) (bool, error) {
+	if operand.IsString || operand.Index != "" {
+		return false, nil
+	}
+	switch pattern {
+	case "n", "nn", "e":
+		return !operand.Indirect && !z80Name(operand.Expr), nil
+	case "(n)", "(nn)":
+		return operand.Indirect && !operand.Immediate && !z80Name(operand.Expr), nil
+	case "(IX+d)", "(IY+d)":
+		_, ok := z80Displacement(operand, pattern[1:3])
+		return ok, nil
+	}
+
+	if operand.Immediate {
+		return false, nil
+	}
+	if strings.HasPrefix(pattern, "(") {
+		return operand.Indirect && isName(Operand{Expr: operand.Expr}, pattern[1:len(pattern)-1]), nil
+	}
+	if !cpu.IsNumber(pattern) {
+		return !operand.Indirect && isName(operand, pattern), nil
+	}
+
+	// A number encoded in the opcode
+	if operand.Indirect || z80Name(operand.Expr) {
+		return false, nil
+	}
+	if lookup == nil {
+		return true, nil
+	}
+	want, err := parseNumber(pattern)
+	if err != nil {
+		return false, err
+	}
+	value, err := operand.Expr.Eval(lookup)
+	if err != nil {
+		return false, err
+	}
+	return value == int64(want), nil
+}
+
+// z80Name reports whether an expression is, or adds to, a register or
+// condition name, as in HL or IX+5
+func z80Name(expr Expr) bool {
+	for {
+		binary, ok := expr.(*BinaryExpr)
+		if !ok {
+			break
+		}
+		expr = binary.Left
+	}
+	symbol, ok := expr.(*SymbolExpr)
+	return ok && z80Names[strings.ToUpper(symbol.Name)]
+}
+
+// z80Displacement returns the displacement expression of an (IX+d) or (IY+d)
+// operand written with the given index register: (IX), (IX+expr) or
+// (IX-expr). The displacement is the whole expression with the register as 0.
+func z80Displacement(operand Operand, index string) (Expr, bool) {
+	if !operand.Indirect || operand.Immediate {
+		return nil, false
+	}
+	expr := operand.Expr
+	for {
+		binary, ok := expr.(*BinaryExpr)
+		if !ok || (binary.Op != "+" && binary.Op != "-") {
+			break
+		}
+		expr = binary.Left
+	}
+	if symbol, ok := expr.(*SymbolExpr); !ok || !strings.EqualFold(symbol.Name, index) {
+		return nil, false
+	}
+	return operand.Expr, true
+}
+
+// encodeZ80 emits a Z80 instruction: its prefixes, then the opcode followed by
+// the displacement and data operands, or for DD CB and FD CB the displacement
+// followed by the opcode
+func (a *assembler) encodeZ80(stmt *Statement, out *emitter) error {
+	enc, err := a.matchZ80(stmt, a.lookup)
+	if err != nil {
+		return err
+	}
+	start := out.addr
+	mnemonic := stmt.Op
+
+	// The displacement, if any, comes first
+	var displacement []byte
+	var data []Operand
+	var patterns []string
+	for i, pattern := range enc.Form.Operands {
+		switch pattern {
+		case "(IX+d)", "(IY+d)":
+			expr, _ := z80Displacement(stmt.Operands[i], pattern[1:3])
+			index := strings.ToUpper(pattern[1:3])
+			value, err := expr.Eval(func(name string) (int64, bool) {
+				if strings.EqualFold(name, index) {
+					return 0, true
+				}
+				return a.lookup(name)
+			})
+			if err != nil {
+				return fmt.Errorf("Invalid displacement for %s: %s (%v)", mnemonic, stmt.Operands[i].Text, err)
+			}
+			if value < -0x80 || value > 0x7F {
+				return fmt.Errorf("Displacement out of range for %s: %s", mnemonic, stmt.Operands[i].Text)
+			}
+			displacement = []byte{byte(value)}
+		case "n", "nn", "e", "(n)", "(nn)":
+			data = append(data, stmt.Operands[i])
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	if enc.Prefix > 0xFF {
+		out.Emit(byte(enc.Prefix>>8), byte(enc.Prefix))
+		out.Emit(displacement...)
+		out.Emit(enc.Opcode)
+		return nil
+	}
+	if enc.Prefix != 0 {
+		out.Emit(byte(enc.Prefix))
+	}
+	out.Emit(enc.Opcode)
+	out.Emit(displacement...)
+
+	for i, operand := range data {
+		value, err := operand.Expr.Eval(a.lookup)
+		if err != nil {
+			return fmt.Errorf("Unknown label or address: %s (%v)", operand.Text, err)
+		}
+		switch patterns[i] {
+		case "n", "(n)":
+			if value < -0x80 || value > 0xFF {
+				return fmt.Errorf("%s only takes 8 bits, got %s", mnemonic, operand.Text)
+			}
+			out.Relocate(out.addr, 1, relocationOf(operand.Expr, a.isLabel))
+			out.Emit(byte(value))
+		case "nn", "(nn)":
+			if value < -0x8000 || value > 0xFFFF {
+				return fmt.Errorf("Address out of range for %s: %s", mnemonic, operand.Text)
+			}
+			out.EmitWord(uint16(value), relocationOf(operand.Expr, a.isLabel))
+		case "e":
+			// The offset counts from the address after the jump
+			offset := value - int64(start) - int64(enc.Instruction.Size)
+			if offset < -0x80 || offset > 0x7F {
+				return fmt.Errorf("Jump target out of range for %s: %s is %d bytes away", mnemonic, operand.Text, offset)
+			}
+			out.Emit(byte(offset))
+		}
+	}
+	return nil
+}
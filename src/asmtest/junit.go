@@ -0,0 +1,84 @@
+package asmtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the results as JUnit XML, one testsuite per test file. A
+// file whose tests could not run is reported as a single test with an error.
+func WriteJUnit(w io.Writer, results []*SuiteResult) error {
+	var report junitTestSuites
+	for _, result := range results {
+		suite := junitTestSuite{Name: result.File, Time: junitTime(result.Duration)}
+		if result.Err != nil {
+			var details []string
+			for _, diag := range result.Diagnostics {
+				details = append(details, diag.String())
+			}
+			suite.Tests, suite.Errors = 1, 1
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      "[build]",
+				ClassName: result.File,
+				Time:      junitTime(0),
+				Error:     &junitMessage{Message: result.Err.Error(), Text: strings.Join(details, "\n")},
+			})
+		}
+		for _, test := range result.Results {
+			testCase := junitTestCase{Name: test.Name, ClassName: result.File, Time: junitTime(test.Duration)}
+			if test.Failed() {
+				suite.Failures++
+				testCase.Failure = &junitMessage{Message: test.Failures[0], Text: strings.Join(test.Failures, "\n")}
+			}
+			suite.Tests++
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitTime formats a duration in seconds as JUnit expects
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,345 @@
+package asmtest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lukasz-gorgol/g8b/src/asm"
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+	"github.com/lukasz-gorgol/g8b/src/machine"
+)
+
+// DefaultMaxSteps is how many instructions a call may execute before the test fails
+const DefaultMaxSteps = 1000000
+
+// callStubAddr is where the CAL instruction that starts a call is placed; the
+// subroutine has returned when the program counter reaches the byte after it
+const callStubAddr = 0xFFFC
+
+// Options control which tests run and how
+type Options struct {
+	Match        *regexp.Regexp // Run only tests whose name matches; nil runs all
+	MaxSteps     int            // Instructions a call may execute (default: DefaultMaxSteps)
+	IncludePaths []string       // Extra INCLUDE and INCBIN search directories for the program
+}
+
+// Result is the outcome of one test
+type Result struct {
+	Name     string
+	Line     int
+	Failures []string // Failed checks as "file:line: message", in order
+	Duration time.Duration
+}
+
+// Failed reports whether any check of the test failed
+func (r Result) Failed() bool {
+	return len(r.Failures) > 0
+}
+
+// SuiteResult is the outcome of a test file
+type SuiteResult struct {
+	File        string
+	Results     []Result
+	Diagnostics []asm.Diagnostic // Messages from assembling the program under test
+	Err         error            // Why the tests could not run, e.g. the program does not assemble
+	Duration    time.Duration
+}
+
+// Failed reports whether the file could not run or any of its tests failed
+func (r *SuiteResult) Failed() bool {
+	if r.Err != nil {
+		return true
+	}
+	for _, result := range r.Results {
+		if result.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures counts the failed tests
+func (r *SuiteResult) Failures() int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Failed() {
+			count++
+		}
+	}
+	return count
+}
+
+// Run assembles the program under test and runs the suite's tests against it
+func Run(suite *Suite, opts Options) *SuiteResult {
+	if opts.MaxSteps <= 0 {
+		opts.MaxSteps = DefaultMaxSteps
+	}
+	result := &SuiteResult{File: suite.File}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	source := suite.Source
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(filepath.Dir(suite.File), source)
+	}
+	program, diags := asm.AssembleFile(source, asm.Options{
+		StartAddress: suite.StartAddress,
+		IncludePaths: opts.IncludePaths,
+		Defines:      suite.Defines,
+	})
+	result.Diagnostics = diags
+	if program == nil {
+		result.Err = fmt.Errorf("%s does not assemble", source)
+		return result
+	}
+	for _, segment := range program.Segments {
+		if segment.End() > callStubAddr && int(segment.Addr) < callStubAddr+4 {
+			result.Err = fmt.Errorf("program overlaps $%04X-$FFFF, which the test runner uses to call subroutines", callStubAddr)
+			return result
+		}
+	}
+
+	for _, test := range suite.Tests {
+		if opts.Match != nil && !opts.Match.MatchString(test.Name) {
+			continue
+		}
+		result.Results = append(result.Results, runTest(suite, program, test, opts))
+	}
+	return result
+}
+
+// testRun is the state of one running test
+type testRun struct {
+	suite   *Suite
+	program *asm.Program
+	machine *machine.Machine
+	opts    Options
+	result  *Result
+}
+
+// runTest runs a test on a freshly loaded machine
+func runTest(suite *Suite, program *asm.Program, test *Test, opts Options) Result {
+	result := Result{Name: test.Name, Line: test.Line}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	m, err := machine.NewMachine(machine.Config{})
+	if err == nil {
+		err = m.Load(program.Image)
+	}
+	if err != nil {
+		result.Failures = append(result.Failures, fmt.Sprintf("%s:%d: %v", suite.File, test.Line, err))
+		return result
+	}
+
+	t := &testRun{suite: suite, program: program, machine: m, opts: opts, result: &result}
+	for _, step := range test.Steps {
+		var err error
+		switch step.Kind {
+		case StepSet:
+			err = t.set(step)
+		case StepCall:
+			err = t.call(step)
+		case StepExpect:
+			err = t.expect(step)
+		}
+		// Failed expectations are recorded and the test goes on; any other
+		// error leaves the machine in an unknown state and ends the test
+		if err != nil {
+			t.fail(step, "%v", err)
+			break
+		}
+	}
+	return result
+}
+
+// fail records a failed check
+func (t *testRun) fail(step Step, format string, args ...interface{}) {
+	t.result.Failures = append(t.result.Failures,
+		fmt.Sprintf("%s:%d: %s", t.suite.File, step.Line, fmt.Sprintf(format, args...)))
+}
+
+// evaluate evaluates a value expression with the program's symbols
+func (t *testRun) evaluate(text string, min, max int64) (int64, error) {
+	value, err := t.program.Evaluate(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", text, err)
+	}
+	if value < min || value > max {
+		return 0, fmt.Errorf("%s = %d is out of range", text, value)
+	}
+	return value, nil
+}
+
+// memoryTarget returns the address of a "[expr]" target
+func (t *testRun) memoryTarget(target string) (uint16, bool, error) {
+	if !strings.HasPrefix(target, "[") {
+		return 0, false, nil
+	}
+	addr, err := t.evaluate(strings.TrimSuffix(target[1:], "]"), 0, 0xFFFF)
+	return uint16(addr), true, err
+}
+
+// flagTarget returns the flag name of a "flags.X" target
+func flagTarget(target string) (string, bool) {
+	if len(target) > 6 && strings.EqualFold(target[:6], "flags.") {
+		return strings.ToUpper(target[6:]), true
+	}
+	return "", false
+}
+
+// set sets a register, flag or memory
+func (t *testRun) set(step Step) error {
+	addr, isMemory, err := t.memoryTarget(step.Target)
+	if err != nil {
+		return err
+	}
+	if isMemory {
+		for i, text := range step.Values {
+			value, err := t.evaluate(text, -0x80, 0xFF)
+			if err != nil {
+				return err
+			}
+			t.machine.Write(addr+uint16(i), byte(value))
+		}
+		return nil
+	}
+
+	if flag, ok := flagTarget(step.Target); ok {
+		value, err := t.evaluate(step.Values[0], 0, 1)
+		if err != nil {
+			return err
+		}
+		return t.machine.SetFlag(flag, value == 1)
+	}
+
+	max := int64(0xFF)
+	if strings.EqualFold(step.Target, "PC") {
+		max = 0xFFFF
+	}
+	value, err := t.evaluate(step.Values[0], -0x80, max)
+	if err != nil {
+		return err
+	}
+	return t.machine.SetRegister(step.Target, uint16(value)&uint16(max))
+}
+
+// expect checks a register, flag or memory and records a failure on a mismatch
+func (t *testRun) expect(step Step) error {
+	addr, isMemory, err := t.memoryTarget(step.Target)
+	if err != nil {
+		return err
+	}
+	if isMemory {
+		var got, want []string
+		mismatch := false
+		for i, text := range step.Values {
+			value, err := t.evaluate(text, -0x80, 0xFF)
+			if err != nil {
+				return err
+			}
+			actual := t.machine.Read(addr + uint16(i))
+			mismatch = mismatch || actual != byte(value)
+			got = append(got, fmt.Sprintf("$%02X", actual))
+			want = append(want, fmt.Sprintf("$%02X", byte(value)))
+		}
+		if mismatch {
+			t.fail(step, "%s = %s, want %s", step.Target, strings.Join(got, ","), strings.Join(want, ","))
+		}
+		return nil
+	}
+
+	state := t.machine.State()
+	if flag, ok := flagTarget(step.Target); ok {
+		actual, ok := state.Flags[flag]
+		if !ok {
+			return fmt.Errorf("unknown flag %s", flag)
+		}
+		value, err := t.evaluate(step.Values[0], 0, 1)
+		if err != nil {
+			return err
+		}
+		if actual != (value == 1) {
+			t.fail(step, "%s = %d, want %d", step.Target, boolToInt(actual), value)
+		}
+		return nil
+	}
+
+	var actual uint16
+	digits := 2
+	switch name := strings.ToUpper(step.Target); name {
+	case "PC":
+		actual, digits = state.PC, 4
+	case "SP":
+		actual = state.SP
+	default:
+		register, ok := state.Registers[name]
+		if !ok {
+			return fmt.Errorf("unknown register %s", step.Target)
+		}
+		actual = register
+	}
+	max := int64(1)<<(4*digits) - 1
+	value, err := t.evaluate(step.Values[0], -0x80, max)
+	if err != nil {
+		return err
+	}
+	if want := uint16(value) & uint16(max); actual != want {
+		t.fail(step, "%s = $%0*X, want $%0*X", step.Target, digits, actual, digits, want)
+	}
+	return nil
+}
+
+// call calls a subroutine through a CAL instruction at callStubAddr and runs
+// until it returns past that instruction
+func (t *testRun) call(step Step) error {
+	target, err := t.evaluate(step.Target, 0, 0xFFFF)
+	if err != nil {
+		return err
+	}
+	opcode, err := callOpcode(t.machine.CPU().GetInstructions())
+	if err != nil {
+		return err
+	}
+	t.machine.Write(callStubAddr, opcode)
+	t.machine.Write(callStubAddr+1, byte(target))
+	t.machine.Write(callStubAddr+2, byte(target>>8))
+	t.machine.SetRegister("PC", callStubAddr)
+
+	returnAddr := uint16(callStubAddr + 3)
+	for i := 0; i <= t.opts.MaxSteps; i++ {
+		// The first step executes the CAL itself
+		if i > 0 && t.machine.CPU().GetPC() == returnAddr {
+			return nil
+		}
+		if err := t.machine.Step(); err != nil {
+			if errors.Is(err, cpu.ErrHalted) {
+				return fmt.Errorf("%s halted at $%04X instead of returning", step.Target, t.machine.CPU().GetPC()-1)
+			}
+			return fmt.Errorf("%s: %v", step.Target, err)
+		}
+	}
+	return fmt.Errorf("%s did not return within %d instructions (PC=$%04X)",
+		step.Target, t.opts.MaxSteps, t.machine.CPU().GetPC())
+}
+
+// callOpcode returns the opcode of the unconditional call instruction
+func callOpcode(instructions map[byte]cpu.Instruction) (byte, error) {
+	for opcode := 0; opcode < 256; opcode++ {
+		if instruction, ok := instructions[byte(opcode)]; ok && instruction.Mnemonic == "CAL" {
+			return byte(opcode), nil
+		}
+	}
+	return 0, fmt.Errorf("the CPU has no CAL instruction")
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
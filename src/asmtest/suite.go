@@ -0,0 +1,185 @@
+// Package asmtest runs unit tests written for assembly subroutines: each test
+// sets registers and memory, calls a label and checks the results.
+package asmtest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// StepKind is what a test step does
+type StepKind int
+
+const (
+	StepSet    StepKind = iota // Set a register, flag or memory
+	StepCall                   // Call a subroutine and wait for it to return
+	StepExpect                 // Check a register, flag or memory
+)
+
+// Suite is a test file: the program under test and its tests
+type Suite struct {
+	File         string            // Path of the test file
+	Source       string            // Program under test; relative paths resolve from the test file
+	StartAddress uint16            // Address the program is assembled for (default $8000)
+	Defines      map[string]string // Symbols defined for the assembly (NAME -> expression)
+	Tests        []*Test
+}
+
+// Test is one named test
+type Test struct {
+	Name  string
+	Line  int
+	Steps []Step
+}
+
+// Step is one statement of a test
+type Step struct {
+	Line   int
+	Kind   StepKind
+	Target string   // Register name, "flags.X", "[expr]" for memory, or the address called
+	Values []string // Value expressions; memory targets may list several bytes
+}
+
+// ParseFile reads a test file
+func ParseFile(path string) (*Suite, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(path, file)
+}
+
+// Parse reads a test file from r; name is used in error messages
+func Parse(name string, r io.Reader) (*Suite, error) {
+	suite := &Suite{File: name, StartAddress: 0x8000, Defines: make(map[string]string)}
+	var test *Test
+
+	scanner := bufio.NewScanner(r)
+	for num := 1; scanner.Scan(); num++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, ';'); i != -1 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		errorf := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%s:%d: %s", name, num, fmt.Sprintf(format, args...))
+		}
+
+		keyword, rest := line, ""
+		if i := strings.IndexAny(line, " \t"); i != -1 {
+			keyword, rest = line[:i], strings.TrimSpace(line[i+1:])
+		}
+		keyword = strings.ToLower(keyword)
+
+		if test == nil {
+			switch keyword {
+			case "source":
+				if suite.Source != "" {
+					return nil, errorf("source is already %s", suite.Source)
+				}
+				suite.Source = strings.Trim(rest, `"`)
+			case "start":
+				addr, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(rest, "$"), "0x"), 16, 16)
+				if err != nil {
+					return nil, errorf("invalid start address %q", rest)
+				}
+				suite.StartAddress = uint16(addr)
+			case "define":
+				symbol, value, _ := strings.Cut(rest, "=")
+				if symbol == "" {
+					return nil, errorf("define needs NAME or NAME=value")
+				}
+				suite.Defines[strings.TrimSpace(symbol)] = strings.TrimSpace(value)
+			case "test":
+				if rest == "" {
+					return nil, errorf("test needs a name")
+				}
+				test = &Test{Name: rest, Line: num}
+			default:
+				return nil, errorf("unknown directive %q outside a test", keyword)
+			}
+			continue
+		}
+
+		switch keyword {
+		case "end":
+			suite.Tests = append(suite.Tests, test)
+			test = nil
+		case "set", "expect":
+			step, err := parseAssignment(rest)
+			if err != nil {
+				return nil, errorf("%v", err)
+			}
+			step.Line = num
+			step.Kind = StepSet
+			if keyword == "expect" {
+				step.Kind = StepExpect
+			}
+			test.Steps = append(test.Steps, step)
+		case "call":
+			if rest == "" {
+				return nil, errorf("call needs a label or address")
+			}
+			test.Steps = append(test.Steps, Step{Line: num, Kind: StepCall, Target: rest})
+		case "test":
+			return nil, errorf("test %q has no end", test.Name)
+		default:
+			return nil, errorf("unknown statement %q in test %q", keyword, test.Name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if test != nil {
+		return nil, fmt.Errorf("%s:%d: test %q has no end", name, test.Line, test.Name)
+	}
+	if suite.Source == "" {
+		return nil, fmt.Errorf("%s: no source file given", name)
+	}
+	return suite, nil
+}
+
+// parseAssignment parses "target=value[,value...]" of a set or expect statement
+func parseAssignment(text string) (Step, error) {
+	var target, values string
+	if strings.HasPrefix(text, "[") {
+		end := strings.IndexByte(text, ']')
+		if end == -1 {
+			return Step{}, fmt.Errorf("missing ] in %q", text)
+		}
+		target, values = text[:end+1], strings.TrimSpace(text[end+1:])
+		if !strings.HasPrefix(values, "=") {
+			return Step{}, fmt.Errorf("expected = after %s", target)
+		}
+		values = values[1:]
+	} else {
+		var ok bool
+		target, values, ok = strings.Cut(text, "=")
+		if !ok {
+			return Step{}, fmt.Errorf("expected target=value, got %q", text)
+		}
+		target = strings.TrimSpace(target)
+	}
+
+	step := Step{Target: target}
+	for _, value := range strings.Split(values, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return Step{}, fmt.Errorf("missing value for %s", target)
+		}
+		step.Values = append(step.Values, value)
+	}
+	if len(step.Values) > 1 && !strings.HasPrefix(target, "[") {
+		return Step{}, fmt.Errorf("%s takes a single value", target)
+	}
+	return step, nil
+}
@@ -1,16 +1,16 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/lukasz-gorgol/g8b/src/cpu"
+	"github.com/lukasz-gorgol/g8b/src/asm"
 )
 
 // Config represents the assembler configuration
@@ -20,129 +20,39 @@ type Config struct {
 	CPUType   string `json:"cpu,omitempty"`
 	StartAddr string `json:"start_addr,omitempty"` // Start address as hex string (e.g., "0x8000")
 	XXD       bool   `json:"xxd,omitempty"`
-}
-
-// assemble performs the two-pass assembly process
-func assemble(inputFile *os.File, cpuType string, startAddress uint16) ([]byte, map[string]uint16) {
-	labels := make(map[string]uint16)
-	var currentAddress uint16 = startAddress
-	var binary []byte
-
-	// Get CPU-specific opcodes and instruction sizes
-	var opcodes map[string]byte
-	var instrSizes map[string]int
-
-	switch cpuType {
-	case "8008":
-		opcodes = cpu.NewIntel8008(1, 1).GetOpcodes()
-		instrSizes = cpu.NewIntel8008(1, 1).GetInstrSizes()
-	default:
-		fmt.Printf("🆘 Unsupported CPU type: %s\n", cpuType)
-		fmt.Println("  Available CPU types: 8008")
-		os.Exit(1)
-	}
-
-	// First pass: collect labels
-	scanner := bufio.NewScanner(inputFile)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, ";") {
-			continue
-		}
-
-		if strings.HasSuffix(line, ":") {
-			label := strings.TrimSuffix(line, ":")
-			labels[label] = currentAddress
-			fmt.Printf("Label: %v, address: $%04X\n", label, currentAddress)
-			continue
-		}
-
-		parts := strings.Fields(line)
-		mnemonic := parts[0]
-		currentAddress += uint16(instrSizes[mnemonic])
-	}
-
-	// Second pass: generate binary
-	inputFile.Seek(0, 0)
-	scanner = bufio.NewScanner(inputFile)
-	currentAddress = 0x8000
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, ";") || strings.HasSuffix(line, ":") {
-			continue
-		}
-
-		if idx := strings.Index(line, ";"); idx != -1 {
-			line = line[:idx]
-		}
-
-		parts := strings.Fields(line)
-		mnemonic := parts[0]
-
-		opcode, exists := opcodes[mnemonic]
-		if !exists {
-			fmt.Printf("🆘 Unknown mnemonic: %s\n", mnemonic)
-			os.Exit(1)
-		}
+	Syntax    string `json:"syntax,omitempty"`  // Mnemonic dialect (default: the CPU's original mnemonics)
+	Listing   string `json:"listing,omitempty"` // Listing file to write (default: none)
 
-		binary = append(binary, opcode)
+	IncludePaths []string          `json:"include_paths,omitempty"` // Directories searched for INCLUDE and INCBIN files
+	Defines      map[string]string `json:"defines,omitempty"`       // Symbols for conditional assembly (NAME -> value)
+	Format       string            `json:"format,omitempty"`        // Output format: bin, g8b, ihex or srec (default: from file extension)
+}
 
-		if len(parts) > 1 {
-			operand := parts[1]
-			switch cpuType {
-			case "8008":
-				handle8008Operand(mnemonic, operand, labels, currentAddress, &binary)
-			}
-		}
-		currentAddress += uint16(instrSizes[mnemonic])
-	}
+// stringList is a flag value that collects repeated string flags
+type stringList []string
 
-	return binary, labels
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
 }
 
-func handle8008Operand(mnemonic, operand string, labels map[string]uint16, currentAddress uint16, binary *[]byte) {
-	if strings.HasPrefix(mnemonic, "J") ||
-		strings.HasPrefix(mnemonic, "CA") ||
-		strings.HasPrefix(mnemonic, "CF") ||
-		strings.HasPrefix(mnemonic, "CT") {
-		// Handle absolute instructions
-		var address uint16
-		if strings.HasPrefix(operand, "$") {
-			address64, _ := strconv.ParseUint(operand[1:], 16, 16)
-			address = uint16(address64)
-		} else if addr, ok := labels[operand]; ok {
-			address = addr
-		} else {
-			fmt.Printf("🆘 Unknown label or address: %s\n", operand)
-			os.Exit(1)
-		}
-		*binary = append(*binary, byte(address&0xFF), byte(address>>8))
-	} else if strings.HasSuffix(mnemonic, "I") {
-		// Handle immediate instructions
-		if strings.HasPrefix(operand, "#$") {
-			value, _ := strconv.ParseUint(operand[2:], 16, 16)
-			if (mnemonic == "LHI" || mnemonic == "LLI") && value > 0xFF {
-				fmt.Printf("🆘 Warning: %s only loads 8 bits, got #$%X (truncated to #$%02X)\n", mnemonic, value, value&0xFF)
-				os.Exit(1)
-			}
-			*binary = append(*binary, byte(value))
-		} else {
-			fmt.Printf("🆘 Invalid immediate value format for %s: %s\n", mnemonic, operand)
-			os.Exit(1)
-		}
-	} else {
-		fmt.Printf("🆘 Error: can't assembly %s: %s\n", mnemonic, operand)
-		os.Exit(1)
-	}
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
 }
 
 func main() {
 	// Define command-line flags
 	configFile := flag.String("c", "", "Path to JSON configuration file")
-	cpuType := flag.String("cpu", "8008", "CPU type (default: 8008)")
+	cpuType := flag.String("cpu", "8008", "CPU type: 8008, 8080, 8085, 6502, 6800, 1802, z80, 4004 or 4040 (default: 8008)")
 	startAddr := flag.String("s", "0x8000", "Start address for program loading and PC initialization (hex string)")
 	xxdFlag := flag.Bool("xxd", false, "Run xxd on output binary after assembly")
+	syntaxFlag := flag.String("syntax", "", "Mnemonic dialect: 8008 (LAB, LMI) or 8080 (MOV A,B, MVI M) (default: 8008)")
+	listingFile := flag.String("l", "", "Write a listing with addresses, code and a symbol cross-reference to this file")
+	format := flag.String("f", "", "Output format: bin, g8b, ihex or srec (default: from file extension)")
+	var includePaths stringList
+	flag.Var(&includePaths, "I", "Add a directory to the INCLUDE/INCBIN search path (repeatable)")
+	var defineFlags stringList
+	flag.Var(&defineFlags, "D", "Define a symbol as NAME=value or NAME (repeatable)")
 	flag.Parse()
 
 	// Parse command-line arguments
@@ -152,7 +62,12 @@ func main() {
 		fmt.Println("\nOptions:")
 		fmt.Println("  -c <file>    Path to JSON configuration file")
 		fmt.Println("  -s <addr>    Start address (hex string, e.g., 0x8000)")
-		fmt.Println("  -cpu <type>  CPU type (default: 8008)")
+		fmt.Println("  -cpu <type>  CPU type: 8008, 8080, 8085, 6502, 6800, 1802, z80, 4004 or 4040 (default: 8008)")
+		fmt.Println("  -syntax <s>  Mnemonic dialect: 8008 (LAB, LMI) or 8080 (MOV A,B, MVI M)")
+		fmt.Println("  -I <dir>     Add a directory to the INCLUDE/INCBIN search path")
+		fmt.Println("  -D <name>    Define a symbol as NAME=value or NAME (value 1)")
+		fmt.Println("  -f <format>  Output format: bin, g8b, ihex or srec (default: from file extension)")
+		fmt.Println("  -l <file>    Write a listing with addresses, code and symbols")
 		fmt.Println("  -xxd         Run xxd on output binary after assembly")
 		os.Exit(1)
 	}
@@ -183,6 +98,17 @@ func main() {
 			os.Exit(1)
 		}
 
+		// Only an explicit -s overrides the CPU's default start address
+		startAddrSet := false
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "s" {
+				startAddrSet = true
+			}
+		})
+		if !startAddrSet {
+			*startAddr = ""
+		}
+
 		args := flag.Args()
 		config = Config{
 			Source:    args[0],
@@ -201,18 +127,77 @@ func main() {
 		}
 	}
 
+	// 4004 and 4040 programs start in ROM at 0, others at 0x8000
+	if config.StartAddr == "" {
+		config.StartAddr = "0x8000"
+		if config.CPUType == "4004" || config.CPUType == "4040" {
+			config.StartAddr = "0x0000"
+		}
+	}
+
 	// If -xxd is set, override config file xxd
 	if *xxdFlag {
 		config.XXD = true
 	}
 
+	// If -syntax is set, override config file syntax
+	if *syntaxFlag != "" {
+		config.Syntax = *syntaxFlag
+	}
+
+	// If -l is set, override config file listing
+	if *listingFile != "" {
+		config.Listing = *listingFile
+	}
+
+	// If -f is set, override config file format
+	if *format != "" {
+		config.Format = *format
+	}
+	if config.Format == "" {
+		config.Format = formatForPath(config.Binary)
+	}
+
+	// Command line include paths are searched after those from the config file
+	config.IncludePaths = append(config.IncludePaths, includePaths...)
+
+	// Command line defines override those from the config file
+	if len(defineFlags) > 0 && config.Defines == nil {
+		config.Defines = make(map[string]string)
+	}
+	for _, define := range defineFlags {
+		name, value, found := strings.Cut(define, "=")
+		if !found {
+			value = "1"
+		}
+		config.Defines[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
 	// Display current configuration
 	fmt.Println("\n📋 Current Configuration:")
 	fmt.Printf("  Source File: %s\n", config.Source)
 	fmt.Printf("  Binary File: %s\n", config.Binary)
+	fmt.Printf("  Format:      %s\n", config.Format)
 	fmt.Printf("  Start Addr:  %s\n", config.StartAddr)
 	fmt.Printf("  CPU Type:    %s\n", config.CPUType)
+	if config.Syntax != "" {
+		fmt.Printf("  Syntax:      %s\n", config.Syntax)
+	}
 	fmt.Printf("  XXD:         %v\n", config.XXD)
+	if config.Listing != "" {
+		fmt.Printf("  Listing:     %s\n", config.Listing)
+	}
+	if len(config.IncludePaths) > 0 {
+		fmt.Printf("  Include:     %s\n", strings.Join(config.IncludePaths, ", "))
+	}
+	if len(config.Defines) > 0 {
+		var defines []string
+		for name, value := range config.Defines {
+			defines = append(defines, name+"="+value)
+		}
+		sort.Strings(defines)
+		fmt.Printf("  Defines:     %s\n", strings.Join(defines, ", "))
+	}
 	fmt.Println()
 
 	// Parse start address
@@ -222,23 +207,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Open source file
-	inputFile, err := os.Open(config.Source)
-	if err != nil {
-		panic(err)
+	// Assemble
+	program, diags := asm.AssembleFile(config.Source, asm.Options{
+		CPU:          config.CPUType,
+		Syntax:       config.Syntax,
+		StartAddress: startAddress,
+		IncludePaths: config.IncludePaths,
+		Defines:      config.Defines,
+	})
+	for _, d := range diags {
+		if d.Severity == asm.SeverityWarning {
+			fmt.Printf("⚠️  %s\n", d)
+		} else {
+			fmt.Printf("🆘 %s\n", d)
+		}
 	}
-	defer inputFile.Close()
-
-	// Create binary file
-	outputFile, err := os.Create(config.Binary)
-	if err != nil {
-		panic(err)
+	if program == nil {
+		os.Exit(1)
 	}
-	defer outputFile.Close()
+	printLabels(program)
 
-	// Assemble
-	binary, _ := assemble(inputFile, config.CPUType, startAddress)
-	outputFile.Write(binary)
+	if err := writeOutput(config.Binary, config.Format, program.Image); err != nil {
+		fmt.Printf("🆘 Error writing %s: %v\n", config.Binary, err)
+		os.Exit(1)
+	}
+	if config.Listing != "" {
+		if err := writeListing(config.Listing, config.Source, program); err != nil {
+			fmt.Printf("🆘 Error writing listing %s: %v\n", config.Listing, err)
+			os.Exit(1)
+		}
+	}
 	fmt.Printf("\n✅ Assembled successfully to %s using %s CPU\n", config.Binary, config.CPUType)
 
 	// Optionally run xxd
@@ -265,3 +263,35 @@ func parseHexAddr(addr string, defaultAddr uint16) (uint16, error) {
 	}
 	return uint16(value), nil
 }
+
+// printLabels shows the address of every label in address order
+func printLabels(program *asm.Program) {
+	var labels []*asm.Symbol
+	for _, symbol := range program.Symbols {
+		if symbol.IsLabel {
+			labels = append(labels, symbol)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Value != labels[j].Value {
+			return labels[i].Value < labels[j].Value
+		}
+		return labels[i].Name < labels[j].Name
+	})
+	for _, label := range labels {
+		fmt.Printf("Label: %v, address: $%04X\n", label.Name, label.Value)
+	}
+}
+
+// writeListing writes the program listing to a file
+func writeListing(path, source string, program *asm.Program) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := asm.WriteListing(file, source, program); err != nil {
+		return err
+	}
+	return file.Close()
+}
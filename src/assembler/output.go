@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/image"
+)
+
+// formatForPath picks an output format from the file extension
+func formatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".g8b":
+		return "g8b"
+	case ".hex", ".ihx":
+		return "ihex"
+	case ".s19", ".srec", ".mot":
+		return "srec"
+	}
+	return "bin"
+}
+
+// writeOutput writes the assembled image in the given format
+func writeOutput(path, format string, img *image.Image) error {
+	var buf bytes.Buffer
+	switch format {
+	case "bin":
+		// A flat binary has no addresses; it starts at the lowest assembled address
+		base, data := img.Flatten()
+		if base != img.Entry {
+			fmt.Printf("⚠️  Flat binary starts at $%04X, not at the start address $%04X\n", base, img.Entry)
+		}
+		buf.Write(data)
+	case "g8b":
+		if err := image.Write(&buf, img); err != nil {
+			return err
+		}
+		if !img.Relocatable {
+			fmt.Println("⚠️  Program uses addresses in a way that cannot be relocated; the image is fixed to its addresses")
+		}
+	case "ihex":
+		if err := image.WriteIntelHex(&buf, img); err != nil {
+			return err
+		}
+	case "srec":
+		if err := image.WriteSRecord(&buf, img, filepath.Base(path)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown output format %q (available: bin, g8b, ihex, srec)", format)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
@@ -1,6 +1,8 @@
 package cpu
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -8,10 +10,28 @@ import (
 type AddressingMode string
 
 const (
-	Immediate AddressingMode = "IMMEDIATE" // #$value
-	Absolute  AddressingMode = "ABSOLUTE"  // $addr
-	Relative  AddressingMode = "RELATIVE"  // label
-	Implied   AddressingMode = "IMPLIED"   // no operand
+	Immediate        AddressingMode = "IMMEDIATE"         // #$value
+	Immediate16      AddressingMode = "IMMEDIATE16"       // #$value, a 16-bit word
+	Absolute         AddressingMode = "ABSOLUTE"          // $addr
+	AbsoluteX        AddressingMode = "ABSOLUTE_X"        // $addr,X
+	AbsoluteY        AddressingMode = "ABSOLUTE_Y"        // $addr,Y
+	ZeroPage         AddressingMode = "ZERO_PAGE"         // $zp, an address in $0000-$00FF
+	ZeroPageX        AddressingMode = "ZERO_PAGE_X"       // $zp,X; the address wraps within the zero page
+	ZeroPageY        AddressingMode = "ZERO_PAGE_Y"       // $zp,Y; the address wraps within the zero page
+	Indirect         AddressingMode = "INDIRECT"          // ($addr), the address stored at addr
+	IndexedIndirect  AddressingMode = "INDEXED_INDIRECT"  // ($zp,X), the address stored at zp+X
+	IndirectIndexed  AddressingMode = "INDIRECT_INDEXED"  // ($zp),Y, the address stored at zp plus Y
+	Accumulator      AddressingMode = "ACCUMULATOR"       // A
+	Indexed          AddressingMode = "INDEXED"           // (IX+d), a signed displacement from an index register
+	IndexedOffset    AddressingMode = "INDEXED_OFFSET"    // n,X, an unsigned offset from the 16-bit index register
+	IndexedImmediate AddressingMode = "INDEXED_IMMEDIATE" // (IX+d),n, a displacement followed by a data byte
+	Relative         AddressingMode = "RELATIVE"          // label
+	Page             AddressingMode = "PAGE"              // $addr in the page of the next instruction; the operand byte is its low 8 bits
+	OperandPage      AddressingMode = "OPERAND_PAGE"      // $addr in the page of the operand byte, which holds its low 8 bits
+	Absolute12       AddressingMode = "ABSOLUTE12"        // $addr, 12 bits with the high 4 in the low nibble of the opcode
+	Implied          AddressingMode = "IMPLIED"           // no operand
+	Prefix           AddressingMode = "PREFIX"            // prefix byte; the next byte is an opcode in the prefix's table
+	IndexedPrefix    AddressingMode = "INDEXED_PREFIX"    // prefix byte followed by a displacement and an opcode in the prefix's table
 )
 
 // Instruction represents a CPU instruction with all its properties
@@ -24,33 +44,154 @@ type Instruction struct {
 	Description string         // Description of the instruction
 }
 
+// PrefixTables are the instruction tables selected by prefix opcodes, keyed by
+// the prefix bytes before the opcode: 0xCB for CB xx and 0xDDCB for DD CB d xx.
+// Instructions in them count the prefix bytes in their size and cycles.
+type PrefixTables map[uint16]map[byte]Instruction
+
+// Decoded is an instruction found by Decode with the layout of its bytes
+type Decoded struct {
+	Instruction
+	Prefix       uint16 // Prefix bytes before the opcode, 0 for unprefixed instructions
+	Displacement int    // Offset of the displacement byte of indexed instructions
+	Operand      int    // Offset of the data or address operand
+}
+
+// Decode finds the instruction at addr, following prefix opcodes into the
+// tables they select
+func Decode(instructions map[byte]Instruction, prefixes PrefixTables, read func(addr uint16) byte, addr uint16) (Decoded, bool) {
+	table := instructions
+	decoded := Decoded{Displacement: -1}
+	for offset := 0; ; {
+		opcode := read(addr + uint16(offset))
+		instruction, ok := table[opcode]
+		if !ok {
+			return decoded, false
+		}
+		switch instruction.Mode {
+		case Prefix:
+			offset++
+		case IndexedPrefix:
+			// The displacement comes before the opcode, as in DD CB d xx
+			decoded.Displacement = offset + 1
+			offset += 2
+		default:
+			decoded.Instruction = instruction
+			decoded.Operand = offset + 1
+			if (instruction.Mode == Indexed || instruction.Mode == IndexedImmediate) && decoded.Displacement < 0 {
+				decoded.Displacement = decoded.Operand
+				decoded.Operand++
+			}
+			return decoded, true
+		}
+		decoded.Prefix = decoded.Prefix<<8 | uint16(opcode)
+		if table, ok = prefixes[decoded.Prefix]; !ok {
+			return decoded, false
+		}
+	}
+}
+
+// ErrHalted is returned by ExecuteInstruction when the CPU executes a halt instruction
+var ErrHalted = errors.New("CPU halted")
+
+// UnknownOpcodeError reports an opcode that is not in the CPU's instruction set
+type UnknownOpcodeError struct {
+	Opcode byte
+	PC     uint16
+}
+
+func (e *UnknownOpcodeError) Error() string {
+	return fmt.Sprintf("unknown opcode $%02X at $%04X", e.Opcode, e.PC)
+}
+
+// FaultError reports an instruction the CPU could not execute, such as one
+// that is not implemented or that accesses an address outside memory
+type FaultError struct {
+	PC  uint16 // Address of the instruction
+	Err error
+}
+
+func (e *FaultError) Error() string {
+	return fmt.Sprintf("fault at $%04X: %v", e.PC, e.Err)
+}
+
+func (e *FaultError) Unwrap() error {
+	return e.Err
+}
+
+// IOBus connects the CPU's input and output instructions to devices
+type IOBus interface {
+	In(port byte) byte
+	Out(port byte, value byte)
+}
+
+// Pins is implemented by CPUs with input pins that devices drive, such as
+// interrupt requests
+type Pins interface {
+	SetPin(name string, high bool) error
+}
+
+// Interrupter is implemented by CPUs whose input pins request interrupts. An
+// interrupt that is pending ends a halt, as on the 8085 when TRAP or an
+// enabled RST input is raised after HLT.
+type Interrupter interface {
+	InterruptPending() bool
+}
+
+// PinListener is told when a CPU changes the level of an output pin, such as
+// the 1802's Q. The cycle count dates the change.
+type PinListener interface {
+	PinChanged(name string, high bool, cycle int)
+}
+
+// OutputPins is implemented by CPUs with output pins that devices watch
+type OutputPins interface {
+	WatchPins(listener PinListener)
+}
+
+// StateDescriber is implemented by CPUs with state that is neither a register
+// nor a flag, such as the 4004's address stack and RAM. Debuggers show the
+// lines after the registers.
+type StateDescriber interface {
+	DescribeState() []string
+}
+
 // CPU interface defines the methods that any CPU implementation must provide
 type ICPU interface {
 	// Base operations
 	GetName() string
 	GetInstructions() map[byte]Instruction
+	GetPrefixTables() PrefixTables
 
 	// Core CPU operations
-	Run()
-	ExecuteInstruction()
+	Run() error
+	ExecuteInstruction() error
+	Start()
+	Stop()
 	GetElapsedTime() time.Duration
 	GetCyclesPerSecond() float64
 
 	// Memory operations
 	Read(addr uint16) byte
 	Write(addr uint16, value byte)
+	MemorySize() int
+
+	// I/O operations
+	SetIOBus(bus IOBus)
+	In(port byte) byte
+	Out(port byte, value byte)
 
 	// Register operations
 	GetPC() uint16
 	SetPC(addr uint16)
-	GetSP() uint8
-	SetSP(value uint8)
-	GetA() uint8
-	SetA(value uint8)
-	GetX() uint8
-	SetX(value uint8)
-	GetY() uint8
-	SetY(value uint8)
+	GetSP() uint16
+	SetSP(value uint16)
+	Registers() []RegisterDesc
+	GetRegister(name string) (uint16, error)
+	SetRegister(name string, value uint16) error
+	Flags() []FlagDesc
+	GetFlag(name string) (bool, error)
+	SetFlag(name string, value bool) error
 
 	// Stack operations
 	Push(value byte)
@@ -66,21 +207,31 @@ type ICPU interface {
 	// Verbose operations
 	SetVerbose(verbose bool)
 	IsVerbose() bool
+
+	// Tracing
+	SetTracer(tracer Tracer)
 }
 
 // BaseCPU provides common functionality for all CPU implementations
 type CPU struct {
 	Name         string // CPU name
 	Instructions map[byte]Instruction
-	PC           uint16    // Program Counter
-	SP           uint8     // Stack Pointer
-	Memory       []uint8   // Memory
-	Cycles       int       // Cycle counter
-	Speed        uint      // CPU speed in Hz
-	startTime    time.Time // Start time for timing
-	stopTime     time.Time // Stop time for timing
-	running      bool      // Whether the CPU is currently running
-	verbose      bool      // Enable verbose output
+	Prefixes     PrefixTables // Tables of prefixed opcodes; nil when the CPU has none
+	PC           uint16       // Program Counter
+	SP           uint8        // Stack Pointer
+	Memory       []uint8      // Memory
+	Cycles       int          // Cycle counter
+	Speed        uint         // CPU speed in Hz
+	IO           IOBus        // Devices on the I/O ports; nil reads 0 and ignores writes
+	startTime    time.Time    // Start time for timing
+	startCycles  int          // Cycle count when timing started
+	stopTime     time.Time    // Stop time for timing
+	running      bool         // Whether the CPU is currently running
+	verbose      bool         // Enable verbose output
+	tracer       Tracer       // Receives a record per instruction when set
+	record       TraceRecord  // Record of the instruction being traced
+	registers    []register   // Registers behind GetRegister and SetRegister, see bindRegisters
+	flags        []flag       // Flags behind GetFlag and SetFlag
 }
 
 func (c CPU) GetName() string {
@@ -91,6 +242,10 @@ func (c CPU) GetInstructions() map[byte]Instruction {
 	return c.Instructions
 }
 
+func (c CPU) GetPrefixTables() PrefixTables {
+	return c.Prefixes
+}
+
 // NewBaseCPU creates a new base CPU instance
 func NewCPU(name string, memorySize int, speed uint, instructions map[byte]Instruction) *CPU {
 	return &CPU{
@@ -112,54 +267,49 @@ func (c *CPU) Write(addr uint16, value byte) {
 	c.Memory[addr] = value
 }
 
-// GetPC returns the program counter
-func (c *CPU) GetPC() uint16 {
-	return c.PC
-}
-
-// SetPC sets the program counter
-func (c *CPU) SetPC(addr uint16) {
-	c.PC = addr
-}
-
-// GetSP returns the stack pointer
-func (c *CPU) GetSP() uint8 {
-	return c.SP
+// MemorySize returns the number of bytes of memory; addresses from it up are not backed
+func (c *CPU) MemorySize() int {
+	return len(c.Memory)
 }
 
-// SetSP sets the stack pointer
-func (c *CPU) SetSP(value uint8) {
-	c.SP = value
+// SetIOBus connects the CPU's I/O ports to a bus
+func (c *CPU) SetIOBus(bus IOBus) {
+	c.IO = bus
 }
 
-// GetA returns the accumulator
-func (c *CPU) GetA() uint8 {
-	return 0 // To be implemented by specific CPU
+// In reads a byte from an input port
+func (c *CPU) In(port byte) byte {
+	if c.IO == nil {
+		return 0
+	}
+	return c.IO.In(port)
 }
 
-// SetA sets the accumulator
-func (c *CPU) SetA(value uint8) {
-	// To be implemented by specific CPU
+// Out writes a byte to an output port
+func (c *CPU) Out(port byte, value byte) {
+	if c.IO != nil {
+		c.IO.Out(port, value)
+	}
 }
 
-// GetX returns the X register
-func (c *CPU) GetX() uint8 {
-	return 0 // To be implemented by specific CPU
+// GetPC returns the program counter
+func (c *CPU) GetPC() uint16 {
+	return c.PC
 }
 
-// SetX sets the X register
-func (c *CPU) SetX(value uint8) {
-	// To be implemented by specific CPU
+// SetPC sets the program counter
+func (c *CPU) SetPC(addr uint16) {
+	c.PC = addr
 }
 
-// GetY returns the Y register
-func (c *CPU) GetY() uint8 {
-	return 0 // To be implemented by specific CPU
+// GetSP returns the stack pointer
+func (c *CPU) GetSP() uint16 {
+	return uint16(c.SP)
 }
 
-// SetY sets the Y register
-func (c *CPU) SetY(value uint8) {
-	// To be implemented by specific CPU
+// SetSP sets the stack pointer; the base stack pointer keeps the low 8 bits
+func (c *CPU) SetSP(value uint16) {
+	c.SP = uint8(value)
 }
 
 // Push pushes a byte onto the stack
@@ -189,11 +339,18 @@ func (c *CPU) Pull16() uint16 {
 	return (high << 8) | low
 }
 
-// Run starts the CPU execution
-func (c *CPU) Run() {
+// Run starts the CPU execution with the cycle counter cleared
+func (c *CPU) Run() error {
+	c.Cycles = 0
+	c.Start()
+	return nil
+}
+
+// Start starts timing execution from the current cycle count
+func (c *CPU) Start() {
 	c.startTime = time.Now()
+	c.startCycles = c.Cycles
 	c.running = true
-	c.Cycles = 0
 }
 
 // Stops the CPU execution
@@ -212,7 +369,7 @@ func (c *CPU) WaitForCycles(cycles int) {
 	}
 
 	// Calculate how long we should have taken so far
-	targetElapsed := time.Duration(float64(c.Cycles) * float64(time.Second) / float64(c.Speed))
+	targetElapsed := time.Duration(float64(c.Cycles-c.startCycles) * float64(time.Second) / float64(c.Speed))
 	actualElapsed := time.Since(c.startTime)
 
 	if actualElapsed < targetElapsed {
@@ -242,7 +399,7 @@ func (c *CPU) GetCyclesPerSecond() float64 {
 	if elapsed == 0 {
 		return 0
 	}
-	return float64(c.Cycles) / elapsed
+	return float64(c.Cycles-c.startCycles) / elapsed
 }
 
 // GetCycles returns the current cycle count
@@ -264,21 +421,3 @@ func (c *CPU) SetVerbose(verbose bool) {
 func (c *CPU) IsVerbose() bool {
 	return c.verbose
 }
-
-// GetOpcodes returns a map of mnemonic to opcode for the instruction set
-func (c *CPU) GetOpcodes() map[string]byte {
-	opcodes := make(map[string]byte)
-	for opcode, instr := range c.GetInstructions() {
-		opcodes[instr.Mnemonic] = opcode
-	}
-	return opcodes
-}
-
-// GetInstrSizes returns a map of mnemonic to instruction size for the instruction set
-func (c *CPU) GetInstrSizes() map[string]int {
-	sizes := make(map[string]int)
-	for _, instr := range c.GetInstructions() {
-		sizes[instr.Mnemonic] = instr.Size
-	}
-	return sizes
-}
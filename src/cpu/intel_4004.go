@@ -0,0 +1,466 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sizes of the 4004's separate program and data memories
+const (
+	Intel4004ROMSize  = 0x1000 // 4 KB of program ROM, sixteen 4001 chips
+	Intel4040ROMSize  = 0x2000 // Two 4 KB ROM banks, selected by DB0 and DB1
+	Intel4004RAMBanks = 8      // RAM banks selected by DCL, each up to four 4002 chips
+)
+
+// I/O ports the 4004's ROM and RAM ports and its TEST pin are connected to
+const (
+	Intel4004ROMPorts = 0x00 // WRR and RDR use port n for the I/O port of ROM chip n (0-15)
+	Intel4004RAMPorts = 0x10 // WMP writes port $10+bank*4+chip, the output port of a RAM chip
+	Intel4004TestPort = 0x30 // Bit 0 read from this port is the TEST pin, which JCN tests
+)
+
+// Intel4040InterruptAddress is where the 4040 goes on an interrupt
+const Intel4040InterruptAddress = 0x003
+
+// Intel4040Instructions are the 4004 instructions with the 4040 extensions
+var Intel4040Instructions = intel4040Instructions()
+
+// intel4040Instructions merges the 4040 extensions into the 4004 instruction set
+func intel4040Instructions() map[byte]Instruction {
+	instructions := make(map[byte]Instruction)
+	for opcode, instruction := range Intel4004Instructions {
+		instructions[opcode] = instruction
+	}
+	for opcode, instruction := range Intel4040Extensions {
+		instructions[opcode] = instruction
+	}
+	return instructions
+}
+
+// Intel4004 represents the Intel 4004 processor, or the 4040 with its
+// extensions. Instructions are fetched from ROM, the CPU's Memory, while data
+// lives in a separate RAM of 4-bit characters selected by DCL and SRC. The PC
+// has 12 bits; on the 4040 bit 12 is the ROM bank, which DB0 and DB1 switch at
+// the next JUN or JMS.
+type Intel4004 struct {
+	CPU
+	A                 uint8                            // Accumulator, 4 bits
+	R                 [24]uint8                        // Index registers R0-R15; R16-R23 are the 4040's second bank of R0-R7
+	Carry             bool                             // Carry/link flag (C); SUB and DAC set it when there is no borrow
+	Stack             [7]uint16                        // Address stack; SP is the index of the next free level
+	SRC               uint8                            // Address sent by SRC: RAM chip (bits 7-6), register (5-4) and character (3-0); ROM chip (7-4)
+	Bank              uint8                            // RAM bank selected by DCL, the 4040's command register
+	RAM               [Intel4004RAMBanks][16][16]uint8 // Main characters by bank, chip and register (SRC bits 7-4) and character
+	Status            [Intel4004RAMBanks][16][4]uint8  // Status characters by bank, chip and register and character
+	RegisterBank      uint8                            // Index register bank selected by the 4040's SB0 and SB1
+	InterruptsEnabled bool                             // Set by the 4040's EIN and cleared by DIN
+
+	depth       uint8  // Levels of the address stack: 3 on the 4004, 7 on the 4040
+	designated  uint16 // ROM bank chosen by DB0 or DB1 as bit 12 of the address; the next JUN or JMS goes there
+	interrupted bool   // An interrupt is being served, until BBS
+	savedSRC    uint8  // SRC when the interrupt was taken, restored by BBS
+}
+
+// NewIntel4004 creates a new 4004 CPU instance with memorySize bytes of ROM
+func NewIntel4004(memorySize int, speed uint) *Intel4004 {
+	c := &Intel4004{
+		CPU:   *NewCPU("Intel4004", memorySize, speed, Intel4004Instructions),
+		depth: 3,
+	}
+	c.SP = 0
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// NewIntel4040 creates a new 4040 CPU instance with memorySize bytes of ROM
+func NewIntel4040(memorySize int, speed uint) *Intel4004 {
+	c := &Intel4004{
+		CPU:   *NewCPU("Intel4040", memorySize, speed, Intel4040Instructions),
+		depth: 7,
+	}
+	c.SP = 0
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// Index returns index register r (0-15) of the selected bank
+func (c *Intel4004) Index(r int) uint8 {
+	return c.R[c.indexSlot(r)]
+}
+
+// SetIndex sets index register r (0-15) of the selected bank, keeping the low 4 bits
+func (c *Intel4004) SetIndex(r int, value uint8) {
+	c.R[c.indexSlot(r)] = value & 0x0F
+}
+
+// indexSlot returns where index register r of the selected bank is kept in R
+func (c *Intel4004) indexSlot(r int) int {
+	if r < 8 && c.RegisterBank == 1 {
+		return 16 + r
+	}
+	return r
+}
+
+// pair returns register pair p as a byte, the even register being the high nibble
+func (c *Intel4004) pair(p byte) byte {
+	return c.Index(int(p)*2)<<4 | c.Index(int(p)*2+1)
+}
+
+// setPair sets register pair p from a byte
+func (c *Intel4004) setPair(p byte, value byte) {
+	c.SetIndex(int(p)*2, value>>4)
+	c.SetIndex(int(p)*2+1, value)
+}
+
+// StackLevels returns the levels of the address stack the CPU has
+func (c *Intel4004) StackLevels() []uint16 {
+	return c.Stack[:c.depth]
+}
+
+// DescribeState shows the address stack and the RAM register selected by SRC
+// with its status characters
+func (c *Intel4004) DescribeState() []string {
+	stack := "Stack:"
+	for _, addr := range c.StackLevels() {
+		stack += fmt.Sprintf(" $%03X", addr)
+	}
+
+	chip := c.SRC >> 4
+	selected := fmt.Sprintf("SRC: $%02X (bank %d, chip %d, register %d, character %d)",
+		c.SRC, c.Bank, chip>>2, chip&3, c.SRC&0x0F)
+	ram := "RAM:"
+	for _, character := range c.RAM[c.Bank][chip] {
+		ram += fmt.Sprintf(" %X", character)
+	}
+	ram += " | Status:"
+	for _, character := range c.Status[c.Bank][chip] {
+		ram += fmt.Sprintf(" %X", character)
+	}
+	return []string{stack, selected, ram}
+}
+
+// Push16 pushes a return address onto the address stack. When the stack is
+// full the oldest address is overwritten.
+func (c *Intel4004) Push16(value uint16) {
+	c.Stack[c.SP] = value & 0x0FFF
+	c.SP = (c.SP + 1) % c.depth
+}
+
+// Pull16 pulls a return address from the address stack
+func (c *Intel4004) Pull16() uint16 {
+	c.SP = (c.SP + c.depth - 1) % c.depth
+	return c.Stack[c.SP]
+}
+
+// Push pushes a byte onto the address stack
+func (c *Intel4004) Push(value byte) {
+	c.Push16(uint16(value))
+}
+
+// Pull pulls the low byte of an address from the address stack
+func (c *Intel4004) Pull() byte {
+	return byte(c.Pull16())
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *Intel4004) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction executes a single instruction. It returns ErrHalted
+// after HLT, an UnknownOpcodeError for opcodes outside the instruction set
+// and a FaultError for instructions it cannot execute.
+func (c *Intel4004) ExecuteInstruction() (err error) {
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.PC = pc
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
+	// Get the instruction
+	opcode := c.Memory[pc]
+	instruction, ok := c.Instructions[opcode]
+	if !ok {
+		return &UnknownOpcodeError{Opcode: opcode, PC: pc}
+	}
+
+	// Only print verbose output if enabled
+	if c.IsVerbose() {
+		var registers strings.Builder
+		for r := 0; r < 16; r++ {
+			fmt.Fprintf(&registers, "%X", c.Index(r))
+		}
+		fmt.Printf("PC: %03X, OP: %02X, MN: %s, A:%X R:%s SRC:%02X | Flags(C): %d\n",
+			pc, opcode, instruction.Mnemonic, c.A, registers.String(), c.SRC, boolToInt(c.Carry))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
+	}
+
+	// Fetch the second byte; the PC counts within 12 bits, keeping the ROM bank
+	var operand byte
+	if instruction.Size == 2 {
+		operand = c.Memory[c.advance(pc, 1)]
+	}
+	c.PC = c.advance(pc, instruction.Size)
+	err = c.execute(instruction, operand)
+
+	// Wait for the appropriate amount of time
+	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// advance returns the address n bytes after addr in the same ROM bank
+func (c *Intel4004) advance(addr uint16, n int) uint16 {
+	return addr&0x1000 | (addr+uint16(n))&0x0FFF
+}
+
+// registerFile lists the registers and flags behind the register API. R0-R15
+// are the index registers of the selected bank, as in Index and SetIndex.
+func (c *Intel4004) registerFile() ([]register, []flag) {
+	registers := []register{
+		{RegisterDesc{Name: "A", Bits: 4}, func() uint16 { return uint16(c.A) }, func(value uint16) { c.A = uint8(value) }},
+	}
+	for r := 0; r < 16; r++ {
+		r := r
+		registers = append(registers, register{
+			RegisterDesc: RegisterDesc{Name: fmt.Sprintf("R%d", r), Bits: 4},
+			get:          func() uint16 { return uint16(c.Index(r)) },
+			set:          func(value uint16) { c.SetIndex(r, uint8(value)) },
+		})
+	}
+	registers = append(registers, byteRegister("SRC", &c.SRC), byteRegister("SP", &c.SP))
+	flags := []flag{boolFlag("C", "Carry", &c.Carry)}
+	return registers, flags
+}
+
+// Interrupt requests an interrupt on the 4040's INT pin. It is ignored while
+// interrupts are disabled, which they always are on the 4004, and while an
+// interrupt is served. The CPU calls Intel4040InterruptAddress in ROM bank 0
+// and keeps SRC for BBS to restore.
+func (c *Intel4004) Interrupt() {
+	if !c.InterruptsEnabled || c.interrupted {
+		return
+	}
+	c.interrupted = true
+	c.savedSRC = c.SRC
+	c.Push16(c.PC)
+	c.PC = Intel4040InterruptAddress
+	c.AddCycles(16)
+}
+
+// execute executes an instruction; operand is its second byte
+func (c *Intel4004) execute(instruction Instruction, operand byte) error {
+	low := instruction.Opcode & 0x0F
+	page := c.PC &^ 0xFF // Page of the next instruction, for JCN, ISZ, FIN and JIN
+
+	switch instruction.Mnemonic {
+	case "NOP":
+	case "HLT":
+		return ErrHalted
+
+	// Index registers
+	case "FIM":
+		c.setPair(low>>1, operand)
+	case "FIN":
+		c.setPair(low>>1, c.load(page|uint16(c.pair(0))))
+	case "INC":
+		c.SetIndex(int(low), c.Index(int(low))+1)
+	case "LD":
+		c.A = c.Index(int(low))
+	case "XCH":
+		a := c.A
+		c.A = c.Index(int(low))
+		c.SetIndex(int(low), a)
+
+	// Accumulator
+	case "ADD":
+		c.add(c.Index(int(low)))
+	case "SUB":
+		c.subtract(c.Index(int(low)))
+	case "LDM":
+		c.A = low
+	case "CLB":
+		c.A = 0
+		c.Carry = false
+	case "CLC":
+		c.Carry = false
+	case "IAC":
+		c.Carry = c.A == 0x0F
+		c.A = (c.A + 1) & 0x0F
+	case "CMC":
+		c.Carry = !c.Carry
+	case "CMA":
+		c.A = ^c.A & 0x0F
+	case "RAL":
+		carry := c.A&0x08 != 0
+		c.A = (c.A<<1 | byte(boolToInt(c.Carry))) & 0x0F
+		c.Carry = carry
+	case "RAR":
+		carry := c.A&0x01 != 0
+		c.A = c.A>>1 | byte(boolToInt(c.Carry))<<3
+		c.Carry = carry
+	case "TCC":
+		c.A = byte(boolToInt(c.Carry))
+		c.Carry = false
+	case "DAC":
+		c.Carry = c.A != 0
+		c.A = (c.A - 1) & 0x0F
+	case "TCS":
+		c.A = 9
+		if c.Carry {
+			c.A = 10
+		}
+		c.Carry = false
+	case "STC":
+		c.Carry = true
+	case "DAA":
+		// The carry is set when the correction overflows and otherwise kept
+		if c.A > 9 || c.Carry {
+			if c.A+6 > 0x0F {
+				c.Carry = true
+			}
+			c.A = (c.A + 6) & 0x0F
+		}
+	case "KBP":
+		// A single set bit becomes its position 1-4; more than one gives 15
+		switch c.A {
+		case 0, 1, 2:
+		case 4:
+			c.A = 3
+		case 8:
+			c.A = 4
+		default:
+			c.A = 15
+		}
+	case "DCL":
+		c.Bank = c.A & 0x07
+
+	// Branches
+	case "JUN":
+		c.PC = c.designated | uint16(low)<<8 | uint16(operand)
+	case "JMS":
+		c.Push16(c.PC)
+		c.PC = c.designated | uint16(low)<<8 | uint16(operand)
+	case "JCN":
+		if c.condition(low) {
+			c.PC = page | uint16(operand)
+		}
+	case "ISZ":
+		value := (c.Index(int(low)) + 1) & 0x0F
+		c.SetIndex(int(low), value)
+		if value != 0 {
+			c.PC = page | uint16(operand)
+		}
+	case "JIN":
+		c.PC = page | uint16(c.pair(low>>1))
+	case "BBL":
+		c.PC = c.PC&0x1000 | c.Pull16()
+		c.A = low
+
+	// RAM and I/O
+	case "SRC":
+		c.SRC = c.pair(low >> 1)
+	case "WRM":
+		c.RAM[c.Bank][c.SRC>>4][c.SRC&0x0F] = c.A
+	case "RDM":
+		c.A = c.RAM[c.Bank][c.SRC>>4][c.SRC&0x0F]
+	case "ADM":
+		c.add(c.RAM[c.Bank][c.SRC>>4][c.SRC&0x0F])
+	case "SBM":
+		c.subtract(c.RAM[c.Bank][c.SRC>>4][c.SRC&0x0F])
+	case "WR0", "WR1", "WR2", "WR3":
+		c.Status[c.Bank][c.SRC>>4][low&0x03] = c.A
+	case "RD0", "RD1", "RD2", "RD3":
+		c.A = c.Status[c.Bank][c.SRC>>4][low&0x03]
+	case "WMP":
+		c.Out(Intel4004RAMPorts+c.Bank*4+c.SRC>>6, c.A)
+	case "WRR":
+		c.Out(Intel4004ROMPorts+c.SRC>>4, c.A)
+	case "RDR":
+		c.A = c.In(Intel4004ROMPorts+c.SRC>>4) & 0x0F
+	case "WPM":
+		// No program RAM is attached
+
+	// 4040 extensions
+	case "BBS":
+		c.PC = c.PC&0x1000 | c.Pull16()
+		c.SRC = c.savedSRC
+		c.interrupted = false
+	case "LCR":
+		c.A = c.Bank
+	case "OR4", "OR5":
+		c.A |= c.Index(int(low))
+	case "AN6", "AN7":
+		c.A &= c.Index(int(low))
+	case "DB0":
+		c.designated = 0
+	case "DB1":
+		c.designated = 0x1000
+	case "SB0":
+		c.RegisterBank = 0
+	case "SB1":
+		c.RegisterBank = 1
+	case "EIN":
+		c.InterruptsEnabled = true
+	case "DIN":
+		c.InterruptsEnabled = false
+	case "RPM":
+		c.A = 0 // No program RAM is attached
+
+	default:
+		return fmt.Errorf("instruction not implemented: %s", instruction.Mnemonic)
+	}
+	return nil
+}
+
+// add adds a value and the carry to the accumulator
+func (c *Intel4004) add(value byte) {
+	result := c.A + value + byte(boolToInt(c.Carry))
+	c.Carry = result > 0x0F
+	c.A = result & 0x0F
+}
+
+// subtract subtracts a value and the borrow, which a set carry stands for,
+// from the accumulator by adding its complement. The carry is then set when
+// there was no borrow.
+func (c *Intel4004) subtract(value byte) {
+	result := c.A + ^value&0x0F + byte(1-boolToInt(c.Carry))
+	c.Carry = result > 0x0F
+	c.A = result & 0x0F
+}
+
+// condition reports whether JCN jumps on a condition: the accumulator is zero
+// (bit 2), the carry is set (bit 1) or the TEST pin is low (bit 0), inverted
+// when bit 3 is set
+func (c *Intel4004) condition(condition byte) bool {
+	jump := condition&0x04 != 0 && c.A == 0 ||
+		condition&0x02 != 0 && c.Carry ||
+		condition&0x01 != 0 && c.In(Intel4004TestPort)&0x01 == 0
+	return jump != (condition&0x08 != 0)
+}
@@ -0,0 +1,286 @@
+package cpu
+
+var Intel4004Instructions = map[byte]Instruction{
+
+	// Index Register Instructions
+	// R0-R15 are 4-bit index registers; register pair p is R(2p) and R(2p+1), with the high nibble in R(2p).
+
+	0x20: {0x20, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 0 (R0R1)"},
+	0x22: {0x22, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 1 (R2R3)"},
+	0x24: {0x24, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 2 (R4R5)"},
+	0x26: {0x26, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 3 (R6R7)"},
+	0x28: {0x28, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 4 (R8R9)"},
+	0x2A: {0x2A, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 5 (R10R11)"},
+	0x2C: {0x2C, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 6 (R12R13)"},
+	0x2E: {0x2E, "FIM", Immediate, 2, 16, "Fetch immediate data into register pair 7 (R14R15)"},
+	0x30: {0x30, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 0 (R0R1)"},
+	0x32: {0x32, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 1 (R2R3)"},
+	0x34: {0x34, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 2 (R4R5)"},
+	0x36: {0x36, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 3 (R6R7)"},
+	0x38: {0x38, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 4 (R8R9)"},
+	0x3A: {0x3A, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 5 (R10R11)"},
+	0x3C: {0x3C, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 6 (R12R13)"},
+	0x3E: {0x3E, "FIN", Implied, 1, 8, "Fetch the ROM byte at R0R1 in the current page into register pair 7 (R14R15)"},
+	0x60: {0x60, "INC", Implied, 1, 8, "Increment register R0"},
+	0x61: {0x61, "INC", Implied, 1, 8, "Increment register R1"},
+	0x62: {0x62, "INC", Implied, 1, 8, "Increment register R2"},
+	0x63: {0x63, "INC", Implied, 1, 8, "Increment register R3"},
+	0x64: {0x64, "INC", Implied, 1, 8, "Increment register R4"},
+	0x65: {0x65, "INC", Implied, 1, 8, "Increment register R5"},
+	0x66: {0x66, "INC", Implied, 1, 8, "Increment register R6"},
+	0x67: {0x67, "INC", Implied, 1, 8, "Increment register R7"},
+	0x68: {0x68, "INC", Implied, 1, 8, "Increment register R8"},
+	0x69: {0x69, "INC", Implied, 1, 8, "Increment register R9"},
+	0x6A: {0x6A, "INC", Implied, 1, 8, "Increment register R10"},
+	0x6B: {0x6B, "INC", Implied, 1, 8, "Increment register R11"},
+	0x6C: {0x6C, "INC", Implied, 1, 8, "Increment register R12"},
+	0x6D: {0x6D, "INC", Implied, 1, 8, "Increment register R13"},
+	0x6E: {0x6E, "INC", Implied, 1, 8, "Increment register R14"},
+	0x6F: {0x6F, "INC", Implied, 1, 8, "Increment register R15"},
+
+	// Accumulator Instructions
+	// The accumulator is 4 bits. ADD, SUB, IAC, DAC, DAA and the rotates set the carry; SUB and DAC
+	// set it when there is no borrow.
+
+	0x80: {0x80, "ADD", Implied, 1, 8, "Add register R0 and the carry to the accumulator"},
+	0x81: {0x81, "ADD", Implied, 1, 8, "Add register R1 and the carry to the accumulator"},
+	0x82: {0x82, "ADD", Implied, 1, 8, "Add register R2 and the carry to the accumulator"},
+	0x83: {0x83, "ADD", Implied, 1, 8, "Add register R3 and the carry to the accumulator"},
+	0x84: {0x84, "ADD", Implied, 1, 8, "Add register R4 and the carry to the accumulator"},
+	0x85: {0x85, "ADD", Implied, 1, 8, "Add register R5 and the carry to the accumulator"},
+	0x86: {0x86, "ADD", Implied, 1, 8, "Add register R6 and the carry to the accumulator"},
+	0x87: {0x87, "ADD", Implied, 1, 8, "Add register R7 and the carry to the accumulator"},
+	0x88: {0x88, "ADD", Implied, 1, 8, "Add register R8 and the carry to the accumulator"},
+	0x89: {0x89, "ADD", Implied, 1, 8, "Add register R9 and the carry to the accumulator"},
+	0x8A: {0x8A, "ADD", Implied, 1, 8, "Add register R10 and the carry to the accumulator"},
+	0x8B: {0x8B, "ADD", Implied, 1, 8, "Add register R11 and the carry to the accumulator"},
+	0x8C: {0x8C, "ADD", Implied, 1, 8, "Add register R12 and the carry to the accumulator"},
+	0x8D: {0x8D, "ADD", Implied, 1, 8, "Add register R13 and the carry to the accumulator"},
+	0x8E: {0x8E, "ADD", Implied, 1, 8, "Add register R14 and the carry to the accumulator"},
+	0x8F: {0x8F, "ADD", Implied, 1, 8, "Add register R15 and the carry to the accumulator"},
+	0x90: {0x90, "SUB", Implied, 1, 8, "Subtract register R0 and the borrow from the accumulator"},
+	0x91: {0x91, "SUB", Implied, 1, 8, "Subtract register R1 and the borrow from the accumulator"},
+	0x92: {0x92, "SUB", Implied, 1, 8, "Subtract register R2 and the borrow from the accumulator"},
+	0x93: {0x93, "SUB", Implied, 1, 8, "Subtract register R3 and the borrow from the accumulator"},
+	0x94: {0x94, "SUB", Implied, 1, 8, "Subtract register R4 and the borrow from the accumulator"},
+	0x95: {0x95, "SUB", Implied, 1, 8, "Subtract register R5 and the borrow from the accumulator"},
+	0x96: {0x96, "SUB", Implied, 1, 8, "Subtract register R6 and the borrow from the accumulator"},
+	0x97: {0x97, "SUB", Implied, 1, 8, "Subtract register R7 and the borrow from the accumulator"},
+	0x98: {0x98, "SUB", Implied, 1, 8, "Subtract register R8 and the borrow from the accumulator"},
+	0x99: {0x99, "SUB", Implied, 1, 8, "Subtract register R9 and the borrow from the accumulator"},
+	0x9A: {0x9A, "SUB", Implied, 1, 8, "Subtract register R10 and the borrow from the accumulator"},
+	0x9B: {0x9B, "SUB", Implied, 1, 8, "Subtract register R11 and the borrow from the accumulator"},
+	0x9C: {0x9C, "SUB", Implied, 1, 8, "Subtract register R12 and the borrow from the accumulator"},
+	0x9D: {0x9D, "SUB", Implied, 1, 8, "Subtract register R13 and the borrow from the accumulator"},
+	0x9E: {0x9E, "SUB", Implied, 1, 8, "Subtract register R14 and the borrow from the accumulator"},
+	0x9F: {0x9F, "SUB", Implied, 1, 8, "Subtract register R15 and the borrow from the accumulator"},
+	0xA0: {0xA0, "LD", Implied, 1, 8, "Load the accumulator from register R0"},
+	0xA1: {0xA1, "LD", Implied, 1, 8, "Load the accumulator from register R1"},
+	0xA2: {0xA2, "LD", Implied, 1, 8, "Load the accumulator from register R2"},
+	0xA3: {0xA3, "LD", Implied, 1, 8, "Load the accumulator from register R3"},
+	0xA4: {0xA4, "LD", Implied, 1, 8, "Load the accumulator from register R4"},
+	0xA5: {0xA5, "LD", Implied, 1, 8, "Load the accumulator from register R5"},
+	0xA6: {0xA6, "LD", Implied, 1, 8, "Load the accumulator from register R6"},
+	0xA7: {0xA7, "LD", Implied, 1, 8, "Load the accumulator from register R7"},
+	0xA8: {0xA8, "LD", Implied, 1, 8, "Load the accumulator from register R8"},
+	0xA9: {0xA9, "LD", Implied, 1, 8, "Load the accumulator from register R9"},
+	0xAA: {0xAA, "LD", Implied, 1, 8, "Load the accumulator from register R10"},
+	0xAB: {0xAB, "LD", Implied, 1, 8, "Load the accumulator from register R11"},
+	0xAC: {0xAC, "LD", Implied, 1, 8, "Load the accumulator from register R12"},
+	0xAD: {0xAD, "LD", Implied, 1, 8, "Load the accumulator from register R13"},
+	0xAE: {0xAE, "LD", Implied, 1, 8, "Load the accumulator from register R14"},
+	0xAF: {0xAF, "LD", Implied, 1, 8, "Load the accumulator from register R15"},
+	0xB0: {0xB0, "XCH", Implied, 1, 8, "Exchange the accumulator with register R0"},
+	0xB1: {0xB1, "XCH", Implied, 1, 8, "Exchange the accumulator with register R1"},
+	0xB2: {0xB2, "XCH", Implied, 1, 8, "Exchange the accumulator with register R2"},
+	0xB3: {0xB3, "XCH", Implied, 1, 8, "Exchange the accumulator with register R3"},
+	0xB4: {0xB4, "XCH", Implied, 1, 8, "Exchange the accumulator with register R4"},
+	0xB5: {0xB5, "XCH", Implied, 1, 8, "Exchange the accumulator with register R5"},
+	0xB6: {0xB6, "XCH", Implied, 1, 8, "Exchange the accumulator with register R6"},
+	0xB7: {0xB7, "XCH", Implied, 1, 8, "Exchange the accumulator with register R7"},
+	0xB8: {0xB8, "XCH", Implied, 1, 8, "Exchange the accumulator with register R8"},
+	0xB9: {0xB9, "XCH", Implied, 1, 8, "Exchange the accumulator with register R9"},
+	0xBA: {0xBA, "XCH", Implied, 1, 8, "Exchange the accumulator with register R10"},
+	0xBB: {0xBB, "XCH", Implied, 1, 8, "Exchange the accumulator with register R11"},
+	0xBC: {0xBC, "XCH", Implied, 1, 8, "Exchange the accumulator with register R12"},
+	0xBD: {0xBD, "XCH", Implied, 1, 8, "Exchange the accumulator with register R13"},
+	0xBE: {0xBE, "XCH", Implied, 1, 8, "Exchange the accumulator with register R14"},
+	0xBF: {0xBF, "XCH", Implied, 1, 8, "Exchange the accumulator with register R15"},
+	0xD0: {0xD0, "LDM", Implied, 1, 8, "Load 0 into the accumulator"},
+	0xD1: {0xD1, "LDM", Implied, 1, 8, "Load 1 into the accumulator"},
+	0xD2: {0xD2, "LDM", Implied, 1, 8, "Load 2 into the accumulator"},
+	0xD3: {0xD3, "LDM", Implied, 1, 8, "Load 3 into the accumulator"},
+	0xD4: {0xD4, "LDM", Implied, 1, 8, "Load 4 into the accumulator"},
+	0xD5: {0xD5, "LDM", Implied, 1, 8, "Load 5 into the accumulator"},
+	0xD6: {0xD6, "LDM", Implied, 1, 8, "Load 6 into the accumulator"},
+	0xD7: {0xD7, "LDM", Implied, 1, 8, "Load 7 into the accumulator"},
+	0xD8: {0xD8, "LDM", Implied, 1, 8, "Load 8 into the accumulator"},
+	0xD9: {0xD9, "LDM", Implied, 1, 8, "Load 9 into the accumulator"},
+	0xDA: {0xDA, "LDM", Implied, 1, 8, "Load 10 into the accumulator"},
+	0xDB: {0xDB, "LDM", Implied, 1, 8, "Load 11 into the accumulator"},
+	0xDC: {0xDC, "LDM", Implied, 1, 8, "Load 12 into the accumulator"},
+	0xDD: {0xDD, "LDM", Implied, 1, 8, "Load 13 into the accumulator"},
+	0xDE: {0xDE, "LDM", Implied, 1, 8, "Load 14 into the accumulator"},
+	0xDF: {0xDF, "LDM", Implied, 1, 8, "Load 15 into the accumulator"},
+	0xF0: {0xF0, "CLB", Implied, 1, 8, "Clear the accumulator and the carry"},
+	0xF1: {0xF1, "CLC", Implied, 1, 8, "Clear the carry"},
+	0xF2: {0xF2, "IAC", Implied, 1, 8, "Increment the accumulator"},
+	0xF3: {0xF3, "CMC", Implied, 1, 8, "Complement the carry"},
+	0xF4: {0xF4, "CMA", Implied, 1, 8, "Complement the accumulator"},
+	0xF5: {0xF5, "RAL", Implied, 1, 8, "Rotate the accumulator left through the carry"},
+	0xF6: {0xF6, "RAR", Implied, 1, 8, "Rotate the accumulator right through the carry"},
+	0xF7: {0xF7, "TCC", Implied, 1, 8, "Transfer the carry to the accumulator and clear it"},
+	0xF8: {0xF8, "DAC", Implied, 1, 8, "Decrement the accumulator"},
+	0xF9: {0xF9, "TCS", Implied, 1, 8, "Transfer the carry for subtraction (9 or 10) to the accumulator and clear it"},
+	0xFA: {0xFA, "STC", Implied, 1, 8, "Set the carry"},
+	0xFB: {0xFB, "DAA", Implied, 1, 8, "Decimal adjust the accumulator"},
+	0xFC: {0xFC, "KBP", Implied, 1, 8, "Keyboard process: convert a single set bit to its position"},
+	0xFD: {0xFD, "DCL", Implied, 1, 8, "Designate the RAM bank from the accumulator"},
+
+	// Branch Instructions
+	// JUN and JMS take a 12-bit address with its high 4 bits in the opcode. JCN and ISZ jump within
+	// the 256-byte page of the next instruction. JCN's condition has 4 bits: invert (8), accumulator
+	// zero (4), carry set (2) and TEST low (1). The address stack has 3 levels and wraps when full.
+
+	0x10: {0x10, "JCN", Page, 2, 16, "Jump in the page on condition 0"},
+	0x11: {0x11, "JCN", Page, 2, 16, "Jump in the page on condition 1"},
+	0x12: {0x12, "JCN", Page, 2, 16, "Jump in the page on condition 2"},
+	0x13: {0x13, "JCN", Page, 2, 16, "Jump in the page on condition 3"},
+	0x14: {0x14, "JCN", Page, 2, 16, "Jump in the page on condition 4"},
+	0x15: {0x15, "JCN", Page, 2, 16, "Jump in the page on condition 5"},
+	0x16: {0x16, "JCN", Page, 2, 16, "Jump in the page on condition 6"},
+	0x17: {0x17, "JCN", Page, 2, 16, "Jump in the page on condition 7"},
+	0x18: {0x18, "JCN", Page, 2, 16, "Jump in the page on condition 8"},
+	0x19: {0x19, "JCN", Page, 2, 16, "Jump in the page on condition 9"},
+	0x1A: {0x1A, "JCN", Page, 2, 16, "Jump in the page on condition 10"},
+	0x1B: {0x1B, "JCN", Page, 2, 16, "Jump in the page on condition 11"},
+	0x1C: {0x1C, "JCN", Page, 2, 16, "Jump in the page on condition 12"},
+	0x1D: {0x1D, "JCN", Page, 2, 16, "Jump in the page on condition 13"},
+	0x1E: {0x1E, "JCN", Page, 2, 16, "Jump in the page on condition 14"},
+	0x1F: {0x1F, "JCN", Page, 2, 16, "Jump in the page on condition 15"},
+	0x31: {0x31, "JIN", Implied, 1, 8, "Jump to register pair 0 (R0R1) in the current page"},
+	0x33: {0x33, "JIN", Implied, 1, 8, "Jump to register pair 1 (R2R3) in the current page"},
+	0x35: {0x35, "JIN", Implied, 1, 8, "Jump to register pair 2 (R4R5) in the current page"},
+	0x37: {0x37, "JIN", Implied, 1, 8, "Jump to register pair 3 (R6R7) in the current page"},
+	0x39: {0x39, "JIN", Implied, 1, 8, "Jump to register pair 4 (R8R9) in the current page"},
+	0x3B: {0x3B, "JIN", Implied, 1, 8, "Jump to register pair 5 (R10R11) in the current page"},
+	0x3D: {0x3D, "JIN", Implied, 1, 8, "Jump to register pair 6 (R12R13) in the current page"},
+	0x3F: {0x3F, "JIN", Implied, 1, 8, "Jump to register pair 7 (R14R15) in the current page"},
+	0x40: {0x40, "JUN", Absolute12, 2, 16, "Jump to the address $000-$0FF"},
+	0x41: {0x41, "JUN", Absolute12, 2, 16, "Jump to the address $100-$1FF"},
+	0x42: {0x42, "JUN", Absolute12, 2, 16, "Jump to the address $200-$2FF"},
+	0x43: {0x43, "JUN", Absolute12, 2, 16, "Jump to the address $300-$3FF"},
+	0x44: {0x44, "JUN", Absolute12, 2, 16, "Jump to the address $400-$4FF"},
+	0x45: {0x45, "JUN", Absolute12, 2, 16, "Jump to the address $500-$5FF"},
+	0x46: {0x46, "JUN", Absolute12, 2, 16, "Jump to the address $600-$6FF"},
+	0x47: {0x47, "JUN", Absolute12, 2, 16, "Jump to the address $700-$7FF"},
+	0x48: {0x48, "JUN", Absolute12, 2, 16, "Jump to the address $800-$8FF"},
+	0x49: {0x49, "JUN", Absolute12, 2, 16, "Jump to the address $900-$9FF"},
+	0x4A: {0x4A, "JUN", Absolute12, 2, 16, "Jump to the address $A00-$AFF"},
+	0x4B: {0x4B, "JUN", Absolute12, 2, 16, "Jump to the address $B00-$BFF"},
+	0x4C: {0x4C, "JUN", Absolute12, 2, 16, "Jump to the address $C00-$CFF"},
+	0x4D: {0x4D, "JUN", Absolute12, 2, 16, "Jump to the address $D00-$DFF"},
+	0x4E: {0x4E, "JUN", Absolute12, 2, 16, "Jump to the address $E00-$EFF"},
+	0x4F: {0x4F, "JUN", Absolute12, 2, 16, "Jump to the address $F00-$FFF"},
+	0x50: {0x50, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $000-$0FF"},
+	0x51: {0x51, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $100-$1FF"},
+	0x52: {0x52, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $200-$2FF"},
+	0x53: {0x53, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $300-$3FF"},
+	0x54: {0x54, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $400-$4FF"},
+	0x55: {0x55, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $500-$5FF"},
+	0x56: {0x56, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $600-$6FF"},
+	0x57: {0x57, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $700-$7FF"},
+	0x58: {0x58, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $800-$8FF"},
+	0x59: {0x59, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $900-$9FF"},
+	0x5A: {0x5A, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $A00-$AFF"},
+	0x5B: {0x5B, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $B00-$BFF"},
+	0x5C: {0x5C, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $C00-$CFF"},
+	0x5D: {0x5D, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $D00-$DFF"},
+	0x5E: {0x5E, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $E00-$EFF"},
+	0x5F: {0x5F, "JMS", Absolute12, 2, 16, "Call the subroutine at the address $F00-$FFF"},
+	0x70: {0x70, "ISZ", Page, 2, 16, "Increment register R0 and jump in the page unless it becomes zero"},
+	0x71: {0x71, "ISZ", Page, 2, 16, "Increment register R1 and jump in the page unless it becomes zero"},
+	0x72: {0x72, "ISZ", Page, 2, 16, "Increment register R2 and jump in the page unless it becomes zero"},
+	0x73: {0x73, "ISZ", Page, 2, 16, "Increment register R3 and jump in the page unless it becomes zero"},
+	0x74: {0x74, "ISZ", Page, 2, 16, "Increment register R4 and jump in the page unless it becomes zero"},
+	0x75: {0x75, "ISZ", Page, 2, 16, "Increment register R5 and jump in the page unless it becomes zero"},
+	0x76: {0x76, "ISZ", Page, 2, 16, "Increment register R6 and jump in the page unless it becomes zero"},
+	0x77: {0x77, "ISZ", Page, 2, 16, "Increment register R7 and jump in the page unless it becomes zero"},
+	0x78: {0x78, "ISZ", Page, 2, 16, "Increment register R8 and jump in the page unless it becomes zero"},
+	0x79: {0x79, "ISZ", Page, 2, 16, "Increment register R9 and jump in the page unless it becomes zero"},
+	0x7A: {0x7A, "ISZ", Page, 2, 16, "Increment register R10 and jump in the page unless it becomes zero"},
+	0x7B: {0x7B, "ISZ", Page, 2, 16, "Increment register R11 and jump in the page unless it becomes zero"},
+	0x7C: {0x7C, "ISZ", Page, 2, 16, "Increment register R12 and jump in the page unless it becomes zero"},
+	0x7D: {0x7D, "ISZ", Page, 2, 16, "Increment register R13 and jump in the page unless it becomes zero"},
+	0x7E: {0x7E, "ISZ", Page, 2, 16, "Increment register R14 and jump in the page unless it becomes zero"},
+	0x7F: {0x7F, "ISZ", Page, 2, 16, "Increment register R15 and jump in the page unless it becomes zero"},
+	0xC0: {0xC0, "BBL", Implied, 1, 8, "Return from subroutine and load 0 into the accumulator"},
+	0xC1: {0xC1, "BBL", Implied, 1, 8, "Return from subroutine and load 1 into the accumulator"},
+	0xC2: {0xC2, "BBL", Implied, 1, 8, "Return from subroutine and load 2 into the accumulator"},
+	0xC3: {0xC3, "BBL", Implied, 1, 8, "Return from subroutine and load 3 into the accumulator"},
+	0xC4: {0xC4, "BBL", Implied, 1, 8, "Return from subroutine and load 4 into the accumulator"},
+	0xC5: {0xC5, "BBL", Implied, 1, 8, "Return from subroutine and load 5 into the accumulator"},
+	0xC6: {0xC6, "BBL", Implied, 1, 8, "Return from subroutine and load 6 into the accumulator"},
+	0xC7: {0xC7, "BBL", Implied, 1, 8, "Return from subroutine and load 7 into the accumulator"},
+	0xC8: {0xC8, "BBL", Implied, 1, 8, "Return from subroutine and load 8 into the accumulator"},
+	0xC9: {0xC9, "BBL", Implied, 1, 8, "Return from subroutine and load 9 into the accumulator"},
+	0xCA: {0xCA, "BBL", Implied, 1, 8, "Return from subroutine and load 10 into the accumulator"},
+	0xCB: {0xCB, "BBL", Implied, 1, 8, "Return from subroutine and load 11 into the accumulator"},
+	0xCC: {0xCC, "BBL", Implied, 1, 8, "Return from subroutine and load 12 into the accumulator"},
+	0xCD: {0xCD, "BBL", Implied, 1, 8, "Return from subroutine and load 13 into the accumulator"},
+	0xCE: {0xCE, "BBL", Implied, 1, 8, "Return from subroutine and load 14 into the accumulator"},
+	0xCF: {0xCF, "BBL", Implied, 1, 8, "Return from subroutine and load 15 into the accumulator"},
+
+	// RAM and I/O Instructions
+	// SRC selects a RAM register and character and a ROM I/O port; DCL selects the RAM bank.
+
+	0x21: {0x21, "SRC", Implied, 1, 8, "Send register pair 0 (R0R1) as the RAM and ROM I/O address"},
+	0x23: {0x23, "SRC", Implied, 1, 8, "Send register pair 1 (R2R3) as the RAM and ROM I/O address"},
+	0x25: {0x25, "SRC", Implied, 1, 8, "Send register pair 2 (R4R5) as the RAM and ROM I/O address"},
+	0x27: {0x27, "SRC", Implied, 1, 8, "Send register pair 3 (R6R7) as the RAM and ROM I/O address"},
+	0x29: {0x29, "SRC", Implied, 1, 8, "Send register pair 4 (R8R9) as the RAM and ROM I/O address"},
+	0x2B: {0x2B, "SRC", Implied, 1, 8, "Send register pair 5 (R10R11) as the RAM and ROM I/O address"},
+	0x2D: {0x2D, "SRC", Implied, 1, 8, "Send register pair 6 (R12R13) as the RAM and ROM I/O address"},
+	0x2F: {0x2F, "SRC", Implied, 1, 8, "Send register pair 7 (R14R15) as the RAM and ROM I/O address"},
+	0xE0: {0xE0, "WRM", Implied, 1, 8, "Write the accumulator to the selected RAM character"},
+	0xE1: {0xE1, "WMP", Implied, 1, 8, "Write the accumulator to the output port of the selected RAM chip"},
+	0xE2: {0xE2, "WRR", Implied, 1, 8, "Write the accumulator to the I/O port of the selected ROM chip"},
+	0xE3: {0xE3, "WPM", Implied, 1, 8, "Write program RAM (no program RAM is emulated, so it does nothing)"},
+	0xE4: {0xE4, "WR0", Implied, 1, 8, "Write the accumulator to status character 0 of the selected RAM register"},
+	0xE5: {0xE5, "WR1", Implied, 1, 8, "Write the accumulator to status character 1 of the selected RAM register"},
+	0xE6: {0xE6, "WR2", Implied, 1, 8, "Write the accumulator to status character 2 of the selected RAM register"},
+	0xE7: {0xE7, "WR3", Implied, 1, 8, "Write the accumulator to status character 3 of the selected RAM register"},
+	0xE8: {0xE8, "SBM", Implied, 1, 8, "Subtract the selected RAM character and the borrow from the accumulator"},
+	0xE9: {0xE9, "RDM", Implied, 1, 8, "Read the selected RAM character into the accumulator"},
+	0xEA: {0xEA, "RDR", Implied, 1, 8, "Read the I/O port of the selected ROM chip into the accumulator"},
+	0xEB: {0xEB, "ADM", Implied, 1, 8, "Add the selected RAM character and the carry to the accumulator"},
+	0xEC: {0xEC, "RD0", Implied, 1, 8, "Read status character 0 of the selected RAM register into the accumulator"},
+	0xED: {0xED, "RD1", Implied, 1, 8, "Read status character 1 of the selected RAM register into the accumulator"},
+	0xEE: {0xEE, "RD2", Implied, 1, 8, "Read status character 2 of the selected RAM register into the accumulator"},
+	0xEF: {0xEF, "RD3", Implied, 1, 8, "Read status character 3 of the selected RAM register into the accumulator"},
+
+	// Machine Control Instructions
+	// The 4004 has no halt; the emulator stops at HLT, which only the 4040 decodes.
+
+	0x00: {0x00, "NOP", Implied, 1, 8, "No operation"},
+	0x01: {0x01, "HLT", Implied, 1, 8, "Halt (a 4040 instruction; the 4004 does not decode it)"},
+}
+
+// Intel4040Extensions are the instructions the 4040 adds in the opcodes the
+// 4004 leaves undefined, from interrupts to index register and ROM banks
+var Intel4040Extensions = map[byte]Instruction{
+	0x01: {0x01, "HLT", Implied, 1, 8, "Halt until an interrupt"},
+	0x02: {0x02, "BBS", Implied, 1, 8, "Return from the interrupt routine and restore SRC"},
+	0x03: {0x03, "LCR", Implied, 1, 8, "Load the command register (the RAM bank) into the accumulator"},
+	0x04: {0x04, "OR4", Implied, 1, 8, "OR register R4 with the accumulator"},
+	0x05: {0x05, "OR5", Implied, 1, 8, "OR register R5 with the accumulator"},
+	0x06: {0x06, "AN6", Implied, 1, 8, "AND register R6 with the accumulator"},
+	0x07: {0x07, "AN7", Implied, 1, 8, "AND register R7 with the accumulator"},
+	0x08: {0x08, "DB0", Implied, 1, 8, "Designate ROM bank 0"},
+	0x09: {0x09, "DB1", Implied, 1, 8, "Designate ROM bank 1"},
+	0x0A: {0x0A, "SB0", Implied, 1, 8, "Select index register bank 0"},
+	0x0B: {0x0B, "SB1", Implied, 1, 8, "Select index register bank 1"},
+	0x0C: {0x0C, "EIN", Implied, 1, 8, "Enable interrupts"},
+	0x0D: {0x0D, "DIN", Implied, 1, 8, "Disable interrupts"},
+	0x0E: {0x0E, "RPM", Implied, 1, 8, "Read program RAM (no program RAM is emulated, so it reads 0)"},
+}
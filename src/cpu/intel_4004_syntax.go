@@ -0,0 +1,48 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Intel4004Syntax is the Intel MCS-4 assembly language (FIM P0, LD R5, JCN 4) with 0FFH numbers
+var Intel4004Syntax = &Syntax{
+	Name:        "4004",
+	Description: "Intel 4004 mnemonics (FIM P0, LD R5, JCN 4)",
+	Forms:       intel4004Forms(Intel4004Instructions),
+	HexSuffix:   true,
+}
+
+// Intel4040Syntax is Intel4004Syntax with the 4040 extensions (EIN, DB1, SB1)
+var Intel4040Syntax = &Syntax{
+	Name:        "4040",
+	Description: "Intel 4040 mnemonics (FIM P0, LD R5, JCN 4, DB1)",
+	Forms:       intel4004Forms(Intel4040Instructions),
+	HexSuffix:   true,
+}
+
+// Intel4004Syntaxes lists the dialects the 4004 can be written in
+var Intel4004Syntaxes = []*Syntax{Intel4004Syntax}
+
+// Intel4040Syntaxes lists the dialects the 4040 can be written in
+var Intel4040Syntaxes = []*Syntax{Intel4040Syntax}
+
+// intel4004Forms builds the form of every 4004 or 4040 opcode. Index
+// registers R0-R15, register pairs P0-P7 and the data or condition nibble in
+// the low 4 bits of the opcode become fixed operands.
+func intel4004Forms(instructions map[byte]Instruction) map[byte]Form {
+	forms := make(map[byte]Form)
+	for opcode, instruction := range instructions {
+		form := Form{Mnemonic: instruction.Mnemonic}
+		low := opcode & 0x0F
+		switch instruction.Mnemonic {
+		case "INC", "ADD", "SUB", "LD", "XCH", "ISZ":
+			form.Operands = []string{fmt.Sprintf("R%d", low)}
+		case "FIM", "SRC", "FIN", "JIN":
+			form.Operands = []string{fmt.Sprintf("P%d", low>>1)}
+		case "BBL", "LDM", "JCN":
+			form.Operands = []string{fmt.Sprint(low)}
+		}
+		forms[opcode] = form
+	}
+	return forms
+}
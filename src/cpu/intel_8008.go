@@ -2,20 +2,19 @@ package cpu
 
 import (
 	"fmt"
-	"os"
 )
 
 // CPU8008 represents the 8008 processor
 type Intel8008 struct {
 	CPU
-	A     uint8 // Accumulator, A register, 0
-	B     uint8 // B register, 1
-	C     uint8 // C register, 2
-	D     uint8 // D register, 3
-	E     uint8 // E register, 4
-	H     uint8 // High-order word, H register, 5
-	L     uint8 // Low-order word, L register, 6
-	Flags struct {
+	A      uint8 // Accumulator, A register, 0
+	B      uint8 // B register, 1
+	C      uint8 // C register, 2
+	D      uint8 // D register, 3
+	E      uint8 // E register, 4
+	H      uint8 // High-order word, H register, 5
+	L      uint8 // Low-order word, L register, 6
+	Status struct {
 		Carry  bool // Carry Flag (C)
 		Zero   bool // Zero Flag (Z)
 		Sign   bool // Sign Flag (S)
@@ -25,122 +24,63 @@ type Intel8008 struct {
 
 // NewCPU creates a new 8008 CPU instance
 func NewIntel8008(memorySize int, speed uint) *Intel8008 {
-	return &Intel8008{
+	c := &Intel8008{
 		CPU: *NewCPU("Intel8008", memorySize, speed, Intel8008Instructions),
 	}
+	c.bindRegisters(c.registerFile())
+	return c
 }
 
-// GetA returns the accumulator
-func (c *Intel8008) GetA() uint8 {
-	return c.A
-}
-
-// SetA sets the accumulator
-func (c *Intel8008) SetA(value uint8) {
-	c.A = value
-}
-
-// GetB returns the B register
-func (c *Intel8008) GetB() uint8 {
-	return c.B
-}
-
-// SetB sets the B register
-func (c *Intel8008) SetB(value uint8) {
-	c.B = value
-}
-
-// GetC returns the C register
-func (c *Intel8008) GetC() uint8 {
-	return c.C
-}
-
-// SetC sets the C register
-func (c *Intel8008) SetC(value uint8) {
-	c.C = value
-}
-
-// GetD returns the D register
-func (c *Intel8008) GetD() uint8 {
-	return c.D
-}
-
-// SetD sets the D register
-func (c *Intel8008) SetD(value uint8) {
-	c.D = value
-}
-
-// GetE returns the E register
-func (c *Intel8008) GetE() uint8 {
-	return c.E
-}
-
-// SetE sets the E register
-func (c *Intel8008) SetE(value uint8) {
-	c.E = value
-}
-
-// GetH returns the H register
-func (c *Intel8008) GetH() uint8 {
-	return c.H
-}
-
-// SetH sets the H register
-func (c *Intel8008) SetH(value uint8) {
-	c.H = value
-}
-
-// GetL returns the L register
-func (c *Intel8008) GetL() uint8 {
-	return c.L
-}
-
-// SetL sets the L register
-func (c *Intel8008) SetL(value uint8) {
-	c.L = value
-}
-
-// Run executes the program starting at the current PC
-func (c *Intel8008) Run() {
+// Run executes the program starting at the current PC until it halts
+func (c *Intel8008) Run() error {
 	// Start timing
 	c.CPU.Run()
+	defer c.CPU.Stop()
 
 	for {
-		// Get the opcode before executing
-		opcode := c.Memory[c.PC]
-		instruction, ok := c.Instructions[opcode]
-		if !ok {
-			fmt.Printf("🆘 Unknown opcode: $%02X at $%04X\n", opcode, c.PC)
-			os.Exit(1) // Exit on unknown opcode
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
 		}
-		if instruction.Mnemonic == "HLT" {
-			c.WaitForCycles(instruction.Cycles)
-			break
-		}
-
-		// Execute one instruction
-		c.ExecuteInstruction()
 	}
-	c.CPU.Stop()
 }
 
-// ExecuteInstruction executes a single instruction
-func (c *Intel8008) ExecuteInstruction() {
+// ExecuteInstruction executes a single instruction. It returns ErrHalted
+// after a halt instruction, an UnknownOpcodeError for opcodes outside the
+// instruction set and a FaultError for instructions it cannot execute.
+func (c *Intel8008) ExecuteInstruction() (err error) {
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.PC = pc
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
 	// Get the opcode
 	opcode := c.Memory[c.PC]
 
 	// Get the instruction
 	instruction, ok := c.Instructions[opcode]
 	if !ok {
-		fmt.Printf("🆘 Unknown opcode: $%02X at $%04X\n", opcode, c.PC)
-		os.Exit(1) // Exit on unknown opcode
+		return &UnknownOpcodeError{Opcode: opcode, PC: c.PC}
 	}
 
 	// Only print verbose output if enabled
 	if c.IsVerbose() {
 		fmt.Printf("PC: %04X, OP: %02X, MN: %s, A:%02X B:%02X C:%02X D:%02X E:%02X H:%02X L:%02X | Flags(CZSP): %d%d%d%d\n",
 			c.PC, opcode, instruction.Mnemonic, c.A, c.B, c.C, c.D, c.E, c.H, c.L,
-			boolToInt(c.Flags.Carry), boolToInt(c.Flags.Zero), boolToInt(c.Flags.Sign), boolToInt(c.Flags.Parity))
+			boolToInt(c.Status.Carry), boolToInt(c.Status.Zero), boolToInt(c.Status.Sign), boolToInt(c.Status.Parity))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
 	}
 
 	// Execute the instruction based on its addressing mode
@@ -148,28 +88,49 @@ func (c *Intel8008) ExecuteInstruction() {
 	case Immediate:
 		value := c.Memory[c.PC+1]
 		c.PC += 2
-		c.executeImmediate(instruction, value)
+		err = c.executeImmediate(instruction, value)
 	case Absolute:
 		low := uint16(c.Memory[c.PC+1])
 		high := uint16(c.Memory[c.PC+2])
 		addr := low | (high << 8)
 		c.PC += 3
-		c.executeAbsolute(instruction, addr)
+		err = c.executeAbsolute(instruction, addr)
 	case Relative:
 		offset := int8(c.Memory[c.PC+1])
 		c.PC += 2
-		c.executeRelative(instruction, offset)
+		err = c.executeRelative(instruction, offset)
 	case Implied:
 		c.PC++
-		c.executeImplied(instruction)
+		err = c.executeImplied(instruction)
 	}
 
 	// Wait for the appropriate amount of time
 	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// registerFile lists the registers and flags behind the register API
+func (c *Intel8008) registerFile() ([]register, []flag) {
+	registers := []register{
+		byteRegister("A", &c.A), byteRegister("B", &c.B), byteRegister("C", &c.C), byteRegister("D", &c.D),
+		byteRegister("E", &c.E), byteRegister("H", &c.H), byteRegister("L", &c.L), byteRegister("SP", &c.SP),
+	}
+	flags := []flag{
+		boolFlag("C", "Carry", &c.Status.Carry), boolFlag("Z", "Zero", &c.Status.Zero),
+		boolFlag("S", "Sign", &c.Status.Sign), boolFlag("P", "Parity", &c.Status.Parity),
+	}
+	return registers, flags
 }
 
 // executeImmediate executes an immediate mode instruction
-func (c *Intel8008) executeImmediate(instruction Instruction, value byte) {
+func (c *Intel8008) executeImmediate(instruction Instruction, value byte) error {
 	switch instruction.Mnemonic {
 	case "LAI":
 		c.A = value
@@ -187,189 +148,188 @@ func (c *Intel8008) executeImmediate(instruction Instruction, value byte) {
 		c.L = value
 	case "LMI":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = value
+		c.store(addr, value)
 	case "ADI":
 		result := uint16(c.A) + uint16(value)
 		c.A = byte(result)
-		c.Flags.Carry = result > 0xFF
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Carry = result > 0xFF
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "ACI":
 		result := uint16(c.A) + uint16(value)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
-		c.Flags.Carry = result > 0xFF
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Carry = result > 0xFF
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "SUI":
 		result := uint16(c.A) - uint16(value)
 		c.A = byte(result)
-		c.Flags.Carry = result > 0xFF
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Carry = result > 0xFF
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "SBI":
 		result := uint16(c.A) - uint16(value)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
-		c.Flags.Carry = result > 0xFF
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Carry = result > 0xFF
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "NDI":
 		c.A &= value
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "XRI":
 		c.A ^= value
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "ORI":
 		c.A |= value
-		c.Flags.Zero = c.A == 0
-		c.Flags.Sign = (c.A & 0x80) != 0
-		c.Flags.Parity = c.calculateParity(c.A)
+		c.Status.Zero = c.A == 0
+		c.Status.Sign = (c.A & 0x80) != 0
+		c.Status.Parity = c.calculateParity(c.A)
 	case "CPI":
 		c.updateCompareFlags(c.A, value)
 	default:
-		fmt.Printf("🆘 Immediate instruction not implemented: %s\n", instruction.Mnemonic)
-		os.Exit(1)
+		return fmt.Errorf("immediate instruction not implemented: %s", instruction.Mnemonic)
 	}
+	return nil
 }
 
 // executeAbsolute executes an absolute mode instruction
-func (c *Intel8008) executeAbsolute(instruction Instruction, addr uint16) {
+func (c *Intel8008) executeAbsolute(instruction Instruction, addr uint16) error {
 	switch instruction.Mnemonic {
 	case "JMP":
 		c.PC = addr
 	case "CAL":
 		// Save return address on stack
 		c.SP--
-		c.Memory[c.SP] = byte(c.PC >> 8)
+		c.store(uint16(c.SP), byte(c.PC>>8))
 		c.SP--
-		c.Memory[c.SP] = byte(c.PC)
+		c.store(uint16(c.SP), byte(c.PC))
 		c.PC = addr
 	case "JFC":
-		if !c.Flags.Carry {
+		if !c.Status.Carry {
 			c.PC = addr
 		}
 	case "JFZ":
-		if !c.Flags.Zero {
+		if !c.Status.Zero {
 			c.PC = addr
 		}
 	case "JFS":
-		if !c.Flags.Sign {
+		if !c.Status.Sign {
 			c.PC = addr
 		}
 	case "JFP":
-		if !c.Flags.Parity {
+		if !c.Status.Parity {
 			c.PC = addr
 		}
 	case "JTC":
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			c.PC = addr
 		}
 	case "JTZ":
-		if c.Flags.Zero {
+		if c.Status.Zero {
 			c.PC = addr
 		}
 	case "JTS":
-		if c.Flags.Sign {
+		if c.Status.Sign {
 			c.PC = addr
 		}
 	case "JTP":
-		if c.Flags.Parity {
+		if c.Status.Parity {
 			c.PC = addr
 		}
 	case "CFC":
-		if !c.Flags.Carry {
+		if !c.Status.Carry {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CFZ":
-		if !c.Flags.Zero {
+		if !c.Status.Zero {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CFS":
-		if !c.Flags.Sign {
+		if !c.Status.Sign {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CFP":
-		if !c.Flags.Parity {
+		if !c.Status.Parity {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CTC":
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CTZ":
-		if c.Flags.Zero {
+		if c.Status.Zero {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CTS":
-		if c.Flags.Sign {
+		if c.Status.Sign {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	case "CTP":
-		if c.Flags.Parity {
+		if c.Status.Parity {
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC >> 8)
+			c.store(uint16(c.SP), byte(c.PC>>8))
 			c.SP--
-			c.Memory[c.SP] = byte(c.PC)
+			c.store(uint16(c.SP), byte(c.PC))
 			c.PC = addr
 		}
 	default:
-		fmt.Printf("🆘 Absolute instruction not implemented: %s\n", instruction.Mnemonic)
-		os.Exit(1)
+		return fmt.Errorf("absolute instruction not implemented: %s", instruction.Mnemonic)
 	}
+	return nil
 }
 
 // executeRelative executes a relative mode instruction
-func (c *Intel8008) executeRelative(instruction Instruction, offset int8) {
+func (c *Intel8008) executeRelative(instruction Instruction, offset int8) error {
 	switch instruction.Mnemonic {
 	default:
-		fmt.Printf("🆘 Relative instruction not implemented: %s\n", instruction.Mnemonic)
-		os.Exit(1)
+		return fmt.Errorf("relative instruction not implemented: %s", instruction.Mnemonic)
 	}
 }
 
 // executeImplied executes an implied mode instruction
-func (c *Intel8008) executeImplied(instruction Instruction) {
+func (c *Intel8008) executeImplied(instruction Instruction) error {
 	switch instruction.Mnemonic {
 	// Register-to-register transfers
 	case "LAB":
@@ -386,7 +346,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.A = c.L
 	case "LAM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.A = c.Memory[addr]
+		c.A = c.load(addr)
 
 	case "LBA":
 		c.B = c.A
@@ -402,7 +362,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.B = c.L
 	case "LBM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.B = c.Memory[addr]
+		c.B = c.load(addr)
 
 	case "LCA":
 		c.C = c.A
@@ -418,7 +378,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.C = c.L
 	case "LCM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.C = c.Memory[addr]
+		c.C = c.load(addr)
 
 	case "LDA":
 		c.D = c.A
@@ -434,7 +394,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.D = c.L
 	case "LDM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.D = c.Memory[addr]
+		c.D = c.load(addr)
 
 	case "LEA":
 		c.E = c.A
@@ -450,7 +410,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.E = c.L
 	case "LEM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.E = c.Memory[addr]
+		c.E = c.load(addr)
 
 	case "LHA":
 		c.H = c.A
@@ -466,7 +426,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.H = c.L
 	case "LHM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.H = c.Memory[addr]
+		c.H = c.load(addr)
 
 	case "LLA":
 		c.L = c.A
@@ -482,30 +442,30 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.L = c.H
 	case "LLM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.L = c.Memory[addr]
+		c.L = c.load(addr)
 
 	// Memory operations
 	case "LMA":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.A
+		c.store(addr, c.A)
 	case "LMB":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.B
+		c.store(addr, c.B)
 	case "LMC":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.C
+		c.store(addr, c.C)
 	case "LMD":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.D
+		c.store(addr, c.D)
 	case "LME":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.E
+		c.store(addr, c.E)
 	case "LMH":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.H
+		c.store(addr, c.H)
 	case "LML":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.Memory[addr] = c.L
+		c.store(addr, c.L)
 
 	// Increment/Decrement
 	case "INB":
@@ -577,63 +537,63 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.updateFlagsWithCarry(result)
 	case "ADM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		result := uint16(c.A) + uint16(c.Memory[addr])
+		result := uint16(c.A) + uint16(c.load(addr))
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 
 	case "ACA":
 		result := uint16(c.A) + uint16(c.A)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACB":
 		result := uint16(c.A) + uint16(c.B)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACC":
 		result := uint16(c.A) + uint16(c.C)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACD":
 		result := uint16(c.A) + uint16(c.D)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACE":
 		result := uint16(c.A) + uint16(c.E)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACH":
 		result := uint16(c.A) + uint16(c.H)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACL":
 		result := uint16(c.A) + uint16(c.L)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
 		c.updateFlagsWithCarry(result)
 	case "ACM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		result := uint16(c.A) + uint16(c.Memory[addr])
-		if c.Flags.Carry {
+		result := uint16(c.A) + uint16(c.load(addr))
+		if c.Status.Carry {
 			result++
 		}
 		c.A = byte(result)
@@ -669,63 +629,63 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.updateFlagsWithBorrow(result)
 	case "SUM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		result := uint16(c.A) - uint16(c.Memory[addr])
+		result := uint16(c.A) - uint16(c.load(addr))
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 
 	case "SBA":
 		result := uint16(c.A) - uint16(c.A)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBB":
 		result := uint16(c.A) - uint16(c.B)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBC":
 		result := uint16(c.A) - uint16(c.C)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBD":
 		result := uint16(c.A) - uint16(c.D)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBE":
 		result := uint16(c.A) - uint16(c.E)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBH":
 		result := uint16(c.A) - uint16(c.H)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBL":
 		result := uint16(c.A) - uint16(c.L)
-		if c.Flags.Carry {
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
 		c.updateFlagsWithBorrow(result)
 	case "SBM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		result := uint16(c.A) - uint16(c.Memory[addr])
-		if c.Flags.Carry {
+		result := uint16(c.A) - uint16(c.load(addr))
+		if c.Status.Carry {
 			result--
 		}
 		c.A = byte(result)
@@ -754,7 +714,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.updateFlags(c.A)
 	case "NDM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.A &= c.Memory[addr]
+		c.A &= c.load(addr)
 		c.updateFlags(c.A)
 
 	case "XRA":
@@ -780,7 +740,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.updateFlags(c.A)
 	case "XRM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.A ^= c.Memory[addr]
+		c.A ^= c.load(addr)
 		c.updateFlags(c.A)
 
 	case "ORA":
@@ -806,7 +766,7 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.updateFlags(c.A)
 	case "ORM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.A |= c.Memory[addr]
+		c.A |= c.load(addr)
 		c.updateFlags(c.A)
 
 	case "CPA":
@@ -825,27 +785,27 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 		c.updateCompareFlags(c.A, c.L)
 	case "CPM":
 		addr := uint16(c.H)<<8 | uint16(c.L)
-		c.updateCompareFlags(c.A, c.Memory[addr])
+		c.updateCompareFlags(c.A, c.load(addr))
 
 	// Rotate instructions
 	case "RLC":
 		carry := (c.A & 0x80) != 0
 		c.A = (c.A << 1) | c.A>>7
-		c.Flags.Carry = carry
+		c.Status.Carry = carry
 	case "RRC":
 		carry := (c.A & 0x01) != 0
 		c.A = (c.A >> 1) | c.A<<7
-		c.Flags.Carry = carry
+		c.Status.Carry = carry
 	case "RAL":
-		oldCarry := c.Flags.Carry
-		c.Flags.Carry = (c.A & 0x80) != 0
+		oldCarry := c.Status.Carry
+		c.Status.Carry = (c.A & 0x80) != 0
 		c.A = (c.A << 1)
 		if oldCarry {
 			c.A |= 0x01
 		}
 	case "RAR":
-		oldCarry := c.Flags.Carry
-		c.Flags.Carry = (c.A & 0x01) != 0
+		oldCarry := c.Status.Carry
+		c.Status.Carry = (c.A & 0x01) != 0
 		c.A = (c.A >> 1)
 		if oldCarry {
 			c.A |= 0x80
@@ -853,41 +813,93 @@ func (c *Intel8008) executeImplied(instruction Instruction) {
 
 	// Return instruction
 	case "RET":
-		c.PC = uint16(c.Memory[c.SP]) | uint16(c.Memory[c.SP+1])<<8
-		c.SP += 2
+		c.ret()
+
+	case "RFC":
+		if !c.Status.Carry {
+			c.ret()
+		}
+	case "RFZ":
+		if !c.Status.Zero {
+			c.ret()
+		}
+	case "RFS":
+		if !c.Status.Sign {
+			c.ret()
+		}
+	case "RFP":
+		if !c.Status.Parity {
+			c.ret()
+		}
+	case "RTC":
+		if c.Status.Carry {
+			c.ret()
+		}
+	case "RTZ":
+		if c.Status.Zero {
+			c.ret()
+		}
+	case "RTS":
+		if c.Status.Sign {
+			c.ret()
+		}
+	case "RTP":
+		if c.Status.Parity {
+			c.ret()
+		}
+
+	// Restart: call the vector encoded in the opcode
+	case "RST":
+		c.SP--
+		c.store(uint16(c.SP), byte(c.PC>>8))
+		c.SP--
+		c.store(uint16(c.SP), byte(c.PC))
+		c.PC = uint16(instruction.Opcode & 0x38)
+
+	// Input and output; the port number is encoded in the opcode
+	case "INP":
+		c.A = c.In(instruction.Opcode >> 1 & 0x07)
+	case "OUT":
+		c.Out(instruction.Opcode>>1&0x1F, c.A)
 
 	case "HLT":
-		os.Exit(0)
+		return ErrHalted
 
 	default:
-		fmt.Printf("🆘 Implied instruction not implemented: %s\n", instruction.Mnemonic)
-		os.Exit(1)
+		return fmt.Errorf("implied instruction not implemented: %s", instruction.Mnemonic)
 	}
+	return nil
+}
+
+// ret returns from a subroutine to the address on the stack
+func (c *Intel8008) ret() {
+	c.PC = uint16(c.load(uint16(c.SP))) | uint16(c.load(uint16(c.SP+1)))<<8
+	c.SP += 2
 }
 
 // Helper functions for flag updates
 func (c *Intel8008) updateFlags(value byte) {
-	c.Flags.Zero = value == 0
-	c.Flags.Sign = (value & 0x80) != 0
-	c.Flags.Parity = c.calculateParity(value)
+	c.Status.Zero = value == 0
+	c.Status.Sign = (value & 0x80) != 0
+	c.Status.Parity = c.calculateParity(value)
 }
 
 func (c *Intel8008) updateFlagsWithCarry(result uint16) {
-	c.Flags.Carry = result > 0xFF
+	c.Status.Carry = result > 0xFF
 	c.updateFlags(byte(result))
 }
 
 func (c *Intel8008) updateFlagsWithBorrow(result uint16) {
-	c.Flags.Carry = result > 0xFF
+	c.Status.Carry = result > 0xFF
 	c.updateFlags(byte(result))
 }
 
 func (c *Intel8008) updateCompareFlags(a, value byte) {
 	result := a - value
-	c.Flags.Carry = a < value
-	c.Flags.Zero = result == 0
-	c.Flags.Sign = (result & 0x80) != 0
-	c.Flags.Parity = c.calculateParity(result)
+	c.Status.Carry = a < value
+	c.Status.Zero = result == 0
+	c.Status.Sign = (result & 0x80) != 0
+	c.Status.Parity = c.calculateParity(result)
 }
 
 func (c *Intel8008) calculateParity(value byte) bool {
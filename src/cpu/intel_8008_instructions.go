@@ -1,11 +1,14 @@
+// Code generated by isagen from intel_8008.isa; DO NOT EDIT.
+
 package cpu
 
+// Intel8008Instructions is the Intel 8008 instruction set
 var Intel8008Instructions = map[byte]Instruction{
 
 	// Index Register Instructions
-	// The load instructions do not affect the flag flip-flops.
-	// The increment and decrement instructions affect all flip-flops except the carry.
-	// Memory registers are addressed by contents of registers H and L.
+	// The load instructions do not affect the flag flip-flops. The increment and
+	// decrement instructions affect all flip-flops except the carry. The memory
+	// register M is addressed by the contents of registers H and L.
 
 	0xC1: {0xC1, "LAB", Implied, 1, 5, "Load register A with content of register B"},
 	0xC2: {0xC2, "LAC", Implied, 1, 5, "Load register A with content of register C"},
@@ -13,42 +16,36 @@ var Intel8008Instructions = map[byte]Instruction{
 	0xC4: {0xC4, "LAE", Implied, 1, 5, "Load register A with content of register E"},
 	0xC5: {0xC5, "LAH", Implied, 1, 5, "Load register A with content of register H"},
 	0xC6: {0xC6, "LAL", Implied, 1, 5, "Load register A with content of register L"},
-
 	0xC8: {0xC8, "LBA", Implied, 1, 5, "Load register B with content of register A"},
 	0xCA: {0xCA, "LBC", Implied, 1, 5, "Load register B with content of register C"},
 	0xCB: {0xCB, "LBD", Implied, 1, 5, "Load register B with content of register D"},
 	0xCC: {0xCC, "LBE", Implied, 1, 5, "Load register B with content of register E"},
 	0xCD: {0xCD, "LBH", Implied, 1, 5, "Load register B with content of register H"},
 	0xCE: {0xCE, "LBL", Implied, 1, 5, "Load register B with content of register L"},
-
 	0xD0: {0xD0, "LCA", Implied, 1, 5, "Load register C with content of register A"},
 	0xD1: {0xD1, "LCB", Implied, 1, 5, "Load register C with content of register B"},
 	0xD3: {0xD3, "LCD", Implied, 1, 5, "Load register C with content of register D"},
 	0xD4: {0xD4, "LCE", Implied, 1, 5, "Load register C with content of register E"},
 	0xD5: {0xD5, "LCH", Implied, 1, 5, "Load register C with content of register H"},
 	0xD6: {0xD6, "LCL", Implied, 1, 5, "Load register C with content of register L"},
-
 	0xD8: {0xD8, "LDA", Implied, 1, 5, "Load register D with content of register A"},
 	0xD9: {0xD9, "LDB", Implied, 1, 5, "Load register D with content of register B"},
 	0xDA: {0xDA, "LDC", Implied, 1, 5, "Load register D with content of register C"},
 	0xDC: {0xDC, "LDE", Implied, 1, 5, "Load register D with content of register E"},
 	0xDD: {0xDD, "LDH", Implied, 1, 5, "Load register D with content of register H"},
 	0xDE: {0xDE, "LDL", Implied, 1, 5, "Load register D with content of register L"},
-
 	0xE0: {0xE0, "LEA", Implied, 1, 5, "Load register E with content of register A"},
 	0xE1: {0xE1, "LEB", Implied, 1, 5, "Load register E with content of register B"},
 	0xE2: {0xE2, "LEC", Implied, 1, 5, "Load register E with content of register C"},
 	0xE3: {0xE3, "LED", Implied, 1, 5, "Load register E with content of register D"},
 	0xE5: {0xE5, "LEH", Implied, 1, 5, "Load register E with content of register H"},
 	0xE6: {0xE6, "LEL", Implied, 1, 5, "Load register E with content of register L"},
-
 	0xE8: {0xE8, "LHA", Implied, 1, 5, "Load register H with content of register A"},
 	0xE9: {0xE9, "LHB", Implied, 1, 5, "Load register H with content of register B"},
 	0xEA: {0xEA, "LHC", Implied, 1, 5, "Load register H with content of register C"},
 	0xEB: {0xEB, "LHD", Implied, 1, 5, "Load register H with content of register D"},
 	0xEC: {0xEC, "LHE", Implied, 1, 5, "Load register H with content of register E"},
 	0xEE: {0xEE, "LHL", Implied, 1, 5, "Load register H with content of register L"},
-
 	0xF0: {0xF0, "LLA", Implied, 1, 5, "Load register L with content of register A"},
 	0xF1: {0xF1, "LLB", Implied, 1, 5, "Load register L with content of register B"},
 	0xF2: {0xF2, "LLC", Implied, 1, 5, "Load register L with content of register C"},
@@ -97,164 +94,143 @@ var Intel8008Instructions = map[byte]Instruction{
 	0x31: {0x31, "DCL", Implied, 1, 5, "Decrement content of register L"},
 
 	// Accumulator Group Instructions
-	// The result of the ALU instructions affect all flag flip-flops.
-	// Rotate instructions affect only the carry flip-flop.
-
-	0x80: {0x80, "ADA", Implied, 1, 5, "Add content of register A to accumulator. Overflow sets carry flag"},
-	0x81: {0x81, "ADB", Implied, 1, 5, "Add content of register B to accumulator. Overflow sets carry flag"},
-	0x82: {0x82, "ADC", Implied, 1, 5, "Add content of register C to accumulator. Overflow sets carry flag"},
-	0x83: {0x83, "ADD", Implied, 1, 5, "Add content of register D to accumulator. Overflow sets carry flag"},
-	0x84: {0x84, "ADE", Implied, 1, 5, "Add content of register E to accumulator. Overflow sets carry flag"},
-	0x85: {0x85, "ADH", Implied, 1, 5, "Add content of register H to accumulator. Overflow sets carry flag"},
-	0x86: {0x86, "ADL", Implied, 1, 5, "Add content of register L to accumulator. Overflow sets carry flag"},
-
-	0x87: {0x87, "ADM", Implied, 1, 8, "Add content of memory register M to accumulator. Overflow sets carry flag"},
-
-	0x04: {0x04, "ADI", Immediate, 2, 8, "Add content of data to accumulator. Overflow sets carry flag"},
-
-	0x88: {0x88, "ACA", Implied, 1, 5, "Add content of register A from accumulator with carry. Overflow sets carry flag"},
-	0x89: {0x89, "ACB", Implied, 1, 5, "Add content of register B from accumulator with carry. Overflow sets carry flag"},
-	0x8A: {0x8A, "ACC", Implied, 1, 5, "Add content of register C from accumulator with carry. Overflow sets carry flag"},
-	0x8B: {0x8B, "ACD", Implied, 1, 5, "Add content of register D from accumulator with carry. Overflow sets carry flag"},
-	0x8C: {0x8C, "ACE", Implied, 1, 5, "Add content of register E from accumulator with carry. Overflow sets carry flag"},
-	0x8D: {0x8D, "ACH", Implied, 1, 5, "Add content of register H from accumulator with carry. Overflow sets carry flag"},
-	0x8E: {0x8E, "ACL", Implied, 1, 5, "Add content of register L from accumulator with carry. Overflow sets carry flag"},
-
-	0x8F: {0x8F, "ACM", Implied, 1, 8, "Add content of memory register M from accumulator with carry. Overflow sets carry flag"},
-
-	0x0C: {0x0C, "ACI", Immediate, 2, 8, "Add content of data from accumulator with carry. Overflow sets carry flag"},
-
-	0x90: {0x90, "SUA", Implied, 1, 5, "Subtract content of register A from accumulator. Underflow sets carry flag"},
-	0x91: {0x91, "SUB", Implied, 1, 5, "Subtract content of register B from accumulator. Underflow sets carry flag"},
-	0x92: {0x92, "SUC", Implied, 1, 5, "Subtract content of register C from accumulator. Underflow sets carry flag"},
-	0x93: {0x93, "SUD", Implied, 1, 5, "Subtract content of register D from accumulator. Underflow sets carry flag"},
-	0x94: {0x94, "SUE", Implied, 1, 5, "Subtract content of register E from accumulator. Underflow sets carry flag"},
-	0x95: {0x95, "SUH", Implied, 1, 5, "Subtract content of register H from accumulator. Underflow sets carry flag"},
-	0x96: {0x96, "SUL", Implied, 1, 5, "Subtract content of register L from accumulator. Underflow sets carry flag"},
-
-	0x97: {0x97, "SUM", Implied, 1, 8, "Subtract content of memory register M from accumulator. Underflow sets carry flag"},
-
-	0x14: {0x14, "SUI", Immediate, 2, 8, "Subtract content of data from accumulator. Underflow sets carry flag"},
-
-	0x98: {0x98, "SBA", Implied, 1, 5, "Subtract content of register A from accumulator with borrow. Underflow sets carry flag"},
-	0x99: {0x99, "SBB", Implied, 1, 5, "Subtract content of register B from accumulator with borrow. Underflow sets carry flag"},
-	0x9A: {0x9A, "SBC", Implied, 1, 5, "Subtract content of register C from accumulator with borrow. Underflow sets carry flag"},
-	0x9B: {0x9B, "SBD", Implied, 1, 5, "Subtract content of register D from accumulator with borrow. Underflow sets carry flag"},
-	0x9C: {0x9C, "SBE", Implied, 1, 5, "Subtract content of register E from accumulator with borrow. Underflow sets carry flag"},
-	0x9D: {0x9D, "SBH", Implied, 1, 5, "Subtract content of register H from accumulator with borrow. Underflow sets carry flag"},
-	0x9E: {0x9E, "SBL", Implied, 1, 5, "Subtract content of register L from accumulator with borrow. Underflow sets carry flag"},
-
-	0x9F: {0x9F, "SBM", Implied, 1, 8, "Subtract content of memory register M from accumulator with borrow. Underflow sets carry flag"},
-
-	0x1C: {0x1C, "SBI", Immediate, 2, 8, "Subtract content of data from accumulator with borrow. Underflow sets carry flag"},
-
-	0xA0: {0xA0, "NDA", Implied, 1, 5, "Compute logical AND of register A content with accumulator"},
-	0xA1: {0xA1, "NDB", Implied, 1, 5, "Compute logical AND of register B content with accumulator"},
-	0xA2: {0xA2, "NDC", Implied, 1, 5, "Compute logical AND of register C content with accumulator"},
-	0xA3: {0xA3, "NDD", Implied, 1, 5, "Compute logical AND of register D content with accumulator"},
-	0xA4: {0xA4, "NDE", Implied, 1, 5, "Compute logical AND of register E content with accumulator"},
-	0xA5: {0xA5, "NDH", Implied, 1, 5, "Compute logical AND of register H content with accumulator"},
-	0xA6: {0xA6, "NDL", Implied, 1, 5, "Compute logical AND of register L content with accumulator"},
-
-	0xA7: {0xA7, "NDM", Implied, 1, 8, "Compute logical AND of memory register M content with accumulator"},
-
-	0x24: {0x24, "NDI", Immediate, 2, 8, "Compute logical AND of data content with accumulator"},
-
-	0xA8: {0xA8, "XRA", Implied, 1, 5, "Compute EXCLUSIVE OR of register A content with accumulator"},
-	0xA9: {0xA9, "XRB", Implied, 1, 5, "Compute EXCLUSIVE OR of register B content with accumulator"},
-	0xAA: {0xAA, "XRC", Implied, 1, 5, "Compute EXCLUSIVE OR of register C content with accumulator"},
-	0xAB: {0xAB, "XRD", Implied, 1, 5, "Compute EXCLUSIVE OR of register D content with accumulator"},
-	0xAC: {0xAC, "XRE", Implied, 1, 5, "Compute EXCLUSIVE OR of register E content with accumulator"},
-	0xAD: {0xAD, "XRH", Implied, 1, 5, "Compute EXCLUSIVE OR of register H content with accumulator"},
-	0xAE: {0xAE, "XRL", Implied, 1, 5, "Compute EXCLUSIVE OR of register L content with accumulator"},
-
-	0xAF: {0xAF, "XRM", Implied, 1, 8, "Compute EXCLUSIVE OR of memory register M content with accumulator"},
-
-	0x2C: {0x2C, "XRI", Immediate, 2, 8, "Compute EXCLUSIVE OR of data content with accumulator"},
-
-	0xB0: {0xB0, "ORA", Implied, 1, 5, "Compute INCLUSIVE OR of register A content with accumulator"},
-	0xB1: {0xB1, "ORB", Implied, 1, 5, "Compute INCLUSIVE OR of register B content with accumulator"},
-	0xB2: {0xB2, "ORC", Implied, 1, 5, "Compute INCLUSIVE OR of register C content with accumulator"},
-	0xB3: {0xB3, "ORD", Implied, 1, 5, "Compute INCLUSIVE OR of register D content with accumulator"},
-	0xB4: {0xB4, "ORE", Implied, 1, 5, "Compute INCLUSIVE OR of register E content with accumulator"},
-	0xB5: {0xB5, "ORH", Implied, 1, 5, "Compute INCLUSIVE OR of register H content with accumulator"},
-	0xB6: {0xB6, "ORL", Implied, 1, 5, "Compute INCLUSIVE OR of register L content with accumulator"},
-
-	0xB7: {0xB7, "ORM", Implied, 1, 8, "Compute INCLUSIVE OR of memory register M content with accumulator"},
-
-	0x34: {0x34, "ORI", Immediate, 2, 8, "Compute INCLUSIVE OR of data content with accumulator"},
-
-	0xB8: {0xB8, "CPA", Implied, 1, 5, "Compare content of register A content with accumulator. Accumulator unchanged"},
-	0xB9: {0xB9, "CPB", Implied, 1, 5, "Compare content of register B content with accumulator. Accumulator unchanged"},
-	0xBA: {0xBA, "CPC", Implied, 1, 5, "Compare content of register C content with accumulator. Accumulator unchanged"},
-	0xBB: {0xBB, "CPD", Implied, 1, 5, "Compare content of register D content with accumulator. Accumulator unchanged"},
-	0xBC: {0xBC, "CPE", Implied, 1, 5, "Compare content of register E content with accumulator. Accumulator unchanged"},
-	0xBD: {0xBD, "CPH", Implied, 1, 5, "Compare content of register H content with accumulator. Accumulator unchanged"},
-	0xBE: {0xBE, "CPL", Implied, 1, 5, "Compare content of register L content with accumulator. Accumulator unchanged"},
-
-	0xBF: {0xBF, "CPM", Implied, 1, 8, "Compare content of memory register M content with accumulator. Accumulator unchanged"},
-
-	0x3C: {0x3C, "CPI", Immediate, 2, 8, "Compare content of data content with accumulator. Accumulator unchanged"},
+	// The ALU instructions affect all flag flip-flops. The rotate instructions
+	// affect only the carry flip-flop.
+
+	0x80: {0x80, "ADA", Implied, 1, 5, "Add register A to the accumulator. Overflow sets carry flag"},
+	0x81: {0x81, "ADB", Implied, 1, 5, "Add register B to the accumulator. Overflow sets carry flag"},
+	0x82: {0x82, "ADC", Implied, 1, 5, "Add register C to the accumulator. Overflow sets carry flag"},
+	0x83: {0x83, "ADD", Implied, 1, 5, "Add register D to the accumulator. Overflow sets carry flag"},
+	0x84: {0x84, "ADE", Implied, 1, 5, "Add register E to the accumulator. Overflow sets carry flag"},
+	0x85: {0x85, "ADH", Implied, 1, 5, "Add register H to the accumulator. Overflow sets carry flag"},
+	0x86: {0x86, "ADL", Implied, 1, 5, "Add register L to the accumulator. Overflow sets carry flag"},
+	0x88: {0x88, "ACA", Implied, 1, 5, "Add register A to the accumulator with carry. Overflow sets carry flag"},
+	0x89: {0x89, "ACB", Implied, 1, 5, "Add register B to the accumulator with carry. Overflow sets carry flag"},
+	0x8A: {0x8A, "ACC", Implied, 1, 5, "Add register C to the accumulator with carry. Overflow sets carry flag"},
+	0x8B: {0x8B, "ACD", Implied, 1, 5, "Add register D to the accumulator with carry. Overflow sets carry flag"},
+	0x8C: {0x8C, "ACE", Implied, 1, 5, "Add register E to the accumulator with carry. Overflow sets carry flag"},
+	0x8D: {0x8D, "ACH", Implied, 1, 5, "Add register H to the accumulator with carry. Overflow sets carry flag"},
+	0x8E: {0x8E, "ACL", Implied, 1, 5, "Add register L to the accumulator with carry. Overflow sets carry flag"},
+	0x90: {0x90, "SUA", Implied, 1, 5, "Subtract register A from the accumulator. Underflow sets carry flag"},
+	0x91: {0x91, "SUB", Implied, 1, 5, "Subtract register B from the accumulator. Underflow sets carry flag"},
+	0x92: {0x92, "SUC", Implied, 1, 5, "Subtract register C from the accumulator. Underflow sets carry flag"},
+	0x93: {0x93, "SUD", Implied, 1, 5, "Subtract register D from the accumulator. Underflow sets carry flag"},
+	0x94: {0x94, "SUE", Implied, 1, 5, "Subtract register E from the accumulator. Underflow sets carry flag"},
+	0x95: {0x95, "SUH", Implied, 1, 5, "Subtract register H from the accumulator. Underflow sets carry flag"},
+	0x96: {0x96, "SUL", Implied, 1, 5, "Subtract register L from the accumulator. Underflow sets carry flag"},
+	0x98: {0x98, "SBA", Implied, 1, 5, "Subtract register A from the accumulator with borrow. Underflow sets carry flag"},
+	0x99: {0x99, "SBB", Implied, 1, 5, "Subtract register B from the accumulator with borrow. Underflow sets carry flag"},
+	0x9A: {0x9A, "SBC", Implied, 1, 5, "Subtract register C from the accumulator with borrow. Underflow sets carry flag"},
+	0x9B: {0x9B, "SBD", Implied, 1, 5, "Subtract register D from the accumulator with borrow. Underflow sets carry flag"},
+	0x9C: {0x9C, "SBE", Implied, 1, 5, "Subtract register E from the accumulator with borrow. Underflow sets carry flag"},
+	0x9D: {0x9D, "SBH", Implied, 1, 5, "Subtract register H from the accumulator with borrow. Underflow sets carry flag"},
+	0x9E: {0x9E, "SBL", Implied, 1, 5, "Subtract register L from the accumulator with borrow. Underflow sets carry flag"},
+	0xA0: {0xA0, "NDA", Implied, 1, 5, "Compute logical AND of register A with the accumulator"},
+	0xA1: {0xA1, "NDB", Implied, 1, 5, "Compute logical AND of register B with the accumulator"},
+	0xA2: {0xA2, "NDC", Implied, 1, 5, "Compute logical AND of register C with the accumulator"},
+	0xA3: {0xA3, "NDD", Implied, 1, 5, "Compute logical AND of register D with the accumulator"},
+	0xA4: {0xA4, "NDE", Implied, 1, 5, "Compute logical AND of register E with the accumulator"},
+	0xA5: {0xA5, "NDH", Implied, 1, 5, "Compute logical AND of register H with the accumulator"},
+	0xA6: {0xA6, "NDL", Implied, 1, 5, "Compute logical AND of register L with the accumulator"},
+	0xA8: {0xA8, "XRA", Implied, 1, 5, "Compute EXCLUSIVE OR of register A with the accumulator"},
+	0xA9: {0xA9, "XRB", Implied, 1, 5, "Compute EXCLUSIVE OR of register B with the accumulator"},
+	0xAA: {0xAA, "XRC", Implied, 1, 5, "Compute EXCLUSIVE OR of register C with the accumulator"},
+	0xAB: {0xAB, "XRD", Implied, 1, 5, "Compute EXCLUSIVE OR of register D with the accumulator"},
+	0xAC: {0xAC, "XRE", Implied, 1, 5, "Compute EXCLUSIVE OR of register E with the accumulator"},
+	0xAD: {0xAD, "XRH", Implied, 1, 5, "Compute EXCLUSIVE OR of register H with the accumulator"},
+	0xAE: {0xAE, "XRL", Implied, 1, 5, "Compute EXCLUSIVE OR of register L with the accumulator"},
+	0xB0: {0xB0, "ORA", Implied, 1, 5, "Compute INCLUSIVE OR of register A with the accumulator"},
+	0xB1: {0xB1, "ORB", Implied, 1, 5, "Compute INCLUSIVE OR of register B with the accumulator"},
+	0xB2: {0xB2, "ORC", Implied, 1, 5, "Compute INCLUSIVE OR of register C with the accumulator"},
+	0xB3: {0xB3, "ORD", Implied, 1, 5, "Compute INCLUSIVE OR of register D with the accumulator"},
+	0xB4: {0xB4, "ORE", Implied, 1, 5, "Compute INCLUSIVE OR of register E with the accumulator"},
+	0xB5: {0xB5, "ORH", Implied, 1, 5, "Compute INCLUSIVE OR of register H with the accumulator"},
+	0xB6: {0xB6, "ORL", Implied, 1, 5, "Compute INCLUSIVE OR of register L with the accumulator"},
+	0xB8: {0xB8, "CPA", Implied, 1, 5, "Compare register A with the accumulator. Accumulator unchanged"},
+	0xB9: {0xB9, "CPB", Implied, 1, 5, "Compare register B with the accumulator. Accumulator unchanged"},
+	0xBA: {0xBA, "CPC", Implied, 1, 5, "Compare register C with the accumulator. Accumulator unchanged"},
+	0xBB: {0xBB, "CPD", Implied, 1, 5, "Compare register D with the accumulator. Accumulator unchanged"},
+	0xBC: {0xBC, "CPE", Implied, 1, 5, "Compare register E with the accumulator. Accumulator unchanged"},
+	0xBD: {0xBD, "CPH", Implied, 1, 5, "Compare register H with the accumulator. Accumulator unchanged"},
+	0xBE: {0xBE, "CPL", Implied, 1, 5, "Compare register L with the accumulator. Accumulator unchanged"},
+
+	0x87: {0x87, "ADM", Implied, 1, 8, "Add memory register M to the accumulator. Overflow sets carry flag"},
+	0x8F: {0x8F, "ACM", Implied, 1, 8, "Add memory register M to the accumulator with carry. Overflow sets carry flag"},
+	0x97: {0x97, "SUM", Implied, 1, 8, "Subtract memory register M from the accumulator. Underflow sets carry flag"},
+	0x9F: {0x9F, "SBM", Implied, 1, 8, "Subtract memory register M from the accumulator with borrow. Underflow sets carry flag"},
+	0xA7: {0xA7, "NDM", Implied, 1, 8, "Compute logical AND of memory register M with the accumulator"},
+	0xAF: {0xAF, "XRM", Implied, 1, 8, "Compute EXCLUSIVE OR of memory register M with the accumulator"},
+	0xB7: {0xB7, "ORM", Implied, 1, 8, "Compute INCLUSIVE OR of memory register M with the accumulator"},
+	0xBF: {0xBF, "CPM", Implied, 1, 8, "Compare memory register M with the accumulator. Accumulator unchanged"},
+
+	0x04: {0x04, "ADI", Immediate, 2, 8, "Add data to the accumulator. Overflow sets carry flag"},
+	0x0C: {0x0C, "ACI", Immediate, 2, 8, "Add data to the accumulator with carry. Overflow sets carry flag"},
+	0x14: {0x14, "SUI", Immediate, 2, 8, "Subtract data from the accumulator. Underflow sets carry flag"},
+	0x1C: {0x1C, "SBI", Immediate, 2, 8, "Subtract data from the accumulator with borrow. Underflow sets carry flag"},
+	0x24: {0x24, "NDI", Immediate, 2, 8, "Compute logical AND of data with the accumulator"},
+	0x2C: {0x2C, "XRI", Immediate, 2, 8, "Compute EXCLUSIVE OR of data with the accumulator"},
+	0x34: {0x34, "ORI", Immediate, 2, 8, "Compute INCLUSIVE OR of data with the accumulator"},
+	0x3C: {0x3C, "CPI", Immediate, 2, 8, "Compare data with the accumulator. Accumulator unchanged"},
 
 	0x02: {0x02, "RLC", Implied, 1, 5, "Rotate content of accumulator left"},
+
 	0x0A: {0x0A, "RRC", Implied, 1, 5, "Rotate content of accumulator right"},
+
 	0x12: {0x12, "RAL", Implied, 1, 5, "Rotate content of accumulator left through carry"},
+
 	0x1A: {0x1A, "RAR", Implied, 1, 5, "Rotate content of accumulator right through carry"},
 
 	// Program Counter and Stack Control Instructions
 
-	0x44: {0x44, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x4C: {0x4C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x54: {0x54, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x5C: {0x5C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x64: {0x64, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x6C: {0x6C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x74: {0x74, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-	0x7C: {0x7C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address B3..B3[6] B2..B2[8]"},
-
-	0x40: {0x40, "JFC", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Carry is false"},
-	0x48: {0x48, "JFZ", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Zero is false"},
-	0x50: {0x50, "JFS", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Sign is false"},
-	0x58: {0x58, "JFP", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Parity is false"},
-
-	0x60: {0x60, "JTC", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Carry is true"},
-	0x68: {0x68, "JTZ", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Zero is true"},
-	0x70: {0x70, "JTS", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Sign is true"},
-	0x78: {0x78, "JTP", Absolute, 3, 11, "Jump to memory address B3..B3[6] B2..B2[8] if flag Parity is true"},
-
-	0x46: {0x46, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x4E: {0x4E, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x56: {0x56, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x5E: {0x5E, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x66: {0x66, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x6E: {0x6E, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x76: {0x76, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-	0x7E: {0x7E, "CAL", Absolute, 3, 11, "Unconditionaly call memory address B3..B3[6] B2..B2[8]. Save current address in stack"},
-
-	0x42: {0x42, "CFC", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Carry is false"},
-	0x4A: {0x4A, "CFZ", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Zero is false"},
-	0x52: {0x52, "CFS", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Sign is false"},
-	0x5A: {0x5A, "CFP", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Parity is false"},
-
-	0x62: {0x62, "CTC", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Carry is true"},
-	0x6A: {0x6A, "CTZ", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Zero is true"},
-	0x72: {0x72, "CTS", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Sign is true"},
-	0x7A: {0x7A, "CTP", Absolute, 3, 11, "Call memory address B3..B3[6] B2..B2[8] and save current address in stack if flag Parity is true"},
-
-	0x07: {0x07, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x0F: {0x0F, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x17: {0x17, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x1F: {0x1F, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x27: {0x27, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x2F: {0x2F, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x37: {0x37, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-	0x3F: {0x3F, "RET", Implied, 1, 5, "Unconditionaly return. Down one level in stack"},
-
-	0x03: {0x03, "RFC", Implied, 1, 5, "Return one level is stack if flag Carry is false"},
+	0x44: {0x44, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x4C: {0x4C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x54: {0x54, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x5C: {0x5C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x64: {0x64, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x6C: {0x6C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x74: {0x74, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+	0x7C: {0x7C, "JMP", Absolute, 3, 11, "Unconditional jump to memory address"},
+
+	0x40: {0x40, "JFC", Absolute, 3, 11, "Jump to memory address if flag Carry is false"},
+	0x48: {0x48, "JFZ", Absolute, 3, 11, "Jump to memory address if flag Zero is false"},
+	0x50: {0x50, "JFS", Absolute, 3, 11, "Jump to memory address if flag Sign is false"},
+	0x58: {0x58, "JFP", Absolute, 3, 11, "Jump to memory address if flag Parity is false"},
+	0x60: {0x60, "JTC", Absolute, 3, 11, "Jump to memory address if flag Carry is true"},
+	0x68: {0x68, "JTZ", Absolute, 3, 11, "Jump to memory address if flag Zero is true"},
+	0x70: {0x70, "JTS", Absolute, 3, 11, "Jump to memory address if flag Sign is true"},
+	0x78: {0x78, "JTP", Absolute, 3, 11, "Jump to memory address if flag Parity is true"},
+
+	0x46: {0x46, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x4E: {0x4E, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x56: {0x56, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x5E: {0x5E, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x66: {0x66, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x6E: {0x6E, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x76: {0x76, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+	0x7E: {0x7E, "CAL", Absolute, 3, 11, "Unconditional call to memory address. Save current address in stack"},
+
+	0x42: {0x42, "CFC", Absolute, 3, 11, "Call memory address and save current address in stack if flag Carry is false"},
+	0x4A: {0x4A, "CFZ", Absolute, 3, 11, "Call memory address and save current address in stack if flag Zero is false"},
+	0x52: {0x52, "CFS", Absolute, 3, 11, "Call memory address and save current address in stack if flag Sign is false"},
+	0x5A: {0x5A, "CFP", Absolute, 3, 11, "Call memory address and save current address in stack if flag Parity is false"},
+	0x62: {0x62, "CTC", Absolute, 3, 11, "Call memory address and save current address in stack if flag Carry is true"},
+	0x6A: {0x6A, "CTZ", Absolute, 3, 11, "Call memory address and save current address in stack if flag Zero is true"},
+	0x72: {0x72, "CTS", Absolute, 3, 11, "Call memory address and save current address in stack if flag Sign is true"},
+	0x7A: {0x7A, "CTP", Absolute, 3, 11, "Call memory address and save current address in stack if flag Parity is true"},
+
+	0x07: {0x07, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x0F: {0x0F, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x17: {0x17, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x1F: {0x1F, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x27: {0x27, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x2F: {0x2F, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x37: {0x37, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+	0x3F: {0x3F, "RET", Implied, 1, 5, "Unconditional return. Down one level in stack"},
+
+	0x03: {0x03, "RFC", Implied, 1, 5, "Return one level in stack if flag Carry is false"},
 	0x0B: {0x0B, "RFZ", Implied, 1, 5, "Return one level in stack if flag Zero is false"},
 	0x13: {0x13, "RFS", Implied, 1, 5, "Return one level in stack if flag Sign is false"},
 	0x1B: {0x1B, "RFP", Implied, 1, 5, "Return one level in stack if flag Parity is false"},
-
 	0x23: {0x23, "RTC", Implied, 1, 5, "Return one level in stack if flag Carry is true"},
 	0x2B: {0x2B, "RTZ", Implied, 1, 5, "Return one level in stack if flag Zero is true"},
 	0x33: {0x33, "RTS", Implied, 1, 5, "Return one level in stack if flag Sign is true"},
@@ -270,15 +246,7 @@ var Intel8008Instructions = map[byte]Instruction{
 	0x3D: {0x3D, "RST", Implied, 1, 5, "Call subroutine at memory address 111000. Up one level in stack"},
 
 	// Input / Output Instructions
-
-	0x41: {0x41, "INP", Implied, 1, 8, "Read content of input port 000 into accumulator"},
-	0x43: {0x43, "INP", Implied, 1, 8, "Read content of input port 001 into accumulator"},
-	0x45: {0x45, "INP", Implied, 1, 8, "Read content of input port 010 into accumulator"},
-	0x47: {0x47, "INP", Implied, 1, 8, "Read content of input port 011 into accumulator"},
-	0x49: {0x49, "INP", Implied, 1, 8, "Read content of input port 100 into accumulator"},
-	0x4B: {0x4B, "INP", Implied, 1, 8, "Read content of input port 101 into accumulator"},
-	0x4D: {0x4D, "INP", Implied, 1, 8, "Read content of input port 110 into accumulator"},
-	0x4F: {0x4F, "INP", Implied, 1, 8, "Read content of input port 111 into accumulator"},
+	// The port number is part of the opcode: ports 0-7 are inputs and 8-31 outputs.
 
 	0x51: {0x51, "OUT", Implied, 1, 6, "Write content of accumulator into output port 01000"},
 	0x53: {0x53, "OUT", Implied, 1, 6, "Write content of accumulator into output port 01001"},
@@ -288,7 +256,6 @@ var Intel8008Instructions = map[byte]Instruction{
 	0x5B: {0x5B, "OUT", Implied, 1, 6, "Write content of accumulator into output port 01101"},
 	0x5D: {0x5D, "OUT", Implied, 1, 6, "Write content of accumulator into output port 01110"},
 	0x5F: {0x5F, "OUT", Implied, 1, 6, "Write content of accumulator into output port 01111"},
-
 	0x61: {0x61, "OUT", Implied, 1, 6, "Write content of accumulator into output port 10000"},
 	0x63: {0x63, "OUT", Implied, 1, 6, "Write content of accumulator into output port 10001"},
 	0x65: {0x65, "OUT", Implied, 1, 6, "Write content of accumulator into output port 10010"},
@@ -297,7 +264,6 @@ var Intel8008Instructions = map[byte]Instruction{
 	0x6B: {0x6B, "OUT", Implied, 1, 6, "Write content of accumulator into output port 10101"},
 	0x6D: {0x6D, "OUT", Implied, 1, 6, "Write content of accumulator into output port 10110"},
 	0x6F: {0x6F, "OUT", Implied, 1, 6, "Write content of accumulator into output port 10111"},
-
 	0x71: {0x71, "OUT", Implied, 1, 6, "Write content of accumulator into output port 11000"},
 	0x73: {0x73, "OUT", Implied, 1, 6, "Write content of accumulator into output port 11001"},
 	0x75: {0x75, "OUT", Implied, 1, 6, "Write content of accumulator into output port 11010"},
@@ -307,7 +273,17 @@ var Intel8008Instructions = map[byte]Instruction{
 	0x7D: {0x7D, "OUT", Implied, 1, 6, "Write content of accumulator into output port 11110"},
 	0x7F: {0x7F, "OUT", Implied, 1, 6, "Write content of accumulator into output port 11111"},
 
+	0x41: {0x41, "INP", Implied, 1, 8, "Read content of input port 000 into accumulator"},
+	0x43: {0x43, "INP", Implied, 1, 8, "Read content of input port 001 into accumulator"},
+	0x45: {0x45, "INP", Implied, 1, 8, "Read content of input port 010 into accumulator"},
+	0x47: {0x47, "INP", Implied, 1, 8, "Read content of input port 011 into accumulator"},
+	0x49: {0x49, "INP", Implied, 1, 8, "Read content of input port 100 into accumulator"},
+	0x4B: {0x4B, "INP", Implied, 1, 8, "Read content of input port 101 into accumulator"},
+	0x4D: {0x4D, "INP", Implied, 1, 8, "Read content of input port 110 into accumulator"},
+	0x4F: {0x4F, "INP", Implied, 1, 8, "Read content of input port 111 into accumulator"},
+
 	// NOP, No Operation Instructions
+	// Loading a register into itself does nothing.
 
 	0xC0: {0xC0, "NOP", Implied, 1, 5, "No operation"},
 	0xC9: {0xC9, "NOP", Implied, 1, 5, "No operation"},
@@ -321,5 +297,514 @@ var Intel8008Instructions = map[byte]Instruction{
 
 	0x00: {0x00, "HLT", Implied, 1, 4, "Enter STOPPED state; remain there until interrupted"},
 	0x01: {0x01, "HLT", Implied, 1, 4, "Enter STOPPED state; remain there until interrupted"},
+
 	0xFF: {0xFF, "HLT", Implied, 1, 4, "Enter STOPPED state; remain there until interrupted"},
 }
+
+// intel8008Forms holds the written form of every opcode, keyed by dialect name
+var intel8008Forms = map[string]map[byte]Form{
+	"8008": {
+		0x00: {"HLT", nil},
+		0x01: {"HLT", nil},
+		0x02: {"RLC", nil},
+		0x03: {"RFC", nil},
+		0x04: {"ADI", nil},
+		0x05: {"RST", []string{"0"}},
+		0x06: {"LAI", nil},
+		0x07: {"RET", nil},
+		0x08: {"INB", nil},
+		0x09: {"DCB", nil},
+		0x0A: {"RRC", nil},
+		0x0B: {"RFZ", nil},
+		0x0C: {"ACI", nil},
+		0x0D: {"RST", []string{"1"}},
+		0x0E: {"LBI", nil},
+		0x0F: {"RET", nil},
+		0x10: {"INC", nil},
+		0x11: {"DCC", nil},
+		0x12: {"RAL", nil},
+		0x13: {"RFS", nil},
+		0x14: {"SUI", nil},
+		0x15: {"RST", []string{"2"}},
+		0x16: {"LCI", nil},
+		0x17: {"RET", nil},
+		0x18: {"IND", nil},
+		0x19: {"DCD", nil},
+		0x1A: {"RAR", nil},
+		0x1B: {"RFP", nil},
+		0x1C: {"SBI", nil},
+		0x1D: {"RST", []string{"3"}},
+		0x1E: {"LDI", nil},
+		0x1F: {"RET", nil},
+		0x20: {"INE", nil},
+		0x21: {"DCE", nil},
+		0x23: {"RTC", nil},
+		0x24: {"NDI", nil},
+		0x25: {"RST", []string{"4"}},
+		0x26: {"LEI", nil},
+		0x27: {"RET", nil},
+		0x28: {"INH", nil},
+		0x29: {"DCH", nil},
+		0x2B: {"RTZ", nil},
+		0x2C: {"XRI", nil},
+		0x2D: {"RST", []string{"5"}},
+		0x2E: {"LHI", nil},
+		0x2F: {"RET", nil},
+		0x30: {"INL", nil},
+		0x31: {"DCL", nil},
+		0x33: {"RTS", nil},
+		0x34: {"ORI", nil},
+		0x35: {"RST", []string{"6"}},
+		0x36: {"LLI", nil},
+		0x37: {"RET", nil},
+		0x3B: {"RTP", nil},
+		0x3C: {"CPI", nil},
+		0x3D: {"RST", []string{"7"}},
+		0x3E: {"LMI", nil},
+		0x3F: {"RET", nil},
+		0x40: {"JFC", nil},
+		0x41: {"INP", []string{"0"}},
+		0x42: {"CFC", nil},
+		0x43: {"INP", []string{"1"}},
+		0x44: {"JMP", nil},
+		0x45: {"INP", []string{"2"}},
+		0x46: {"CAL", nil},
+		0x47: {"INP", []string{"3"}},
+		0x48: {"JFZ", nil},
+		0x49: {"INP", []string{"4"}},
+		0x4A: {"CFZ", nil},
+		0x4B: {"INP", []string{"5"}},
+		0x4C: {"JMP", nil},
+		0x4D: {"INP", []string{"6"}},
+		0x4E: {"CAL", nil},
+		0x4F: {"INP", []string{"7"}},
+		0x50: {"JFS", nil},
+		0x51: {"OUT", []string{"8"}},
+		0x52: {"CFS", nil},
+		0x53: {"OUT", []string{"9"}},
+		0x54: {"JMP", nil},
+		0x55: {"OUT", []string{"10"}},
+		0x56: {"CAL", nil},
+		0x57: {"OUT", []string{"11"}},
+		0x58: {"JFP", nil},
+		0x59: {"OUT", []string{"12"}},
+		0x5A: {"CFP", nil},
+		0x5B: {"OUT", []string{"13"}},
+		0x5C: {"JMP", nil},
+		0x5D: {"OUT", []string{"14"}},
+		0x5E: {"CAL", nil},
+		0x5F: {"OUT", []string{"15"}},
+		0x60: {"JTC", nil},
+		0x61: {"OUT", []string{"16"}},
+		0x62: {"CTC", nil},
+		0x63: {"OUT", []string{"17"}},
+		0x64: {"JMP", nil},
+		0x65: {"OUT", []string{"18"}},
+		0x66: {"CAL", nil},
+		0x67: {"OUT", []string{"19"}},
+		0x68: {"JTZ", nil},
+		0x69: {"OUT", []string{"20"}},
+		0x6A: {"CTZ", nil},
+		0x6B: {"OUT", []string{"21"}},
+		0x6C: {"JMP", nil},
+		0x6D: {"OUT", []string{"22"}},
+		0x6E: {"CAL", nil},
+		0x6F: {"OUT", []string{"23"}},
+		0x70: {"JTS", nil},
+		0x71: {"OUT", []string{"24"}},
+		0x72: {"CTS", nil},
+		0x73: {"OUT", []string{"25"}},
+		0x74: {"JMP", nil},
+		0x75: {"OUT", []string{"26"}},
+		0x76: {"CAL", nil},
+		0x77: {"OUT", []string{"27"}},
+		0x78: {"JTP", nil},
+		0x79: {"OUT", []string{"28"}},
+		0x7A: {"CTP", nil},
+		0x7B: {"OUT", []string{"29"}},
+		0x7C: {"JMP", nil},
+		0x7D: {"OUT", []string{"30"}},
+		0x7E: {"CAL", nil},
+		0x7F: {"OUT", []string{"31"}},
+		0x80: {"ADA", nil},
+		0x81: {"ADB", nil},
+		0x82: {"ADC", nil},
+		0x83: {"ADD", nil},
+		0x84: {"ADE", nil},
+		0x85: {"ADH", nil},
+		0x86: {"ADL", nil},
+		0x87: {"ADM", nil},
+		0x88: {"ACA", nil},
+		0x89: {"ACB", nil},
+		0x8A: {"ACC", nil},
+		0x8B: {"ACD", nil},
+		0x8C: {"ACE", nil},
+		0x8D: {"ACH", nil},
+		0x8E: {"ACL", nil},
+		0x8F: {"ACM", nil},
+		0x90: {"SUA", nil},
+		0x91: {"SUB", nil},
+		0x92: {"SUC", nil},
+		0x93: {"SUD", nil},
+		0x94: {"SUE", nil},
+		0x95: {"SUH", nil},
+		0x96: {"SUL", nil},
+		0x97: {"SUM", nil},
+		0x98: {"SBA", nil},
+		0x99: {"SBB", nil},
+		0x9A: {"SBC", nil},
+		0x9B: {"SBD", nil},
+		0x9C: {"SBE", nil},
+		0x9D: {"SBH", nil},
+		0x9E: {"SBL", nil},
+		0x9F: {"SBM", nil},
+		0xA0: {"NDA", nil},
+		0xA1: {"NDB", nil},
+		0xA2: {"NDC", nil},
+		0xA3: {"NDD", nil},
+		0xA4: {"NDE", nil},
+		0xA5: {"NDH", nil},
+		0xA6: {"NDL", nil},
+		0xA7: {"NDM", nil},
+		0xA8: {"XRA", nil},
+		0xA9: {"XRB", nil},
+		0xAA: {"XRC", nil},
+		0xAB: {"XRD", nil},
+		0xAC: {"XRE", nil},
+		0xAD: {"XRH", nil},
+		0xAE: {"XRL", nil},
+		0xAF: {"XRM", nil},
+		0xB0: {"ORA", nil},
+		0xB1: {"ORB", nil},
+		0xB2: {"ORC", nil},
+		0xB3: {"ORD", nil},
+		0xB4: {"ORE", nil},
+		0xB5: {"ORH", nil},
+		0xB6: {"ORL", nil},
+		0xB7: {"ORM", nil},
+		0xB8: {"CPA", nil},
+		0xB9: {"CPB", nil},
+		0xBA: {"CPC", nil},
+		0xBB: {"CPD", nil},
+		0xBC: {"CPE", nil},
+		0xBD: {"CPH", nil},
+		0xBE: {"CPL", nil},
+		0xBF: {"CPM", nil},
+		0xC0: {"NOP", nil},
+		0xC1: {"LAB", nil},
+		0xC2: {"LAC", nil},
+		0xC3: {"LAD", nil},
+		0xC4: {"LAE", nil},
+		0xC5: {"LAH", nil},
+		0xC6: {"LAL", nil},
+		0xC7: {"LAM", nil},
+		0xC8: {"LBA", nil},
+		0xC9: {"NOP", nil},
+		0xCA: {"LBC", nil},
+		0xCB: {"LBD", nil},
+		0xCC: {"LBE", nil},
+		0xCD: {"LBH", nil},
+		0xCE: {"LBL", nil},
+		0xCF: {"LBM", nil},
+		0xD0: {"LCA", nil},
+		0xD1: {"LCB", nil},
+		0xD2: {"NOP", nil},
+		0xD3: {"LCD", nil},
+		0xD4: {"LCE", nil},
+		0xD5: {"LCH", nil},
+		0xD6: {"LCL", nil},
+		0xD7: {"LCM", nil},
+		0xD8: {"LDA", nil},
+		0xD9: {"LDB", nil},
+		0xDA: {"LDC", nil},
+		0xDB: {"NOP", nil},
+		0xDC: {"LDE", nil},
+		0xDD: {"LDH", nil},
+		0xDE: {"LDL", nil},
+		0xDF: {"LDM", nil},
+		0xE0: {"LEA", nil},
+		0xE1: {"LEB", nil},
+		0xE2: {"LEC", nil},
+		0xE3: {"LED", nil},
+		0xE4: {"NOP", nil},
+		0xE5: {"LEH", nil},
+		0xE6: {"LEL", nil},
+		0xE7: {"LEM", nil},
+		0xE8: {"LHA", nil},
+		0xE9: {"LHB", nil},
+		0xEA: {"LHC", nil},
+		0xEB: {"LHD", nil},
+		0xEC: {"LHE", nil},
+		0xED: {"NOP", nil},
+		0xEE: {"LHL", nil},
+		0xEF: {"LHM", nil},
+		0xF0: {"LLA", nil},
+		0xF1: {"LLB", nil},
+		0xF2: {"LLC", nil},
+		0xF3: {"LLD", nil},
+		0xF4: {"LLE", nil},
+		0xF5: {"LLH", nil},
+		0xF6: {"NOP", nil},
+		0xF7: {"LLM", nil},
+		0xF8: {"LMA", nil},
+		0xF9: {"LMB", nil},
+		0xFA: {"LMC", nil},
+		0xFB: {"LMD", nil},
+		0xFC: {"LME", nil},
+		0xFD: {"LMH", nil},
+		0xFE: {"LML", nil},
+		0xFF: {"HLT", nil},
+	},
+	"8080": {
+		0x00: {"HLT", nil},
+		0x01: {"HLT", nil},
+		0x02: {"RLC", nil},
+		0x03: {"RNC", nil},
+		0x04: {"ADI", nil},
+		0x05: {"RST", []string{"0"}},
+		0x06: {"MVI", []string{"A"}},
+		0x07: {"RET", nil},
+		0x08: {"INR", []string{"B"}},
+		0x09: {"DCR", []string{"B"}},
+		0x0A: {"RRC", nil},
+		0x0B: {"RNZ", nil},
+		0x0C: {"ACI", nil},
+		0x0D: {"RST", []string{"1"}},
+		0x0E: {"MVI", []string{"B"}},
+		0x0F: {"RET", nil},
+		0x10: {"INR", []string{"C"}},
+		0x11: {"DCR", []string{"C"}},
+		0x12: {"RAL", nil},
+		0x13: {"RP", nil},
+		0x14: {"SUI", nil},
+		0x15: {"RST", []string{"2"}},
+		0x16: {"MVI", []string{"C"}},
+		0x17: {"RET", nil},
+		0x18: {"INR", []string{"D"}},
+		0x19: {"DCR", []string{"D"}},
+		0x1A: {"RAR", nil},
+		0x1B: {"RPO", nil},
+		0x1C: {"SBI", nil},
+		0x1D: {"RST", []string{"3"}},
+		0x1E: {"MVI", []string{"D"}},
+		0x1F: {"RET", nil},
+		0x20: {"INR", []string{"E"}},
+		0x21: {"DCR", []string{"E"}},
+		0x23: {"RC", nil},
+		0x24: {"ANI", nil},
+		0x25: {"RST", []string{"4"}},
+		0x26: {"MVI", []string{"E"}},
+		0x27: {"RET", nil},
+		0x28: {"INR", []string{"H"}},
+		0x29: {"DCR", []string{"H"}},
+		0x2B: {"RZ", nil},
+		0x2C: {"XRI", nil},
+		0x2D: {"RST", []string{"5"}},
+		0x2E: {"MVI", []string{"H"}},
+		0x2F: {"RET", nil},
+		0x30: {"INR", []string{"L"}},
+		0x31: {"DCR", []string{"L"}},
+		0x33: {"RM", nil},
+		0x34: {"ORI", nil},
+		0x35: {"RST", []string{"6"}},
+		0x36: {"MVI", []string{"L"}},
+		0x37: {"RET", nil},
+		0x3B: {"RPE", nil},
+		0x3C: {"CPI", nil},
+		0x3D: {"RST", []string{"7"}},
+		0x3E: {"MVI", []string{"M"}},
+		0x3F: {"RET", nil},
+		0x40: {"JNC", nil},
+		0x41: {"IN", []string{"0"}},
+		0x42: {"CNC", nil},
+		0x43: {"IN", []string{"1"}},
+		0x44: {"JMP", nil},
+		0x45: {"IN", []string{"2"}},
+		0x46: {"CALL", nil},
+		0x47: {"IN", []string{"3"}},
+		0x48: {"JNZ", nil},
+		0x49: {"IN", []string{"4"}},
+		0x4A: {"CNZ", nil},
+		0x4B: {"IN", []string{"5"}},
+		0x4C: {"JMP", nil},
+		0x4D: {"IN", []string{"6"}},
+		0x4E: {"CALL", nil},
+		0x4F: {"IN", []string{"7"}},
+		0x50: {"JP", nil},
+		0x51: {"OUT", []string{"8"}},
+		0x52: {"CP", nil},
+		0x53: {"OUT", []string{"9"}},
+		0x54: {"JMP", nil},
+		0x55: {"OUT", []string{"10"}},
+		0x56: {"CALL", nil},
+		0x57: {"OUT", []string{"11"}},
+		0x58: {"JPO", nil},
+		0x59: {"OUT", []string{"12"}},
+		0x5A: {"CPO", nil},
+		0x5B: {"OUT", []string{"13"}},
+		0x5C: {"JMP", nil},
+		0x5D: {"OUT", []string{"14"}},
+		0x5E: {"CALL", nil},
+		0x5F: {"OUT", []string{"15"}},
+		0x60: {"JC", nil},
+		0x61: {"OUT", []string{"16"}},
+		0x62: {"CC", nil},
+		0x63: {"OUT", []string{"17"}},
+		0x64: {"JMP", nil},
+		0x65: {"OUT", []string{"18"}},
+		0x66: {"CALL", nil},
+		0x67: {"OUT", []string{"19"}},
+		0x68: {"JZ", nil},
+		0x69: {"OUT", []string{"20"}},
+		0x6A: {"CZ", nil},
+		0x6B: {"OUT", []string{"21"}},
+		0x6C: {"JMP", nil},
+		0x6D: {"OUT", []string{"22"}},
+		0x6E: {"CALL", nil},
+		0x6F: {"OUT", []string{"23"}},
+		0x70: {"JM", nil},
+		0x71: {"OUT", []string{"24"}},
+		0x72: {"CM", nil},
+		0x73: {"OUT", []string{"25"}},
+		0x74: {"JMP", nil},
+		0x75: {"OUT", []string{"26"}},
+		0x76: {"CALL", nil},
+		0x77: {"OUT", []string{"27"}},
+		0x78: {"JPE", nil},
+		0x79: {"OUT", []string{"28"}},
+		0x7A: {"CPE", nil},
+		0x7B: {"OUT", []string{"29"}},
+		0x7C: {"JMP", nil},
+		0x7D: {"OUT", []string{"30"}},
+		0x7E: {"CALL", nil},
+		0x7F: {"OUT", []string{"31"}},
+		0x80: {"ADD", []string{"A"}},
+		0x81: {"ADD", []string{"B"}},
+		0x82: {"ADD", []string{"C"}},
+		0x83: {"ADD", []string{"D"}},
+		0x84: {"ADD", []string{"E"}},
+		0x85: {"ADD", []string{"H"}},
+		0x86: {"ADD", []string{"L"}},
+		0x87: {"ADD", []string{"M"}},
+		0x88: {"ADC", []string{"A"}},
+		0x89: {"ADC", []string{"B"}},
+		0x8A: {"ADC", []string{"C"}},
+		0x8B: {"ADC", []string{"D"}},
+		0x8C: {"ADC", []string{"E"}},
+		0x8D: {"ADC", []string{"H"}},
+		0x8E: {"ADC", []string{"L"}},
+		0x8F: {"ADC", []string{"M"}},
+		0x90: {"SUB", []string{"A"}},
+		0x91: {"SUB", []string{"B"}},
+		0x92: {"SUB", []string{"C"}},
+		0x93: {"SUB", []string{"D"}},
+		0x94: {"SUB", []string{"E"}},
+		0x95: {"SUB", []string{"H"}},
+		0x96: {"SUB", []string{"L"}},
+		0x97: {"SUB", []string{"M"}},
+		0x98: {"SBB", []string{"A"}},
+		0x99: {"SBB", []string{"B"}},
+		0x9A: {"SBB", []string{"C"}},
+		0x9B: {"SBB", []string{"D"}},
+		0x9C: {"SBB", []string{"E"}},
+		0x9D: {"SBB", []string{"H"}},
+		0x9E: {"SBB", []string{"L"}},
+		0x9F: {"SBB", []string{"M"}},
+		0xA0: {"ANA", []string{"A"}},
+		0xA1: {"ANA", []string{"B"}},
+		0xA2: {"ANA", []string{"C"}},
+		0xA3: {"ANA", []string{"D"}},
+		0xA4: {"ANA", []string{"E"}},
+		0xA5: {"ANA", []string{"H"}},
+		0xA6: {"ANA", []string{"L"}},
+		0xA7: {"ANA", []string{"M"}},
+		0xA8: {"XRA", []string{"A"}},
+		0xA9: {"XRA", []string{"B"}},
+		0xAA: {"XRA", []string{"C"}},
+		0xAB: {"XRA", []string{"D"}},
+		0xAC: {"XRA", []string{"E"}},
+		0xAD: {"XRA", []string{"H"}},
+		0xAE: {"XRA", []string{"L"}},
+		0xAF: {"XRA", []string{"M"}},
+		0xB0: {"ORA", []string{"A"}},
+		0xB1: {"ORA", []string{"B"}},
+		0xB2: {"ORA", []string{"C"}},
+		0xB3: {"ORA", []string{"D"}},
+		0xB4: {"ORA", []string{"E"}},
+		0xB5: {"ORA", []string{"H"}},
+		0xB6: {"ORA", []string{"L"}},
+		0xB7: {"ORA", []string{"M"}},
+		0xB8: {"CMP", []string{"A"}},
+		0xB9: {"CMP", []string{"B"}},
+		0xBA: {"CMP", []string{"C"}},
+		0xBB: {"CMP", []string{"D"}},
+		0xBC: {"CMP", []string{"E"}},
+		0xBD: {"CMP", []string{"H"}},
+		0xBE: {"CMP", []string{"L"}},
+		0xBF: {"CMP", []string{"M"}},
+		0xC0: {"NOP", nil},
+		0xC1: {"MOV", []string{"A", "B"}},
+		0xC2: {"MOV", []string{"A", "C"}},
+		0xC3: {"MOV", []string{"A", "D"}},
+		0xC4: {"MOV", []string{"A", "E"}},
+		0xC5: {"MOV", []string{"A", "H"}},
+		0xC6: {"MOV", []string{"A", "L"}},
+		0xC7: {"MOV", []string{"A", "M"}},
+		0xC8: {"MOV", []string{"B", "A"}},
+		0xC9: {"NOP", nil},
+		0xCA: {"MOV", []string{"B", "C"}},
+		0xCB: {"MOV", []string{"B", "D"}},
+		0xCC: {"MOV", []string{"B", "E"}},
+		0xCD: {"MOV", []string{"B", "H"}},
+		0xCE: {"MOV", []string{"B", "L"}},
+		0xCF: {"MOV", []string{"B", "M"}},
+		0xD0: {"MOV", []string{"C", "A"}},
+		0xD1: {"MOV", []string{"C", "B"}},
+		0xD2: {"NOP", nil},
+		0xD3: {"MOV", []string{"C", "D"}},
+		0xD4: {"MOV", []string{"C", "E"}},
+		0xD5: {"MOV", []string{"C", "H"}},
+		0xD6: {"MOV", []string{"C", "L"}},
+		0xD7: {"MOV", []string{"C", "M"}},
+		0xD8: {"MOV", []string{"D", "A"}},
+		0xD9: {"MOV", []string{"D", "B"}},
+		0xDA: {"MOV", []string{"D", "C"}},
+		0xDB: {"NOP", nil},
+		0xDC: {"MOV", []string{"D", "E"}},
+		0xDD: {"MOV", []string{"D", "H"}},
+		0xDE: {"MOV", []string{"D", "L"}},
+		0xDF: {"MOV", []string{"D", "M"}},
+		0xE0: {"MOV", []string{"E", "A"}},
+		0xE1: {"MOV", []string{"E", "B"}},
+		0xE2: {"MOV", []string{"E", "C"}},
+		0xE3: {"MOV", []string{"E", "D"}},
+		0xE4: {"NOP", nil},
+		0xE5: {"MOV", []string{"E", "H"}},
+		0xE6: {"MOV", []string{"E", "L"}},
+		0xE7: {"MOV", []string{"E", "M"}},
+		0xE8: {"MOV", []string{"H", "A"}},
+		0xE9: {"MOV", []string{"H", "B"}},
+		0xEA: {"MOV", []string{"H", "C"}},
+		0xEB: {"MOV", []string{"H", "D"}},
+		0xEC: {"MOV", []string{"H", "E"}},
+		0xED: {"NOP", nil},
+		0xEE: {"MOV", []string{"H", "L"}},
+		0xEF: {"MOV", []string{"H", "M"}},
+		0xF0: {"MOV", []string{"L", "A"}},
+		0xF1: {"MOV", []string{"L", "B"}},
+		0xF2: {"MOV", []string{"L", "C"}},
+		0xF3: {"MOV", []string{"L", "D"}},
+		0xF4: {"MOV", []string{"L", "E"}},
+		0xF5: {"MOV", []string{"L", "H"}},
+		0xF6: {"NOP", nil},
+		0xF7: {"MOV", []string{"L", "M"}},
+		0xF8: {"MOV", []string{"M", "A"}},
+		0xF9: {"MOV", []string{"M", "B"}},
+		0xFA: {"MOV", []string{"M", "C"}},
+		0xFB: {"MOV", []string{"M", "D"}},
+		0xFC: {"MOV", []string{"M", "E"}},
+		0xFD: {"MOV", []string{"M", "H"}},
+		0xFE: {"MOV", []string{"M", "L"}},
+		0xFF: {"HLT", nil},
+	},
+}
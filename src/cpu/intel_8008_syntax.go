@@ -0,0 +1,22 @@
+package cpu
+
+//go:generate go run ../isagen -go intel_8008_instructions.go -doc ../../docs/intel_8008.md intel_8008.isa
+
+// Intel8008Syntax is the original Intel 8008 dialect (LAB, LMI, JFC, ...) with #$hex immediates
+var Intel8008Syntax = &Syntax{
+	Name:            "8008",
+	Description:     "original Intel 8008 mnemonics (LAB, LMI, JFC)",
+	Forms:           intel8008Forms["8008"],
+	ImmediatePrefix: "#",
+}
+
+// Intel8080StyleSyntax is the later dialect that writes 8008 code with 8080 mnemonics (MOV A,B, MVI M, JNC, ...)
+var Intel8080StyleSyntax = &Syntax{
+	Name:        "8080",
+	Description: "8080-style mnemonics (MOV A,B, MVI M, JNC)",
+	Forms:       intel8008Forms["8080"],
+	HexSuffix:   true,
+}
+
+// Intel8008Syntaxes lists the dialects the 8008 can be written in
+var Intel8008Syntaxes = []*Syntax{Intel8008Syntax, Intel8080StyleSyntax}
@@ -0,0 +1,543 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Intel8080 represents the 8080 processor
+type Intel8080 struct {
+	CPU
+	A      uint8  // Accumulator, A register, 7
+	B      uint8  // B register, 0
+	C      uint8  // C register, 1
+	D      uint8  // D register, 2
+	E      uint8  // E register, 3
+	H      uint8  // High-order byte of HL, H register, 4
+	L      uint8  // Low-order byte of HL, L register, 5
+	SP     uint16 // Stack pointer; the stack can be anywhere in memory
+	Status struct {
+		Sign     bool // Sign Flag (S)
+		Zero     bool // Zero Flag (Z)
+		AuxCarry bool // Auxiliary Carry Flag (AC), the carry out of bit 3
+		Parity   bool // Parity Flag (P)
+		Carry    bool // Carry Flag (C)
+	}
+	InterruptsEnabled bool // Interrupt enable flip-flop, set by EI and cleared by DI
+}
+
+// NewIntel8080 creates a new 8080 CPU instance
+func NewIntel8080(memorySize int, speed uint) *Intel8080 {
+	c := &Intel8080{
+		CPU: *NewCPU("Intel8080", memorySize, speed, Intel8080Instructions),
+	}
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// GetSP returns the stack pointer
+func (c *Intel8080) GetSP() uint16 {
+	return c.SP
+}
+
+// SetSP sets the stack pointer
+func (c *Intel8080) SetSP(value uint16) {
+	c.SP = value
+}
+
+// GetPSW returns the program status word: the accumulator in the high byte
+// and the flags in the low byte, as PUSH PSW stores them
+func (c *Intel8080) GetPSW() uint16 {
+	return uint16(c.A)<<8 | uint16(c.flagsByte())
+}
+
+// SetPSW sets the accumulator and flags from a program status word
+func (c *Intel8080) SetPSW(value uint16) {
+	c.A = byte(value >> 8)
+	c.setFlagsByte(byte(value))
+}
+
+// Push pushes a byte onto the stack
+func (c *Intel8080) Push(value byte) {
+	c.SP--
+	c.store(c.SP, value)
+}
+
+// Pull pulls a byte from the stack
+func (c *Intel8080) Pull() byte {
+	value := c.load(c.SP)
+	c.SP++
+	return value
+}
+
+// Push16 pushes a 16-bit value onto the stack, high byte first
+func (c *Intel8080) Push16(value uint16) {
+	c.Push(byte(value >> 8))
+	c.Push(byte(value))
+}
+
+// Pull16 pulls a 16-bit value from the stack
+func (c *Intel8080) Pull16() uint16 {
+	low := uint16(c.Pull())
+	return uint16(c.Pull())<<8 | low
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *Intel8080) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction executes a single instruction. It returns ErrHalted
+// after HLT and a FaultError for instructions it cannot execute. Every
+// opcode is defined on the 8080, so there are no unknown opcodes.
+func (c *Intel8080) ExecuteInstruction() error {
+	return c.step(c.execute)
+}
+
+// step fetches the instruction at the PC and its operand and runs it with
+// execute, which the 8085 uses to add its own instructions to the 8080's
+func (c *Intel8080) step(execute func(opcode byte, data byte, word uint16) error) (err error) {
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.PC = pc
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
+	// Get the opcode
+	opcode := c.Memory[c.PC]
+
+	// Get the instruction
+	instruction, ok := c.Instructions[opcode]
+	if !ok {
+		return &UnknownOpcodeError{Opcode: opcode, PC: c.PC}
+	}
+
+	// Only print verbose output if enabled
+	if c.IsVerbose() {
+		fmt.Printf("PC: %04X, OP: %02X, MN: %s, A:%02X B:%02X C:%02X D:%02X E:%02X H:%02X L:%02X SP:%04X | Flags(SZAPC): %d%d%d%d%d\n",
+			c.PC, opcode, instruction.Mnemonic, c.A, c.B, c.C, c.D, c.E, c.H, c.L, c.SP,
+			boolToInt(c.Status.Sign), boolToInt(c.Status.Zero), boolToInt(c.Status.AuxCarry),
+			boolToInt(c.Status.Parity), boolToInt(c.Status.Carry))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
+	}
+
+	// Fetch the operand; the PC points past the instruction while it executes
+	var data byte
+	var word uint16
+	switch instruction.Size {
+	case 2:
+		data = c.Memory[c.PC+1]
+	case 3:
+		word = uint16(c.Memory[c.PC+1]) | uint16(c.Memory[c.PC+2])<<8
+	}
+	c.PC += uint16(instruction.Size)
+	err = execute(opcode, data, word)
+
+	// Wait for the appropriate amount of time
+	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// registerFile lists the registers and flags behind the register API
+func (c *Intel8080) registerFile() ([]register, []flag) {
+	registers := []register{
+		byteRegister("A", &c.A), byteRegister("B", &c.B), byteRegister("C", &c.C), byteRegister("D", &c.D),
+		byteRegister("E", &c.E), byteRegister("H", &c.H), byteRegister("L", &c.L), wordRegister("SP", &c.SP),
+	}
+	flags := []flag{
+		boolFlag("S", "Sign", &c.Status.Sign), boolFlag("Z", "Zero", &c.Status.Zero),
+		boolFlag("AC", "Auxiliary carry", &c.Status.AuxCarry), boolFlag("P", "Parity", &c.Status.Parity),
+		boolFlag("C", "Carry", &c.Status.Carry), boolFlag("IE", "Interrupts enabled", &c.InterruptsEnabled),
+	}
+	return registers, flags
+}
+
+// execute executes an instruction whose operand, if any, is data or word.
+// Registers and register pairs are decoded from the opcode bits: DDD and SSS
+// select B, C, D, E, H, L, M or A, and RP selects BC, DE, HL or SP.
+func (c *Intel8080) execute(opcode byte, data byte, word uint16) error {
+	switch opcode & 0xC0 {
+	case 0x40:
+		// MOV D,S (01DDDSSS); MOV M,M is HLT
+		if opcode == 0x76 {
+			return ErrHalted
+		}
+		c.setRegister(opcode>>3&7, c.register(opcode&7))
+		return nil
+	case 0x80:
+		// Register and memory ALU operations (10AAASSS)
+		c.alu(opcode>>3&7, c.register(opcode&7))
+		return nil
+	}
+
+	switch opcode {
+	case 0x00, 0x08, 0x10, 0x18, 0x20, 0x28, 0x30, 0x38: // NOP
+	case 0x01, 0x11, 0x21, 0x31: // LXI
+		c.setPair(opcode>>4&3, word)
+	case 0x02, 0x12: // STAX
+		c.store(c.pair(opcode>>4&1), c.A)
+	case 0x0A, 0x1A: // LDAX
+		c.A = c.load(c.pair(opcode >> 4 & 1))
+	case 0x03, 0x13, 0x23, 0x33: // INX
+		c.setPair(opcode>>4&3, c.pair(opcode>>4&3)+1)
+	case 0x0B, 0x1B, 0x2B, 0x3B: // DCX
+		c.setPair(opcode>>4&3, c.pair(opcode>>4&3)-1)
+	case 0x09, 0x19, 0x29, 0x39: // DAD
+		result := uint32(c.hl()) + uint32(c.pair(opcode>>4&3))
+		c.Status.Carry = result > 0xFFFF
+		c.setPair(2, uint16(result))
+	case 0x04, 0x0C, 0x14, 0x1C, 0x24, 0x2C, 0x34, 0x3C: // INR
+		r := opcode >> 3 & 7
+		result := c.register(r) + 1
+		c.Status.AuxCarry = result&0x0F == 0
+		c.updateFlags(result)
+		c.setRegister(r, result)
+	case 0x05, 0x0D, 0x15, 0x1D, 0x25, 0x2D, 0x35, 0x3D: // DCR
+		r := opcode >> 3 & 7
+		result := c.register(r) - 1
+		c.Status.AuxCarry = result&0x0F != 0x0F
+		c.updateFlags(result)
+		c.setRegister(r, result)
+	case 0x06, 0x0E, 0x16, 0x1E, 0x26, 0x2E, 0x36, 0x3E: // MVI
+		c.setRegister(opcode>>3&7, data)
+	case 0x22: // SHLD
+		c.store(word, c.L)
+		c.store(word+1, c.H)
+	case 0x2A: // LHLD
+		c.L = c.load(word)
+		c.H = c.load(word + 1)
+	case 0x32: // STA
+		c.store(word, c.A)
+	case 0x3A: // LDA
+		c.A = c.load(word)
+
+	// Accumulator and carry operations
+	case 0x07: // RLC
+		c.Status.Carry = c.A&0x80 != 0
+		c.A = c.A<<1 | c.A>>7
+	case 0x0F: // RRC
+		c.Status.Carry = c.A&0x01 != 0
+		c.A = c.A>>1 | c.A<<7
+	case 0x17: // RAL
+		carry := c.Status.Carry
+		c.Status.Carry = c.A&0x80 != 0
+		c.A <<= 1
+		if carry {
+			c.A |= 0x01
+		}
+	case 0x1F: // RAR
+		carry := c.Status.Carry
+		c.Status.Carry = c.A&0x01 != 0
+		c.A >>= 1
+		if carry {
+			c.A |= 0x80
+		}
+	case 0x27: // DAA
+		c.decimalAdjust()
+	case 0x2F: // CMA
+		c.A = ^c.A
+	case 0x37: // STC
+		c.Status.Carry = true
+	case 0x3F: // CMC
+		c.Status.Carry = !c.Status.Carry
+
+	// Immediate ALU operations (11AAA110)
+	case 0xC6, 0xCE, 0xD6, 0xDE, 0xE6, 0xEE, 0xF6, 0xFE:
+		c.alu(opcode>>3&7, data)
+
+	// Jumps, calls and returns
+	case 0xC3, 0xCB: // JMP
+		c.PC = word
+	case 0xC2, 0xCA, 0xD2, 0xDA, 0xE2, 0xEA, 0xF2, 0xFA: // Jcc
+		if c.condition(opcode >> 3 & 7) {
+			c.PC = word
+		}
+	case 0xCD, 0xDD, 0xED, 0xFD: // CALL
+		c.Push16(c.PC)
+		c.PC = word
+	case 0xC4, 0xCC, 0xD4, 0xDC, 0xE4, 0xEC, 0xF4, 0xFC: // Ccc
+		if c.condition(opcode >> 3 & 7) {
+			c.Push16(c.PC)
+			c.PC = word
+			c.AddCycles(6)
+		}
+	case 0xC9, 0xD9: // RET
+		c.PC = c.Pull16()
+	case 0xC0, 0xC8, 0xD0, 0xD8, 0xE0, 0xE8, 0xF0, 0xF8: // Rcc
+		if c.condition(opcode >> 3 & 7) {
+			c.PC = c.Pull16()
+			c.AddCycles(6)
+		}
+	case 0xC7, 0xCF, 0xD7, 0xDF, 0xE7, 0xEF, 0xF7, 0xFF: // RST
+		c.Push16(c.PC)
+		c.PC = uint16(opcode & 0x38)
+	case 0xE9: // PCHL
+		c.PC = c.hl()
+
+	// Stack operations
+	case 0xC5, 0xD5, 0xE5: // PUSH B, D, H
+		c.Push16(c.pair(opcode >> 4 & 3))
+	case 0xF5: // PUSH PSW
+		c.Push16(c.GetPSW())
+	case 0xC1, 0xD1, 0xE1: // POP B, D, H
+		c.setPair(opcode>>4&3, c.Pull16())
+	case 0xF1: // POP PSW
+		c.SetPSW(c.Pull16())
+	case 0xE3: // XTHL
+		l, h := c.load(c.SP), c.load(c.SP+1)
+		c.store(c.SP, c.L)
+		c.store(c.SP+1, c.H)
+		c.L, c.H = l, h
+	case 0xF9: // SPHL
+		c.SP = c.hl()
+	case 0xEB: // XCHG
+		c.D, c.E, c.H, c.L = c.H, c.L, c.D, c.E
+
+	// Input/output and machine control
+	case 0xDB: // IN
+		c.A = c.In(data)
+	case 0xD3: // OUT
+		c.Out(data, c.A)
+	case 0xFB: // EI
+		c.InterruptsEnabled = true
+	case 0xF3: // DI
+		c.InterruptsEnabled = false
+
+	default:
+		return fmt.Errorf("instruction not implemented: $%02X", opcode)
+	}
+	return nil
+}
+
+// register reads a register by its 3-bit code; 6 is the memory byte at HL
+func (c *Intel8080) register(r byte) byte {
+	switch r {
+	case 0:
+		return c.B
+	case 1:
+		return c.C
+	case 2:
+		return c.D
+	case 3:
+		return c.E
+	case 4:
+		return c.H
+	case 5:
+		return c.L
+	case 6:
+		return c.load(c.hl())
+	}
+	return c.A
+}
+
+// setRegister writes a register by its 3-bit code; 6 is the memory byte at HL
+func (c *Intel8080) setRegister(r byte, value byte) {
+	switch r {
+	case 0:
+		c.B = value
+	case 1:
+		c.C = value
+	case 2:
+		c.D = value
+	case 3:
+		c.E = value
+	case 4:
+		c.H = value
+	case 5:
+		c.L = value
+	case 6:
+		c.store(c.hl(), value)
+	default:
+		c.A = value
+	}
+}
+
+// pair reads a register pair by its 2-bit code: BC, DE, HL or SP
+func (c *Intel8080) pair(rp byte) uint16 {
+	switch rp {
+	case 0:
+		return uint16(c.B)<<8 | uint16(c.C)
+	case 1:
+		return uint16(c.D)<<8 | uint16(c.E)
+	case 2:
+		return c.hl()
+	}
+	return c.SP
+}
+
+// setPair writes a register pair by its 2-bit code: BC, DE, HL or SP
+func (c *Intel8080) setPair(rp byte, value uint16) {
+	switch rp {
+	case 0:
+		c.B, c.C = byte(value>>8), byte(value)
+	case 1:
+		c.D, c.E = byte(value>>8), byte(value)
+	case 2:
+		c.H, c.L = byte(value>>8), byte(value)
+	default:
+		c.SP = value
+	}
+}
+
+// hl returns the HL register pair, the address of memory register M
+func (c *Intel8080) hl() uint16 {
+	return uint16(c.H)<<8 | uint16(c.L)
+}
+
+// condition tests a condition by its 3-bit code: NZ, Z, NC, C, PO, PE, P or M
+func (c *Intel8080) condition(cc byte) bool {
+	switch cc {
+	case 0:
+		return !c.Status.Zero
+	case 1:
+		return c.Status.Zero
+	case 2:
+		return !c.Status.Carry
+	case 3:
+		return c.Status.Carry
+	case 4:
+		return !c.Status.Parity
+	case 5:
+		return c.Status.Parity
+	case 6:
+		return !c.Status.Sign
+	}
+	return c.Status.Sign
+}
+
+// alu performs an ALU operation by its 3-bit code on the accumulator:
+// ADD, ADC, SUB, SBB, ANA, XRA, ORA or CMP
+func (c *Intel8080) alu(operation byte, value byte) {
+	switch operation {
+	case 0: // ADD
+		c.A = c.add(value, 0)
+	case 1: // ADC
+		c.A = c.add(value, byte(boolToInt(c.Status.Carry)))
+	case 2: // SUB
+		c.A = c.subtract(value, 0)
+	case 3: // SBB
+		c.A = c.subtract(value, byte(boolToInt(c.Status.Carry)))
+	case 4: // ANA; AC is the OR of bit 3 of the operands
+		c.Status.AuxCarry = (c.A|value)&0x08 != 0
+		c.A &= value
+		c.Status.Carry = false
+		c.updateFlags(c.A)
+	case 5: // XRA
+		c.A ^= value
+		c.Status.AuxCarry, c.Status.Carry = false, false
+		c.updateFlags(c.A)
+	case 6: // ORA
+		c.A |= value
+		c.Status.AuxCarry, c.Status.Carry = false, false
+		c.updateFlags(c.A)
+	default: // CMP
+		c.subtract(value, 0)
+	}
+}
+
+// add returns A + value + carry and sets all flags
+func (c *Intel8080) add(value, carry byte) byte {
+	result := uint16(c.A) + uint16(value) + uint16(carry)
+	c.Status.AuxCarry = c.A&0x0F+value&0x0F+carry > 0x0F
+	c.Status.Carry = result > 0xFF
+	c.updateFlags(byte(result))
+	return byte(result)
+}
+
+// subtract returns A - value - borrow and sets all flags. The 8080 subtracts
+// by adding the complement, so AC is the carry out of bit 3 of that addition
+// and C is the inverted carry, the borrow.
+func (c *Intel8080) subtract(value, borrow byte) byte {
+	result := uint16(c.A) - uint16(value) - uint16(borrow)
+	c.Status.AuxCarry = c.A&0x0F+^value&0x0F+(1-borrow) > 0x0F
+	c.Status.Carry = result > 0xFF
+	c.updateFlags(byte(result))
+	return byte(result)
+}
+
+// decimalAdjust corrects the accumulator after adding two BCD numbers (DAA)
+func (c *Intel8080) decimalAdjust() {
+	var correction byte
+	carry := c.Status.Carry
+	low, high := c.A&0x0F, c.A>>4
+	if c.Status.AuxCarry || low > 9 {
+		correction |= 0x06
+	}
+	if c.Status.Carry || high > 9 || (high >= 9 && low > 9) {
+		correction |= 0x60
+		carry = true
+	}
+	c.A = c.add(correction, 0)
+	c.Status.Carry = carry
+}
+
+// updateFlags sets S, Z and P from a result
+func (c *Intel8080) updateFlags(value byte) {
+	c.Status.Sign = value&0x80 != 0
+	c.Status.Zero = value == 0
+	c.Status.Parity = parity(value)
+}
+
+// flagsByte packs the flags as PUSH PSW stores them: S Z 0 AC 0 P 1 C
+func (c *Intel8080) flagsByte() byte {
+	flags := byte(0x02)
+	if c.Status.Sign {
+		flags |= 0x80
+	}
+	if c.Status.Zero {
+		flags |= 0x40
+	}
+	if c.Status.AuxCarry {
+		flags |= 0x10
+	}
+	if c.Status.Parity {
+		flags |= 0x04
+	}
+	if c.Status.Carry {
+		flags |= 0x01
+	}
+	return flags
+}
+
+// setFlagsByte unpacks the flags from a PSW byte
+func (c *Intel8080) setFlagsByte(flags byte) {
+	c.Status.Sign = flags&0x80 != 0
+	c.Status.Zero = flags&0x40 != 0
+	c.Status.AuxCarry = flags&0x10 != 0
+	c.Status.Parity = flags&0x04 != 0
+	c.Status.Carry = flags&0x01 != 0
+}
+
+// parity reports whether a byte has an even number of set bits
+func parity(value byte) bool {
+	value ^= value >> 4
+	value ^= value >> 2
+	value ^= value >> 1
+	return value&1 == 0
+}
@@ -0,0 +1,14 @@
+package cpu
+
+//go:generate go run ../isagen -go intel_8080_instructions.go -doc ../../docs/intel_8080.md intel_8080.isa
+
+// Intel8080Syntax is the Intel 8080 assembly language (MOV A,B, LXI H, JNZ) with 0FFH numbers
+var Intel8080Syntax = &Syntax{
+	Name:        "8080",
+	Description: "Intel 8080 mnemonics (MOV A,B, LXI H, JNZ)",
+	Forms:       intel8080Forms["8080"],
+	HexSuffix:   true,
+}
+
+// Intel8080Syntaxes lists the dialects the 8080 can be written in
+var Intel8080Syntaxes = []*Syntax{Intel8080Syntax}
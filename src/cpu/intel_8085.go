@@ -0,0 +1,265 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Restart addresses of the 8085's interrupt inputs, highest priority first
+const (
+	Intel8085TrapAddress  = 0x0024 // TRAP, which cannot be masked
+	Intel8085RST75Address = 0x003C // RST 7.5
+	Intel8085RST65Address = 0x0034 // RST 6.5
+	Intel8085RST55Address = 0x002C // RST 5.5
+)
+
+// Interrupt mask bits set by SIM and read by RIM
+const (
+	Intel8085MaskRST55 = 0x01
+	Intel8085MaskRST65 = 0x02
+	Intel8085MaskRST75 = 0x04
+)
+
+// SerialLine is the other end of the 8085's serial data lines. Times are in
+// CPU cycles, so a line can clock bits at the rate the program expects.
+type SerialLine interface {
+	SID(cycle int) bool        // Level of SID when RIM reads it
+	SOD(cycle int, level bool) // SIM set SOD to a new level
+}
+
+// Intel8085 represents the 8085 processor: an 8080 with RIM and SIM, the
+// interrupt inputs TRAP, RST 7.5, RST 6.5 and RST 5.5, and the serial lines
+// SID and SOD. It decodes the 8080 instructions with the 8080's decoder; the
+// 8080's undocumented duplicate opcodes are not part of its instruction set.
+type Intel8085 struct {
+	Intel8080
+	Masks        uint8      // RST 5.5, 6.5 and 7.5 masks set by SIM (Intel8085Mask bits)
+	RST75Pending bool       // RST 7.5 saw a rising edge; cleared when served or by SIM
+	SOD          bool       // Serial output data latch, set by SIM
+	Serial       SerialLine // Drives SID and receives SOD; without one SID reads low
+
+	trap, rst75, rst65, rst55 bool // Levels of the interrupt inputs
+	trapPending               bool // TRAP saw a rising edge and has not been served
+	trapped                   bool // TRAP was served and RIM has not read the saved enable state
+	savedEnable               bool // Interrupt enable state when TRAP was served
+	enableDelay               bool // EI was the last instruction, so interrupts wait one more
+}
+
+// NewIntel8085 creates a new 8085 CPU instance
+func NewIntel8085(memorySize int, speed uint) *Intel8085 {
+	c := &Intel8085{
+		Intel8080: Intel8080{CPU: *NewCPU("Intel8085", memorySize, speed, Intel8085Instructions)},
+		Masks:     Intel8085MaskRST55 | Intel8085MaskRST65 | Intel8085MaskRST75, // Reset masks them all
+	}
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// registerFile adds the interrupt masks and SOD to the 8080's registers and flags
+func (c *Intel8085) registerFile() ([]register, []flag) {
+	registers, flags := c.Intel8080.registerFile()
+	masks := []struct {
+		name string
+		bit  uint8
+	}{{"M5.5", Intel8085MaskRST55}, {"M6.5", Intel8085MaskRST65}, {"M7.5", Intel8085MaskRST75}}
+	for _, mask := range masks {
+		bit := mask.bit
+		flags = append(flags, flag{
+			FlagDesc: FlagDesc{Name: mask.name, Description: "RST " + mask.name[1:] + " masked"},
+			get:      func() bool { return c.Masks&bit != 0 },
+			set: func(value bool) {
+				if value {
+					c.Masks |= bit
+				} else {
+					c.Masks &^= bit
+				}
+			},
+		})
+	}
+	flags = append(flags, boolFlag("SOD", "Serial output data", &c.SOD))
+	return registers, flags
+}
+
+// SetPin sets the level of an input pin: TRAP, RST7.5, RST6.5 or RST5.5.
+// RST 7.5 is taken on a rising edge, which stays pending until it is served;
+// TRAP on a rising edge while the pin is still high; RST 6.5 and RST 5.5 while
+// they are high. Interrupts are taken before the next instruction.
+func (c *Intel8085) SetPin(name string, high bool) error {
+	switch name {
+	case "TRAP":
+		if high && !c.trap {
+			c.trapPending = true
+		}
+		c.trap = high
+	case "RST7.5":
+		if high && !c.rst75 {
+			c.RST75Pending = true
+		}
+		c.rst75 = high
+	case "RST6.5":
+		c.rst65 = high
+	case "RST5.5":
+		c.rst55 = high
+	default:
+		return fmt.Errorf("the 8085 has no input pin %s (available: TRAP, RST7.5, RST6.5, RST5.5)", name)
+	}
+	return nil
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *Intel8085) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction serves a pending interrupt, if any, and executes a
+// single instruction. It returns ErrHalted after HLT and a FaultError for
+// instructions it cannot execute.
+func (c *Intel8085) ExecuteInstruction() error {
+	c.interrupt()
+	return c.step(func(opcode byte, data byte, word uint16) error {
+		c.enableDelay = false
+		return c.execute(opcode, data, word)
+	})
+}
+
+// execute executes the instructions the 8085 adds or changes and hands the
+// others to the 8080
+func (c *Intel8085) execute(opcode byte, data byte, word uint16) error {
+	switch opcode {
+	case 0x20: // RIM
+		c.A = c.readInterruptMasks()
+	case 0x30: // SIM
+		c.setInterruptMasks(c.A)
+	case 0xFB: // EI; interrupts are taken after the next instruction
+		c.InterruptsEnabled = true
+		c.enableDelay = true
+
+	// Conditional jumps are shorter when not taken; calls and returns take
+	// other cycle counts than on the 8080
+	case 0xC2, 0xCA, 0xD2, 0xDA, 0xE2, 0xEA, 0xF2, 0xFA: // Jcc
+		if c.condition(opcode >> 3 & 7) {
+			c.PC = word
+			c.AddCycles(3)
+		}
+	case 0xC4, 0xCC, 0xD4, 0xDC, 0xE4, 0xEC, 0xF4, 0xFC: // Ccc
+		if c.condition(opcode >> 3 & 7) {
+			c.Push16(c.PC)
+			c.PC = word
+			c.AddCycles(9)
+		}
+	case 0xC0, 0xC8, 0xD0, 0xD8, 0xE0, 0xE8, 0xF0, 0xF8: // Rcc
+		if c.condition(opcode >> 3 & 7) {
+			c.PC = c.Pull16()
+			c.AddCycles(6)
+		}
+
+	default:
+		err := c.Intel8080.execute(opcode, data, word)
+		if opcode&0xF8 == 0xA0 || opcode == 0xE6 {
+			// ANA and ANI always set AC on the 8085
+			c.Status.AuxCarry = true
+		}
+		return err
+	}
+	return nil
+}
+
+// readInterruptMasks returns the byte RIM loads: SID (bit 7), the pending
+// RST 7.5, 6.5 and 5.5 interrupts (bits 6-4), the interrupt enable (bit 3) and
+// the masks (bits 2-0). Right after TRAP it shows the enable state TRAP saved.
+func (c *Intel8085) readInterruptMasks() byte {
+	value := c.Masks
+	enabled := c.InterruptsEnabled
+	if c.trapped {
+		enabled = c.savedEnable
+		c.trapped = false
+	}
+	for _, bit := range []struct {
+		set  bool
+		mask byte
+	}{
+		{c.Serial != nil && c.Serial.SID(c.Cycles), 0x80}, {c.RST75Pending, 0x40},
+		{c.rst65, 0x20}, {c.rst55, 0x10}, {enabled, 0x08},
+	} {
+		if bit.set {
+			value |= bit.mask
+		}
+	}
+	return value
+}
+
+// setInterruptMasks carries out SIM: bit 3 enables setting the masks from
+// bits 2-0, bit 4 clears a pending RST 7.5 and bit 6 enables setting SOD from
+// bit 7
+func (c *Intel8085) setInterruptMasks(value byte) {
+	if value&0x08 != 0 {
+		c.Masks = value & 0x07
+	}
+	if value&0x10 != 0 {
+		c.RST75Pending = false
+	}
+	if value&0x40 != 0 {
+		sod := value&0x80 != 0
+		if sod != c.SOD && c.Serial != nil {
+			c.Serial.SOD(c.Cycles, sod)
+		}
+		c.SOD = sod
+	}
+}
+
+// InterruptPending reports whether an interrupt will be served before the
+// next instruction, which also ends a halt
+func (c *Intel8085) InterruptPending() bool {
+	_, ok := c.pendingInterrupt()
+	return ok
+}
+
+// pendingInterrupt returns the restart address of the highest-priority
+// interrupt that can be served: TRAP, which cannot be masked, then the enabled
+// and unmasked RST 7.5, RST 6.5 and RST 5.5
+func (c *Intel8085) pendingInterrupt() (uint16, bool) {
+	switch {
+	case c.trapPending && c.trap:
+		return Intel8085TrapAddress, true
+	case !c.InterruptsEnabled || c.enableDelay:
+		return 0, false
+	case c.RST75Pending && c.Masks&Intel8085MaskRST75 == 0:
+		return Intel8085RST75Address, true
+	case c.rst65 && c.Masks&Intel8085MaskRST65 == 0:
+		return Intel8085RST65Address, true
+	case c.rst55 && c.Masks&Intel8085MaskRST55 == 0:
+		return Intel8085RST55Address, true
+	}
+	return 0, false
+}
+
+// interrupt serves the pending interrupt, if any. Like RST, it pushes the PC
+// and jumps to the restart address, and it disables interrupts until the next
+// EI.
+func (c *Intel8085) interrupt() {
+	address, ok := c.pendingInterrupt()
+	if !ok {
+		return
+	}
+	switch address {
+	case Intel8085TrapAddress:
+		c.trapPending = false
+		c.trapped, c.savedEnable = true, c.InterruptsEnabled
+	case Intel8085RST75Address:
+		c.RST75Pending = false
+	}
+	c.InterruptsEnabled = false
+	c.Push16(c.PC)
+	c.PC = address
+	c.WaitForCycles(12)
+}
@@ -0,0 +1,571 @@
+// Code generated by isagen from intel_8085.isa; DO NOT EDIT.
+
+package cpu
+
+// Intel8085Instructions is the Intel 8085 instruction set
+var Intel8085Instructions = map[byte]Instruction{
+
+	// Data Transfer Instructions
+	// Register pairs are BC, DE, HL and SP; M is the memory byte addressed by HL.
+	// Data transfers do not affect the flags.
+
+	0x40: {0x40, "MOV", Implied, 1, 4, "Move register B to register B"},
+	0x41: {0x41, "MOV", Implied, 1, 4, "Move register C to register B"},
+	0x42: {0x42, "MOV", Implied, 1, 4, "Move register D to register B"},
+	0x43: {0x43, "MOV", Implied, 1, 4, "Move register E to register B"},
+	0x44: {0x44, "MOV", Implied, 1, 4, "Move register H to register B"},
+	0x45: {0x45, "MOV", Implied, 1, 4, "Move register L to register B"},
+	0x47: {0x47, "MOV", Implied, 1, 4, "Move the accumulator to register B"},
+	0x48: {0x48, "MOV", Implied, 1, 4, "Move register B to register C"},
+	0x49: {0x49, "MOV", Implied, 1, 4, "Move register C to register C"},
+	0x4A: {0x4A, "MOV", Implied, 1, 4, "Move register D to register C"},
+	0x4B: {0x4B, "MOV", Implied, 1, 4, "Move register E to register C"},
+	0x4C: {0x4C, "MOV", Implied, 1, 4, "Move register H to register C"},
+	0x4D: {0x4D, "MOV", Implied, 1, 4, "Move register L to register C"},
+	0x4F: {0x4F, "MOV", Implied, 1, 4, "Move the accumulator to register C"},
+	0x50: {0x50, "MOV", Implied, 1, 4, "Move register B to register D"},
+	0x51: {0x51, "MOV", Implied, 1, 4, "Move register C to register D"},
+	0x52: {0x52, "MOV", Implied, 1, 4, "Move register D to register D"},
+	0x53: {0x53, "MOV", Implied, 1, 4, "Move register E to register D"},
+	0x54: {0x54, "MOV", Implied, 1, 4, "Move register H to register D"},
+	0x55: {0x55, "MOV", Implied, 1, 4, "Move register L to register D"},
+	0x57: {0x57, "MOV", Implied, 1, 4, "Move the accumulator to register D"},
+	0x58: {0x58, "MOV", Implied, 1, 4, "Move register B to register E"},
+	0x59: {0x59, "MOV", Implied, 1, 4, "Move register C to register E"},
+	0x5A: {0x5A, "MOV", Implied, 1, 4, "Move register D to register E"},
+	0x5B: {0x5B, "MOV", Implied, 1, 4, "Move register E to register E"},
+	0x5C: {0x5C, "MOV", Implied, 1, 4, "Move register H to register E"},
+	0x5D: {0x5D, "MOV", Implied, 1, 4, "Move register L to register E"},
+	0x5F: {0x5F, "MOV", Implied, 1, 4, "Move the accumulator to register E"},
+	0x60: {0x60, "MOV", Implied, 1, 4, "Move register B to register H"},
+	0x61: {0x61, "MOV", Implied, 1, 4, "Move register C to register H"},
+	0x62: {0x62, "MOV", Implied, 1, 4, "Move register D to register H"},
+	0x63: {0x63, "MOV", Implied, 1, 4, "Move register E to register H"},
+	0x64: {0x64, "MOV", Implied, 1, 4, "Move register H to register H"},
+	0x65: {0x65, "MOV", Implied, 1, 4, "Move register L to register H"},
+	0x67: {0x67, "MOV", Implied, 1, 4, "Move the accumulator to register H"},
+	0x68: {0x68, "MOV", Implied, 1, 4, "Move register B to register L"},
+	0x69: {0x69, "MOV", Implied, 1, 4, "Move register C to register L"},
+	0x6A: {0x6A, "MOV", Implied, 1, 4, "Move register D to register L"},
+	0x6B: {0x6B, "MOV", Implied, 1, 4, "Move register E to register L"},
+	0x6C: {0x6C, "MOV", Implied, 1, 4, "Move register H to register L"},
+	0x6D: {0x6D, "MOV", Implied, 1, 4, "Move register L to register L"},
+	0x6F: {0x6F, "MOV", Implied, 1, 4, "Move the accumulator to register L"},
+	0x78: {0x78, "MOV", Implied, 1, 4, "Move register B to the accumulator"},
+	0x79: {0x79, "MOV", Implied, 1, 4, "Move register C to the accumulator"},
+	0x7A: {0x7A, "MOV", Implied, 1, 4, "Move register D to the accumulator"},
+	0x7B: {0x7B, "MOV", Implied, 1, 4, "Move register E to the accumulator"},
+	0x7C: {0x7C, "MOV", Implied, 1, 4, "Move register H to the accumulator"},
+	0x7D: {0x7D, "MOV", Implied, 1, 4, "Move register L to the accumulator"},
+	0x7F: {0x7F, "MOV", Implied, 1, 4, "Move the accumulator to the accumulator"},
+
+	0x46: {0x46, "MOV", Implied, 1, 7, "Move memory at HL to register B"},
+	0x4E: {0x4E, "MOV", Implied, 1, 7, "Move memory at HL to register C"},
+	0x56: {0x56, "MOV", Implied, 1, 7, "Move memory at HL to register D"},
+	0x5E: {0x5E, "MOV", Implied, 1, 7, "Move memory at HL to register E"},
+	0x66: {0x66, "MOV", Implied, 1, 7, "Move memory at HL to register H"},
+	0x6E: {0x6E, "MOV", Implied, 1, 7, "Move memory at HL to register L"},
+	0x7E: {0x7E, "MOV", Implied, 1, 7, "Move memory at HL to the accumulator"},
+
+	0x70: {0x70, "MOV", Implied, 1, 7, "Move register B to memory at HL"},
+	0x71: {0x71, "MOV", Implied, 1, 7, "Move register C to memory at HL"},
+	0x72: {0x72, "MOV", Implied, 1, 7, "Move register D to memory at HL"},
+	0x73: {0x73, "MOV", Implied, 1, 7, "Move register E to memory at HL"},
+	0x74: {0x74, "MOV", Implied, 1, 7, "Move register H to memory at HL"},
+	0x75: {0x75, "MOV", Implied, 1, 7, "Move register L to memory at HL"},
+	0x77: {0x77, "MOV", Implied, 1, 7, "Move the accumulator to memory at HL"},
+
+	0x06: {0x06, "MVI", Immediate, 2, 7, "Move immediate data to register B"},
+	0x0E: {0x0E, "MVI", Immediate, 2, 7, "Move immediate data to register C"},
+	0x16: {0x16, "MVI", Immediate, 2, 7, "Move immediate data to register D"},
+	0x1E: {0x1E, "MVI", Immediate, 2, 7, "Move immediate data to register E"},
+	0x26: {0x26, "MVI", Immediate, 2, 7, "Move immediate data to register H"},
+	0x2E: {0x2E, "MVI", Immediate, 2, 7, "Move immediate data to register L"},
+	0x3E: {0x3E, "MVI", Immediate, 2, 7, "Move immediate data to the accumulator"},
+
+	0x36: {0x36, "MVI", Immediate, 2, 10, "Move immediate data to memory at HL"},
+
+	0x01: {0x01, "LXI", Immediate16, 3, 10, "Load immediate data into register pair BC"},
+	0x11: {0x11, "LXI", Immediate16, 3, 10, "Load immediate data into register pair DE"},
+	0x21: {0x21, "LXI", Immediate16, 3, 10, "Load immediate data into register pair HL"},
+	0x31: {0x31, "LXI", Immediate16, 3, 10, "Load immediate data into register pair SP"},
+
+	0x02: {0x02, "STAX", Implied, 1, 7, "Store the accumulator at the address in BC"},
+	0x12: {0x12, "STAX", Implied, 1, 7, "Store the accumulator at the address in DE"},
+
+	0x0A: {0x0A, "LDAX", Implied, 1, 7, "Load the accumulator from the address in BC"},
+	0x1A: {0x1A, "LDAX", Implied, 1, 7, "Load the accumulator from the address in DE"},
+
+	0x22: {0x22, "SHLD", Absolute, 3, 16, "Store L and H at the address and the next"},
+
+	0x2A: {0x2A, "LHLD", Absolute, 3, 16, "Load L and H from the address and the next"},
+
+	0x32: {0x32, "STA", Absolute, 3, 13, "Store the accumulator at the address"},
+
+	0x3A: {0x3A, "LDA", Absolute, 3, 13, "Load the accumulator from the address"},
+
+	0xEB: {0xEB, "XCHG", Implied, 1, 4, "Exchange HL with DE"},
+
+	// Arithmetic and Logical Instructions
+	// They set S, Z, AC, P and C from the result, except INR and DCR, which keep C,
+	// INX, DCX and DAD, which only change C for DAD, and the rotates, which only change C.
+
+	0x04: {0x04, "INR", Implied, 1, 4, "Increment register B"},
+	0x0C: {0x0C, "INR", Implied, 1, 4, "Increment register C"},
+	0x14: {0x14, "INR", Implied, 1, 4, "Increment register D"},
+	0x1C: {0x1C, "INR", Implied, 1, 4, "Increment register E"},
+	0x24: {0x24, "INR", Implied, 1, 4, "Increment register H"},
+	0x2C: {0x2C, "INR", Implied, 1, 4, "Increment register L"},
+	0x3C: {0x3C, "INR", Implied, 1, 4, "Increment the accumulator"},
+
+	0x34: {0x34, "INR", Implied, 1, 10, "Increment memory at HL"},
+
+	0x05: {0x05, "DCR", Implied, 1, 4, "Decrement register B"},
+	0x0D: {0x0D, "DCR", Implied, 1, 4, "Decrement register C"},
+	0x15: {0x15, "DCR", Implied, 1, 4, "Decrement register D"},
+	0x1D: {0x1D, "DCR", Implied, 1, 4, "Decrement register E"},
+	0x25: {0x25, "DCR", Implied, 1, 4, "Decrement register H"},
+	0x2D: {0x2D, "DCR", Implied, 1, 4, "Decrement register L"},
+	0x3D: {0x3D, "DCR", Implied, 1, 4, "Decrement the accumulator"},
+
+	0x35: {0x35, "DCR", Implied, 1, 10, "Decrement memory at HL"},
+
+	0x03: {0x03, "INX", Implied, 1, 6, "Increment register pair BC"},
+	0x13: {0x13, "INX", Implied, 1, 6, "Increment register pair DE"},
+	0x23: {0x23, "INX", Implied, 1, 6, "Increment register pair HL"},
+	0x33: {0x33, "INX", Implied, 1, 6, "Increment register pair SP"},
+
+	0x0B: {0x0B, "DCX", Implied, 1, 6, "Decrement register pair BC"},
+	0x1B: {0x1B, "DCX", Implied, 1, 6, "Decrement register pair DE"},
+	0x2B: {0x2B, "DCX", Implied, 1, 6, "Decrement register pair HL"},
+	0x3B: {0x3B, "DCX", Implied, 1, 6, "Decrement register pair SP"},
+
+	0x09: {0x09, "DAD", Implied, 1, 10, "Add register pair BC to HL"},
+	0x19: {0x19, "DAD", Implied, 1, 10, "Add register pair DE to HL"},
+	0x29: {0x29, "DAD", Implied, 1, 10, "Add register pair HL to HL"},
+	0x39: {0x39, "DAD", Implied, 1, 10, "Add register pair SP to HL"},
+
+	0x80: {0x80, "ADD", Implied, 1, 4, "Add register B to the accumulator"},
+	0x81: {0x81, "ADD", Implied, 1, 4, "Add register C to the accumulator"},
+	0x82: {0x82, "ADD", Implied, 1, 4, "Add register D to the accumulator"},
+	0x83: {0x83, "ADD", Implied, 1, 4, "Add register E to the accumulator"},
+	0x84: {0x84, "ADD", Implied, 1, 4, "Add register H to the accumulator"},
+	0x85: {0x85, "ADD", Implied, 1, 4, "Add register L to the accumulator"},
+	0x87: {0x87, "ADD", Implied, 1, 4, "Add the accumulator to the accumulator"},
+	0x88: {0x88, "ADC", Implied, 1, 4, "Add register B and the carry to the accumulator"},
+	0x89: {0x89, "ADC", Implied, 1, 4, "Add register C and the carry to the accumulator"},
+	0x8A: {0x8A, "ADC", Implied, 1, 4, "Add register D and the carry to the accumulator"},
+	0x8B: {0x8B, "ADC", Implied, 1, 4, "Add register E and the carry to the accumulator"},
+	0x8C: {0x8C, "ADC", Implied, 1, 4, "Add register H and the carry to the accumulator"},
+	0x8D: {0x8D, "ADC", Implied, 1, 4, "Add register L and the carry to the accumulator"},
+	0x8F: {0x8F, "ADC", Implied, 1, 4, "Add the accumulator and the carry to the accumulator"},
+	0x90: {0x90, "SUB", Implied, 1, 4, "Subtract register B from the accumulator"},
+	0x91: {0x91, "SUB", Implied, 1, 4, "Subtract register C from the accumulator"},
+	0x92: {0x92, "SUB", Implied, 1, 4, "Subtract register D from the accumulator"},
+	0x93: {0x93, "SUB", Implied, 1, 4, "Subtract register E from the accumulator"},
+	0x94: {0x94, "SUB", Implied, 1, 4, "Subtract register H from the accumulator"},
+	0x95: {0x95, "SUB", Implied, 1, 4, "Subtract register L from the accumulator"},
+	0x97: {0x97, "SUB", Implied, 1, 4, "Subtract the accumulator from the accumulator"},
+	0x98: {0x98, "SBB", Implied, 1, 4, "Subtract register B and the borrow from the accumulator"},
+	0x99: {0x99, "SBB", Implied, 1, 4, "Subtract register C and the borrow from the accumulator"},
+	0x9A: {0x9A, "SBB", Implied, 1, 4, "Subtract register D and the borrow from the accumulator"},
+	0x9B: {0x9B, "SBB", Implied, 1, 4, "Subtract register E and the borrow from the accumulator"},
+	0x9C: {0x9C, "SBB", Implied, 1, 4, "Subtract register H and the borrow from the accumulator"},
+	0x9D: {0x9D, "SBB", Implied, 1, 4, "Subtract register L and the borrow from the accumulator"},
+	0x9F: {0x9F, "SBB", Implied, 1, 4, "Subtract the accumulator and the borrow from the accumulator"},
+	0xA0: {0xA0, "ANA", Implied, 1, 4, "AND register B with the accumulator"},
+	0xA1: {0xA1, "ANA", Implied, 1, 4, "AND register C with the accumulator"},
+	0xA2: {0xA2, "ANA", Implied, 1, 4, "AND register D with the accumulator"},
+	0xA3: {0xA3, "ANA", Implied, 1, 4, "AND register E with the accumulator"},
+	0xA4: {0xA4, "ANA", Implied, 1, 4, "AND register H with the accumulator"},
+	0xA5: {0xA5, "ANA", Implied, 1, 4, "AND register L with the accumulator"},
+	0xA7: {0xA7, "ANA", Implied, 1, 4, "AND the accumulator with the accumulator"},
+	0xA8: {0xA8, "XRA", Implied, 1, 4, "Exclusive OR register B with the accumulator"},
+	0xA9: {0xA9, "XRA", Implied, 1, 4, "Exclusive OR register C with the accumulator"},
+	0xAA: {0xAA, "XRA", Implied, 1, 4, "Exclusive OR register D with the accumulator"},
+	0xAB: {0xAB, "XRA", Implied, 1, 4, "Exclusive OR register E with the accumulator"},
+	0xAC: {0xAC, "XRA", Implied, 1, 4, "Exclusive OR register H with the accumulator"},
+	0xAD: {0xAD, "XRA", Implied, 1, 4, "Exclusive OR register L with the accumulator"},
+	0xAF: {0xAF, "XRA", Implied, 1, 4, "Exclusive OR the accumulator with the accumulator"},
+	0xB0: {0xB0, "ORA", Implied, 1, 4, "OR register B with the accumulator"},
+	0xB1: {0xB1, "ORA", Implied, 1, 4, "OR register C with the accumulator"},
+	0xB2: {0xB2, "ORA", Implied, 1, 4, "OR register D with the accumulator"},
+	0xB3: {0xB3, "ORA", Implied, 1, 4, "OR register E with the accumulator"},
+	0xB4: {0xB4, "ORA", Implied, 1, 4, "OR register H with the accumulator"},
+	0xB5: {0xB5, "ORA", Implied, 1, 4, "OR register L with the accumulator"},
+	0xB7: {0xB7, "ORA", Implied, 1, 4, "OR the accumulator with the accumulator"},
+	0xB8: {0xB8, "CMP", Implied, 1, 4, "Compare register B with the accumulator"},
+	0xB9: {0xB9, "CMP", Implied, 1, 4, "Compare register C with the accumulator"},
+	0xBA: {0xBA, "CMP", Implied, 1, 4, "Compare register D with the accumulator"},
+	0xBB: {0xBB, "CMP", Implied, 1, 4, "Compare register E with the accumulator"},
+	0xBC: {0xBC, "CMP", Implied, 1, 4, "Compare register H with the accumulator"},
+	0xBD: {0xBD, "CMP", Implied, 1, 4, "Compare register L with the accumulator"},
+	0xBF: {0xBF, "CMP", Implied, 1, 4, "Compare the accumulator with the accumulator"},
+
+	0x86: {0x86, "ADD", Implied, 1, 7, "Add memory at HL to the accumulator"},
+	0x8E: {0x8E, "ADC", Implied, 1, 7, "Add memory at HL and the carry to the accumulator"},
+	0x96: {0x96, "SUB", Implied, 1, 7, "Subtract memory at HL from the accumulator"},
+	0x9E: {0x9E, "SBB", Implied, 1, 7, "Subtract memory at HL and the borrow from the accumulator"},
+	0xA6: {0xA6, "ANA", Implied, 1, 7, "AND memory at HL with the accumulator"},
+	0xAE: {0xAE, "XRA", Implied, 1, 7, "Exclusive OR memory at HL with the accumulator"},
+	0xB6: {0xB6, "ORA", Implied, 1, 7, "OR memory at HL with the accumulator"},
+	0xBE: {0xBE, "CMP", Implied, 1, 7, "Compare memory at HL with the accumulator"},
+
+	0xC6: {0xC6, "ADI", Immediate, 2, 7, "Add immediate data to the accumulator"},
+	0xCE: {0xCE, "ACI", Immediate, 2, 7, "Add immediate data and the carry to the accumulator"},
+	0xD6: {0xD6, "SUI", Immediate, 2, 7, "Subtract immediate data from the accumulator"},
+	0xDE: {0xDE, "SBI", Immediate, 2, 7, "Subtract immediate data and the borrow from the accumulator"},
+	0xE6: {0xE6, "ANI", Immediate, 2, 7, "AND immediate data with the accumulator"},
+	0xEE: {0xEE, "XRI", Immediate, 2, 7, "Exclusive OR immediate data with the accumulator"},
+	0xF6: {0xF6, "ORI", Immediate, 2, 7, "OR immediate data with the accumulator"},
+	0xFE: {0xFE, "CPI", Immediate, 2, 7, "Compare immediate data with the accumulator"},
+
+	0x07: {0x07, "RLC", Implied, 1, 4, "Rotate the accumulator left"},
+
+	0x0F: {0x0F, "RRC", Implied, 1, 4, "Rotate the accumulator right"},
+
+	0x17: {0x17, "RAL", Implied, 1, 4, "Rotate the accumulator left through the carry"},
+
+	0x1F: {0x1F, "RAR", Implied, 1, 4, "Rotate the accumulator right through the carry"},
+
+	0x27: {0x27, "DAA", Implied, 1, 4, "Decimal adjust the accumulator"},
+
+	0x2F: {0x2F, "CMA", Implied, 1, 4, "Complement the accumulator"},
+
+	0x37: {0x37, "STC", Implied, 1, 4, "Set the carry"},
+
+	0x3F: {0x3F, "CMC", Implied, 1, 4, "Complement the carry"},
+
+	// Branch Instructions
+	// Conditional jumps take 3 more cycles, conditional calls 9 and conditional
+	// returns 6 when the condition is met.
+
+	0xC3: {0xC3, "JMP", Absolute, 3, 10, "Jump to the address"},
+
+	0xC2: {0xC2, "JNZ", Absolute, 3, 7, "Jump to the address if not zero"},
+	0xCA: {0xCA, "JZ", Absolute, 3, 7, "Jump to the address if zero"},
+	0xD2: {0xD2, "JNC", Absolute, 3, 7, "Jump to the address if no carry"},
+	0xDA: {0xDA, "JC", Absolute, 3, 7, "Jump to the address if carry"},
+	0xE2: {0xE2, "JPO", Absolute, 3, 7, "Jump to the address if parity odd"},
+	0xEA: {0xEA, "JPE", Absolute, 3, 7, "Jump to the address if parity even"},
+	0xF2: {0xF2, "JP", Absolute, 3, 7, "Jump to the address if plus"},
+	0xFA: {0xFA, "JM", Absolute, 3, 7, "Jump to the address if minus"},
+
+	0xCD: {0xCD, "CALL", Absolute, 3, 18, "Call the subroutine at the address"},
+
+	0xC4: {0xC4, "CNZ", Absolute, 3, 9, "Call the subroutine at the address if not zero"},
+	0xCC: {0xCC, "CZ", Absolute, 3, 9, "Call the subroutine at the address if zero"},
+	0xD4: {0xD4, "CNC", Absolute, 3, 9, "Call the subroutine at the address if no carry"},
+	0xDC: {0xDC, "CC", Absolute, 3, 9, "Call the subroutine at the address if carry"},
+	0xE4: {0xE4, "CPO", Absolute, 3, 9, "Call the subroutine at the address if parity odd"},
+	0xEC: {0xEC, "CPE", Absolute, 3, 9, "Call the subroutine at the address if parity even"},
+	0xF4: {0xF4, "CP", Absolute, 3, 9, "Call the subroutine at the address if plus"},
+	0xFC: {0xFC, "CM", Absolute, 3, 9, "Call the subroutine at the address if minus"},
+
+	0xC9: {0xC9, "RET", Implied, 1, 10, "Return from subroutine"},
+
+	0xC0: {0xC0, "RNZ", Implied, 1, 6, "Return if not zero"},
+	0xC8: {0xC8, "RZ", Implied, 1, 6, "Return if zero"},
+	0xD0: {0xD0, "RNC", Implied, 1, 6, "Return if no carry"},
+	0xD8: {0xD8, "RC", Implied, 1, 6, "Return if carry"},
+	0xE0: {0xE0, "RPO", Implied, 1, 6, "Return if parity odd"},
+	0xE8: {0xE8, "RPE", Implied, 1, 6, "Return if parity even"},
+	0xF0: {0xF0, "RP", Implied, 1, 6, "Return if plus"},
+	0xF8: {0xF8, "RM", Implied, 1, 6, "Return if minus"},
+
+	0xC7: {0xC7, "RST", Implied, 1, 12, "Call the subroutine at $0000"},
+	0xCF: {0xCF, "RST", Implied, 1, 12, "Call the subroutine at $0008"},
+	0xD7: {0xD7, "RST", Implied, 1, 12, "Call the subroutine at $0010"},
+	0xDF: {0xDF, "RST", Implied, 1, 12, "Call the subroutine at $0018"},
+	0xE7: {0xE7, "RST", Implied, 1, 12, "Call the subroutine at $0020"},
+	0xEF: {0xEF, "RST", Implied, 1, 12, "Call the subroutine at $0028"},
+	0xF7: {0xF7, "RST", Implied, 1, 12, "Call the subroutine at $0030"},
+	0xFF: {0xFF, "RST", Implied, 1, 12, "Call the subroutine at $0038"},
+
+	0xE9: {0xE9, "PCHL", Implied, 1, 6, "Jump to the address in HL"},
+
+	// Stack, I/O and Machine Control Instructions
+
+	0xC5: {0xC5, "PUSH", Implied, 1, 12, "Push register pair BC"},
+	0xD5: {0xD5, "PUSH", Implied, 1, 12, "Push register pair DE"},
+	0xE5: {0xE5, "PUSH", Implied, 1, 12, "Push register pair HL"},
+	0xF5: {0xF5, "PUSH", Implied, 1, 12, "Push register pair PSW"},
+
+	0xC1: {0xC1, "POP", Implied, 1, 10, "Pop register pair BC"},
+	0xD1: {0xD1, "POP", Implied, 1, 10, "Pop register pair DE"},
+	0xE1: {0xE1, "POP", Implied, 1, 10, "Pop register pair HL"},
+
+	0xF1: {0xF1, "POP", Implied, 1, 10, "Pop register pair PSW"},
+
+	0xE3: {0xE3, "XTHL", Implied, 1, 16, "Exchange HL with the top of the stack"},
+
+	0xF9: {0xF9, "SPHL", Implied, 1, 6, "Load SP from HL"},
+
+	0xDB: {0xDB, "IN", Immediate, 2, 10, "Read the input port into the accumulator"},
+
+	0xD3: {0xD3, "OUT", Immediate, 2, 10, "Write the accumulator to the output port"},
+
+	0xFB: {0xFB, "EI", Implied, 1, 4, "Enable interrupts"},
+
+	0xF3: {0xF3, "DI", Implied, 1, 4, "Disable interrupts"},
+
+	0x20: {0x20, "RIM", Implied, 1, 4, "Read the interrupt masks, pending interrupts and SID into the accumulator"},
+
+	0x30: {0x30, "SIM", Implied, 1, 4, "Set the interrupt masks and SOD from the accumulator"},
+
+	0x76: {0x76, "HLT", Implied, 1, 5, "Halt"},
+
+	0x00: {0x00, "NOP", Implied, 1, 4, "No operation"},
+}
+
+// intel8085Forms holds the written form of every opcode, keyed by dialect name
+var intel8085Forms = map[string]map[byte]Form{
+	"8085": {
+		0x00: {"NOP", nil},
+		0x01: {"LXI", []string{"B"}},
+		0x02: {"STAX", []string{"B"}},
+		0x03: {"INX", []string{"B"}},
+		0x04: {"INR", []string{"B"}},
+		0x05: {"DCR", []string{"B"}},
+		0x06: {"MVI", []string{"B"}},
+		0x07: {"RLC", nil},
+		0x09: {"DAD", []string{"B"}},
+		0x0A: {"LDAX", []string{"B"}},
+		0x0B: {"DCX", []string{"B"}},
+		0x0C: {"INR", []string{"C"}},
+		0x0D: {"DCR", []string{"C"}},
+		0x0E: {"MVI", []string{"C"}},
+		0x0F: {"RRC", nil},
+		0x11: {"LXI", []string{"D"}},
+		0x12: {"STAX", []string{"D"}},
+		0x13: {"INX", []string{"D"}},
+		0x14: {"INR", []string{"D"}},
+		0x15: {"DCR", []string{"D"}},
+		0x16: {"MVI", []string{"D"}},
+		0x17: {"RAL", nil},
+		0x19: {"DAD", []string{"D"}},
+		0x1A: {"LDAX", []string{"D"}},
+		0x1B: {"DCX", []string{"D"}},
+		0x1C: {"INR", []string{"E"}},
+		0x1D: {"DCR", []string{"E"}},
+		0x1E: {"MVI", []string{"E"}},
+		0x1F: {"RAR", nil},
+		0x20: {"RIM", nil},
+		0x21: {"LXI", []string{"H"}},
+		0x22: {"SHLD", nil},
+		0x23: {"INX", []string{"H"}},
+		0x24: {"INR", []string{"H"}},
+		0x25: {"DCR", []string{"H"}},
+		0x26: {"MVI", []string{"H"}},
+		0x27: {"DAA", nil},
+		0x29: {"DAD", []string{"H"}},
+		0x2A: {"LHLD", nil},
+		0x2B: {"DCX", []string{"H"}},
+		0x2C: {"INR", []string{"L"}},
+		0x2D: {"DCR", []string{"L"}},
+		0x2E: {"MVI", []string{"L"}},
+		0x2F: {"CMA", nil},
+		0x30: {"SIM", nil},
+		0x31: {"LXI", []string{"SP"}},
+		0x32: {"STA", nil},
+		0x33: {"INX", []string{"SP"}},
+		0x34: {"INR", []string{"M"}},
+		0x35: {"DCR", []string{"M"}},
+		0x36: {"MVI", []string{"M"}},
+		0x37: {"STC", nil},
+		0x39: {"DAD", []string{"SP"}},
+		0x3A: {"LDA", nil},
+		0x3B: {"DCX", []string{"SP"}},
+		0x3C: {"INR", []string{"A"}},
+		0x3D: {"DCR", []string{"A"}},
+		0x3E: {"MVI", []string{"A"}},
+		0x3F: {"CMC", nil},
+		0x40: {"MOV", []string{"B", "B"}},
+		0x41: {"MOV", []string{"B", "C"}},
+		0x42: {"MOV", []string{"B", "D"}},
+		0x43: {"MOV", []string{"B", "E"}},
+		0x44: {"MOV", []string{"B", "H"}},
+		0x45: {"MOV", []string{"B", "L"}},
+		0x46: {"MOV", []string{"B", "M"}},
+		0x47: {"MOV", []string{"B", "A"}},
+		0x48: {"MOV", []string{"C", "B"}},
+		0x49: {"MOV", []string{"C", "C"}},
+		0x4A: {"MOV", []string{"C", "D"}},
+		0x4B: {"MOV", []string{"C", "E"}},
+		0x4C: {"MOV", []string{"C", "H"}},
+		0x4D: {"MOV", []string{"C", "L"}},
+		0x4E: {"MOV", []string{"C", "M"}},
+		0x4F: {"MOV", []string{"C", "A"}},
+		0x50: {"MOV", []string{"D", "B"}},
+		0x51: {"MOV", []string{"D", "C"}},
+		0x52: {"MOV", []string{"D", "D"}},
+		0x53: {"MOV", []string{"D", "E"}},
+		0x54: {"MOV", []string{"D", "H"}},
+		0x55: {"MOV", []string{"D", "L"}},
+		0x56: {"MOV", []string{"D", "M"}},
+		0x57: {"MOV", []string{"D", "A"}},
+		0x58: {"MOV", []string{"E", "B"}},
+		0x59: {"MOV", []string{"E", "C"}},
+		0x5A: {"MOV", []string{"E", "D"}},
+		0x5B: {"MOV", []string{"E", "E"}},
+		0x5C: {"MOV", []string{"E", "H"}},
+		0x5D: {"MOV", []string{"E", "L"}},
+		0x5E: {"MOV", []string{"E", "M"}},
+		0x5F: {"MOV", []string{"E", "A"}},
+		0x60: {"MOV", []string{"H", "B"}},
+		0x61: {"MOV", []string{"H", "C"}},
+		0x62: {"MOV", []string{"H", "D"}},
+		0x63: {"MOV", []string{"H", "E"}},
+		0x64: {"MOV", []string{"H", "H"}},
+		0x65: {"MOV", []string{"H", "L"}},
+		0x66: {"MOV", []string{"H", "M"}},
+		0x67: {"MOV", []string{"H", "A"}},
+		0x68: {"MOV", []string{"L", "B"}},
+		0x69: {"MOV", []string{"L", "C"}},
+		0x6A: {"MOV", []string{"L", "D"}},
+		0x6B: {"MOV", []string{"L", "E"}},
+		0x6C: {"MOV", []string{"L", "H"}},
+		0x6D: {"MOV", []string{"L", "L"}},
+		0x6E: {"MOV", []string{"L", "M"}},
+		0x6F: {"MOV", []string{"L", "A"}},
+		0x70: {"MOV", []string{"M", "B"}},
+		0x71: {"MOV", []string{"M", "C"}},
+		0x72: {"MOV", []string{"M", "D"}},
+		0x73: {"MOV", []string{"M", "E"}},
+		0x74: {"MOV", []string{"M", "H"}},
+		0x75: {"MOV", []string{"M", "L"}},
+		0x76: {"HLT", nil},
+		0x77: {"MOV", []string{"M", "A"}},
+		0x78: {"MOV", []string{"A", "B"}},
+		0x79: {"MOV", []string{"A", "C"}},
+		0x7A: {"MOV", []string{"A", "D"}},
+		0x7B: {"MOV", []string{"A", "E"}},
+		0x7C: {"MOV", []string{"A", "H"}},
+		0x7D: {"MOV", []string{"A", "L"}},
+		0x7E: {"MOV", []string{"A", "M"}},
+		0x7F: {"MOV", []string{"A", "A"}},
+		0x80: {"ADD", []string{"B"}},
+		0x81: {"ADD", []string{"C"}},
+		0x82: {"ADD", []string{"D"}},
+		0x83: {"ADD", []string{"E"}},
+		0x84: {"ADD", []string{"H"}},
+		0x85: {"ADD", []string{"L"}},
+		0x86: {"ADD", []string{"M"}},
+		0x87: {"ADD", []string{"A"}},
+		0x88: {"ADC", []string{"B"}},
+		0x89: {"ADC", []string{"C"}},
+		0x8A: {"ADC", []string{"D"}},
+		0x8B: {"ADC", []string{"E"}},
+		0x8C: {"ADC", []string{"H"}},
+		0x8D: {"ADC", []string{"L"}},
+		0x8E: {"ADC", []string{"M"}},
+		0x8F: {"ADC", []string{"A"}},
+		0x90: {"SUB", []string{"B"}},
+		0x91: {"SUB", []string{"C"}},
+		0x92: {"SUB", []string{"D"}},
+		0x93: {"SUB", []string{"E"}},
+		0x94: {"SUB", []string{"H"}},
+		0x95: {"SUB", []string{"L"}},
+		0x96: {"SUB", []string{"M"}},
+		0x97: {"SUB", []string{"A"}},
+		0x98: {"SBB", []string{"B"}},
+		0x99: {"SBB", []string{"C"}},
+		0x9A: {"SBB", []string{"D"}},
+		0x9B: {"SBB", []string{"E"}},
+		0x9C: {"SBB", []string{"H"}},
+		0x9D: {"SBB", []string{"L"}},
+		0x9E: {"SBB", []string{"M"}},
+		0x9F: {"SBB", []string{"A"}},
+		0xA0: {"ANA", []string{"B"}},
+		0xA1: {"ANA", []string{"C"}},
+		0xA2: {"ANA", []string{"D"}},
+		0xA3: {"ANA", []string{"E"}},
+		0xA4: {"ANA", []string{"H"}},
+		0xA5: {"ANA", []string{"L"}},
+		0xA6: {"ANA", []string{"M"}},
+		0xA7: {"ANA", []string{"A"}},
+		0xA8: {"XRA", []string{"B"}},
+		0xA9: {"XRA", []string{"C"}},
+		0xAA: {"XRA", []string{"D"}},
+		0xAB: {"XRA", []string{"E"}},
+		0xAC: {"XRA", []string{"H"}},
+		0xAD: {"XRA", []string{"L"}},
+		0xAE: {"XRA", []string{"M"}},
+		0xAF: {"XRA", []string{"A"}},
+		0xB0: {"ORA", []string{"B"}},
+		0xB1: {"ORA", []string{"C"}},
+		0xB2: {"ORA", []string{"D"}},
+		0xB3: {"ORA", []string{"E"}},
+		0xB4: {"ORA", []string{"H"}},
+		0xB5: {"ORA", []string{"L"}},
+		0xB6: {"ORA", []string{"M"}},
+		0xB7: {"ORA", []string{"A"}},
+		0xB8: {"CMP", []string{"B"}},
+		0xB9: {"CMP", []string{"C"}},
+		0xBA: {"CMP", []string{"D"}},
+		0xBB: {"CMP", []string{"E"}},
+		0xBC: {"CMP", []string{"H"}},
+		0xBD: {"CMP", []string{"L"}},
+		0xBE: {"CMP", []string{"M"}},
+		0xBF: {"CMP", []string{"A"}},
+		0xC0: {"RNZ", nil},
+		0xC1: {"POP", []string{"B"}},
+		0xC2: {"JNZ", nil},
+		0xC3: {"JMP", nil},
+		0xC4: {"CNZ", nil},
+		0xC5: {"PUSH", []string{"B"}},
+		0xC6: {"ADI", nil},
+		0xC7: {"RST", []string{"0"}},
+		0xC8: {"RZ", nil},
+		0xC9: {"RET", nil},
+		0xCA: {"JZ", nil},
+		0xCC: {"CZ", nil},
+		0xCD: {"CALL", nil},
+		0xCE: {"ACI", nil},
+		0xCF: {"RST", []string{"1"}},
+		0xD0: {"RNC", nil},
+		0xD1: {"POP", []string{"D"}},
+		0xD2: {"JNC", nil},
+		0xD3: {"OUT", nil},
+		0xD4: {"CNC", nil},
+		0xD5: {"PUSH", []string{"D"}},
+		0xD6: {"SUI", nil},
+		0xD7: {"RST", []string{"2"}},
+		0xD8: {"RC", nil},
+		0xDA: {"JC", nil},
+		0xDB: {"IN", nil},
+		0xDC: {"CC", nil},
+		0xDE: {"SBI", nil},
+		0xDF: {"RST", []string{"3"}},
+		0xE0: {"RPO", nil},
+		0xE1: {"POP", []string{"H"}},
+		0xE2: {"JPO", nil},
+		0xE3: {"XTHL", nil},
+		0xE4: {"CPO", nil},
+		0xE5: {"PUSH", []string{"H"}},
+		0xE6: {"ANI", nil},
+		0xE7: {"RST", []string{"4"}},
+		0xE8: {"RPE", nil},
+		0xE9: {"PCHL", nil},
+		0xEA: {"JPE", nil},
+		0xEB: {"XCHG", nil},
+		0xEC: {"CPE", nil},
+		0xEE: {"XRI", nil},
+		0xEF: {"RST", []string{"5"}},
+		0xF0: {"RP", nil},
+		0xF1: {"POP", []string{"PSW"}},
+		0xF2: {"JP", nil},
+		0xF3: {"DI", nil},
+		0xF4: {"CP", nil},
+		0xF5: {"PUSH", []string{"PSW"}},
+		0xF6: {"ORI", nil},
+		0xF7: {"RST", []string{"6"}},
+		0xF8: {"RM", nil},
+		0xF9: {"SPHL", nil},
+		0xFA: {"JM", nil},
+		0xFB: {"EI", nil},
+		0xFC: {"CM", nil},
+		0xFE: {"CPI", nil},
+		0xFF: {"RST", []string{"7"}},
+	},
+}
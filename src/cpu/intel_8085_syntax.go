@@ -0,0 +1,14 @@
+package cpu
+
+//go:generate go run ../isagen -go intel_8085_instructions.go -doc ../../docs/intel_8085.md intel_8085.isa
+
+// Intel8085Syntax is the Intel 8080 assembly language with the 8085's RIM and SIM
+var Intel8085Syntax = &Syntax{
+	Name:        "8085",
+	Description: "Intel 8085 mnemonics (MOV A,B, LXI H, RIM, SIM)",
+	Forms:       intel8085Forms["8085"],
+	HexSuffix:   true,
+}
+
+// Intel8085Syntaxes lists the dialects the 8085 can be written in
+var Intel8085Syntaxes = []*Syntax{Intel8085Syntax}
@@ -0,0 +1,562 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Interrupt vectors at the top of the 6502 address space
+const (
+	MOS6502NMIVector   = 0xFFFA // Non-maskable interrupt
+	MOS6502ResetVector = 0xFFFC // Reset
+	MOS6502IRQVector   = 0xFFFE // Interrupt request and BRK
+)
+
+// Processor status bits as PHP, BRK and interrupts push them
+const (
+	mos6502Carry     = 0x01
+	mos6502Zero      = 0x02
+	mos6502Interrupt = 0x04
+	mos6502Decimal   = 0x08
+	mos6502Break     = 0x10 // Only in pushed copies: set by PHP and BRK, clear for interrupts
+	mos6502Unused    = 0x20 // Always reads as 1
+	mos6502Overflow  = 0x40
+	mos6502Negative  = 0x80
+)
+
+// MOS6502 represents the NMOS 6502 processor. The stack pointer is the
+// base CPU's 8-bit SP, addressing the stack page at $0100-$01FF.
+type MOS6502 struct {
+	CPU
+	A      uint8 // Accumulator
+	X      uint8 // X index register
+	Y      uint8 // Y index register
+	Status struct {
+		Negative         bool // Negative Flag (N), bit 7 of the result
+		Overflow         bool // Overflow Flag (V), signed overflow
+		Decimal          bool // Decimal Mode Flag (D), BCD arithmetic in ADC and SBC
+		InterruptDisable bool // Interrupt Disable Flag (I)
+		Zero             bool // Zero Flag (Z)
+		Carry            bool // Carry Flag (C)
+	}
+
+	irqLine    bool // Level of the IRQ input
+	nmiLine    bool // Level of the NMI input
+	nmiPending bool // NMI saw a rising edge and has not been served
+}
+
+// NewMOS6502 creates a new 6502 CPU instance
+func NewMOS6502(memorySize int, speed uint) *MOS6502 {
+	c := &MOS6502{
+		CPU: *NewCPU("MOS6502", memorySize, speed, MOS6502Instructions),
+	}
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// GetStatus returns the processor status register P as PHP pushes it
+func (c *MOS6502) GetStatus() byte {
+	return c.statusByte(true)
+}
+
+// SetStatus sets the flags from a processor status byte
+func (c *MOS6502) SetStatus(value byte) {
+	c.Status.Negative = value&mos6502Negative != 0
+	c.Status.Overflow = value&mos6502Overflow != 0
+	c.Status.Decimal = value&mos6502Decimal != 0
+	c.Status.InterruptDisable = value&mos6502Interrupt != 0
+	c.Status.Zero = value&mos6502Zero != 0
+	c.Status.Carry = value&mos6502Carry != 0
+}
+
+// Push pushes a byte onto the stack page
+func (c *MOS6502) Push(value byte) {
+	c.store(0x0100|uint16(c.SP), value)
+	c.SP--
+}
+
+// Pull pulls a byte from the stack page
+func (c *MOS6502) Pull() byte {
+	c.SP++
+	return c.load(0x0100 | uint16(c.SP))
+}
+
+// Push16 pushes a 16-bit value onto the stack, high byte first
+func (c *MOS6502) Push16(value uint16) {
+	c.Push(byte(value >> 8))
+	c.Push(byte(value))
+}
+
+// Pull16 pulls a 16-bit value from the stack
+func (c *MOS6502) Pull16() uint16 {
+	low := uint16(c.Pull())
+	return uint16(c.Pull())<<8 | low
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *MOS6502) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction takes a pending interrupt, if any, and executes a single
+// instruction. It returns ErrHalted after HLT, an UnknownOpcodeError for
+// opcodes outside the official set and a FaultError for instructions it
+// cannot execute.
+func (c *MOS6502) ExecuteInstruction() (err error) {
+	c.interrupt()
+
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.PC = pc
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
+	// Get the opcode
+	opcode := c.Memory[c.PC]
+
+	// Get the instruction
+	instruction, ok := c.Instructions[opcode]
+	if !ok {
+		return &UnknownOpcodeError{Opcode: opcode, PC: c.PC}
+	}
+
+	// Only print verbose output if enabled
+	if c.IsVerbose() {
+		fmt.Printf("PC: %04X, OP: %02X, MN: %s, A:%02X X:%02X Y:%02X SP:%02X | Flags(NVDIZC): %d%d%d%d%d%d\n",
+			c.PC, opcode, instruction.Mnemonic, c.A, c.X, c.Y, c.SP,
+			boolToInt(c.Status.Negative), boolToInt(c.Status.Overflow), boolToInt(c.Status.Decimal),
+			boolToInt(c.Status.InterruptDisable), boolToInt(c.Status.Zero), boolToInt(c.Status.Carry))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
+	}
+
+	// Fetch the operand; the PC points past the instruction while it executes
+	var data byte
+	var word uint16
+	switch instruction.Size {
+	case 2:
+		data = c.Memory[c.PC+1]
+	case 3:
+		word = uint16(c.Memory[c.PC+1]) | uint16(c.Memory[c.PC+2])<<8
+	}
+	c.PC += uint16(instruction.Size)
+	err = c.execute(instruction, data, word)
+
+	// Wait for the appropriate amount of time
+	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// registerFile lists the registers and flags behind the register API
+func (c *MOS6502) registerFile() ([]register, []flag) {
+	registers := []register{
+		byteRegister("A", &c.A), byteRegister("X", &c.X), byteRegister("Y", &c.Y), byteRegister("SP", &c.SP),
+	}
+	flags := []flag{
+		boolFlag("N", "Negative", &c.Status.Negative), boolFlag("V", "Overflow", &c.Status.Overflow),
+		boolFlag("D", "Decimal mode", &c.Status.Decimal), boolFlag("I", "Interrupt disable", &c.Status.InterruptDisable),
+		boolFlag("Z", "Zero", &c.Status.Zero), boolFlag("C", "Carry", &c.Status.Carry),
+	}
+	return registers, flags
+}
+
+// address returns the effective address of an operand in the given mode and
+// whether indexing crossed a page, which costs reads an extra cycle
+func (c *MOS6502) address(mode AddressingMode, data byte, word uint16) (addr uint16, crossed bool) {
+	switch mode {
+	case ZeroPage:
+		return uint16(data), false
+	case ZeroPageX:
+		return uint16(data + c.X), false
+	case ZeroPageY:
+		return uint16(data + c.Y), false
+	case Absolute:
+		return word, false
+	case AbsoluteX:
+		addr = word + uint16(c.X)
+		return addr, addr&0xFF00 != word&0xFF00
+	case AbsoluteY:
+		addr = word + uint16(c.Y)
+		return addr, addr&0xFF00 != word&0xFF00
+	case Indirect:
+		// The pointer's high byte does not carry into the next page
+		low := uint16(c.load(word))
+		return uint16(c.load(word&0xFF00|(word+1)&0x00FF))<<8 | low, false
+	case IndexedIndirect:
+		pointer := data + c.X
+		return uint16(c.load(uint16(pointer+1)))<<8 | uint16(c.load(uint16(pointer))), false
+	case IndirectIndexed:
+		base := uint16(c.load(uint16(data+1)))<<8 | uint16(c.load(uint16(data)))
+		addr = base + uint16(c.Y)
+		return addr, addr&0xFF00 != base&0xFF00
+	case Relative:
+		return c.PC + uint16(int8(data)), false
+	}
+	return 0, false
+}
+
+// execute executes an instruction whose operand, if any, is data or word
+func (c *MOS6502) execute(instruction Instruction, data byte, word uint16) error {
+	mode := instruction.Mode
+	addr, crossed := c.address(mode, data, word)
+
+	// read returns the operand of instructions that use a value
+	read := func() byte {
+		switch mode {
+		case Immediate:
+			return data
+		case Accumulator:
+			return c.A
+		}
+		return c.load(addr)
+	}
+	// write stores the result of a read-modify-write instruction
+	write := func(value byte) {
+		if mode == Accumulator {
+			c.A = value
+		} else {
+			c.store(addr, value)
+		}
+	}
+
+	switch instruction.Mnemonic {
+	// Load and store
+	case "LDA":
+		c.A = c.updateFlags(read())
+		c.pageCycle(crossed)
+	case "LDX":
+		c.X = c.updateFlags(read())
+		c.pageCycle(crossed)
+	case "LDY":
+		c.Y = c.updateFlags(read())
+		c.pageCycle(crossed)
+	case "STA":
+		c.store(addr, c.A)
+	case "STX":
+		c.store(addr, c.X)
+	case "STY":
+		c.store(addr, c.Y)
+
+	// Register transfers
+	case "TAX":
+		c.X = c.updateFlags(c.A)
+	case "TAY":
+		c.Y = c.updateFlags(c.A)
+	case "TSX":
+		c.X = c.updateFlags(c.SP)
+	case "TXA":
+		c.A = c.updateFlags(c.X)
+	case "TXS":
+		c.SP = c.X
+	case "TYA":
+		c.A = c.updateFlags(c.Y)
+
+	// Stack
+	case "PHA":
+		c.Push(c.A)
+	case "PHP":
+		c.Push(c.statusByte(true))
+	case "PLA":
+		c.A = c.updateFlags(c.Pull())
+	case "PLP":
+		c.SetStatus(c.Pull())
+
+	// Logical
+	case "AND":
+		c.A = c.updateFlags(c.A & read())
+		c.pageCycle(crossed)
+	case "EOR":
+		c.A = c.updateFlags(c.A ^ read())
+		c.pageCycle(crossed)
+	case "ORA":
+		c.A = c.updateFlags(c.A | read())
+		c.pageCycle(crossed)
+	case "BIT":
+		value := read()
+		c.Status.Zero = c.A&value == 0
+		c.Status.Negative = value&0x80 != 0
+		c.Status.Overflow = value&0x40 != 0
+
+	// Arithmetic
+	case "ADC":
+		c.add(read())
+		c.pageCycle(crossed)
+	case "SBC":
+		c.subtract(read())
+		c.pageCycle(crossed)
+	case "CMP":
+		c.compare(c.A, read())
+		c.pageCycle(crossed)
+	case "CPX":
+		c.compare(c.X, read())
+	case "CPY":
+		c.compare(c.Y, read())
+
+	// Increments and decrements
+	case "INC":
+		write(c.updateFlags(read() + 1))
+	case "DEC":
+		write(c.updateFlags(read() - 1))
+	case "INX":
+		c.X = c.updateFlags(c.X + 1)
+	case "INY":
+		c.Y = c.updateFlags(c.Y + 1)
+	case "DEX":
+		c.X = c.updateFlags(c.X - 1)
+	case "DEY":
+		c.Y = c.updateFlags(c.Y - 1)
+
+	// Shifts and rotates
+	case "ASL":
+		value := read()
+		c.Status.Carry = value&0x80 != 0
+		write(c.updateFlags(value << 1))
+	case "LSR":
+		value := read()
+		c.Status.Carry = value&0x01 != 0
+		write(c.updateFlags(value >> 1))
+	case "ROL":
+		value := read()
+		result := value<<1 | byte(boolToInt(c.Status.Carry))
+		c.Status.Carry = value&0x80 != 0
+		write(c.updateFlags(result))
+	case "ROR":
+		value := read()
+		result := value>>1 | byte(boolToInt(c.Status.Carry))<<7
+		c.Status.Carry = value&0x01 != 0
+		write(c.updateFlags(result))
+
+	// Jumps and subroutines
+	case "JMP":
+		c.PC = addr
+	case "JSR":
+		c.Push16(c.PC - 1)
+		c.PC = addr
+	case "RTS":
+		c.PC = c.Pull16() + 1
+	case "RTI":
+		c.SetStatus(c.Pull())
+		c.PC = c.Pull16()
+
+	// Branches
+	case "BCC":
+		c.branch(!c.Status.Carry, addr)
+	case "BCS":
+		c.branch(c.Status.Carry, addr)
+	case "BEQ":
+		c.branch(c.Status.Zero, addr)
+	case "BNE":
+		c.branch(!c.Status.Zero, addr)
+	case "BMI":
+		c.branch(c.Status.Negative, addr)
+	case "BPL":
+		c.branch(!c.Status.Negative, addr)
+	case "BVS":
+		c.branch(c.Status.Overflow, addr)
+	case "BVC":
+		c.branch(!c.Status.Overflow, addr)
+
+	// Status flags
+	case "CLC":
+		c.Status.Carry = false
+	case "CLD":
+		c.Status.Decimal = false
+	case "CLI":
+		c.Status.InterruptDisable = false
+	case "CLV":
+		c.Status.Overflow = false
+	case "SEC":
+		c.Status.Carry = true
+	case "SED":
+		c.Status.Decimal = true
+	case "SEI":
+		c.Status.InterruptDisable = true
+
+	// System
+	case "BRK":
+		// BRK skips the byte after it, so the return address is PC+1
+		c.Push16(c.PC + 1)
+		c.Push(c.statusByte(true))
+		c.Status.InterruptDisable = true
+		c.PC = uint16(c.load(MOS6502IRQVector+1))<<8 | uint16(c.load(MOS6502IRQVector))
+	case "NOP":
+	case "HLT":
+		return ErrHalted
+	default:
+		return fmt.Errorf("instruction %s not implemented", instruction.Mnemonic)
+	}
+	return nil
+}
+
+// SetPin sets the level of an input pin: IRQ or NMI. IRQ is taken while it
+// is high and the interrupt disable flag is clear; NMI on a rising edge.
+// Interrupts are taken before the next instruction. HLT locks up the
+// processor, so no interrupt ends it.
+func (c *MOS6502) SetPin(name string, high bool) error {
+	switch name {
+	case "IRQ":
+		c.irqLine = high
+	case "NMI":
+		if high && !c.nmiLine {
+			c.nmiPending = true
+		}
+		c.nmiLine = high
+	default:
+		return fmt.Errorf("the 6502 has no input pin %s (available: IRQ, NMI)", name)
+	}
+	return nil
+}
+
+// interrupt takes a pending interrupt, NMI before IRQ
+func (c *MOS6502) interrupt() {
+	switch {
+	case c.nmiPending:
+		c.nmiPending = false
+		c.enterInterrupt(MOS6502NMIVector)
+	case c.irqLine && !c.Status.InterruptDisable:
+		c.enterInterrupt(MOS6502IRQVector)
+	}
+}
+
+// enterInterrupt pushes the PC and status with the break bit clear and jumps through the vector
+func (c *MOS6502) enterInterrupt(vector uint16) {
+	c.Push16(c.PC)
+	c.Push(c.statusByte(false))
+	c.Status.InterruptDisable = true
+	c.PC = uint16(c.Read(vector+1))<<8 | uint16(c.Read(vector))
+	c.WaitForCycles(7)
+}
+
+// pageCycle adds the cycle a read takes when indexing crosses a page
+func (c *MOS6502) pageCycle(crossed bool) {
+	if crossed {
+		c.AddCycles(1)
+	}
+}
+
+// branch jumps to target when the condition holds. A taken branch takes one
+// more cycle, and another when the target is in a different page.
+func (c *MOS6502) branch(condition bool, target uint16) {
+	if !condition {
+		return
+	}
+	c.AddCycles(1)
+	if target&0xFF00 != c.PC&0xFF00 {
+		c.AddCycles(1)
+	}
+	c.PC = target
+}
+
+// add adds a value and the carry to the accumulator. In decimal mode the
+// NMOS 6502 sets Z from the binary sum and N and V from the intermediate
+// result before the high digit is adjusted.
+func (c *MOS6502) add(value byte) {
+	carry := boolToInt(c.Status.Carry)
+	if !c.Status.Decimal {
+		sum := int(c.A) + int(value) + carry
+		c.Status.Carry = sum > 0xFF
+		c.Status.Overflow = (c.A^byte(sum))&(value^byte(sum))&0x80 != 0
+		c.A = c.updateFlags(byte(sum))
+		return
+	}
+
+	low := int(c.A&0x0F) + int(value&0x0F) + carry
+	if low > 0x09 {
+		low += 0x06
+	}
+	sum := low&0x0F + int(c.A&0xF0) + int(value&0xF0)
+	if low > 0x0F {
+		sum += 0x10
+	}
+	c.Status.Zero = byte(int(c.A)+int(value)+carry) == 0
+	c.Status.Negative = sum&0x80 != 0
+	c.Status.Overflow = (c.A^byte(sum))&0x80 != 0 && (c.A^value)&0x80 == 0
+	if sum&0x1F0 > 0x90 {
+		sum += 0x60
+	}
+	c.Status.Carry = sum&0xFF0 > 0xF0
+	c.A = byte(sum)
+}
+
+// subtract subtracts a value and the borrow (the inverted carry) from the
+// accumulator. The flags always come from the binary difference.
+func (c *MOS6502) subtract(value byte) {
+	borrow := 1 - boolToInt(c.Status.Carry)
+	difference := int(c.A) - int(value) - borrow
+	c.Status.Carry = difference >= 0
+	c.Status.Overflow = (c.A^byte(difference))&(c.A^value)&0x80 != 0
+	c.updateFlags(byte(difference))
+	if !c.Status.Decimal {
+		c.A = byte(difference)
+		return
+	}
+
+	low := int(c.A&0x0F) - int(value&0x0F) - borrow
+	var result int
+	if low&0x10 != 0 {
+		result = (low-0x06)&0x0F | (int(c.A&0xF0) - int(value&0xF0) - 0x10)
+	} else {
+		result = low&0x0F | (int(c.A&0xF0) - int(value&0xF0))
+	}
+	if result&0x100 != 0 {
+		result -= 0x60
+	}
+	c.A = byte(result)
+}
+
+// compare sets the flags as for register minus value without changing the register
+func (c *MOS6502) compare(register byte, value byte) {
+	c.Status.Carry = register >= value
+	c.updateFlags(register - value)
+}
+
+// updateFlags sets N and Z from a result and returns it
+func (c *MOS6502) updateFlags(result byte) byte {
+	c.Status.Zero = result == 0
+	c.Status.Negative = result&0x80 != 0
+	return result
+}
+
+// statusByte packs the flags into the processor status register (NV1BDIZC)
+func (c *MOS6502) statusByte(brk bool) byte {
+	status := byte(mos6502Unused)
+	flags := []struct {
+		set bool
+		bit byte
+	}{
+		{c.Status.Negative, mos6502Negative}, {c.Status.Overflow, mos6502Overflow}, {brk, mos6502Break},
+		{c.Status.Decimal, mos6502Decimal}, {c.Status.InterruptDisable, mos6502Interrupt},
+		{c.Status.Zero, mos6502Zero}, {c.Status.Carry, mos6502Carry},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			status |= flag.bit
+		}
+	}
+	return status
+}
@@ -0,0 +1,223 @@
+package cpu
+
+var MOS6502Instructions = map[byte]Instruction{
+
+	// Load and Store Instructions
+	// Loads set N and Z from the value loaded; stores do not affect the flags.
+	// Indexed reads take one more cycle when the address crosses a page boundary.
+
+	0xA1: {0xA1, "LDA", IndexedIndirect, 2, 6, "Load the accumulator from memory (indexed indirect)"},
+	0xA5: {0xA5, "LDA", ZeroPage, 2, 3, "Load the accumulator from memory (zero page)"},
+	0xA9: {0xA9, "LDA", Immediate, 2, 2, "Load the accumulator from memory (immediate data)"},
+	0xAD: {0xAD, "LDA", Absolute, 3, 4, "Load the accumulator from memory (absolute)"},
+	0xB1: {0xB1, "LDA", IndirectIndexed, 2, 5, "Load the accumulator from memory (indirect indexed)"},
+	0xB5: {0xB5, "LDA", ZeroPageX, 2, 4, "Load the accumulator from memory (zero page,X)"},
+	0xB9: {0xB9, "LDA", AbsoluteY, 3, 4, "Load the accumulator from memory (absolute,Y)"},
+	0xBD: {0xBD, "LDA", AbsoluteX, 3, 4, "Load the accumulator from memory (absolute,X)"},
+
+	0xA2: {0xA2, "LDX", Immediate, 2, 2, "Load the X register from memory (immediate data)"},
+	0xA6: {0xA6, "LDX", ZeroPage, 2, 3, "Load the X register from memory (zero page)"},
+	0xAE: {0xAE, "LDX", Absolute, 3, 4, "Load the X register from memory (absolute)"},
+	0xB6: {0xB6, "LDX", ZeroPageY, 2, 4, "Load the X register from memory (zero page,Y)"},
+	0xBE: {0xBE, "LDX", AbsoluteY, 3, 4, "Load the X register from memory (absolute,Y)"},
+
+	0xA0: {0xA0, "LDY", Immediate, 2, 2, "Load the Y register from memory (immediate data)"},
+	0xA4: {0xA4, "LDY", ZeroPage, 2, 3, "Load the Y register from memory (zero page)"},
+	0xAC: {0xAC, "LDY", Absolute, 3, 4, "Load the Y register from memory (absolute)"},
+	0xB4: {0xB4, "LDY", ZeroPageX, 2, 4, "Load the Y register from memory (zero page,X)"},
+	0xBC: {0xBC, "LDY", AbsoluteX, 3, 4, "Load the Y register from memory (absolute,X)"},
+
+	0x81: {0x81, "STA", IndexedIndirect, 2, 6, "Store the accumulator in memory (indexed indirect)"},
+	0x85: {0x85, "STA", ZeroPage, 2, 3, "Store the accumulator in memory (zero page)"},
+	0x8D: {0x8D, "STA", Absolute, 3, 4, "Store the accumulator in memory (absolute)"},
+	0x91: {0x91, "STA", IndirectIndexed, 2, 6, "Store the accumulator in memory (indirect indexed)"},
+	0x95: {0x95, "STA", ZeroPageX, 2, 4, "Store the accumulator in memory (zero page,X)"},
+	0x99: {0x99, "STA", AbsoluteY, 3, 5, "Store the accumulator in memory (absolute,Y)"},
+	0x9D: {0x9D, "STA", AbsoluteX, 3, 5, "Store the accumulator in memory (absolute,X)"},
+
+	0x86: {0x86, "STX", ZeroPage, 2, 3, "Store the X register in memory (zero page)"},
+	0x8E: {0x8E, "STX", Absolute, 3, 4, "Store the X register in memory (absolute)"},
+	0x96: {0x96, "STX", ZeroPageY, 2, 4, "Store the X register in memory (zero page,Y)"},
+
+	0x84: {0x84, "STY", ZeroPage, 2, 3, "Store the Y register in memory (zero page)"},
+	0x8C: {0x8C, "STY", Absolute, 3, 4, "Store the Y register in memory (absolute)"},
+	0x94: {0x94, "STY", ZeroPageX, 2, 4, "Store the Y register in memory (zero page,X)"},
+
+	// Register Transfer Instructions
+	// Transfers set N and Z, except TXS.
+
+	0xAA: {0xAA, "TAX", Implied, 1, 2, "Transfer the accumulator to X"},
+	0xA8: {0xA8, "TAY", Implied, 1, 2, "Transfer the accumulator to Y"},
+	0xBA: {0xBA, "TSX", Implied, 1, 2, "Transfer the stack pointer to X"},
+	0x8A: {0x8A, "TXA", Implied, 1, 2, "Transfer X to the accumulator"},
+	0x9A: {0x9A, "TXS", Implied, 1, 2, "Transfer X to the stack pointer"},
+	0x98: {0x98, "TYA", Implied, 1, 2, "Transfer Y to the accumulator"},
+
+	// Stack Instructions
+	// The stack is the page at $0100-$01FF and grows down from $01FF.
+
+	0x48: {0x48, "PHA", Implied, 1, 3, "Push the accumulator on the stack"},
+	0x08: {0x08, "PHP", Implied, 1, 3, "Push the processor status on the stack"},
+	0x68: {0x68, "PLA", Implied, 1, 4, "Pull the accumulator from the stack"},
+	0x28: {0x28, "PLP", Implied, 1, 4, "Pull the processor status from the stack"},
+
+	// Logical Instructions
+	// BIT sets Z from A AND memory, and copies memory bits 7 and 6 to N and V.
+
+	0x21: {0x21, "AND", IndexedIndirect, 2, 6, "AND memory with the accumulator (indexed indirect)"},
+	0x25: {0x25, "AND", ZeroPage, 2, 3, "AND memory with the accumulator (zero page)"},
+	0x29: {0x29, "AND", Immediate, 2, 2, "AND memory with the accumulator (immediate data)"},
+	0x2D: {0x2D, "AND", Absolute, 3, 4, "AND memory with the accumulator (absolute)"},
+	0x31: {0x31, "AND", IndirectIndexed, 2, 5, "AND memory with the accumulator (indirect indexed)"},
+	0x35: {0x35, "AND", ZeroPageX, 2, 4, "AND memory with the accumulator (zero page,X)"},
+	0x39: {0x39, "AND", AbsoluteY, 3, 4, "AND memory with the accumulator (absolute,Y)"},
+	0x3D: {0x3D, "AND", AbsoluteX, 3, 4, "AND memory with the accumulator (absolute,X)"},
+
+	0x24: {0x24, "BIT", ZeroPage, 2, 3, "Test memory bits with the accumulator (zero page)"},
+	0x2C: {0x2C, "BIT", Absolute, 3, 4, "Test memory bits with the accumulator (absolute)"},
+
+	0x41: {0x41, "EOR", IndexedIndirect, 2, 6, "Exclusive OR memory with the accumulator (indexed indirect)"},
+	0x45: {0x45, "EOR", ZeroPage, 2, 3, "Exclusive OR memory with the accumulator (zero page)"},
+	0x49: {0x49, "EOR", Immediate, 2, 2, "Exclusive OR memory with the accumulator (immediate data)"},
+	0x4D: {0x4D, "EOR", Absolute, 3, 4, "Exclusive OR memory with the accumulator (absolute)"},
+	0x51: {0x51, "EOR", IndirectIndexed, 2, 5, "Exclusive OR memory with the accumulator (indirect indexed)"},
+	0x55: {0x55, "EOR", ZeroPageX, 2, 4, "Exclusive OR memory with the accumulator (zero page,X)"},
+	0x59: {0x59, "EOR", AbsoluteY, 3, 4, "Exclusive OR memory with the accumulator (absolute,Y)"},
+	0x5D: {0x5D, "EOR", AbsoluteX, 3, 4, "Exclusive OR memory with the accumulator (absolute,X)"},
+
+	0x01: {0x01, "ORA", IndexedIndirect, 2, 6, "OR memory with the accumulator (indexed indirect)"},
+	0x05: {0x05, "ORA", ZeroPage, 2, 3, "OR memory with the accumulator (zero page)"},
+	0x09: {0x09, "ORA", Immediate, 2, 2, "OR memory with the accumulator (immediate data)"},
+	0x0D: {0x0D, "ORA", Absolute, 3, 4, "OR memory with the accumulator (absolute)"},
+	0x11: {0x11, "ORA", IndirectIndexed, 2, 5, "OR memory with the accumulator (indirect indexed)"},
+	0x15: {0x15, "ORA", ZeroPageX, 2, 4, "OR memory with the accumulator (zero page,X)"},
+	0x19: {0x19, "ORA", AbsoluteY, 3, 4, "OR memory with the accumulator (absolute,Y)"},
+	0x1D: {0x1D, "ORA", AbsoluteX, 3, 4, "OR memory with the accumulator (absolute,X)"},
+
+	// Arithmetic Instructions
+	// ADC and SBC work in BCD when the decimal flag is set. Compares set C, Z and N
+	// as for a subtraction without borrow.
+
+	0x61: {0x61, "ADC", IndexedIndirect, 2, 6, "Add memory and the carry to the accumulator (indexed indirect)"},
+	0x65: {0x65, "ADC", ZeroPage, 2, 3, "Add memory and the carry to the accumulator (zero page)"},
+	0x69: {0x69, "ADC", Immediate, 2, 2, "Add memory and the carry to the accumulator (immediate data)"},
+	0x6D: {0x6D, "ADC", Absolute, 3, 4, "Add memory and the carry to the accumulator (absolute)"},
+	0x71: {0x71, "ADC", IndirectIndexed, 2, 5, "Add memory and the carry to the accumulator (indirect indexed)"},
+	0x75: {0x75, "ADC", ZeroPageX, 2, 4, "Add memory and the carry to the accumulator (zero page,X)"},
+	0x79: {0x79, "ADC", AbsoluteY, 3, 4, "Add memory and the carry to the accumulator (absolute,Y)"},
+	0x7D: {0x7D, "ADC", AbsoluteX, 3, 4, "Add memory and the carry to the accumulator (absolute,X)"},
+
+	0xC1: {0xC1, "CMP", IndexedIndirect, 2, 6, "Compare memory with the accumulator (indexed indirect)"},
+	0xC5: {0xC5, "CMP", ZeroPage, 2, 3, "Compare memory with the accumulator (zero page)"},
+	0xC9: {0xC9, "CMP", Immediate, 2, 2, "Compare memory with the accumulator (immediate data)"},
+	0xCD: {0xCD, "CMP", Absolute, 3, 4, "Compare memory with the accumulator (absolute)"},
+	0xD1: {0xD1, "CMP", IndirectIndexed, 2, 5, "Compare memory with the accumulator (indirect indexed)"},
+	0xD5: {0xD5, "CMP", ZeroPageX, 2, 4, "Compare memory with the accumulator (zero page,X)"},
+	0xD9: {0xD9, "CMP", AbsoluteY, 3, 4, "Compare memory with the accumulator (absolute,Y)"},
+	0xDD: {0xDD, "CMP", AbsoluteX, 3, 4, "Compare memory with the accumulator (absolute,X)"},
+
+	0xE0: {0xE0, "CPX", Immediate, 2, 2, "Compare memory with the X register (immediate data)"},
+	0xE4: {0xE4, "CPX", ZeroPage, 2, 3, "Compare memory with the X register (zero page)"},
+	0xEC: {0xEC, "CPX", Absolute, 3, 4, "Compare memory with the X register (absolute)"},
+
+	0xC0: {0xC0, "CPY", Immediate, 2, 2, "Compare memory with the Y register (immediate data)"},
+	0xC4: {0xC4, "CPY", ZeroPage, 2, 3, "Compare memory with the Y register (zero page)"},
+	0xCC: {0xCC, "CPY", Absolute, 3, 4, "Compare memory with the Y register (absolute)"},
+
+	0xE1: {0xE1, "SBC", IndexedIndirect, 2, 6, "Subtract memory and the borrow from the accumulator (indexed indirect)"},
+	0xE5: {0xE5, "SBC", ZeroPage, 2, 3, "Subtract memory and the borrow from the accumulator (zero page)"},
+	0xE9: {0xE9, "SBC", Immediate, 2, 2, "Subtract memory and the borrow from the accumulator (immediate data)"},
+	0xED: {0xED, "SBC", Absolute, 3, 4, "Subtract memory and the borrow from the accumulator (absolute)"},
+	0xF1: {0xF1, "SBC", IndirectIndexed, 2, 5, "Subtract memory and the borrow from the accumulator (indirect indexed)"},
+	0xF5: {0xF5, "SBC", ZeroPageX, 2, 4, "Subtract memory and the borrow from the accumulator (zero page,X)"},
+	0xF9: {0xF9, "SBC", AbsoluteY, 3, 4, "Subtract memory and the borrow from the accumulator (absolute,Y)"},
+	0xFD: {0xFD, "SBC", AbsoluteX, 3, 4, "Subtract memory and the borrow from the accumulator (absolute,X)"},
+
+	// Increment and Decrement Instructions
+	// These set N and Z and leave the carry unchanged.
+
+	0xC6: {0xC6, "DEC", ZeroPage, 2, 5, "Decrement memory by one (zero page)"},
+	0xCE: {0xCE, "DEC", Absolute, 3, 6, "Decrement memory by one (absolute)"},
+	0xD6: {0xD6, "DEC", ZeroPageX, 2, 6, "Decrement memory by one (zero page,X)"},
+	0xDE: {0xDE, "DEC", AbsoluteX, 3, 7, "Decrement memory by one (absolute,X)"},
+
+	0xCA: {0xCA, "DEX", Implied, 1, 2, "Decrement the X register by one"},
+
+	0x88: {0x88, "DEY", Implied, 1, 2, "Decrement the Y register by one"},
+
+	0xE6: {0xE6, "INC", ZeroPage, 2, 5, "Increment memory by one (zero page)"},
+	0xEE: {0xEE, "INC", Absolute, 3, 6, "Increment memory by one (absolute)"},
+	0xF6: {0xF6, "INC", ZeroPageX, 2, 6, "Increment memory by one (zero page,X)"},
+	0xFE: {0xFE, "INC", AbsoluteX, 3, 7, "Increment memory by one (absolute,X)"},
+
+	0xE8: {0xE8, "INX", Implied, 1, 2, "Increment the X register by one"},
+
+	0xC8: {0xC8, "INY", Implied, 1, 2, "Increment the Y register by one"},
+
+	// Shift and Rotate Instructions
+	// The bit shifted out goes to the carry.
+
+	0x06: {0x06, "ASL", ZeroPage, 2, 5, "Shift left one bit (zero page)"},
+	0x0A: {0x0A, "ASL", Accumulator, 1, 2, "Shift left one bit (accumulator)"},
+	0x0E: {0x0E, "ASL", Absolute, 3, 6, "Shift left one bit (absolute)"},
+	0x16: {0x16, "ASL", ZeroPageX, 2, 6, "Shift left one bit (zero page,X)"},
+	0x1E: {0x1E, "ASL", AbsoluteX, 3, 7, "Shift left one bit (absolute,X)"},
+
+	0x46: {0x46, "LSR", ZeroPage, 2, 5, "Shift right one bit (zero page)"},
+	0x4A: {0x4A, "LSR", Accumulator, 1, 2, "Shift right one bit (accumulator)"},
+	0x4E: {0x4E, "LSR", Absolute, 3, 6, "Shift right one bit (absolute)"},
+	0x56: {0x56, "LSR", ZeroPageX, 2, 6, "Shift right one bit (zero page,X)"},
+	0x5E: {0x5E, "LSR", AbsoluteX, 3, 7, "Shift right one bit (absolute,X)"},
+
+	0x26: {0x26, "ROL", ZeroPage, 2, 5, "Rotate left one bit through the carry (zero page)"},
+	0x2A: {0x2A, "ROL", Accumulator, 1, 2, "Rotate left one bit through the carry (accumulator)"},
+	0x2E: {0x2E, "ROL", Absolute, 3, 6, "Rotate left one bit through the carry (absolute)"},
+	0x36: {0x36, "ROL", ZeroPageX, 2, 6, "Rotate left one bit through the carry (zero page,X)"},
+	0x3E: {0x3E, "ROL", AbsoluteX, 3, 7, "Rotate left one bit through the carry (absolute,X)"},
+
+	0x66: {0x66, "ROR", ZeroPage, 2, 5, "Rotate right one bit through the carry (zero page)"},
+	0x6A: {0x6A, "ROR", Accumulator, 1, 2, "Rotate right one bit through the carry (accumulator)"},
+	0x6E: {0x6E, "ROR", Absolute, 3, 6, "Rotate right one bit through the carry (absolute)"},
+	0x76: {0x76, "ROR", ZeroPageX, 2, 6, "Rotate right one bit through the carry (zero page,X)"},
+	0x7E: {0x7E, "ROR", AbsoluteX, 3, 7, "Rotate right one bit through the carry (absolute,X)"},
+
+	// Jump and Subroutine Instructions
+	// JSR pushes the address of its last byte; RTS pulls it and adds one.
+	// JMP ($xxFF) reads the high byte of the target from $xx00, as the NMOS 6502 does.
+
+	0x4C: {0x4C, "JMP", Absolute, 3, 3, "Jump to the address"},
+	0x6C: {0x6C, "JMP", Indirect, 3, 5, "Jump to the address stored at the address"},
+	0x20: {0x20, "JSR", Absolute, 3, 6, "Jump to a subroutine, saving the return address"},
+	0x40: {0x40, "RTI", Implied, 1, 6, "Return from an interrupt"},
+	0x60: {0x60, "RTS", Implied, 1, 6, "Return from a subroutine"},
+
+	// Branch Instructions
+	// Branches take one more cycle when taken and another when they cross a page.
+
+	0x90: {0x90, "BCC", Relative, 2, 2, "Branch if the carry is clear"},
+	0xB0: {0xB0, "BCS", Relative, 2, 2, "Branch if the carry is set"},
+	0xF0: {0xF0, "BEQ", Relative, 2, 2, "Branch if the result was zero"},
+	0x30: {0x30, "BMI", Relative, 2, 2, "Branch if the result was negative"},
+	0xD0: {0xD0, "BNE", Relative, 2, 2, "Branch if the result was not zero"},
+	0x10: {0x10, "BPL", Relative, 2, 2, "Branch if the result was positive"},
+	0x50: {0x50, "BVC", Relative, 2, 2, "Branch if the overflow is clear"},
+	0x70: {0x70, "BVS", Relative, 2, 2, "Branch if the overflow is set"},
+
+	// Status Flag Instructions
+
+	0x18: {0x18, "CLC", Implied, 1, 2, "Clear the carry flag"},
+	0xD8: {0xD8, "CLD", Implied, 1, 2, "Clear the decimal mode flag"},
+	0x58: {0x58, "CLI", Implied, 1, 2, "Clear the interrupt disable flag"},
+	0xB8: {0xB8, "CLV", Implied, 1, 2, "Clear the overflow flag"},
+	0x38: {0x38, "SEC", Implied, 1, 2, "Set the carry flag"},
+	0xF8: {0xF8, "SED", Implied, 1, 2, "Set the decimal mode flag"},
+	0x78: {0x78, "SEI", Implied, 1, 2, "Set the interrupt disable flag"},
+
+	// System Instructions
+	// HLT is the undocumented JAM opcode $02, which locks up the processor; the
+	// emulator stops there.
+
+	0x00: {0x00, "BRK", Implied, 1, 7, "Force an interrupt through the IRQ/BRK vector"},
+	0x02: {0x02, "HLT", Implied, 1, 1, "Halt the processor (undocumented JAM opcode)"},
+	0xEA: {0xEA, "NOP", Implied, 1, 2, "No operation"},
+}
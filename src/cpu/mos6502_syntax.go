@@ -0,0 +1,13 @@
+package cpu
+
+// MOS6502Syntax is the MOS Technology 6502 assembly language (LDA #$1A, STA $0200,X, LDA ($10),Y).
+// The addressing mode is written in the operand, so every form is just the mnemonic.
+var MOS6502Syntax = &Syntax{
+	Name:            "6502",
+	Description:     "MOS 6502 mnemonics (LDA #$1A, STA $0200,X)",
+	Forms:           MnemonicSyntax("6502", MOS6502Instructions).Forms,
+	ImmediatePrefix: "#",
+}
+
+// MOS6502Syntaxes lists the dialects the 6502 can be written in
+var MOS6502Syntaxes = []*Syntax{MOS6502Syntax}
@@ -0,0 +1,625 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Interrupt vectors at the top of the 6800 address space
+const (
+	Motorola6800IRQVector   = 0xFFF8 // Interrupt request
+	Motorola6800SWIVector   = 0xFFFA // Software interrupt
+	Motorola6800NMIVector   = 0xFFFC // Non-maskable interrupt
+	Motorola6800ResetVector = 0xFFFE // Reset
+)
+
+// Condition code register bits (11HINZVC)
+const (
+	m6800Carry     = 0x01
+	m6800Overflow  = 0x02
+	m6800Zero      = 0x04
+	m6800Negative  = 0x08
+	m6800Interrupt = 0x10
+	m6800HalfCarry = 0x20
+	m6800Unused    = 0xC0 // Always read as 1
+)
+
+// motorola6800AccumulatorOps are the operations whose mnemonics end in the
+// accumulator they use, as in LDAA, ADDB, NEGA and PSHB
+var motorola6800AccumulatorOps = map[string]bool{
+	"SUB": true, "CMP": true, "SBC": true, "AND": true, "BIT": true, "LDA": true, "STA": true, "EOR": true,
+	"ADC": true, "ORA": true, "ADD": true, "NEG": true, "COM": true, "LSR": true, "ROR": true, "ASR": true,
+	"ASL": true, "ROL": true, "DEC": true, "INC": true, "TST": true, "CLR": true, "PSH": true, "PUL": true,
+}
+
+// Motorola6800 represents the Motorola 6800 processor. It has two
+// accumulators, a 16-bit index register and a 16-bit stack pointer, and it
+// stores 16-bit values high byte first.
+type Motorola6800 struct {
+	CPU
+	A      uint8  // Accumulator A
+	B      uint8  // Accumulator B
+	X      uint16 // Index register
+	SP     uint16 // Stack pointer; it points at the next free byte below the stack
+	Status struct {
+		HalfCarry     bool // Half Carry Flag (H), the carry out of bit 3 of additions
+		InterruptMask bool // Interrupt Mask (I); IRQ is ignored while it is set
+		Negative      bool // Negative Flag (N)
+		Zero          bool // Zero Flag (Z)
+		Overflow      bool // Overflow Flag (V), signed overflow
+		Carry         bool // Carry Flag (C), the borrow after subtractions
+	}
+	waiting bool // WAI pushed the registers and waits for an interrupt
+
+	irqLine    bool // Level of the IRQ input
+	nmiLine    bool // Level of the NMI input
+	nmiPending bool // NMI saw a rising edge and has not been served
+}
+
+// NewMotorola6800 creates a new 6800 CPU instance
+func NewMotorola6800(memorySize int, speed uint) *Motorola6800 {
+	c := &Motorola6800{
+		CPU: *NewCPU("Motorola6800", memorySize, speed, Motorola6800Instructions),
+	}
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// GetSP returns the stack pointer
+func (c *Motorola6800) GetSP() uint16 {
+	return c.SP
+}
+
+// SetSP sets the stack pointer
+func (c *Motorola6800) SetSP(value uint16) {
+	c.SP = value
+}
+
+// GetCCR returns the condition code register as TPA reads it
+func (c *Motorola6800) GetCCR() byte {
+	ccr := byte(m6800Unused)
+	flags := []struct {
+		set bool
+		bit byte
+	}{
+		{c.Status.HalfCarry, m6800HalfCarry}, {c.Status.InterruptMask, m6800Interrupt},
+		{c.Status.Negative, m6800Negative}, {c.Status.Zero, m6800Zero},
+		{c.Status.Overflow, m6800Overflow}, {c.Status.Carry, m6800Carry},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			ccr |= flag.bit
+		}
+	}
+	return ccr
+}
+
+// SetCCR sets the flags from a condition code register value
+func (c *Motorola6800) SetCCR(value byte) {
+	c.Status.HalfCarry = value&m6800HalfCarry != 0
+	c.Status.InterruptMask = value&m6800Interrupt != 0
+	c.Status.Negative = value&m6800Negative != 0
+	c.Status.Zero = value&m6800Zero != 0
+	c.Status.Overflow = value&m6800Overflow != 0
+	c.Status.Carry = value&m6800Carry != 0
+}
+
+// Push pushes a byte onto the stack
+func (c *Motorola6800) Push(value byte) {
+	c.store(c.SP, value)
+	c.SP--
+}
+
+// Pull pulls a byte from the stack
+func (c *Motorola6800) Pull() byte {
+	c.SP++
+	return c.load(c.SP)
+}
+
+// Push16 pushes a 16-bit value onto the stack, low byte first, so that it
+// is stored high byte first
+func (c *Motorola6800) Push16(value uint16) {
+	c.Push(byte(value))
+	c.Push(byte(value >> 8))
+}
+
+// Pull16 pulls a 16-bit value from the stack
+func (c *Motorola6800) Pull16() uint16 {
+	high := uint16(c.Pull())
+	return high<<8 | uint16(c.Pull())
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *Motorola6800) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction takes a pending interrupt, if any, and executes a single
+// instruction. It returns ErrHalted after HLT and WAI, an UnknownOpcodeError
+// for opcodes outside the 6800's instruction set and a FaultError for
+// instructions it cannot execute.
+func (c *Motorola6800) ExecuteInstruction() (err error) {
+	c.interrupt()
+
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.PC = pc
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
+	// Get the opcode
+	opcode := c.Memory[c.PC]
+
+	// Get the instruction
+	instruction, ok := c.Instructions[opcode]
+	if !ok {
+		return &UnknownOpcodeError{Opcode: opcode, PC: c.PC}
+	}
+
+	// Only print verbose output if enabled
+	if c.IsVerbose() {
+		fmt.Printf("PC: %04X, OP: %02X, MN: %s, A:%02X B:%02X X:%04X SP:%04X | Flags(HINZVC): %d%d%d%d%d%d\n",
+			c.PC, opcode, instruction.Mnemonic, c.A, c.B, c.X, c.SP,
+			boolToInt(c.Status.HalfCarry), boolToInt(c.Status.InterruptMask), boolToInt(c.Status.Negative),
+			boolToInt(c.Status.Zero), boolToInt(c.Status.Overflow), boolToInt(c.Status.Carry))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
+	}
+
+	// Fetch the operand, high byte first; the PC points past the instruction while it executes
+	var data byte
+	var word uint16
+	switch instruction.Size {
+	case 2:
+		data = c.Memory[c.PC+1]
+	case 3:
+		word = uint16(c.Memory[c.PC+1])<<8 | uint16(c.Memory[c.PC+2])
+	}
+	c.PC += uint16(instruction.Size)
+	err = c.execute(instruction, data, word)
+
+	// Wait for the appropriate amount of time
+	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// registerFile lists the registers and flags behind the register API
+func (c *Motorola6800) registerFile() ([]register, []flag) {
+	registers := []register{
+		byteRegister("A", &c.A), byteRegister("B", &c.B), wordRegister("X", &c.X), wordRegister("SP", &c.SP),
+	}
+	flags := []flag{
+		boolFlag("H", "Half carry", &c.Status.HalfCarry), boolFlag("I", "Interrupt mask", &c.Status.InterruptMask),
+		boolFlag("N", "Negative", &c.Status.Negative), boolFlag("Z", "Zero", &c.Status.Zero),
+		boolFlag("V", "Overflow", &c.Status.Overflow), boolFlag("C", "Carry", &c.Status.Carry),
+	}
+	return registers, flags
+}
+
+// address returns the effective address of an operand in the given mode
+func (c *Motorola6800) address(mode AddressingMode, data byte, word uint16) uint16 {
+	switch mode {
+	case ZeroPage:
+		return uint16(data)
+	case IndexedOffset:
+		return c.X + uint16(data)
+	case Absolute:
+		return word
+	case Relative:
+		return c.PC + uint16(int8(data))
+	}
+	return 0
+}
+
+// execute executes an instruction whose operand, if any, is data or word.
+// Accumulator forms such as LDAA and NEGB are executed as their operation on
+// A or B.
+func (c *Motorola6800) execute(instruction Instruction, data byte, word uint16) error {
+	mode := instruction.Mode
+	addr := c.address(mode, data, word)
+
+	operation, acc := instruction.Mnemonic, (*uint8)(nil)
+	if n := len(operation) - 1; n == 3 && motorola6800AccumulatorOps[operation[:n]] {
+		switch operation[n] {
+		case 'A':
+			operation, acc = operation[:n], &c.A
+		case 'B':
+			operation, acc = operation[:n], &c.B
+		}
+	}
+
+	// read returns the 8-bit operand: immediate data, the accumulator of
+	// the inherent forms or the byte at the address
+	read := func() byte {
+		switch mode {
+		case Immediate:
+			return data
+		case Implied:
+			return *acc
+		}
+		return c.load(addr)
+	}
+	// write stores the result of a read-modify-write instruction
+	write := func(value byte) {
+		if mode == Implied {
+			*acc = value
+		} else {
+			c.store(addr, value)
+		}
+	}
+	// read16 returns the 16-bit operand of CPX, LDS and LDX
+	read16 := func() uint16 {
+		if mode == Immediate16 {
+			return word
+		}
+		return uint16(c.load(addr))<<8 | uint16(c.load(addr+1))
+	}
+	// write16 stores X or SP for STX and STS
+	write16 := func(value uint16) {
+		c.store(addr, byte(value>>8))
+		c.store(addr+1, byte(value))
+		c.updateFlags16(value)
+	}
+	carry := byte(boolToInt(c.Status.Carry))
+
+	switch operation {
+	// Loads, stores and transfers
+	case "LDA":
+		*acc = c.updateFlags(read())
+	case "STA":
+		c.store(addr, c.updateFlags(*acc))
+	case "TAB":
+		c.B = c.updateFlags(c.A)
+	case "TBA":
+		c.A = c.updateFlags(c.B)
+
+	// Arithmetic
+	case "ADD":
+		*acc = c.add(*acc, read(), 0)
+	case "ADC":
+		*acc = c.add(*acc, read(), carry)
+	case "SUB":
+		*acc = c.subtract(*acc, read(), 0)
+	case "SBC":
+		*acc = c.subtract(*acc, read(), carry)
+	case "CMP":
+		c.subtract(*acc, read(), 0)
+	case "ABA":
+		c.A = c.add(c.A, c.B, 0)
+	case "SBA":
+		c.A = c.subtract(c.A, c.B, 0)
+	case "CBA":
+		c.subtract(c.A, c.B, 0)
+	case "DAA":
+		c.decimalAdjust()
+
+	// Logical
+	case "AND":
+		*acc = c.updateFlags(*acc & read())
+	case "BIT":
+		c.updateFlags(*acc & read())
+	case "EOR":
+		*acc = c.updateFlags(*acc ^ read())
+	case "ORA":
+		*acc = c.updateFlags(*acc | read())
+
+	// Negate, complement, increment, decrement, test and clear
+	case "NEG":
+		write(c.subtract(0, read(), 0))
+	case "COM":
+		write(c.updateFlags(^read()))
+		c.Status.Carry = true
+	case "INC":
+		value := read()
+		c.Status.Overflow = value == 0x7F
+		write(c.setNZ(value + 1))
+	case "DEC":
+		value := read()
+		c.Status.Overflow = value == 0x80
+		write(c.setNZ(value - 1))
+	case "TST":
+		c.updateFlags(read())
+		c.Status.Carry = false
+	case "CLR":
+		write(c.updateFlags(0))
+		c.Status.Carry = false
+
+	// Shifts and rotates
+	case "ASL":
+		value := read()
+		write(c.shift(value<<1, value&0x80 != 0))
+	case "ASR":
+		value := read()
+		write(c.shift(value>>1|value&0x80, value&0x01 != 0))
+	case "LSR":
+		value := read()
+		write(c.shift(value>>1, value&0x01 != 0))
+	case "ROL":
+		value := read()
+		write(c.shift(value<<1|carry, value&0x80 != 0))
+	case "ROR":
+		value := read()
+		write(c.shift(value>>1|carry<<7, value&0x01 != 0))
+
+	// Index register and stack pointer
+	case "LDX":
+		c.X = read16()
+		c.updateFlags16(c.X)
+	case "LDS":
+		c.SP = read16()
+		c.updateFlags16(c.SP)
+	case "STX":
+		write16(c.X)
+	case "STS":
+		write16(c.SP)
+	case "CPX":
+		c.compareIndex(read16())
+	case "INX":
+		c.X++
+		c.Status.Zero = c.X == 0
+	case "DEX":
+		c.X--
+		c.Status.Zero = c.X == 0
+	case "INS":
+		c.SP++
+	case "DES":
+		c.SP--
+	case "TSX":
+		c.X = c.SP + 1
+	case "TXS":
+		c.SP = c.X - 1
+	case "PSH":
+		c.Push(*acc)
+	case "PUL":
+		*acc = c.Pull()
+
+	// Jumps and branches
+	case "JMP":
+		c.PC = addr
+	case "JSR", "BSR":
+		c.Push16(c.PC)
+		c.PC = addr
+	case "RTS":
+		c.PC = c.Pull16()
+	case "BRA", "BHI", "BLS", "BCC", "BCS", "BNE", "BEQ", "BVC", "BVS", "BPL", "BMI", "BGE", "BLT", "BGT", "BLE":
+		if c.condition(operation) {
+			c.PC = addr
+		}
+
+	// Condition codes
+	case "TAP":
+		c.SetCCR(c.A)
+	case "TPA":
+		c.A = c.GetCCR()
+	case "CLC":
+		c.Status.Carry = false
+	case "SEC":
+		c.Status.Carry = true
+	case "CLV":
+		c.Status.Overflow = false
+	case "SEV":
+		c.Status.Overflow = true
+	case "CLI":
+		c.Status.InterruptMask = false
+	case "SEI":
+		c.Status.InterruptMask = true
+
+	// Interrupts and system
+	case "SWI":
+		c.pushRegisters()
+		c.Status.InterruptMask = true
+		c.PC = c.vector(Motorola6800SWIVector)
+	case "RTI":
+		c.SetCCR(c.Pull())
+		c.B = c.Pull()
+		c.A = c.Pull()
+		c.X = c.Pull16()
+		c.PC = c.Pull16()
+	case "WAI":
+		c.pushRegisters()
+		c.waiting = true
+		return ErrHalted
+	case "NOP":
+	case "HLT":
+		return ErrHalted
+	default:
+		return fmt.Errorf("instruction %s not implemented", instruction.Mnemonic)
+	}
+	return nil
+}
+
+// SetPin sets the level of an input pin: IRQ or NMI. IRQ is taken while it
+// is high and the interrupt mask is clear; NMI on a rising edge. Interrupts
+// are taken before the next instruction.
+func (c *Motorola6800) SetPin(name string, high bool) error {
+	switch name {
+	case "IRQ":
+		c.irqLine = high
+	case "NMI":
+		if high && !c.nmiLine {
+			c.nmiPending = true
+		}
+		c.nmiLine = high
+	default:
+		return fmt.Errorf("the 6800 has no input pin %s (available: IRQ, NMI)", name)
+	}
+	return nil
+}
+
+// InterruptPending reports whether an interrupt will be taken before the next
+// instruction, which also ends WAI
+func (c *Motorola6800) InterruptPending() bool {
+	return c.nmiPending || c.irqLine && !c.Status.InterruptMask
+}
+
+// interrupt takes a pending interrupt, NMI before IRQ. It pushes the
+// registers, unless WAI already did, sets the interrupt mask and jumps
+// through the vector.
+func (c *Motorola6800) interrupt() {
+	if !c.InterruptPending() {
+		return
+	}
+	vector := uint16(Motorola6800IRQVector)
+	if c.nmiPending {
+		c.nmiPending = false
+		vector = Motorola6800NMIVector
+	}
+	if c.waiting {
+		c.waiting = false
+		c.WaitForCycles(4)
+	} else {
+		c.pushRegisters()
+		c.WaitForCycles(12)
+	}
+	c.Status.InterruptMask = true
+	c.PC = c.vector(vector)
+}
+
+// pushRegisters saves the PC, X, A, B and the CCR for RTI, as SWI, WAI and interrupts do
+func (c *Motorola6800) pushRegisters() {
+	c.Push16(c.PC)
+	c.Push16(c.X)
+	c.Push(c.A)
+	c.Push(c.B)
+	c.Push(c.GetCCR())
+}
+
+// vector reads an interrupt vector, high byte first
+func (c *Motorola6800) vector(addr uint16) uint16 {
+	return uint16(c.Read(addr))<<8 | uint16(c.Read(addr+1))
+}
+
+// condition tests the condition of a branch
+func (c *Motorola6800) condition(branch string) bool {
+	f := &c.Status
+	switch branch {
+	case "BHI":
+		return !f.Carry && !f.Zero
+	case "BLS":
+		return f.Carry || f.Zero
+	case "BCC":
+		return !f.Carry
+	case "BCS":
+		return f.Carry
+	case "BNE":
+		return !f.Zero
+	case "BEQ":
+		return f.Zero
+	case "BVC":
+		return !f.Overflow
+	case "BVS":
+		return f.Overflow
+	case "BPL":
+		return !f.Negative
+	case "BMI":
+		return f.Negative
+	case "BGE":
+		return f.Negative == f.Overflow
+	case "BLT":
+		return f.Negative != f.Overflow
+	case "BGT":
+		return !f.Zero && f.Negative == f.Overflow
+	case "BLE":
+		return f.Zero || f.Negative != f.Overflow
+	}
+	return true // BRA
+}
+
+// add returns a + value + carry and sets H, N, Z, V and C
+func (c *Motorola6800) add(a, value, carry byte) byte {
+	sum := uint16(a) + uint16(value) + uint16(carry)
+	result := byte(sum)
+	c.Status.HalfCarry = a&0x0F+value&0x0F+carry > 0x0F
+	c.Status.Overflow = (a^result)&(value^result)&0x80 != 0
+	c.Status.Carry = sum > 0xFF
+	return c.setNZ(result)
+}
+
+// subtract returns a - value - borrow and sets N, Z, V and C, the borrow
+func (c *Motorola6800) subtract(a, value, borrow byte) byte {
+	result := a - value - borrow
+	c.Status.Overflow = (a^value)&(a^result)&0x80 != 0
+	c.Status.Carry = uint16(a) < uint16(value)+uint16(borrow)
+	return c.setNZ(result)
+}
+
+// compareIndex compares X with a 16-bit value for CPX. Z comes from all 16
+// bits, but N and V only from subtracting the high bytes, as on the 6800;
+// C is not changed.
+func (c *Motorola6800) compareIndex(value uint16) {
+	high, valueHigh := byte(c.X>>8), byte(value>>8)
+	result := high - valueHigh
+	c.Status.Zero = c.X == value
+	c.Status.Negative = result&0x80 != 0
+	c.Status.Overflow = (high^valueHigh)&(high^result)&0x80 != 0
+}
+
+// decimalAdjust corrects accumulator A after adding two BCD numbers (DAA)
+func (c *Motorola6800) decimalAdjust() {
+	var correction uint16
+	low, high := c.A&0x0F, c.A&0xF0
+	if c.Status.HalfCarry || low > 0x09 {
+		correction |= 0x06
+	}
+	if c.Status.Carry || high > 0x90 || (high > 0x80 && low > 0x09) {
+		correction |= 0x60
+	}
+	sum := uint16(c.A) + correction
+	c.Status.Carry = c.Status.Carry || sum > 0xFF
+	c.A = c.updateFlags(byte(sum))
+}
+
+// shift sets the flags after a shift or rotate: C is the bit shifted out
+// and V is N exclusive-ORed with C
+func (c *Motorola6800) shift(result byte, carry bool) byte {
+	c.setNZ(result)
+	c.Status.Carry = carry
+	c.Status.Overflow = c.Status.Negative != carry
+	return result
+}
+
+// updateFlags sets N and Z from a result and clears V, as loads and logical
+// operations do, and returns the result
+func (c *Motorola6800) updateFlags(result byte) byte {
+	c.Status.Overflow = false
+	return c.setNZ(result)
+}
+
+// updateFlags16 sets N and Z from a 16-bit value and clears V
+func (c *Motorola6800) updateFlags16(value uint16) {
+	c.Status.Negative = value&0x8000 != 0
+	c.Status.Zero = value == 0
+	c.Status.Overflow = false
+}
+
+// setNZ sets N and Z from a result and returns it
+func (c *Motorola6800) setNZ(result byte) byte {
+	c.Status.Negative = result&0x80 != 0
+	c.Status.Zero = result == 0
+	return result
+}
@@ -0,0 +1,245 @@
+package cpu
+
+var Motorola6800Instructions = map[byte]Instruction{
+
+	// Load, Store and Transfer Instructions
+	// A and B are written in the mnemonic (LDAA, STAB). Loads, stores and transfers
+	// set N and Z and clear V.
+
+	0x16: {0x16, "TAB", Implied, 1, 2, "Transfer accumulator A to accumulator B"},
+	0x17: {0x17, "TBA", Implied, 1, 2, "Transfer accumulator B to accumulator A"},
+	0x86: {0x86, "LDAA", Immediate, 2, 2, "Load accumulator A from memory (immediate)"},
+	0x96: {0x96, "LDAA", ZeroPage, 2, 3, "Load accumulator A from memory (direct)"},
+	0x97: {0x97, "STAA", ZeroPage, 2, 4, "Store accumulator A in memory (direct)"},
+	0xA6: {0xA6, "LDAA", IndexedOffset, 2, 5, "Load accumulator A from memory (indexed)"},
+	0xA7: {0xA7, "STAA", IndexedOffset, 2, 6, "Store accumulator A in memory (indexed)"},
+	0xB6: {0xB6, "LDAA", Absolute, 3, 4, "Load accumulator A from memory (extended)"},
+	0xB7: {0xB7, "STAA", Absolute, 3, 5, "Store accumulator A in memory (extended)"},
+	0xC6: {0xC6, "LDAB", Immediate, 2, 2, "Load accumulator B from memory (immediate)"},
+	0xD6: {0xD6, "LDAB", ZeroPage, 2, 3, "Load accumulator B from memory (direct)"},
+	0xD7: {0xD7, "STAB", ZeroPage, 2, 4, "Store accumulator B in memory (direct)"},
+	0xE6: {0xE6, "LDAB", IndexedOffset, 2, 5, "Load accumulator B from memory (indexed)"},
+	0xE7: {0xE7, "STAB", IndexedOffset, 2, 6, "Store accumulator B in memory (indexed)"},
+	0xF6: {0xF6, "LDAB", Absolute, 3, 4, "Load accumulator B from memory (extended)"},
+	0xF7: {0xF7, "STAB", Absolute, 3, 5, "Store accumulator B in memory (extended)"},
+
+	// Arithmetic Instructions
+	// They set N, Z, V and C; additions also set H, the carry out of bit 3, for DAA.
+	// C is the borrow after subtractions and compares.
+
+	0x10: {0x10, "SBA", Implied, 1, 2, "Subtract accumulator B from accumulator A"},
+	0x11: {0x11, "CBA", Implied, 1, 2, "Compare accumulator A with accumulator B"},
+	0x19: {0x19, "DAA", Implied, 1, 2, "Decimal adjust accumulator A"},
+	0x1B: {0x1B, "ABA", Implied, 1, 2, "Add accumulator B to accumulator A"},
+	0x80: {0x80, "SUBA", Immediate, 2, 2, "Subtract memory from accumulator A (immediate)"},
+	0x81: {0x81, "CMPA", Immediate, 2, 2, "Compare memory with accumulator A (immediate)"},
+	0x82: {0x82, "SBCA", Immediate, 2, 2, "Subtract memory and the carry from accumulator A (immediate)"},
+	0x89: {0x89, "ADCA", Immediate, 2, 2, "Add memory and the carry to accumulator A (immediate)"},
+	0x8B: {0x8B, "ADDA", Immediate, 2, 2, "Add memory to accumulator A (immediate)"},
+	0x90: {0x90, "SUBA", ZeroPage, 2, 3, "Subtract memory from accumulator A (direct)"},
+	0x91: {0x91, "CMPA", ZeroPage, 2, 3, "Compare memory with accumulator A (direct)"},
+	0x92: {0x92, "SBCA", ZeroPage, 2, 3, "Subtract memory and the carry from accumulator A (direct)"},
+	0x99: {0x99, "ADCA", ZeroPage, 2, 3, "Add memory and the carry to accumulator A (direct)"},
+	0x9B: {0x9B, "ADDA", ZeroPage, 2, 3, "Add memory to accumulator A (direct)"},
+	0xA0: {0xA0, "SUBA", IndexedOffset, 2, 5, "Subtract memory from accumulator A (indexed)"},
+	0xA1: {0xA1, "CMPA", IndexedOffset, 2, 5, "Compare memory with accumulator A (indexed)"},
+	0xA2: {0xA2, "SBCA", IndexedOffset, 2, 5, "Subtract memory and the carry from accumulator A (indexed)"},
+	0xA9: {0xA9, "ADCA", IndexedOffset, 2, 5, "Add memory and the carry to accumulator A (indexed)"},
+	0xAB: {0xAB, "ADDA", IndexedOffset, 2, 5, "Add memory to accumulator A (indexed)"},
+	0xB0: {0xB0, "SUBA", Absolute, 3, 4, "Subtract memory from accumulator A (extended)"},
+	0xB1: {0xB1, "CMPA", Absolute, 3, 4, "Compare memory with accumulator A (extended)"},
+	0xB2: {0xB2, "SBCA", Absolute, 3, 4, "Subtract memory and the carry from accumulator A (extended)"},
+	0xB9: {0xB9, "ADCA", Absolute, 3, 4, "Add memory and the carry to accumulator A (extended)"},
+	0xBB: {0xBB, "ADDA", Absolute, 3, 4, "Add memory to accumulator A (extended)"},
+	0xC0: {0xC0, "SUBB", Immediate, 2, 2, "Subtract memory from accumulator B (immediate)"},
+	0xC1: {0xC1, "CMPB", Immediate, 2, 2, "Compare memory with accumulator B (immediate)"},
+	0xC2: {0xC2, "SBCB", Immediate, 2, 2, "Subtract memory and the carry from accumulator B (immediate)"},
+	0xC9: {0xC9, "ADCB", Immediate, 2, 2, "Add memory and the carry to accumulator B (immediate)"},
+	0xCB: {0xCB, "ADDB", Immediate, 2, 2, "Add memory to accumulator B (immediate)"},
+	0xD0: {0xD0, "SUBB", ZeroPage, 2, 3, "Subtract memory from accumulator B (direct)"},
+	0xD1: {0xD1, "CMPB", ZeroPage, 2, 3, "Compare memory with accumulator B (direct)"},
+	0xD2: {0xD2, "SBCB", ZeroPage, 2, 3, "Subtract memory and the carry from accumulator B (direct)"},
+	0xD9: {0xD9, "ADCB", ZeroPage, 2, 3, "Add memory and the carry to accumulator B (direct)"},
+	0xDB: {0xDB, "ADDB", ZeroPage, 2, 3, "Add memory to accumulator B (direct)"},
+	0xE0: {0xE0, "SUBB", IndexedOffset, 2, 5, "Subtract memory from accumulator B (indexed)"},
+	0xE1: {0xE1, "CMPB", IndexedOffset, 2, 5, "Compare memory with accumulator B (indexed)"},
+	0xE2: {0xE2, "SBCB", IndexedOffset, 2, 5, "Subtract memory and the carry from accumulator B (indexed)"},
+	0xE9: {0xE9, "ADCB", IndexedOffset, 2, 5, "Add memory and the carry to accumulator B (indexed)"},
+	0xEB: {0xEB, "ADDB", IndexedOffset, 2, 5, "Add memory to accumulator B (indexed)"},
+	0xF0: {0xF0, "SUBB", Absolute, 3, 4, "Subtract memory from accumulator B (extended)"},
+	0xF1: {0xF1, "CMPB", Absolute, 3, 4, "Compare memory with accumulator B (extended)"},
+	0xF2: {0xF2, "SBCB", Absolute, 3, 4, "Subtract memory and the carry from accumulator B (extended)"},
+	0xF9: {0xF9, "ADCB", Absolute, 3, 4, "Add memory and the carry to accumulator B (extended)"},
+	0xFB: {0xFB, "ADDB", Absolute, 3, 4, "Add memory to accumulator B (extended)"},
+
+	// Logical Instructions
+	// They set N and Z and clear V.
+
+	0x84: {0x84, "ANDA", Immediate, 2, 2, "AND memory with accumulator A (immediate)"},
+	0x85: {0x85, "BITA", Immediate, 2, 2, "Test the bits of accumulator A ANDed with memory (immediate)"},
+	0x88: {0x88, "EORA", Immediate, 2, 2, "Exclusive OR memory with accumulator A (immediate)"},
+	0x8A: {0x8A, "ORAA", Immediate, 2, 2, "OR memory with accumulator A (immediate)"},
+	0x94: {0x94, "ANDA", ZeroPage, 2, 3, "AND memory with accumulator A (direct)"},
+	0x95: {0x95, "BITA", ZeroPage, 2, 3, "Test the bits of accumulator A ANDed with memory (direct)"},
+	0x98: {0x98, "EORA", ZeroPage, 2, 3, "Exclusive OR memory with accumulator A (direct)"},
+	0x9A: {0x9A, "ORAA", ZeroPage, 2, 3, "OR memory with accumulator A (direct)"},
+	0xA4: {0xA4, "ANDA", IndexedOffset, 2, 5, "AND memory with accumulator A (indexed)"},
+	0xA5: {0xA5, "BITA", IndexedOffset, 2, 5, "Test the bits of accumulator A ANDed with memory (indexed)"},
+	0xA8: {0xA8, "EORA", IndexedOffset, 2, 5, "Exclusive OR memory with accumulator A (indexed)"},
+	0xAA: {0xAA, "ORAA", IndexedOffset, 2, 5, "OR memory with accumulator A (indexed)"},
+	0xB4: {0xB4, "ANDA", Absolute, 3, 4, "AND memory with accumulator A (extended)"},
+	0xB5: {0xB5, "BITA", Absolute, 3, 4, "Test the bits of accumulator A ANDed with memory (extended)"},
+	0xB8: {0xB8, "EORA", Absolute, 3, 4, "Exclusive OR memory with accumulator A (extended)"},
+	0xBA: {0xBA, "ORAA", Absolute, 3, 4, "OR memory with accumulator A (extended)"},
+	0xC4: {0xC4, "ANDB", Immediate, 2, 2, "AND memory with accumulator B (immediate)"},
+	0xC5: {0xC5, "BITB", Immediate, 2, 2, "Test the bits of accumulator B ANDed with memory (immediate)"},
+	0xC8: {0xC8, "EORB", Immediate, 2, 2, "Exclusive OR memory with accumulator B (immediate)"},
+	0xCA: {0xCA, "ORAB", Immediate, 2, 2, "OR memory with accumulator B (immediate)"},
+	0xD4: {0xD4, "ANDB", ZeroPage, 2, 3, "AND memory with accumulator B (direct)"},
+	0xD5: {0xD5, "BITB", ZeroPage, 2, 3, "Test the bits of accumulator B ANDed with memory (direct)"},
+	0xD8: {0xD8, "EORB", ZeroPage, 2, 3, "Exclusive OR memory with accumulator B (direct)"},
+	0xDA: {0xDA, "ORAB", ZeroPage, 2, 3, "OR memory with accumulator B (direct)"},
+	0xE4: {0xE4, "ANDB", IndexedOffset, 2, 5, "AND memory with accumulator B (indexed)"},
+	0xE5: {0xE5, "BITB", IndexedOffset, 2, 5, "Test the bits of accumulator B ANDed with memory (indexed)"},
+	0xE8: {0xE8, "EORB", IndexedOffset, 2, 5, "Exclusive OR memory with accumulator B (indexed)"},
+	0xEA: {0xEA, "ORAB", IndexedOffset, 2, 5, "OR memory with accumulator B (indexed)"},
+	0xF4: {0xF4, "ANDB", Absolute, 3, 4, "AND memory with accumulator B (extended)"},
+	0xF5: {0xF5, "BITB", Absolute, 3, 4, "Test the bits of accumulator B ANDed with memory (extended)"},
+	0xF8: {0xF8, "EORB", Absolute, 3, 4, "Exclusive OR memory with accumulator B (extended)"},
+	0xFA: {0xFA, "ORAB", Absolute, 3, 4, "OR memory with accumulator B (extended)"},
+
+	// Negate, Complement, Increment, Decrement, Test and Clear
+	// The accumulator forms name the accumulator (NEGA, CLRB); the others work on memory.
+	// INC and DEC set V on signed overflow and leave C alone.
+
+	0x40: {0x40, "NEGA", Implied, 1, 2, "Negate accumulator A"},
+	0x43: {0x43, "COMA", Implied, 1, 2, "Complement accumulator A"},
+	0x4A: {0x4A, "DECA", Implied, 1, 2, "Decrement accumulator A"},
+	0x4C: {0x4C, "INCA", Implied, 1, 2, "Increment accumulator A"},
+	0x4D: {0x4D, "TSTA", Implied, 1, 2, "Test accumulator A for zero or minus"},
+	0x4F: {0x4F, "CLRA", Implied, 1, 2, "Clear accumulator A"},
+	0x50: {0x50, "NEGB", Implied, 1, 2, "Negate accumulator B"},
+	0x53: {0x53, "COMB", Implied, 1, 2, "Complement accumulator B"},
+	0x5A: {0x5A, "DECB", Implied, 1, 2, "Decrement accumulator B"},
+	0x5C: {0x5C, "INCB", Implied, 1, 2, "Increment accumulator B"},
+	0x5D: {0x5D, "TSTB", Implied, 1, 2, "Test accumulator B for zero or minus"},
+	0x5F: {0x5F, "CLRB", Implied, 1, 2, "Clear accumulator B"},
+	0x60: {0x60, "NEG", IndexedOffset, 2, 7, "Negate memory (indexed)"},
+	0x63: {0x63, "COM", IndexedOffset, 2, 7, "Complement memory (indexed)"},
+	0x6A: {0x6A, "DEC", IndexedOffset, 2, 7, "Decrement memory (indexed)"},
+	0x6C: {0x6C, "INC", IndexedOffset, 2, 7, "Increment memory (indexed)"},
+	0x6D: {0x6D, "TST", IndexedOffset, 2, 7, "Test memory for zero or minus (indexed)"},
+	0x6F: {0x6F, "CLR", IndexedOffset, 2, 7, "Clear memory (indexed)"},
+	0x70: {0x70, "NEG", Absolute, 3, 6, "Negate memory (extended)"},
+	0x73: {0x73, "COM", Absolute, 3, 6, "Complement memory (extended)"},
+	0x7A: {0x7A, "DEC", Absolute, 3, 6, "Decrement memory (extended)"},
+	0x7C: {0x7C, "INC", Absolute, 3, 6, "Increment memory (extended)"},
+	0x7D: {0x7D, "TST", Absolute, 3, 6, "Test memory for zero or minus (extended)"},
+	0x7F: {0x7F, "CLR", Absolute, 3, 6, "Clear memory (extended)"},
+
+	// Shift and Rotate Instructions
+	// C receives the bit shifted out and V is N exclusive-ORed with C.
+
+	0x44: {0x44, "LSRA", Implied, 1, 2, "Shift accumulator A right one bit"},
+	0x46: {0x46, "RORA", Implied, 1, 2, "Rotate accumulator A right through the carry"},
+	0x47: {0x47, "ASRA", Implied, 1, 2, "Shift accumulator A right one bit, keeping the sign"},
+	0x48: {0x48, "ASLA", Implied, 1, 2, "Shift accumulator A left one bit"},
+	0x49: {0x49, "ROLA", Implied, 1, 2, "Rotate accumulator A left through the carry"},
+	0x54: {0x54, "LSRB", Implied, 1, 2, "Shift accumulator B right one bit"},
+	0x56: {0x56, "RORB", Implied, 1, 2, "Rotate accumulator B right through the carry"},
+	0x57: {0x57, "ASRB", Implied, 1, 2, "Shift accumulator B right one bit, keeping the sign"},
+	0x58: {0x58, "ASLB", Implied, 1, 2, "Shift accumulator B left one bit"},
+	0x59: {0x59, "ROLB", Implied, 1, 2, "Rotate accumulator B left through the carry"},
+	0x64: {0x64, "LSR", IndexedOffset, 2, 7, "Shift memory right one bit (indexed)"},
+	0x66: {0x66, "ROR", IndexedOffset, 2, 7, "Rotate memory right through the carry (indexed)"},
+	0x67: {0x67, "ASR", IndexedOffset, 2, 7, "Shift memory right one bit, keeping the sign (indexed)"},
+	0x68: {0x68, "ASL", IndexedOffset, 2, 7, "Shift memory left one bit (indexed)"},
+	0x69: {0x69, "ROL", IndexedOffset, 2, 7, "Rotate memory left through the carry (indexed)"},
+	0x74: {0x74, "LSR", Absolute, 3, 6, "Shift memory right one bit (extended)"},
+	0x76: {0x76, "ROR", Absolute, 3, 6, "Rotate memory right through the carry (extended)"},
+	0x77: {0x77, "ASR", Absolute, 3, 6, "Shift memory right one bit, keeping the sign (extended)"},
+	0x78: {0x78, "ASL", Absolute, 3, 6, "Shift memory left one bit (extended)"},
+	0x79: {0x79, "ROL", Absolute, 3, 6, "Rotate memory left through the carry (extended)"},
+
+	// Index Register and Stack Pointer Instructions
+	// X and SP are 16 bits. CPX, LDX and LDS set N and Z from all 16 bits; INX and
+	// DEX set only Z. The stack grows down and SP points at the next free byte.
+
+	0x08: {0x08, "INX", Implied, 1, 4, "Increment the index register"},
+	0x09: {0x09, "DEX", Implied, 1, 4, "Decrement the index register"},
+	0x30: {0x30, "TSX", Implied, 1, 4, "Transfer the stack pointer plus one to the index register"},
+	0x31: {0x31, "INS", Implied, 1, 4, "Increment the stack pointer"},
+	0x32: {0x32, "PULA", Implied, 1, 4, "Pull accumulator A from the stack"},
+	0x33: {0x33, "PULB", Implied, 1, 4, "Pull accumulator B from the stack"},
+	0x34: {0x34, "DES", Implied, 1, 4, "Decrement the stack pointer"},
+	0x35: {0x35, "TXS", Implied, 1, 4, "Transfer the index register minus one to the stack pointer"},
+	0x36: {0x36, "PSHA", Implied, 1, 4, "Push accumulator A onto the stack"},
+	0x37: {0x37, "PSHB", Implied, 1, 4, "Push accumulator B onto the stack"},
+	0x8C: {0x8C, "CPX", Immediate16, 3, 3, "Compare the index register with memory (immediate)"},
+	0x8E: {0x8E, "LDS", Immediate16, 3, 3, "Load the stack pointer from memory (immediate)"},
+	0x9C: {0x9C, "CPX", ZeroPage, 2, 4, "Compare the index register with memory (direct)"},
+	0x9E: {0x9E, "LDS", ZeroPage, 2, 4, "Load the stack pointer from memory (direct)"},
+	0x9F: {0x9F, "STS", ZeroPage, 2, 5, "Store the stack pointer in memory (direct)"},
+	0xAC: {0xAC, "CPX", IndexedOffset, 2, 6, "Compare the index register with memory (indexed)"},
+	0xAE: {0xAE, "LDS", IndexedOffset, 2, 6, "Load the stack pointer from memory (indexed)"},
+	0xAF: {0xAF, "STS", IndexedOffset, 2, 7, "Store the stack pointer in memory (indexed)"},
+	0xBC: {0xBC, "CPX", Absolute, 3, 5, "Compare the index register with memory (extended)"},
+	0xBE: {0xBE, "LDS", Absolute, 3, 5, "Load the stack pointer from memory (extended)"},
+	0xBF: {0xBF, "STS", Absolute, 3, 6, "Store the stack pointer in memory (extended)"},
+	0xCE: {0xCE, "LDX", Immediate16, 3, 3, "Load the index register from memory (immediate)"},
+	0xDE: {0xDE, "LDX", ZeroPage, 2, 4, "Load the index register from memory (direct)"},
+	0xDF: {0xDF, "STX", ZeroPage, 2, 5, "Store the index register in memory (direct)"},
+	0xEE: {0xEE, "LDX", IndexedOffset, 2, 6, "Load the index register from memory (indexed)"},
+	0xEF: {0xEF, "STX", IndexedOffset, 2, 7, "Store the index register in memory (indexed)"},
+	0xFE: {0xFE, "LDX", Absolute, 3, 5, "Load the index register from memory (extended)"},
+	0xFF: {0xFF, "STX", Absolute, 3, 6, "Store the index register in memory (extended)"},
+
+	// Jump and Branch Instructions
+	// Branch offsets count from the address after the branch. BHI and BLS are the
+	// unsigned comparisons, BGT, BGE, BLT and BLE the signed ones.
+
+	0x20: {0x20, "BRA", Relative, 2, 4, "Branch always"},
+	0x22: {0x22, "BHI", Relative, 2, 4, "Branch if higher (C and Z clear)"},
+	0x23: {0x23, "BLS", Relative, 2, 4, "Branch if lower or same (C or Z set)"},
+	0x24: {0x24, "BCC", Relative, 2, 4, "Branch if carry clear"},
+	0x25: {0x25, "BCS", Relative, 2, 4, "Branch if carry set"},
+	0x26: {0x26, "BNE", Relative, 2, 4, "Branch if not equal (Z clear)"},
+	0x27: {0x27, "BEQ", Relative, 2, 4, "Branch if equal (Z set)"},
+	0x28: {0x28, "BVC", Relative, 2, 4, "Branch if overflow clear"},
+	0x29: {0x29, "BVS", Relative, 2, 4, "Branch if overflow set"},
+	0x2A: {0x2A, "BPL", Relative, 2, 4, "Branch if plus (N clear)"},
+	0x2B: {0x2B, "BMI", Relative, 2, 4, "Branch if minus (N set)"},
+	0x2C: {0x2C, "BGE", Relative, 2, 4, "Branch if greater or equal (N xor V clear)"},
+	0x2D: {0x2D, "BLT", Relative, 2, 4, "Branch if less than (N xor V set)"},
+	0x2E: {0x2E, "BGT", Relative, 2, 4, "Branch if greater than (Z clear and N xor V clear)"},
+	0x2F: {0x2F, "BLE", Relative, 2, 4, "Branch if less or equal (Z set or N xor V set)"},
+	0x39: {0x39, "RTS", Implied, 1, 5, "Return from subroutine"},
+	0x6E: {0x6E, "JMP", IndexedOffset, 2, 4, "Jump to the address (indexed)"},
+	0x7E: {0x7E, "JMP", Absolute, 3, 3, "Jump to the address (extended)"},
+	0x8D: {0x8D, "BSR", Relative, 2, 8, "Branch to subroutine"},
+	0xAD: {0xAD, "JSR", IndexedOffset, 2, 8, "Jump to the subroutine at the address (indexed)"},
+	0xBD: {0xBD, "JSR", Absolute, 3, 9, "Jump to the subroutine at the address (extended)"},
+
+	// Condition Code Register Instructions
+	// The CCR is 11HINZVC: half carry, interrupt mask, negative, zero, overflow and carry.
+
+	0x06: {0x06, "TAP", Implied, 1, 2, "Transfer accumulator A to the condition code register"},
+	0x07: {0x07, "TPA", Implied, 1, 2, "Transfer the condition code register to accumulator A"},
+	0x0A: {0x0A, "CLV", Implied, 1, 2, "Clear the overflow flag"},
+	0x0B: {0x0B, "SEV", Implied, 1, 2, "Set the overflow flag"},
+	0x0C: {0x0C, "CLC", Implied, 1, 2, "Clear the carry flag"},
+	0x0D: {0x0D, "SEC", Implied, 1, 2, "Set the carry flag"},
+	0x0E: {0x0E, "CLI", Implied, 1, 2, "Clear the interrupt mask, enabling IRQ"},
+	0x0F: {0x0F, "SEI", Implied, 1, 2, "Set the interrupt mask, disabling IRQ"},
+
+	// Interrupt and System Instructions
+	// SWI, IRQ and NMI push PC, X, A, B and the CCR, set I and jump through their
+	// vectors. HLT is the undocumented HCF opcode $9D, which locks up the processor;
+	// the emulator stops there and at WAI.
+
+	0x01: {0x01, "NOP", Implied, 1, 2, "No operation"},
+	0x3B: {0x3B, "RTI", Implied, 1, 10, "Return from interrupt, pulling all registers"},
+	0x3E: {0x3E, "WAI", Implied, 1, 9, "Push all registers and wait for an interrupt"},
+	0x3F: {0x3F, "SWI", Implied, 1, 12, "Software interrupt through the vector at $FFFA"},
+	0x9D: {0x9D, "HLT", Implied, 1, 1, "Halt the processor (undocumented HCF opcode)"},
+}
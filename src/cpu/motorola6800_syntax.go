@@ -0,0 +1,15 @@
+package cpu
+
+// Motorola6800Syntax is the Motorola 6800 assembly language (LDAA #$1A, STAB $10,X, JMP $C000).
+// Like the 6502's, the addressing mode is written in the operand, so every
+// form is just the mnemonic; addresses are stored high byte first.
+var Motorola6800Syntax = &Syntax{
+	Name:            "6800",
+	Description:     "Motorola 6800 mnemonics (LDAA #$1A, STAB $10,X)",
+	Forms:           MnemonicSyntax("6800", Motorola6800Instructions).Forms,
+	ImmediatePrefix: "#",
+	BigEndian:       true,
+}
+
+// Motorola6800Syntaxes lists the dialects the 6800 can be written in
+var Motorola6800Syntaxes = []*Syntax{Motorola6800Syntax}
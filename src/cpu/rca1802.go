@@ -0,0 +1,479 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// RCA1802 represents the RCA CDP1802 (COSMAC) processor. It has no dedicated
+// program counter or stack pointer: P selects which of the sixteen 16-bit
+// registers is the program counter and X which one addresses memory for the
+// ALU and I/O instructions. The embedded PC mirrors R(P) between instructions.
+//
+// The 1802's signals are pins devices drive and watch: the flag inputs EF1-EF4
+// tested by the B1-B4 branches, the interrupt request INT, and the Q output set
+// by SEQ and REQ.
+type RCA1802 struct {
+	CPU
+	R       [16]uint16  // Scratchpad registers R0-R15
+	D       uint8       // Data register, the accumulator
+	DF      bool        // Data flag, the carry; set after a subtraction when there is no borrow
+	P       uint8       // Number of the program counter register
+	X       uint8       // Number of the index register
+	T       uint8       // X and P saved by an interrupt or MARK
+	IE      bool        // Interrupts enabled
+	Q       bool        // Q output
+	EF      [4]bool     // Levels of the flag inputs EF1-EF4
+	INT     bool        // Level of the interrupt request input
+	Outputs PinListener // Told when Q changes
+}
+
+// NewRCA1802 creates a new 1802 CPU instance in its reset state: P and X are
+// 0, so R0 is the program counter, and interrupts are enabled
+func NewRCA1802(memorySize int, speed uint) *RCA1802 {
+	c := &RCA1802{
+		CPU: *NewCPU("RCA1802", memorySize, speed, RCA1802Instructions),
+		IE:  true,
+	}
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// SetPin sets the level of an input pin: EF1-EF4 or INT. An interrupt is
+// taken before the next instruction while INT is high and IE is set.
+func (c *RCA1802) SetPin(name string, high bool) error {
+	switch name {
+	case "EF1", "EF2", "EF3", "EF4":
+		c.EF[name[2]-'1'] = high
+	case "INT":
+		c.INT = high
+	default:
+		return fmt.Errorf("the 1802 has no input pin %s (available: EF1, EF2, EF3, EF4, INT)", name)
+	}
+	return nil
+}
+
+// WatchPins sets the listener told when the program changes Q
+func (c *RCA1802) WatchPins(listener PinListener) {
+	c.Outputs = listener
+}
+
+// GetR returns register R(n); R(P) is the program counter
+func (c *RCA1802) GetR(n int) uint16 {
+	if n == int(c.P) {
+		return c.PC
+	}
+	return c.R[n]
+}
+
+// SetR sets register R(n); setting R(P) moves the program counter
+func (c *RCA1802) SetR(n int, value uint16) {
+	c.R[n] = value
+	if n == int(c.P) {
+		c.PC = value
+	}
+}
+
+// SetP selects the program counter register, as SEP does
+func (c *RCA1802) SetP(n uint8) {
+	c.R[c.P] = c.PC
+	c.P = n & 0x0F
+	c.PC = c.R[c.P]
+}
+
+// GetSP returns R2, the stack pointer by convention
+func (c *RCA1802) GetSP() uint16 {
+	return c.GetR(2)
+}
+
+// SetSP sets R2
+func (c *RCA1802) SetSP(value uint16) {
+	c.SetR(2, value)
+}
+
+// Push stores a byte at R2 and decrements it, as STXD does with X set to 2
+func (c *RCA1802) Push(value byte) {
+	c.store(c.GetR(2), value)
+	c.SetR(2, c.GetR(2)-1)
+}
+
+// Pull increments R2 and loads the byte it points to
+func (c *RCA1802) Pull() byte {
+	c.SetR(2, c.GetR(2)+1)
+	return c.load(c.GetR(2))
+}
+
+// Push16 pushes a 16-bit value, low byte first, so that it is stored high byte first
+func (c *RCA1802) Push16(value uint16) {
+	c.Push(byte(value))
+	c.Push(byte(value >> 8))
+}
+
+// Pull16 pulls a 16-bit value
+func (c *RCA1802) Pull16() uint16 {
+	high := uint16(c.Pull())
+	return high<<8 | uint16(c.Pull())
+}
+
+// DMAIn stores a byte a device transfers at R0 and increments R0, taking one machine cycle
+func (c *RCA1802) DMAIn(value byte) {
+	c.store(c.GetR(0), value)
+	c.SetR(0, c.GetR(0)+1)
+	c.AddCycles(8)
+}
+
+// DMAOut returns the byte at R0 for a device and increments R0, taking one machine cycle
+func (c *RCA1802) DMAOut() byte {
+	value := c.load(c.GetR(0))
+	c.SetR(0, c.GetR(0)+1)
+	c.AddCycles(8)
+	return value
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *RCA1802) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction takes a pending interrupt, if any, and executes a single
+// instruction. It returns ErrHalted after IDL and a FaultError for
+// instructions it cannot execute.
+func (c *RCA1802) ExecuteInstruction() (err error) {
+	c.interrupt()
+
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.SetR(int(c.P), pc)
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
+	// Get the opcode
+	opcode := c.Memory[c.PC]
+
+	// Get the instruction
+	instruction, ok := c.Instructions[opcode]
+	if !ok {
+		return &UnknownOpcodeError{Opcode: opcode, PC: c.PC}
+	}
+
+	// Only print verbose output if enabled
+	if c.IsVerbose() {
+		fmt.Printf("PC: %04X, OP: %02X, MN: %s, D:%02X P:%X X:%X R(X):%04X T:%02X | DF:%d IE:%d Q:%d\n",
+			c.PC, opcode, instruction.Mnemonic, c.D, c.P, c.X, c.GetR(int(c.X)), c.T,
+			boolToInt(c.DF), boolToInt(c.IE), boolToInt(c.Q))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
+	}
+
+	// Fetch the operand, high byte first. R(P) points past the instruction
+	// while it executes, and the PC follows R(P) afterwards.
+	var data byte
+	var word uint16
+	switch instruction.Size {
+	case 2:
+		data = c.Memory[c.PC+1]
+	case 3:
+		word = uint16(c.Memory[c.PC+1])<<8 | uint16(c.Memory[c.PC+2])
+	}
+	c.R[c.P] = c.PC + uint16(instruction.Size)
+	err = c.execute(opcode, data, word)
+	c.PC = c.R[c.P]
+
+	// Wait for the appropriate amount of time
+	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// registerFile lists the registers and flags behind the register API. R(P) is
+// read and written through the program counter, as in GetR and SetR.
+func (c *RCA1802) registerFile() ([]register, []flag) {
+	registers := []register{
+		byteRegister("D", &c.D),
+		{RegisterDesc{Name: "P", Bits: 4}, func() uint16 { return uint16(c.P) }, func(value uint16) { c.SetP(uint8(value)) }},
+		{RegisterDesc{Name: "X", Bits: 4}, func() uint16 { return uint16(c.X) }, func(value uint16) { c.X = uint8(value) }},
+		byteRegister("T", &c.T),
+	}
+	for n := range c.R {
+		n := n
+		registers = append(registers, register{
+			RegisterDesc: RegisterDesc{Name: fmt.Sprintf("R%d", n), Bits: 16},
+			get:          func() uint16 { return c.GetR(n) },
+			set:          func(value uint16) { c.SetR(n, value) },
+		})
+	}
+	flags := []flag{
+		boolFlag("DF", "Data flag, the carry", &c.DF), boolFlag("IE", "Interrupts enabled", &c.IE),
+		{FlagDesc{Name: "Q", Description: "Q output"}, func() bool { return c.Q }, c.setQ},
+	}
+	for n := range c.EF {
+		flags = append(flags, boolFlag(fmt.Sprintf("EF%d", n+1), fmt.Sprintf("Level of flag input %d", n+1), &c.EF[n]))
+	}
+	return registers, flags
+}
+
+// InterruptPending reports whether an interrupt will be taken before the next
+// instruction, which also ends IDL
+func (c *RCA1802) InterruptPending() bool {
+	return c.INT && c.IE
+}
+
+// interrupt takes an interrupt while INT is high and interrupts are enabled:
+// it saves X and P in T, disables interrupts and continues with P set to 1
+// and X to 2, so R1 holds the address of the interrupt routine
+func (c *RCA1802) interrupt() {
+	if !c.InterruptPending() {
+		return
+	}
+	c.T = c.X<<4 | c.P
+	c.IE = false
+	c.X = 2
+	c.SetP(1)
+	c.WaitForCycles(8)
+}
+
+// execute executes an instruction whose operand, if any, is data or word.
+// The low 4 bits of most opcodes select the register R(N) they work on.
+func (c *RCA1802) execute(opcode byte, data byte, word uint16) error {
+	n := opcode & 0x0F
+	rn := &c.R[n]
+	rx := &c.R[c.X]
+	rp := &c.R[c.P]
+	borrow := byte(1 - boolToInt(c.DF))
+
+	switch opcode >> 4 {
+	case 0x0:
+		if n == 0 { // IDL
+			return ErrHalted
+		}
+		c.D = c.load(*rn) // LDN
+		return nil
+	case 0x1: // INC
+		*rn++
+		return nil
+	case 0x2: // DEC
+		*rn--
+		return nil
+	case 0x3: // Short branches and SKP
+		if n == 0x8 { // SKP
+			*rp++
+		} else if c.branchCondition(n) {
+			// The operand byte, just before R(P), gives the page
+			*rp = (*rp-1)&0xFF00 | uint16(data)
+		}
+		return nil
+	case 0x4: // LDA
+		c.D = c.load(*rn)
+		*rn++
+		return nil
+	case 0x5: // STR
+		c.store(*rn, c.D)
+		return nil
+	case 0x6:
+		switch {
+		case n == 0: // IRX
+			*rx++
+		case n < 8: // OUT
+			c.Out(n, c.load(*rx))
+			*rx++
+		case n > 8: // INP
+			c.D = c.In(n - 8)
+			c.store(*rx, c.D)
+		default:
+			return fmt.Errorf("opcode %02X is undefined on the 1802", opcode)
+		}
+		return nil
+	case 0x8: // GLO
+		c.D = byte(*rn)
+		return nil
+	case 0x9: // GHI
+		c.D = byte(*rn >> 8)
+		return nil
+	case 0xA: // PLO
+		*rn = *rn&0xFF00 | uint16(c.D)
+		return nil
+	case 0xB: // PHI
+		*rn = *rn&0x00FF | uint16(c.D)<<8
+		return nil
+	case 0xC: // Long branches and skips
+		switch {
+		case n == 0x4: // NOP
+		case n < 0x4 || n > 0x8 && n < 0xC: // LBR, LBQ, LBZ, LBDF, LBNQ, LBNZ, LBNF
+			if c.branchCondition(n) {
+				*rp = word
+			}
+		default: // LSNQ, LSNZ, LSNF, LSKP, LSIE, LSQ, LSZ, LSDF
+			if c.skipCondition(n) {
+				*rp += 2
+			}
+		}
+		return nil
+	case 0xD: // SEP
+		c.P = n
+		return nil
+	case 0xE: // SEX
+		c.X = n
+		return nil
+	}
+
+	switch opcode {
+	case 0x70, 0x71: // RET, DIS
+		value := c.load(*rx)
+		*rx++
+		c.X, c.P = value>>4, value&0x0F
+		c.IE = opcode == 0x70
+	case 0x72: // LDXA
+		c.D = c.load(*rx)
+		*rx++
+	case 0x73: // STXD
+		c.store(*rx, c.D)
+		*rx--
+	case 0x78: // SAV
+		c.store(*rx, c.T)
+	case 0x79: // MARK
+		c.T = c.X<<4 | c.P
+		c.store(c.R[2], c.T)
+		c.X = c.P
+		c.R[2]--
+	case 0x7A: // REQ
+		c.setQ(false)
+	case 0x7B: // SEQ
+		c.setQ(true)
+	case 0xF0: // LDX
+		c.D = c.load(*rx)
+	case 0xF8: // LDI
+		c.D = data
+
+	// Logic on the byte R(X) points to or on immediate data
+	case 0xF1:
+		c.D |= c.load(*rx)
+	case 0xF9:
+		c.D |= data
+	case 0xF2:
+		c.D &= c.load(*rx)
+	case 0xFA:
+		c.D &= data
+	case 0xF3:
+		c.D ^= c.load(*rx)
+	case 0xFB:
+		c.D ^= data
+
+	// Arithmetic; DF is the carry, or no borrow after a subtraction
+	case 0xF4: // ADD
+		c.add(c.load(*rx), 0)
+	case 0xFC: // ADI
+		c.add(data, 0)
+	case 0x74: // ADC
+		c.add(c.load(*rx), byte(boolToInt(c.DF)))
+	case 0x7C: // ADCI
+		c.add(data, byte(boolToInt(c.DF)))
+	case 0xF5: // SD
+		c.D = c.subtract(c.load(*rx), c.D, 0)
+	case 0xFD: // SDI
+		c.D = c.subtract(data, c.D, 0)
+	case 0x75: // SDB
+		c.D = c.subtract(c.load(*rx), c.D, borrow)
+	case 0x7D: // SDBI
+		c.D = c.subtract(data, c.D, borrow)
+	case 0xF7: // SM
+		c.D = c.subtract(c.D, c.load(*rx), 0)
+	case 0xFF: // SMI
+		c.D = c.subtract(c.D, data, 0)
+	case 0x77: // SMB
+		c.D = c.subtract(c.D, c.load(*rx), borrow)
+	case 0x7F: // SMBI
+		c.D = c.subtract(c.D, data, borrow)
+
+	// Shifts
+	case 0xF6: // SHR
+		c.DF, c.D = c.D&0x01 != 0, c.D>>1
+	case 0x76: // SHRC
+		c.DF, c.D = c.D&0x01 != 0, c.D>>1|byte(boolToInt(c.DF))<<7
+	case 0xFE: // SHL
+		c.DF, c.D = c.D&0x80 != 0, c.D<<1
+	case 0x7E: // SHLC
+		c.DF, c.D = c.D&0x80 != 0, c.D<<1|byte(boolToInt(c.DF))
+	default:
+		return fmt.Errorf("instruction %02X not implemented", opcode)
+	}
+	return nil
+}
+
+// branchCondition tests the condition in the low 4 bits of a short or long
+// branch: bit 3 inverts the condition in bits 0-2 (always, Q, D zero, DF,
+// then EF1-EF4)
+func (c *RCA1802) branchCondition(n byte) bool {
+	var condition bool
+	switch n & 0x7 {
+	case 0:
+		condition = true
+	case 1:
+		condition = c.Q
+	case 2:
+		condition = c.D == 0
+	case 3:
+		condition = c.DF
+	default:
+		condition = c.EF[n&0x7-4]
+	}
+	return condition != (n&0x8 != 0)
+}
+
+// skipCondition tests the condition of a long skip. LSNQ, LSNZ and LSNF
+// (C5-C7) skip when Q, D zero and DF are false, LSQ, LSZ and LSDF (CD-CF)
+// when they are true, LSKP (C8) always and LSIE (CC) when interrupts are
+// enabled.
+func (c *RCA1802) skipCondition(n byte) bool {
+	switch n {
+	case 0x8:
+		return true
+	case 0xC:
+		return c.IE
+	}
+	return !c.branchCondition(n ^ 0x4)
+}
+
+// setQ sets the Q output and tells the pin listener when it changes
+func (c *RCA1802) setQ(level bool) {
+	if level != c.Q && c.Outputs != nil {
+		c.Outputs.PinChanged("Q", level, c.Cycles)
+	}
+	c.Q = level
+}
+
+// add sets D to D + value + carry and DF to the carry out
+func (c *RCA1802) add(value, carry byte) {
+	sum := uint16(c.D) + uint16(value) + uint16(carry)
+	c.D = byte(sum)
+	c.DF = sum > 0xFF
+}
+
+// subtract returns a - b - borrow and sets DF when there is no borrow
+func (c *RCA1802) subtract(a, b, borrow byte) byte {
+	c.DF = uint16(a) >= uint16(b)+uint16(borrow)
+	return a - b - borrow
+}
@@ -0,0 +1,287 @@
+package cpu
+
+var RCA1802Instructions = map[byte]Instruction{
+
+	// Register Instructions
+	// R0-R15 are 16-bit registers. D is the 8-bit accumulator and moves bytes in and out of them.
+
+	0x10: {0x10, "INC", Implied, 1, 16, "Increment register R0"},
+	0x11: {0x11, "INC", Implied, 1, 16, "Increment register R1"},
+	0x12: {0x12, "INC", Implied, 1, 16, "Increment register R2"},
+	0x13: {0x13, "INC", Implied, 1, 16, "Increment register R3"},
+	0x14: {0x14, "INC", Implied, 1, 16, "Increment register R4"},
+	0x15: {0x15, "INC", Implied, 1, 16, "Increment register R5"},
+	0x16: {0x16, "INC", Implied, 1, 16, "Increment register R6"},
+	0x17: {0x17, "INC", Implied, 1, 16, "Increment register R7"},
+	0x18: {0x18, "INC", Implied, 1, 16, "Increment register R8"},
+	0x19: {0x19, "INC", Implied, 1, 16, "Increment register R9"},
+	0x1A: {0x1A, "INC", Implied, 1, 16, "Increment register R10"},
+	0x1B: {0x1B, "INC", Implied, 1, 16, "Increment register R11"},
+	0x1C: {0x1C, "INC", Implied, 1, 16, "Increment register R12"},
+	0x1D: {0x1D, "INC", Implied, 1, 16, "Increment register R13"},
+	0x1E: {0x1E, "INC", Implied, 1, 16, "Increment register R14"},
+	0x1F: {0x1F, "INC", Implied, 1, 16, "Increment register R15"},
+	0x20: {0x20, "DEC", Implied, 1, 16, "Decrement register R0"},
+	0x21: {0x21, "DEC", Implied, 1, 16, "Decrement register R1"},
+	0x22: {0x22, "DEC", Implied, 1, 16, "Decrement register R2"},
+	0x23: {0x23, "DEC", Implied, 1, 16, "Decrement register R3"},
+	0x24: {0x24, "DEC", Implied, 1, 16, "Decrement register R4"},
+	0x25: {0x25, "DEC", Implied, 1, 16, "Decrement register R5"},
+	0x26: {0x26, "DEC", Implied, 1, 16, "Decrement register R6"},
+	0x27: {0x27, "DEC", Implied, 1, 16, "Decrement register R7"},
+	0x28: {0x28, "DEC", Implied, 1, 16, "Decrement register R8"},
+	0x29: {0x29, "DEC", Implied, 1, 16, "Decrement register R9"},
+	0x2A: {0x2A, "DEC", Implied, 1, 16, "Decrement register R10"},
+	0x2B: {0x2B, "DEC", Implied, 1, 16, "Decrement register R11"},
+	0x2C: {0x2C, "DEC", Implied, 1, 16, "Decrement register R12"},
+	0x2D: {0x2D, "DEC", Implied, 1, 16, "Decrement register R13"},
+	0x2E: {0x2E, "DEC", Implied, 1, 16, "Decrement register R14"},
+	0x2F: {0x2F, "DEC", Implied, 1, 16, "Decrement register R15"},
+	0x80: {0x80, "GLO", Implied, 1, 16, "Get the low byte of register R0 into D"},
+	0x81: {0x81, "GLO", Implied, 1, 16, "Get the low byte of register R1 into D"},
+	0x82: {0x82, "GLO", Implied, 1, 16, "Get the low byte of register R2 into D"},
+	0x83: {0x83, "GLO", Implied, 1, 16, "Get the low byte of register R3 into D"},
+	0x84: {0x84, "GLO", Implied, 1, 16, "Get the low byte of register R4 into D"},
+	0x85: {0x85, "GLO", Implied, 1, 16, "Get the low byte of register R5 into D"},
+	0x86: {0x86, "GLO", Implied, 1, 16, "Get the low byte of register R6 into D"},
+	0x87: {0x87, "GLO", Implied, 1, 16, "Get the low byte of register R7 into D"},
+	0x88: {0x88, "GLO", Implied, 1, 16, "Get the low byte of register R8 into D"},
+	0x89: {0x89, "GLO", Implied, 1, 16, "Get the low byte of register R9 into D"},
+	0x8A: {0x8A, "GLO", Implied, 1, 16, "Get the low byte of register R10 into D"},
+	0x8B: {0x8B, "GLO", Implied, 1, 16, "Get the low byte of register R11 into D"},
+	0x8C: {0x8C, "GLO", Implied, 1, 16, "Get the low byte of register R12 into D"},
+	0x8D: {0x8D, "GLO", Implied, 1, 16, "Get the low byte of register R13 into D"},
+	0x8E: {0x8E, "GLO", Implied, 1, 16, "Get the low byte of register R14 into D"},
+	0x8F: {0x8F, "GLO", Implied, 1, 16, "Get the low byte of register R15 into D"},
+	0x90: {0x90, "GHI", Implied, 1, 16, "Get the high byte of register R0 into D"},
+	0x91: {0x91, "GHI", Implied, 1, 16, "Get the high byte of register R1 into D"},
+	0x92: {0x92, "GHI", Implied, 1, 16, "Get the high byte of register R2 into D"},
+	0x93: {0x93, "GHI", Implied, 1, 16, "Get the high byte of register R3 into D"},
+	0x94: {0x94, "GHI", Implied, 1, 16, "Get the high byte of register R4 into D"},
+	0x95: {0x95, "GHI", Implied, 1, 16, "Get the high byte of register R5 into D"},
+	0x96: {0x96, "GHI", Implied, 1, 16, "Get the high byte of register R6 into D"},
+	0x97: {0x97, "GHI", Implied, 1, 16, "Get the high byte of register R7 into D"},
+	0x98: {0x98, "GHI", Implied, 1, 16, "Get the high byte of register R8 into D"},
+	0x99: {0x99, "GHI", Implied, 1, 16, "Get the high byte of register R9 into D"},
+	0x9A: {0x9A, "GHI", Implied, 1, 16, "Get the high byte of register R10 into D"},
+	0x9B: {0x9B, "GHI", Implied, 1, 16, "Get the high byte of register R11 into D"},
+	0x9C: {0x9C, "GHI", Implied, 1, 16, "Get the high byte of register R12 into D"},
+	0x9D: {0x9D, "GHI", Implied, 1, 16, "Get the high byte of register R13 into D"},
+	0x9E: {0x9E, "GHI", Implied, 1, 16, "Get the high byte of register R14 into D"},
+	0x9F: {0x9F, "GHI", Implied, 1, 16, "Get the high byte of register R15 into D"},
+	0xA0: {0xA0, "PLO", Implied, 1, 16, "Put D into the low byte of register R0"},
+	0xA1: {0xA1, "PLO", Implied, 1, 16, "Put D into the low byte of register R1"},
+	0xA2: {0xA2, "PLO", Implied, 1, 16, "Put D into the low byte of register R2"},
+	0xA3: {0xA3, "PLO", Implied, 1, 16, "Put D into the low byte of register R3"},
+	0xA4: {0xA4, "PLO", Implied, 1, 16, "Put D into the low byte of register R4"},
+	0xA5: {0xA5, "PLO", Implied, 1, 16, "Put D into the low byte of register R5"},
+	0xA6: {0xA6, "PLO", Implied, 1, 16, "Put D into the low byte of register R6"},
+	0xA7: {0xA7, "PLO", Implied, 1, 16, "Put D into the low byte of register R7"},
+	0xA8: {0xA8, "PLO", Implied, 1, 16, "Put D into the low byte of register R8"},
+	0xA9: {0xA9, "PLO", Implied, 1, 16, "Put D into the low byte of register R9"},
+	0xAA: {0xAA, "PLO", Implied, 1, 16, "Put D into the low byte of register R10"},
+	0xAB: {0xAB, "PLO", Implied, 1, 16, "Put D into the low byte of register R11"},
+	0xAC: {0xAC, "PLO", Implied, 1, 16, "Put D into the low byte of register R12"},
+	0xAD: {0xAD, "PLO", Implied, 1, 16, "Put D into the low byte of register R13"},
+	0xAE: {0xAE, "PLO", Implied, 1, 16, "Put D into the low byte of register R14"},
+	0xAF: {0xAF, "PLO", Implied, 1, 16, "Put D into the low byte of register R15"},
+	0xB0: {0xB0, "PHI", Implied, 1, 16, "Put D into the high byte of register R0"},
+	0xB1: {0xB1, "PHI", Implied, 1, 16, "Put D into the high byte of register R1"},
+	0xB2: {0xB2, "PHI", Implied, 1, 16, "Put D into the high byte of register R2"},
+	0xB3: {0xB3, "PHI", Implied, 1, 16, "Put D into the high byte of register R3"},
+	0xB4: {0xB4, "PHI", Implied, 1, 16, "Put D into the high byte of register R4"},
+	0xB5: {0xB5, "PHI", Implied, 1, 16, "Put D into the high byte of register R5"},
+	0xB6: {0xB6, "PHI", Implied, 1, 16, "Put D into the high byte of register R6"},
+	0xB7: {0xB7, "PHI", Implied, 1, 16, "Put D into the high byte of register R7"},
+	0xB8: {0xB8, "PHI", Implied, 1, 16, "Put D into the high byte of register R8"},
+	0xB9: {0xB9, "PHI", Implied, 1, 16, "Put D into the high byte of register R9"},
+	0xBA: {0xBA, "PHI", Implied, 1, 16, "Put D into the high byte of register R10"},
+	0xBB: {0xBB, "PHI", Implied, 1, 16, "Put D into the high byte of register R11"},
+	0xBC: {0xBC, "PHI", Implied, 1, 16, "Put D into the high byte of register R12"},
+	0xBD: {0xBD, "PHI", Implied, 1, 16, "Put D into the high byte of register R13"},
+	0xBE: {0xBE, "PHI", Implied, 1, 16, "Put D into the high byte of register R14"},
+	0xBF: {0xBF, "PHI", Implied, 1, 16, "Put D into the high byte of register R15"},
+
+	// Memory Reference Instructions
+	// Memory is addressed through a register: R(N) in the opcode, or R(X), the index register X selects.
+
+	0x01: {0x01, "LDN", Implied, 1, 16, "Load D from the byte R1 points to"},
+	0x02: {0x02, "LDN", Implied, 1, 16, "Load D from the byte R2 points to"},
+	0x03: {0x03, "LDN", Implied, 1, 16, "Load D from the byte R3 points to"},
+	0x04: {0x04, "LDN", Implied, 1, 16, "Load D from the byte R4 points to"},
+	0x05: {0x05, "LDN", Implied, 1, 16, "Load D from the byte R5 points to"},
+	0x06: {0x06, "LDN", Implied, 1, 16, "Load D from the byte R6 points to"},
+	0x07: {0x07, "LDN", Implied, 1, 16, "Load D from the byte R7 points to"},
+	0x08: {0x08, "LDN", Implied, 1, 16, "Load D from the byte R8 points to"},
+	0x09: {0x09, "LDN", Implied, 1, 16, "Load D from the byte R9 points to"},
+	0x0A: {0x0A, "LDN", Implied, 1, 16, "Load D from the byte R10 points to"},
+	0x0B: {0x0B, "LDN", Implied, 1, 16, "Load D from the byte R11 points to"},
+	0x0C: {0x0C, "LDN", Implied, 1, 16, "Load D from the byte R12 points to"},
+	0x0D: {0x0D, "LDN", Implied, 1, 16, "Load D from the byte R13 points to"},
+	0x0E: {0x0E, "LDN", Implied, 1, 16, "Load D from the byte R14 points to"},
+	0x0F: {0x0F, "LDN", Implied, 1, 16, "Load D from the byte R15 points to"},
+	0x40: {0x40, "LDA", Implied, 1, 16, "Load D from the byte R0 points to and advance R0"},
+	0x41: {0x41, "LDA", Implied, 1, 16, "Load D from the byte R1 points to and advance R1"},
+	0x42: {0x42, "LDA", Implied, 1, 16, "Load D from the byte R2 points to and advance R2"},
+	0x43: {0x43, "LDA", Implied, 1, 16, "Load D from the byte R3 points to and advance R3"},
+	0x44: {0x44, "LDA", Implied, 1, 16, "Load D from the byte R4 points to and advance R4"},
+	0x45: {0x45, "LDA", Implied, 1, 16, "Load D from the byte R5 points to and advance R5"},
+	0x46: {0x46, "LDA", Implied, 1, 16, "Load D from the byte R6 points to and advance R6"},
+	0x47: {0x47, "LDA", Implied, 1, 16, "Load D from the byte R7 points to and advance R7"},
+	0x48: {0x48, "LDA", Implied, 1, 16, "Load D from the byte R8 points to and advance R8"},
+	0x49: {0x49, "LDA", Implied, 1, 16, "Load D from the byte R9 points to and advance R9"},
+	0x4A: {0x4A, "LDA", Implied, 1, 16, "Load D from the byte R10 points to and advance R10"},
+	0x4B: {0x4B, "LDA", Implied, 1, 16, "Load D from the byte R11 points to and advance R11"},
+	0x4C: {0x4C, "LDA", Implied, 1, 16, "Load D from the byte R12 points to and advance R12"},
+	0x4D: {0x4D, "LDA", Implied, 1, 16, "Load D from the byte R13 points to and advance R13"},
+	0x4E: {0x4E, "LDA", Implied, 1, 16, "Load D from the byte R14 points to and advance R14"},
+	0x4F: {0x4F, "LDA", Implied, 1, 16, "Load D from the byte R15 points to and advance R15"},
+	0x50: {0x50, "STR", Implied, 1, 16, "Store D at the byte R0 points to"},
+	0x51: {0x51, "STR", Implied, 1, 16, "Store D at the byte R1 points to"},
+	0x52: {0x52, "STR", Implied, 1, 16, "Store D at the byte R2 points to"},
+	0x53: {0x53, "STR", Implied, 1, 16, "Store D at the byte R3 points to"},
+	0x54: {0x54, "STR", Implied, 1, 16, "Store D at the byte R4 points to"},
+	0x55: {0x55, "STR", Implied, 1, 16, "Store D at the byte R5 points to"},
+	0x56: {0x56, "STR", Implied, 1, 16, "Store D at the byte R6 points to"},
+	0x57: {0x57, "STR", Implied, 1, 16, "Store D at the byte R7 points to"},
+	0x58: {0x58, "STR", Implied, 1, 16, "Store D at the byte R8 points to"},
+	0x59: {0x59, "STR", Implied, 1, 16, "Store D at the byte R9 points to"},
+	0x5A: {0x5A, "STR", Implied, 1, 16, "Store D at the byte R10 points to"},
+	0x5B: {0x5B, "STR", Implied, 1, 16, "Store D at the byte R11 points to"},
+	0x5C: {0x5C, "STR", Implied, 1, 16, "Store D at the byte R12 points to"},
+	0x5D: {0x5D, "STR", Implied, 1, 16, "Store D at the byte R13 points to"},
+	0x5E: {0x5E, "STR", Implied, 1, 16, "Store D at the byte R14 points to"},
+	0x5F: {0x5F, "STR", Implied, 1, 16, "Store D at the byte R15 points to"},
+	0x60: {0x60, "IRX", Implied, 1, 16, "Increment register R(X)"},
+	0x72: {0x72, "LDXA", Implied, 1, 16, "Load D from the byte R(X) points to and advance R(X)"},
+	0x73: {0x73, "STXD", Implied, 1, 16, "Store D at the byte R(X) points to and decrement R(X)"},
+	0xF0: {0xF0, "LDX", Implied, 1, 16, "Load D from the byte R(X) points to"},
+	0xF8: {0xF8, "LDI", Immediate, 2, 16, "Load immediate data into D"},
+
+	// Arithmetic and Logic Instructions
+	// The operand is the byte R(X) points to, or immediate data at R(P). DF is the carry, and it is set
+	// after a subtraction when there is no borrow.
+
+	0x74: {0x74, "ADC", Implied, 1, 16, "Add the byte R(X) points to and DF to D, carry into DF"},
+	0x75: {0x75, "SDB", Implied, 1, 16, "Subtract D and the borrow from the byte R(X) points to"},
+	0x76: {0x76, "SHRC", Implied, 1, 16, "Shift D right through DF"},
+	0x77: {0x77, "SMB", Implied, 1, 16, "Subtract the byte R(X) points to and the borrow from D"},
+	0x7C: {0x7C, "ADCI", Immediate, 2, 16, "Add immediate data and DF to D, carry into DF"},
+	0x7D: {0x7D, "SDBI", Immediate, 2, 16, "Subtract D and the borrow from immediate data"},
+	0x7E: {0x7E, "SHLC", Implied, 1, 16, "Shift D left through DF"},
+	0x7F: {0x7F, "SMBI", Immediate, 2, 16, "Subtract immediate data and the borrow from D"},
+	0xF1: {0xF1, "OR", Implied, 1, 16, "OR the byte R(X) points to with D"},
+	0xF2: {0xF2, "AND", Implied, 1, 16, "AND the byte R(X) points to with D"},
+	0xF3: {0xF3, "XOR", Implied, 1, 16, "Exclusive-OR the byte R(X) points to with D"},
+	0xF4: {0xF4, "ADD", Implied, 1, 16, "Add the byte R(X) points to to D, carry into DF"},
+	0xF5: {0xF5, "SD", Implied, 1, 16, "Subtract D from the byte R(X) points to; DF is set when there is no borrow"},
+	0xF6: {0xF6, "SHR", Implied, 1, 16, "Shift D right, bit 0 into DF"},
+	0xF7: {0xF7, "SM", Implied, 1, 16, "Subtract the byte R(X) points to from D; DF is set when there is no borrow"},
+	0xF9: {0xF9, "ORI", Immediate, 2, 16, "OR immediate data with D"},
+	0xFA: {0xFA, "ANI", Immediate, 2, 16, "AND immediate data with D"},
+	0xFB: {0xFB, "XRI", Immediate, 2, 16, "Exclusive-OR immediate data with D"},
+	0xFC: {0xFC, "ADI", Immediate, 2, 16, "Add immediate data to D, carry into DF"},
+	0xFD: {0xFD, "SDI", Immediate, 2, 16, "Subtract D from immediate data; DF is set when there is no borrow"},
+	0xFE: {0xFE, "SHL", Implied, 1, 16, "Shift D left, bit 7 into DF"},
+	0xFF: {0xFF, "SMI", Immediate, 2, 16, "Subtract immediate data from D; DF is set when there is no borrow"},
+
+	// Branch and Skip Instructions
+	// Short branches replace the low byte of the program counter with their operand, so they stay in
+	// the page of the operand byte. Long branches take a 16-bit address, high byte first, and long skips
+	// jump over the next two bytes.
+
+	0x30: {0x30, "BR", OperandPage, 2, 16, "Branch within the page of the operand"},
+	0x31: {0x31, "BQ", OperandPage, 2, 16, "Branch if Q is set within the page of the operand"},
+	0x32: {0x32, "BZ", OperandPage, 2, 16, "Branch if D is zero within the page of the operand"},
+	0x33: {0x33, "BDF", OperandPage, 2, 16, "Branch if DF is set within the page of the operand"},
+	0x34: {0x34, "B1", OperandPage, 2, 16, "Branch if EF1 is high within the page of the operand"},
+	0x35: {0x35, "B2", OperandPage, 2, 16, "Branch if EF2 is high within the page of the operand"},
+	0x36: {0x36, "B3", OperandPage, 2, 16, "Branch if EF3 is high within the page of the operand"},
+	0x37: {0x37, "B4", OperandPage, 2, 16, "Branch if EF4 is high within the page of the operand"},
+	0x38: {0x38, "SKP", Implied, 1, 16, "Skip the next byte"},
+	0x39: {0x39, "BNQ", OperandPage, 2, 16, "Branch if Q is clear within the page of the operand"},
+	0x3A: {0x3A, "BNZ", OperandPage, 2, 16, "Branch if D is not zero within the page of the operand"},
+	0x3B: {0x3B, "BNF", OperandPage, 2, 16, "Branch if DF is clear within the page of the operand"},
+	0x3C: {0x3C, "BN1", OperandPage, 2, 16, "Branch if EF1 is low within the page of the operand"},
+	0x3D: {0x3D, "BN2", OperandPage, 2, 16, "Branch if EF2 is low within the page of the operand"},
+	0x3E: {0x3E, "BN3", OperandPage, 2, 16, "Branch if EF3 is low within the page of the operand"},
+	0x3F: {0x3F, "BN4", OperandPage, 2, 16, "Branch if EF4 is low within the page of the operand"},
+	0xC0: {0xC0, "LBR", Absolute, 3, 24, "Long branch"},
+	0xC1: {0xC1, "LBQ", Absolute, 3, 24, "Long branch if Q is set"},
+	0xC2: {0xC2, "LBZ", Absolute, 3, 24, "Long branch if D is zero"},
+	0xC3: {0xC3, "LBDF", Absolute, 3, 24, "Long branch if DF is set"},
+	0xC4: {0xC4, "NOP", Implied, 1, 24, "No operation"},
+	0xC5: {0xC5, "LSNQ", Implied, 1, 24, "Skip the next two bytes if Q is clear"},
+	0xC6: {0xC6, "LSNZ", Implied, 1, 24, "Skip the next two bytes if D is not zero"},
+	0xC7: {0xC7, "LSNF", Implied, 1, 24, "Skip the next two bytes if DF is clear"},
+	0xC8: {0xC8, "LSKP", Implied, 1, 24, "Skip the next two bytes"},
+	0xC9: {0xC9, "LBNQ", Absolute, 3, 24, "Long branch if Q is clear"},
+	0xCA: {0xCA, "LBNZ", Absolute, 3, 24, "Long branch if D is not zero"},
+	0xCB: {0xCB, "LBNF", Absolute, 3, 24, "Long branch if DF is clear"},
+	0xCC: {0xCC, "LSIE", Implied, 1, 24, "Skip the next two bytes if interrupts are enabled"},
+	0xCD: {0xCD, "LSQ", Implied, 1, 24, "Skip the next two bytes if Q is set"},
+	0xCE: {0xCE, "LSZ", Implied, 1, 24, "Skip the next two bytes if D is zero"},
+	0xCF: {0xCF, "LSDF", Implied, 1, 24, "Skip the next two bytes if DF is set"},
+
+	// Control Instructions
+	// P selects the register used as program counter and X the index register. Interrupts save X and P
+	// in T, then set P to 1 and X to 2.
+
+	0x00: {0x00, "IDL", Implied, 1, 16, "Idle until a DMA or interrupt request (the emulator halts)"},
+	0x70: {0x70, "RET", Implied, 1, 16, "Return: load X and P from the byte R(X) points to, advance R(X) and enable interrupts"},
+	0x71: {0x71, "DIS", Implied, 1, 16, "Disable: load X and P from the byte R(X) points to, advance R(X) and disable interrupts"},
+	0x78: {0x78, "SAV", Implied, 1, 16, "Save T at the byte R(X) points to"},
+	0x79: {0x79, "MARK", Implied, 1, 16, "Save X and P in T and at the byte R2 points to, set X to P and decrement R2"},
+	0xD0: {0xD0, "SEP", Implied, 1, 16, "Set P to 0, making R0 the program counter"},
+	0xD1: {0xD1, "SEP", Implied, 1, 16, "Set P to 1, making R1 the program counter"},
+	0xD2: {0xD2, "SEP", Implied, 1, 16, "Set P to 2, making R2 the program counter"},
+	0xD3: {0xD3, "SEP", Implied, 1, 16, "Set P to 3, making R3 the program counter"},
+	0xD4: {0xD4, "SEP", Implied, 1, 16, "Set P to 4, making R4 the program counter"},
+	0xD5: {0xD5, "SEP", Implied, 1, 16, "Set P to 5, making R5 the program counter"},
+	0xD6: {0xD6, "SEP", Implied, 1, 16, "Set P to 6, making R6 the program counter"},
+	0xD7: {0xD7, "SEP", Implied, 1, 16, "Set P to 7, making R7 the program counter"},
+	0xD8: {0xD8, "SEP", Implied, 1, 16, "Set P to 8, making R8 the program counter"},
+	0xD9: {0xD9, "SEP", Implied, 1, 16, "Set P to 9, making R9 the program counter"},
+	0xDA: {0xDA, "SEP", Implied, 1, 16, "Set P to 10, making R10 the program counter"},
+	0xDB: {0xDB, "SEP", Implied, 1, 16, "Set P to 11, making R11 the program counter"},
+	0xDC: {0xDC, "SEP", Implied, 1, 16, "Set P to 12, making R12 the program counter"},
+	0xDD: {0xDD, "SEP", Implied, 1, 16, "Set P to 13, making R13 the program counter"},
+	0xDE: {0xDE, "SEP", Implied, 1, 16, "Set P to 14, making R14 the program counter"},
+	0xDF: {0xDF, "SEP", Implied, 1, 16, "Set P to 15, making R15 the program counter"},
+	0xE0: {0xE0, "SEX", Implied, 1, 16, "Set X to 0, making R0 the index register"},
+	0xE1: {0xE1, "SEX", Implied, 1, 16, "Set X to 1, making R1 the index register"},
+	0xE2: {0xE2, "SEX", Implied, 1, 16, "Set X to 2, making R2 the index register"},
+	0xE3: {0xE3, "SEX", Implied, 1, 16, "Set X to 3, making R3 the index register"},
+	0xE4: {0xE4, "SEX", Implied, 1, 16, "Set X to 4, making R4 the index register"},
+	0xE5: {0xE5, "SEX", Implied, 1, 16, "Set X to 5, making R5 the index register"},
+	0xE6: {0xE6, "SEX", Implied, 1, 16, "Set X to 6, making R6 the index register"},
+	0xE7: {0xE7, "SEX", Implied, 1, 16, "Set X to 7, making R7 the index register"},
+	0xE8: {0xE8, "SEX", Implied, 1, 16, "Set X to 8, making R8 the index register"},
+	0xE9: {0xE9, "SEX", Implied, 1, 16, "Set X to 9, making R9 the index register"},
+	0xEA: {0xEA, "SEX", Implied, 1, 16, "Set X to 10, making R10 the index register"},
+	0xEB: {0xEB, "SEX", Implied, 1, 16, "Set X to 11, making R11 the index register"},
+	0xEC: {0xEC, "SEX", Implied, 1, 16, "Set X to 12, making R12 the index register"},
+	0xED: {0xED, "SEX", Implied, 1, 16, "Set X to 13, making R13 the index register"},
+	0xEE: {0xEE, "SEX", Implied, 1, 16, "Set X to 14, making R14 the index register"},
+	0xEF: {0xEF, "SEX", Implied, 1, 16, "Set X to 15, making R15 the index register"},
+
+	// Input and Output Instructions
+	// OUT and INP put port numbers 1-7 on the N lines. Q is an output line and EF1-EF4 are input flags.
+
+	0x61: {0x61, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 1 and advance R(X)"},
+	0x62: {0x62, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 2 and advance R(X)"},
+	0x63: {0x63, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 3 and advance R(X)"},
+	0x64: {0x64, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 4 and advance R(X)"},
+	0x65: {0x65, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 5 and advance R(X)"},
+	0x66: {0x66, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 6 and advance R(X)"},
+	0x67: {0x67, "OUT", Implied, 1, 16, "Output the byte R(X) points to on port 7 and advance R(X)"},
+	0x69: {0x69, "INP", Implied, 1, 16, "Input port 1 into D and the byte R(X) points to"},
+	0x6A: {0x6A, "INP", Implied, 1, 16, "Input port 2 into D and the byte R(X) points to"},
+	0x6B: {0x6B, "INP", Implied, 1, 16, "Input port 3 into D and the byte R(X) points to"},
+	0x6C: {0x6C, "INP", Implied, 1, 16, "Input port 4 into D and the byte R(X) points to"},
+	0x6D: {0x6D, "INP", Implied, 1, 16, "Input port 5 into D and the byte R(X) points to"},
+	0x6E: {0x6E, "INP", Implied, 1, 16, "Input port 6 into D and the byte R(X) points to"},
+	0x6F: {0x6F, "INP", Implied, 1, 16, "Input port 7 into D and the byte R(X) points to"},
+	0x7A: {0x7A, "REQ", Implied, 1, 16, "Reset Q"},
+	0x7B: {0x7B, "SEQ", Implied, 1, 16, "Set Q"},
+}
@@ -0,0 +1,35 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// RCA1802Syntax is the RCA COSMAC assembly language (LDN R5, OUT 4, LBR $0200).
+// Registers are written R0-R15 and I/O ports as their number; 16-bit
+// addresses are stored high byte first.
+var RCA1802Syntax = &Syntax{
+	Name:        "1802",
+	Description: "RCA 1802 mnemonics (LDN R5, OUT 4, LBR $0200)",
+	Forms:       rca1802Forms(RCA1802Instructions),
+	BigEndian:   true,
+}
+
+// RCA1802Syntaxes lists the dialects the 1802 can be written in
+var RCA1802Syntaxes = []*Syntax{RCA1802Syntax}
+
+// rca1802Forms builds the form of every 1802 opcode. The register or port
+// number in the low 4 bits of the opcode becomes a fixed operand.
+func rca1802Forms(instructions map[byte]Instruction) map[byte]Form {
+	forms := make(map[byte]Form)
+	for opcode, instruction := range instructions {
+		form := Form{Mnemonic: instruction.Mnemonic}
+		switch instruction.Mnemonic {
+		case "LDN", "INC", "DEC", "LDA", "STR", "GLO", "GHI", "PLO", "PHI", "SEP", "SEX":
+			form.Operands = []string{fmt.Sprintf("R%d", opcode&0x0F)}
+		case "OUT", "INP":
+			form.Operands = []string{fmt.Sprint(opcode & 0x07)}
+		}
+		forms[opcode] = form
+	}
+	return forms
+}
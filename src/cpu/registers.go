@@ -0,0 +1,169 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegisterDesc describes a register that can be read and written by name
+type RegisterDesc struct {
+	Name string // Upper-case name, such as "A", "IX" or "R3"
+	Bits int    // Width of the register: 2, 4, 8 or 16 bits
+}
+
+// FlagDesc describes a flag that can be read and written by name, such as a
+// condition flag or an interrupt enable
+type FlagDesc struct {
+	Name        string // Upper-case name, such as "C" or "IE"
+	Description string // What the flag means, such as "Carry"
+}
+
+// register binds a RegisterDesc to the CPU state that holds it
+type register struct {
+	RegisterDesc
+	get func() uint16
+	set func(value uint16)
+}
+
+// flag binds a FlagDesc to the CPU state that holds it
+type flag struct {
+	FlagDesc
+	get func() bool
+	set func(value bool)
+}
+
+// byteRegister binds an 8-bit register to its field
+func byteRegister(name string, field *uint8) register {
+	return register{
+		RegisterDesc: RegisterDesc{Name: name, Bits: 8},
+		get:          func() uint16 { return uint16(*field) },
+		set:          func(value uint16) { *field = uint8(value) },
+	}
+}
+
+// wordRegister binds a 16-bit register to its field
+func wordRegister(name string, field *uint16) register {
+	return register{
+		RegisterDesc: RegisterDesc{Name: name, Bits: 16},
+		get:          func() uint16 { return *field },
+		set:          func(value uint16) { *field = value },
+	}
+}
+
+// boolFlag binds a flag to its field
+func boolFlag(name, description string, field *bool) flag {
+	return flag{
+		FlagDesc: FlagDesc{Name: name, Description: description},
+		get:      func() bool { return *field },
+		set:      func(value bool) { *field = value },
+	}
+}
+
+// bindRegisters installs the registers and flags behind the register API.
+// The bindings point into the CPU, so a constructor calls it once the CPU has
+// its final address.
+func (c *CPU) bindRegisters(registers []register, flags []flag) {
+	c.registers = registers
+	c.flags = flags
+}
+
+// Registers describes the registers in the order a debugger shows them. PC is
+// not listed; SP is listed by CPUs that have a stack pointer register.
+func (c *CPU) Registers() []RegisterDesc {
+	descs := make([]RegisterDesc, len(c.registers))
+	for i, r := range c.registers {
+		descs[i] = r.RegisterDesc
+	}
+	return descs
+}
+
+// GetRegister reads a register by name; the name is not case-sensitive and
+// may be PC or one of the names in Registers
+func (c *CPU) GetRegister(name string) (uint16, error) {
+	if strings.EqualFold(name, "PC") {
+		return c.PC, nil
+	}
+	r, ok := c.lookupRegister(name)
+	if !ok {
+		return 0, fmt.Errorf("unknown register %s", name)
+	}
+	return r.get(), nil
+}
+
+// SetRegister writes a register by name, keeping the bits that fit in it
+func (c *CPU) SetRegister(name string, value uint16) error {
+	if strings.EqualFold(name, "PC") {
+		c.PC = value
+		return nil
+	}
+	r, ok := c.lookupRegister(name)
+	if !ok {
+		return fmt.Errorf("unknown register %s", name)
+	}
+	if r.Bits < 16 {
+		value &= 1<<r.Bits - 1
+	}
+	r.set(value)
+	return nil
+}
+
+// lookupRegister finds a register by name, ignoring case
+func (c *CPU) lookupRegister(name string) (register, bool) {
+	for _, r := range c.registers {
+		if strings.EqualFold(r.Name, name) {
+			return r, true
+		}
+	}
+	return register{}, false
+}
+
+// Flags describes the flags in the order a debugger shows them
+func (c *CPU) Flags() []FlagDesc {
+	descs := make([]FlagDesc, len(c.flags))
+	for i, f := range c.flags {
+		descs[i] = f.FlagDesc
+	}
+	return descs
+}
+
+// GetFlag reads a flag by name; the name is not case-sensitive
+func (c *CPU) GetFlag(name string) (bool, error) {
+	f, ok := c.lookupFlag(name)
+	if !ok {
+		return false, fmt.Errorf("unknown flag %s", name)
+	}
+	return f.get(), nil
+}
+
+// SetFlag sets or clears a flag by name
+func (c *CPU) SetFlag(name string, value bool) error {
+	f, ok := c.lookupFlag(name)
+	if !ok {
+		return fmt.Errorf("unknown flag %s", name)
+	}
+	f.set(value)
+	return nil
+}
+
+// lookupFlag finds a flag by name, ignoring case
+func (c *CPU) lookupFlag(name string) (flag, bool) {
+	for _, f := range c.flags {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return flag{}, false
+}
+
+// traceState adds the registers and flags after an instruction to its trace record
+func (c *CPU) traceState() {
+	record := &c.record
+	record.Registers = record.Registers[:0]
+	for _, r := range c.registers {
+		record.Registers = append(record.Registers, RegisterValue{r.Name, r.get()})
+	}
+	record.Flags = record.Flags[:0]
+	for _, f := range c.flags {
+		record.Flags = append(record.Flags, FlagValue{f.Name, f.get()})
+	}
+}
@@ -0,0 +1,204 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Form is how an instruction is written in an assembly dialect: a mnemonic
+// followed by fixed operands. A fixed operand is a register name, or a decimal
+// number for a value encoded in the opcode such as a port or restart number.
+// A data byte or address, if the instruction takes one, is written after the
+// fixed operands, unless the form marks where it goes with one of the
+// placeholders n, nn, (n), (nn), e (a relative jump target) or (IX+d) and
+// (IY+d) (an indexed displacement), as in "LD (IX+d),n".
+type Form struct {
+	Mnemonic string
+	Operands []string
+}
+
+// String returns the form as written in source, e.g. "MOV A,B"
+func (f Form) String() string {
+	if len(f.Operands) == 0 {
+		return f.Mnemonic
+	}
+	return f.Mnemonic + " " + strings.Join(f.Operands, ",")
+}
+
+// Syntax is an assembly language dialect for an instruction set
+type Syntax struct {
+	Name            string        // Dialect name used to select it
+	Description     string        // One-line description for help texts
+	Forms           map[byte]Form // Written form of each opcode
+	PrefixForms     PrefixForms   // Written form of each opcode after a prefix
+	ImmediatePrefix string        // Prefix written before immediate data, e.g. "#"
+	HexSuffix       bool          // Write numbers as 0FFH instead of $FF
+	BigEndian       bool          // 16-bit operands are stored high byte first
+}
+
+// PrefixForms are the forms of the instructions in each prefix table, keyed like PrefixTables
+type PrefixForms map[uint16]map[byte]Form
+
+// MnemonicSyntax returns a dialect that writes each instruction as its table mnemonic with #$hex immediates
+func MnemonicSyntax(name string, instructions map[byte]Instruction) *Syntax {
+	forms := make(map[byte]Form)
+	for opcode, instruction := range instructions {
+		forms[opcode] = Form{Mnemonic: instruction.Mnemonic}
+	}
+	return &Syntax{Name: name, Description: "instruction table mnemonics", Forms: forms, ImmediatePrefix: "#"}
+}
+
+// Syntaxes returns the dialects a CPU's code can be written in, the default first
+func Syntaxes(processor ICPU) []*Syntax {
+	switch processor.(type) {
+	case *Intel8008:
+		return Intel8008Syntaxes
+	case *Intel8080:
+		return Intel8080Syntaxes
+	case *Intel8085:
+		return Intel8085Syntaxes
+	case *MOS6502:
+		return MOS6502Syntaxes
+	case *Z80:
+		return Z80Syntaxes
+	case *Motorola6800:
+		return Motorola6800Syntaxes
+	case *RCA1802:
+		return RCA1802Syntaxes
+	case *Intel4004:
+		if processor.GetName() == "Intel4040" {
+			return Intel4040Syntaxes
+		}
+		return Intel4004Syntaxes
+	}
+	return []*Syntax{MnemonicSyntax(processor.GetName(), processor.GetInstructions())}
+}
+
+// FindSyntax returns the dialect with the given name
+func FindSyntax(syntaxes []*Syntax, name string) (*Syntax, error) {
+	var names []string
+	for _, syntax := range syntaxes {
+		if strings.EqualFold(syntax.Name, name) {
+			return syntax, nil
+		}
+		names = append(names, syntax.Name)
+	}
+	return nil, fmt.Errorf("unknown syntax %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// IsNumber reports whether a fixed operand is a number rather than a register name
+func IsNumber(operand string) bool {
+	return operand != "" && operand[0] >= '0' && operand[0] <= '9'
+}
+
+// FormatNumber writes a value as a hex number of the given digit count in the dialect's notation
+func (s *Syntax) FormatNumber(value uint16, digits int) string {
+	if s.HexSuffix {
+		text := fmt.Sprintf("%0*XH", digits, value)
+		if text[0] > '9' {
+			text = "0" + text // Intel notation needs a leading digit
+		}
+		return text
+	}
+	return fmt.Sprintf("$%0*X", digits, value)
+}
+
+// Disassemble returns the instruction at addr written in the given syntax and its size in bytes
+func Disassemble(syntax *Syntax, instructions map[byte]Instruction, prefixes PrefixTables, read func(addr uint16) byte, addr uint16) (string, int) {
+	decoded, ok := Decode(instructions, prefixes, read, addr)
+	forms := syntax.Forms
+	if decoded.Prefix != 0 {
+		forms = syntax.PrefixForms[decoded.Prefix]
+	}
+	form, hasForm := forms[decoded.Opcode]
+	if !ok || !hasForm {
+		return "???", 1
+	}
+	instruction := decoded.Instruction
+
+	zeroPage := func() string { return syntax.FormatNumber(uint16(read(addr+uint16(decoded.Operand))), 2) }
+	absolute := func() string {
+		operand := addr + uint16(decoded.Operand)
+		if syntax.BigEndian {
+			return syntax.FormatNumber(uint16(read(operand))<<8|uint16(read(operand+1)), 4)
+		}
+		return syntax.FormatNumber(uint16(read(operand))|uint16(read(operand+1))<<8, 4)
+	}
+	relative := func() string {
+		// The offset is from the address after the branch
+		target := addr + uint16(instruction.Size) + uint16(int8(read(addr+uint16(decoded.Operand))))
+		return syntax.FormatNumber(target, 4)
+	}
+
+	operands := make([]string, 0, len(form.Operands)+2)
+	placeholders := false
+	for _, operand := range form.Operands {
+		switch operand {
+		case "n", "(n)":
+			operand = strings.Replace(operand, "n", zeroPage(), 1)
+		case "nn", "(nn)":
+			operand = strings.Replace(operand, "nn", absolute(), 1)
+		case "e":
+			operand = relative()
+		case "(IX+d)", "(IY+d)":
+			displacement := int(int8(read(addr + uint16(decoded.Displacement))))
+			sign := "+"
+			if displacement < 0 {
+				sign, displacement = "-", -displacement
+			}
+			operand = operand[:3] + sign + syntax.FormatNumber(uint16(displacement), 2) + ")"
+		default:
+			operands = append(operands, operand)
+			continue
+		}
+		operands = append(operands, operand)
+		placeholders = true
+	}
+
+	if !placeholders {
+		switch instruction.Mode {
+		case Immediate:
+			operands = append(operands, syntax.ImmediatePrefix+zeroPage())
+		case Immediate16:
+			operands = append(operands, syntax.ImmediatePrefix+absolute())
+		case Absolute:
+			operands = append(operands, absolute())
+		case AbsoluteX:
+			operands = append(operands, absolute(), "X")
+		case AbsoluteY:
+			operands = append(operands, absolute(), "Y")
+		case ZeroPage:
+			operands = append(operands, zeroPage())
+		case ZeroPageX:
+			operands = append(operands, zeroPage(), "X")
+		case ZeroPageY:
+			operands = append(operands, zeroPage(), "Y")
+		case IndexedOffset:
+			operands = append(operands, zeroPage(), "X")
+		case Indirect:
+			operands = append(operands, "("+absolute()+")")
+		case IndexedIndirect:
+			operands = append(operands, "("+zeroPage()+",X)")
+		case IndirectIndexed:
+			operands = append(operands, "("+zeroPage()+")", "Y")
+		case Accumulator:
+			operands = append(operands, "A")
+		case Relative:
+			operands = append(operands, relative())
+		case OperandPage:
+			page := (addr + uint16(decoded.Operand)) &^ 0xFF
+			operands = append(operands, syntax.FormatNumber(page|uint16(read(addr+uint16(decoded.Operand))), 4))
+		case Page:
+			// The target is in the page of the next instruction
+			next := addr&0x1000 | (addr+uint16(instruction.Size))&0x0FFF
+			operands = append(operands, syntax.FormatNumber(next&^0xFF|uint16(read(addr+uint16(decoded.Operand))), 3))
+		case Absolute12:
+			target := uint16(decoded.Opcode&0x0F)<<8 | uint16(read(addr+uint16(decoded.Operand)))
+			operands = append(operands, syntax.FormatNumber(target, 3))
+		}
+	}
+	if len(operands) == 0 {
+		return form.Mnemonic, instruction.Size
+	}
+	return fmt.Sprintf("%-4s %s", form.Mnemonic, strings.Join(operands, ",")), instruction.Size
+}
@@ -0,0 +1,71 @@
+package cpu
+
+// RegisterValue is the value of a named register
+type RegisterValue struct {
+	Name  string
+	Value uint16
+}
+
+// FlagValue is the state of a named flag
+type FlagValue struct {
+	Name string
+	Set  bool
+}
+
+// MemoryAccess is a data read or write made by an instruction. Instruction
+// fetches are not included; they are the record's Bytes.
+type MemoryAccess struct {
+	Addr  uint16
+	Value byte
+	Write bool
+}
+
+// TraceRecord describes one executed instruction. The CPU reuses the record,
+// so a tracer must copy anything it keeps after Trace returns.
+type TraceRecord struct {
+	Cycle     int             // Cycle count before the instruction
+	PC        uint16          // Address of the instruction
+	Bytes     []byte          // Opcode and operand bytes
+	Registers []RegisterValue // Registers after the instruction
+	Flags     []FlagValue     // Flags after the instruction
+	Memory    []MemoryAccess  // Data accesses in the order they were made
+}
+
+// Tracer receives a record for every instruction the CPU executes
+type Tracer interface {
+	Trace(record *TraceRecord)
+}
+
+// SetTracer installs a tracer; nil turns tracing off
+func (c *CPU) SetTracer(tracer Tracer) {
+	c.tracer = tracer
+}
+
+// beginTrace starts the record for the instruction at pc
+func (c *CPU) beginTrace(pc uint16, size int) {
+	record := &c.record
+	record.Cycle = c.Cycles
+	record.PC = pc
+	record.Bytes = record.Bytes[:0]
+	for i := 0; i < size && int(pc)+i < len(c.Memory); i++ {
+		record.Bytes = append(record.Bytes, c.Memory[int(pc)+i])
+	}
+	record.Memory = record.Memory[:0]
+}
+
+// load reads a data byte from memory, recording the access when tracing
+func (c *CPU) load(addr uint16) byte {
+	value := c.Memory[addr]
+	if c.tracer != nil {
+		c.record.Memory = append(c.record.Memory, MemoryAccess{Addr: addr, Value: value})
+	}
+	return value
+}
+
+// store writes a data byte to memory, recording the access when tracing
+func (c *CPU) store(addr uint16, value byte) {
+	c.Memory[addr] = value
+	if c.tracer != nil {
+		c.record.Memory = append(c.record.Memory, MemoryAccess{Addr: addr, Value: value, Write: true})
+	}
+}
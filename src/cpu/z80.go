@@ -0,0 +1,1080 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Z80 interrupt entry points
+const (
+	Z80NMIAddress = 0x0066 // Non-maskable interrupt
+	Z80IM1Address = 0x0038 // Maskable interrupt in interrupt mode 1
+)
+
+// Flag register bits as PUSH AF stores them
+const (
+	z80Carry          = 0x01
+	z80Subtract       = 0x02
+	z80ParityOverflow = 0x04
+	z80X              = 0x08 // Undocumented copy of bit 3 of a result
+	z80HalfCarry      = 0x10
+	z80Y              = 0x20 // Undocumented copy of bit 5 of a result
+	z80Zero           = 0x40
+	z80Sign           = 0x80
+)
+
+// Z80 represents the Zilog Z80 processor. The DD and FD prefixes make the
+// instruction that follows use IX or IY in place of HL; while it executes,
+// index, memory and halves say how.
+type Z80 struct {
+	CPU
+	A      uint8 // Accumulator, A register, 7
+	B      uint8 // B register, 0
+	C      uint8 // C register, 1
+	D      uint8 // D register, 2
+	E      uint8 // E register, 3
+	H      uint8 // High-order byte of HL, H register, 4
+	L      uint8 // Low-order byte of HL, L register, 5
+	Status struct {
+		Sign           bool // Sign Flag (S)
+		Zero           bool // Zero Flag (Z)
+		Y              bool // Undocumented flag, bit 5
+		HalfCarry      bool // Half Carry Flag (H), the carry out of bit 3
+		X              bool // Undocumented flag, bit 3
+		ParityOverflow bool // Parity/Overflow Flag (P/V)
+		Subtract       bool // Add/Subtract Flag (N), for DAA
+		Carry          bool // Carry Flag (C)
+	}
+	Alternate struct {
+		A, F, B, C, D, E, H, L uint8 // Registers swapped in by EX AF,AF' and EXX
+	}
+	IX   uint16 // Index register IX
+	IY   uint16 // Index register IY
+	SP   uint16 // Stack pointer; the stack can be anywhere in memory
+	I    uint8  // Interrupt page register, the high byte of IM 2 vectors
+	R    uint8  // Memory refresh register; bits 0-6 count opcode fetches
+	IFF1 bool   // Interrupt enable flip-flop, set by EI and cleared by DI
+	IFF2 bool   // Copy of IFF1 kept during a non-maskable interrupt
+	IM   uint8  // Interrupt mode 0, 1 or 2
+	WZ   uint16 // Internal address latch (MEMPTR), visible in the flags of BIT n,(HL)
+
+	// Byte the interrupting device puts on the data bus when INT is taken: the
+	// RST instruction in IM 0, the low byte of the vector address in IM 2
+	InterruptData byte
+
+	index          byte   // Index prefix of the executing instruction: $DD, $FD or 0 for HL
+	memory         uint16 // Address of the memory operand (HL) or (IX+d)
+	halves         bool   // H and L mean the halves of IX or IY
+	interruptDelay bool   // EI was the last instruction, so interrupts wait for one more
+	intLine        bool   // Level of the INT input
+	nmiLine        bool   // Level of the NMI input
+	nmiPending     bool   // NMI saw a rising edge and has not been served
+}
+
+// NewZ80 creates a new Z80 CPU instance
+func NewZ80(memorySize int, speed uint) *Z80 {
+	c := &Z80{
+		CPU: *NewCPU("Z80", memorySize, speed, Z80Instructions),
+	}
+	c.Prefixes = Z80PrefixTables
+	c.SP = 0xFFFF
+	c.InterruptData = 0xFF // An idle bus reads $FF, which is RST 38H
+	c.bindRegisters(c.registerFile())
+	return c
+}
+
+// GetSP returns the stack pointer
+func (c *Z80) GetSP() uint16 {
+	return c.SP
+}
+
+// SetSP sets the stack pointer
+func (c *Z80) SetSP(value uint16) {
+	c.SP = value
+}
+
+// GetF returns the flag register as PUSH AF stores it: S Z Y H X P/V N C
+func (c *Z80) GetF() byte {
+	var flags byte
+	for _, flag := range []struct {
+		set bool
+		bit byte
+	}{
+		{c.Status.Sign, z80Sign}, {c.Status.Zero, z80Zero}, {c.Status.Y, z80Y}, {c.Status.HalfCarry, z80HalfCarry},
+		{c.Status.X, z80X}, {c.Status.ParityOverflow, z80ParityOverflow}, {c.Status.Subtract, z80Subtract},
+		{c.Status.Carry, z80Carry},
+	} {
+		if flag.set {
+			flags |= flag.bit
+		}
+	}
+	return flags
+}
+
+// SetF sets the flags from a flag register byte
+func (c *Z80) SetF(flags byte) {
+	c.Status.Sign = flags&z80Sign != 0
+	c.Status.Zero = flags&z80Zero != 0
+	c.Status.Y = flags&z80Y != 0
+	c.Status.HalfCarry = flags&z80HalfCarry != 0
+	c.Status.X = flags&z80X != 0
+	c.Status.ParityOverflow = flags&z80ParityOverflow != 0
+	c.Status.Subtract = flags&z80Subtract != 0
+	c.Status.Carry = flags&z80Carry != 0
+}
+
+// Push pushes a byte onto the stack
+func (c *Z80) Push(value byte) {
+	c.SP--
+	c.store(c.SP, value)
+}
+
+// Pull pulls a byte from the stack
+func (c *Z80) Pull() byte {
+	value := c.load(c.SP)
+	c.SP++
+	return value
+}
+
+// Push16 pushes a 16-bit value onto the stack, high byte first
+func (c *Z80) Push16(value uint16) {
+	c.Push(byte(value >> 8))
+	c.Push(byte(value))
+}
+
+// Pull16 pulls a 16-bit value from the stack
+func (c *Z80) Pull16() uint16 {
+	low := uint16(c.Pull())
+	return uint16(c.Pull())<<8 | low
+}
+
+// Run executes the program starting at the current PC until it halts
+func (c *Z80) Run() error {
+	// Start timing
+	c.CPU.Run()
+	defer c.CPU.Stop()
+
+	for {
+		if err := c.ExecuteInstruction(); err != nil {
+			if err == ErrHalted {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExecuteInstruction takes a pending interrupt, if any, and executes a single
+// instruction with its prefixes. It returns ErrHalted after HALT and a
+// FaultError for instructions it cannot execute. Every opcode is defined on
+// the Z80, so there are no unknown opcodes.
+func (c *Z80) ExecuteInstruction() (err error) {
+	c.interrupt()
+
+	pc := c.PC
+	defer func() {
+		// Memory is a slice, so addresses beyond its size panic
+		if r := recover(); r != nil {
+			c.PC = pc
+			err = &FaultError{PC: pc, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if int(pc) >= len(c.Memory) {
+		return &FaultError{PC: pc, Err: fmt.Errorf("program counter outside %d bytes of memory", len(c.Memory))}
+	}
+
+	// Get the instruction, following any prefixes
+	decoded, ok := Decode(c.Instructions, c.Prefixes, c.Read, c.PC)
+	if !ok {
+		return &UnknownOpcodeError{Opcode: c.Memory[c.PC], PC: c.PC}
+	}
+	instruction := decoded.Instruction
+
+	// Only print verbose output if enabled
+	if c.IsVerbose() {
+		opcode := fmt.Sprintf("%02X", instruction.Opcode)
+		if decoded.Prefix != 0 {
+			opcode = fmt.Sprintf("%X%s", decoded.Prefix, opcode)
+		}
+		fmt.Printf("PC: %04X, OP: %s, MN: %s, A:%02X B:%02X C:%02X D:%02X E:%02X H:%02X L:%02X IX:%04X IY:%04X SP:%04X | Flags(SZHPNC): %d%d%d%d%d%d\n",
+			c.PC, opcode, instruction.Mnemonic, c.A, c.B, c.C, c.D, c.E, c.H, c.L, c.IX, c.IY, c.SP,
+			boolToInt(c.Status.Sign), boolToInt(c.Status.Zero), boolToInt(c.Status.HalfCarry),
+			boolToInt(c.Status.ParityOverflow), boolToInt(c.Status.Subtract), boolToInt(c.Status.Carry))
+	}
+
+	if c.tracer != nil {
+		c.beginTrace(pc, instruction.Size)
+	}
+
+	// Every prefix and opcode is an opcode fetch that counts up R, except the
+	// opcode of DD CB and FD CB instructions, which is read like an operand
+	fetches := 1
+	for prefix := decoded.Prefix; prefix != 0; prefix >>= 8 {
+		fetches++
+	}
+	if decoded.Prefix > 0xFF {
+		fetches--
+	}
+	c.R = c.R&0x80 | (c.R+byte(fetches))&0x7F
+	c.interruptDelay = false
+
+	// Fetch the operand; the PC points past the instruction while it executes
+	var data byte
+	var word uint16
+	var displacement int8
+	switch instruction.Size - decoded.Operand {
+	case 1:
+		data = c.Memory[c.PC+uint16(decoded.Operand)]
+	case 2:
+		word = uint16(c.Memory[c.PC+uint16(decoded.Operand)]) | uint16(c.Memory[c.PC+uint16(decoded.Operand)+1])<<8
+	}
+	if decoded.Displacement >= 0 {
+		displacement = int8(c.Memory[c.PC+uint16(decoded.Displacement)])
+	}
+	c.PC += uint16(instruction.Size)
+	err = c.execute(decoded, data, word, displacement)
+
+	// Wait for the appropriate amount of time
+	c.WaitForCycles(instruction.Cycles)
+	if err != nil && err != ErrHalted {
+		return &FaultError{PC: pc, Err: err}
+	}
+	if c.tracer != nil {
+		c.traceState()
+		c.tracer.Trace(&c.record)
+	}
+	return err
+}
+
+// registerFile lists the registers and flags behind the register API. The
+// alternate registers are named with a quote, as in EX AF,AF'.
+func (c *Z80) registerFile() ([]register, []flag) {
+	registers := []register{
+		byteRegister("A", &c.A), byteRegister("B", &c.B), byteRegister("C", &c.C), byteRegister("D", &c.D),
+		byteRegister("E", &c.E), byteRegister("H", &c.H), byteRegister("L", &c.L),
+		wordRegister("IX", &c.IX), wordRegister("IY", &c.IY), wordRegister("SP", &c.SP),
+		byteRegister("A'", &c.Alternate.A), byteRegister("F'", &c.Alternate.F), byteRegister("B'", &c.Alternate.B),
+		byteRegister("C'", &c.Alternate.C), byteRegister("D'", &c.Alternate.D), byteRegister("E'", &c.Alternate.E),
+		byteRegister("H'", &c.Alternate.H), byteRegister("L'", &c.Alternate.L),
+		byteRegister("I", &c.I), byteRegister("R", &c.R),
+		{RegisterDesc{Name: "IM", Bits: 2}, func() uint16 { return uint16(c.IM) }, func(value uint16) { c.IM = uint8(value) }},
+	}
+	flags := []flag{
+		boolFlag("S", "Sign", &c.Status.Sign), boolFlag("Z", "Zero", &c.Status.Zero),
+		boolFlag("Y", "Undocumented, bit 5", &c.Status.Y), boolFlag("H", "Half carry", &c.Status.HalfCarry),
+		boolFlag("X", "Undocumented, bit 3", &c.Status.X), boolFlag("P", "Parity/overflow", &c.Status.ParityOverflow),
+		boolFlag("N", "Subtract", &c.Status.Subtract), boolFlag("C", "Carry", &c.Status.Carry),
+		boolFlag("IFF1", "Interrupts enabled", &c.IFF1), boolFlag("IFF2", "Copy of IFF1 during NMI", &c.IFF2),
+	}
+	return registers, flags
+}
+
+// SetPin sets the level of an input pin: INT or NMI. INT is taken while it
+// is high and interrupts are enabled, except right after EI; NMI on a rising
+// edge. Interrupts are taken before the next instruction.
+func (c *Z80) SetPin(name string, high bool) error {
+	switch name {
+	case "INT":
+		c.intLine = high
+	case "NMI":
+		if high && !c.nmiLine {
+			c.nmiPending = true
+		}
+		c.nmiLine = high
+	default:
+		return fmt.Errorf("the Z80 has no input pin %s (available: INT, NMI)", name)
+	}
+	return nil
+}
+
+// InterruptPending reports whether an interrupt will be taken before the next
+// instruction, which also ends HALT
+func (c *Z80) InterruptPending() bool {
+	return c.nmiPending || c.intLine && c.IFF1 && !c.interruptDelay
+}
+
+// interrupt takes a pending interrupt. A non-maskable interrupt jumps to
+// 0066H and keeps the interrupt enable state in IFF2 for RETN to restore.
+// INT is taken as IM selects: in mode 0 InterruptData is the RST to execute,
+// mode 1 jumps to 0038H and in mode 2 InterruptData is the low byte of the
+// vector address.
+func (c *Z80) interrupt() {
+	if !c.InterruptPending() {
+		return
+	}
+	c.R = c.R&0x80 | (c.R+1)&0x7F
+	c.Push16(c.PC)
+	if c.nmiPending {
+		c.nmiPending = false
+		c.IFF1 = false
+		c.PC = Z80NMIAddress
+		c.WZ = c.PC
+		c.WaitForCycles(11)
+		return
+	}
+
+	c.IFF1, c.IFF2 = false, false
+	switch c.IM {
+	case 0:
+		c.PC = uint16(c.InterruptData & 0x38)
+		c.WaitForCycles(13)
+	case 1:
+		c.PC = Z80IM1Address
+		c.WaitForCycles(13)
+	default:
+		vector := uint16(c.I)<<8 | uint16(c.InterruptData)
+		c.PC = uint16(c.Read(vector+1))<<8 | uint16(c.Read(vector))
+		c.WaitForCycles(19)
+	}
+	c.WZ = c.PC
+}
+
+// execute executes a decoded instruction whose operands, if any, are data,
+// word and the index displacement. Registers, pairs, conditions and
+// operations are decoded from the opcode fields x (bits 7-6), y (bits 5-3)
+// and z (bits 2-0), with p and q the high and low bits of y.
+func (c *Z80) execute(decoded Decoded, data byte, word uint16, displacement int8) error {
+	instruction := decoded.Instruction
+	if instruction.Mnemonic == "NOP" {
+		return nil
+	}
+	opcode := instruction.Opcode
+
+	// DD and FD select IX or IY; a memory operand is then at IX+d and H and L stay themselves
+	index := decoded.Prefix
+	if index > 0xFF {
+		index >>= 8 // DD CB and FD CB
+	}
+	c.index = 0
+	if index == 0xDD || index == 0xFD {
+		c.index = byte(index)
+	}
+	c.memory = c.hl()
+	c.halves = c.index != 0
+	if decoded.Displacement >= 0 {
+		c.memory += uint16(displacement)
+		c.WZ = c.memory
+		c.halves = false
+	}
+
+	switch decoded.Prefix {
+	case 0xCB, 0xDDCB, 0xFDCB:
+		c.executeBit(opcode)
+		return nil
+	case 0xED:
+		return c.executeExtended(opcode, word)
+	}
+	return c.executeMain(opcode, data, word)
+}
+
+// executeMain executes an unprefixed instruction, or one after DD or FD
+func (c *Z80) executeMain(opcode byte, data byte, word uint16) error {
+	x, y, z := opcode>>6, opcode>>3&7, opcode&7
+	p := y >> 1
+
+	switch x {
+	case 1:
+		// LD r,r' (01 yyy zzz); LD (HL),(HL) is HALT
+		if opcode == 0x76 {
+			return ErrHalted
+		}
+		c.setRegister(y, c.register(z))
+		return nil
+	case 2:
+		// ALU operations on registers (10 yyy zzz)
+		c.alu(y, c.register(z))
+		return nil
+	}
+
+	switch opcode {
+	case 0x08: // EX AF,AF'
+		f := c.GetF()
+		c.A, c.Alternate.A = c.Alternate.A, c.A
+		c.SetF(c.Alternate.F)
+		c.Alternate.F = f
+	case 0x10: // DJNZ
+		c.B--
+		if c.B != 0 {
+			c.jump(data)
+			c.AddCycles(5)
+		}
+	case 0x18: // JR
+		c.jump(data)
+	case 0x20, 0x28, 0x30, 0x38: // JR cc
+		if c.condition(y - 4) {
+			c.jump(data)
+			c.AddCycles(5)
+		}
+	case 0x01, 0x11, 0x21, 0x31: // LD rp,nn
+		c.setPair(p, word)
+	case 0x09, 0x19, 0x29, 0x39: // ADD HL,rp
+		c.setHL(c.add16(c.hl(), c.pair(p)))
+	case 0x02, 0x12: // LD (BC),A and LD (DE),A
+		addr := c.pair(p)
+		c.store(addr, c.A)
+		c.WZ = uint16(c.A)<<8 | (addr+1)&0xFF
+	case 0x0A, 0x1A: // LD A,(BC) and LD A,(DE)
+		addr := c.pair(p)
+		c.A = c.load(addr)
+		c.WZ = addr + 1
+	case 0x22: // LD (nn),HL
+		hl := c.hl()
+		c.store(word, byte(hl))
+		c.store(word+1, byte(hl>>8))
+		c.WZ = word + 1
+	case 0x2A: // LD HL,(nn)
+		c.setHL(uint16(c.load(word)) | uint16(c.load(word+1))<<8)
+		c.WZ = word + 1
+	case 0x32: // LD (nn),A
+		c.store(word, c.A)
+		c.WZ = uint16(c.A)<<8 | (word+1)&0xFF
+	case 0x3A: // LD A,(nn)
+		c.A = c.load(word)
+		c.WZ = word + 1
+	case 0x03, 0x13, 0x23, 0x33: // INC rp
+		c.setPair(p, c.pair(p)+1)
+	case 0x0B, 0x1B, 0x2B, 0x3B: // DEC rp
+		c.setPair(p, c.pair(p)-1)
+	case 0x04, 0x0C, 0x14, 0x1C, 0x24, 0x2C, 0x34, 0x3C: // INC r
+		c.setRegister(y, c.increment(c.register(y)))
+	case 0x05, 0x0D, 0x15, 0x1D, 0x25, 0x2D, 0x35, 0x3D: // DEC r
+		c.setRegister(y, c.decrement(c.register(y)))
+	case 0x06, 0x0E, 0x16, 0x1E, 0x26, 0x2E, 0x36, 0x3E: // LD r,n
+		c.setRegister(y, data)
+
+	// Accumulator and flag operations
+	case 0x07, 0x0F, 0x17, 0x1F: // RLCA, RRCA, RLA and RRA leave S, Z and P/V alone
+		sign, zero, parity := c.Status.Sign, c.Status.Zero, c.Status.ParityOverflow
+		c.A = c.rotate(y, c.A)
+		c.Status.Sign, c.Status.Zero, c.Status.ParityOverflow = sign, zero, parity
+	case 0x27: // DAA
+		c.decimalAdjust()
+	case 0x2F: // CPL
+		c.A = ^c.A
+		c.Status.HalfCarry, c.Status.Subtract = true, true
+		c.setXY(c.A)
+	case 0x37: // SCF
+		c.Status.Carry = true
+		c.Status.HalfCarry, c.Status.Subtract = false, false
+		c.setXY(c.A)
+	case 0x3F: // CCF
+		c.Status.HalfCarry = c.Status.Carry
+		c.Status.Carry = !c.Status.Carry
+		c.Status.Subtract = false
+		c.setXY(c.A)
+
+	// ALU operations on immediate data (11 yyy 110)
+	case 0xC6, 0xCE, 0xD6, 0xDE, 0xE6, 0xEE, 0xF6, 0xFE:
+		c.alu(y, data)
+
+	// Jumps, calls and returns
+	case 0xC3: // JP nn
+		c.PC, c.WZ = word, word
+	case 0xC2, 0xCA, 0xD2, 0xDA, 0xE2, 0xEA, 0xF2, 0xFA: // JP cc,nn
+		c.WZ = word
+		if c.condition(y) {
+			c.PC = word
+		}
+	case 0xCD: // CALL nn
+		c.Push16(c.PC)
+		c.PC, c.WZ = word, word
+	case 0xC4, 0xCC, 0xD4, 0xDC, 0xE4, 0xEC, 0xF4, 0xFC: // CALL cc,nn
+		c.WZ = word
+		if c.condition(y) {
+			c.Push16(c.PC)
+			c.PC = word
+			c.AddCycles(7)
+		}
+	case 0xC9: // RET
+		c.PC = c.Pull16()
+		c.WZ = c.PC
+	case 0xC0, 0xC8, 0xD0, 0xD8, 0xE0, 0xE8, 0xF0, 0xF8: // RET cc
+		if c.condition(y) {
+			c.PC = c.Pull16()
+			c.WZ = c.PC
+			c.AddCycles(6)
+		}
+	case 0xC7, 0xCF, 0xD7, 0xDF, 0xE7, 0xEF, 0xF7, 0xFF: // RST
+		c.Push16(c.PC)
+		c.PC = uint16(opcode & 0x38)
+		c.WZ = c.PC
+	case 0xE9: // JP (HL)
+		c.PC = c.hl()
+
+	// Stack and exchange operations
+	case 0xC5, 0xD5, 0xE5: // PUSH BC, DE, HL
+		c.Push16(c.pair(p))
+	case 0xF5: // PUSH AF
+		c.Push16(uint16(c.A)<<8 | uint16(c.GetF()))
+	case 0xC1, 0xD1, 0xE1: // POP BC, DE, HL
+		c.setPair(p, c.Pull16())
+	case 0xF1: // POP AF
+		af := c.Pull16()
+		c.A = byte(af >> 8)
+		c.SetF(byte(af))
+	case 0xE3: // EX (SP),HL
+		value := uint16(c.load(c.SP)) | uint16(c.load(c.SP+1))<<8
+		hl := c.hl()
+		c.store(c.SP, byte(hl))
+		c.store(c.SP+1, byte(hl>>8))
+		c.setHL(value)
+		c.WZ = value
+	case 0xF9: // LD SP,HL
+		c.SP = c.hl()
+	case 0xEB: // EX DE,HL, which the index prefixes do not change
+		c.D, c.E, c.H, c.L = c.H, c.L, c.D, c.E
+	case 0xD9: // EXX
+		alt := &c.Alternate
+		c.B, c.C, alt.B, alt.C = alt.B, alt.C, c.B, c.C
+		c.D, c.E, alt.D, alt.E = alt.D, alt.E, c.D, c.E
+		c.H, c.L, alt.H, alt.L = alt.H, alt.L, c.H, c.L
+
+	// Input/output and machine control
+	case 0xDB: // IN A,(n)
+		c.WZ = uint16(c.A)<<8 | uint16(data) + 1
+		c.A = c.In(data)
+	case 0xD3: // OUT (n),A
+		c.Out(data, c.A)
+		c.WZ = uint16(c.A)<<8 | uint16(data+1)
+	case 0xF3: // DI
+		c.IFF1, c.IFF2 = false, false
+	case 0xFB: // EI
+		c.IFF1, c.IFF2 = true, true
+		c.interruptDelay = true
+
+	default:
+		return fmt.Errorf("instruction not implemented: $%02X", opcode)
+	}
+	return nil
+}
+
+// executeBit executes a rotate, shift or bit instruction after CB, DD CB or
+// FD CB. Indexed forms other than BIT also copy the result to the register
+// in the opcode unless it is (HL).
+func (c *Z80) executeBit(opcode byte) {
+	x, y, z := opcode>>6, opcode>>3&7, opcode&7
+	indexed := c.index != 0
+	r := z
+	if indexed {
+		r = 6
+	}
+	value := c.register(r)
+
+	var result byte
+	switch x {
+	case 0: // Rotates and shifts
+		result = c.rotate(y, value)
+	case 1: // BIT
+		c.testBit(y, value)
+		switch {
+		case indexed, r == 6:
+			// The undocumented flags come from the high byte of the address latch
+			c.setXY(byte(c.WZ >> 8))
+		default:
+			c.setXY(value)
+		}
+		return
+	case 2: // RES
+		result = value &^ (1 << y)
+	default: // SET
+		result = value | 1<<y
+	}
+	c.setRegister(r, result)
+	if indexed && z != 6 {
+		c.halves = false
+		c.setRegister(z, result)
+	}
+}
+
+// executeExtended executes an instruction after the ED prefix
+func (c *Z80) executeExtended(opcode byte, word uint16) error {
+	x, y, z := opcode>>6, opcode>>3&7, opcode&7
+	p, q := y>>1, y&1
+
+	if x == 2 {
+		c.executeBlock(y, z)
+		return nil
+	}
+
+	switch z {
+	case 0: // IN r,(C); IN F,(C) only sets the flags
+		value := c.In(c.C)
+		c.WZ = c.pair(0) + 1
+		c.Status.HalfCarry, c.Status.Subtract = false, false
+		c.setSZP(value)
+		if y != 6 {
+			c.setRegister(y, value)
+		}
+	case 1: // OUT (C),r; OUT (C),0 writes zero
+		var value byte
+		if y != 6 {
+			value = c.register(y)
+		}
+		c.Out(c.C, value)
+		c.WZ = c.pair(0) + 1
+	case 2: // SBC HL,rp and ADC HL,rp
+		if q == 0 {
+			c.setHL(c.subtract16(c.hl(), c.pair(p)))
+		} else {
+			c.setHL(c.addCarry16(c.hl(), c.pair(p)))
+		}
+	case 3: // LD (nn),rp and LD rp,(nn)
+		if q == 0 {
+			value := c.pair(p)
+			c.store(word, byte(value))
+			c.store(word+1, byte(value>>8))
+		} else {
+			c.setPair(p, uint16(c.load(word))|uint16(c.load(word+1))<<8)
+		}
+		c.WZ = word + 1
+	case 4: // NEG
+		value := c.A
+		c.A = 0
+		c.A = c.subtract(value, 0)
+	case 5: // RETN and RETI restore the interrupt enable state saved by an NMI
+		c.PC = c.Pull16()
+		c.WZ = c.PC
+		c.IFF1 = c.IFF2
+	case 6: // IM 0, 1 or 2
+		c.IM = []uint8{0, 0, 1, 2}[y&3]
+	case 7:
+		switch y {
+		case 0: // LD I,A
+			c.I = c.A
+		case 1: // LD R,A
+			c.R = c.A
+		case 2, 3: // LD A,I and LD A,R copy IFF2 to P/V
+			c.A = c.I
+			if y == 3 {
+				c.A = c.R
+			}
+			c.setSZXY(c.A)
+			c.Status.HalfCarry, c.Status.Subtract = false, false
+			c.Status.ParityOverflow = c.IFF2
+		case 4: // RRD
+			value := c.load(c.hl())
+			c.store(c.hl(), c.A<<4|value>>4)
+			c.A = c.A&0xF0 | value&0x0F
+			c.rotateDigitFlags()
+		case 5: // RLD
+			value := c.load(c.hl())
+			c.store(c.hl(), value<<4|c.A&0x0F)
+			c.A = c.A&0xF0 | value>>4
+			c.rotateDigitFlags()
+		}
+	}
+	return nil
+}
+
+// executeBlock executes a block transfer, search or I/O instruction (ED 10 yyy 0zz):
+// LDI, CPI, INI and OUTI move up with y = 4, the D forms move down with
+// y = 5, and y = 6 and 7 are their repeating forms, which run again until done.
+func (c *Z80) executeBlock(y, z byte) {
+	step := uint16(1)
+	if y&1 != 0 {
+		step = 0xFFFF
+	}
+	hl := c.pair(2)
+	again := false
+
+	switch z {
+	case 0: // LDI, LDD, LDIR and LDDR
+		value := c.load(hl)
+		de := c.pair(1)
+		c.store(de, value)
+		c.setPair(1, de+step)
+		c.setPair(0, c.pair(0)-1)
+		n := value + c.A
+		c.Status.X, c.Status.Y = n&0x08 != 0, n&0x02 != 0
+		c.Status.HalfCarry, c.Status.Subtract = false, false
+		c.Status.ParityOverflow = c.pair(0) != 0
+		again = c.Status.ParityOverflow
+	case 1: // CPI, CPD, CPIR and CPDR
+		value := c.load(hl)
+		result := c.A - value
+		c.Status.HalfCarry = c.A&0x0F < value&0x0F
+		c.Status.Sign, c.Status.Zero = result&0x80 != 0, result == 0
+		c.Status.Subtract = true
+		c.setPair(0, c.pair(0)-1)
+		c.Status.ParityOverflow = c.pair(0) != 0
+		n := result - byte(boolToInt(c.Status.HalfCarry))
+		c.Status.X, c.Status.Y = n&0x08 != 0, n&0x02 != 0
+		c.WZ += step
+		again = c.Status.ParityOverflow && !c.Status.Zero
+	case 2: // INI, IND, INIR and INDR
+		value := c.In(c.C)
+		c.store(hl, value)
+		c.WZ = c.pair(0) + step
+		c.B--
+		c.blockIOFlags(value, c.C+byte(step))
+		again = c.B != 0
+	case 3: // OUTI, OUTD, OTIR and OTDR
+		value := c.load(hl)
+		c.B--
+		c.Out(c.C, value)
+		c.WZ = c.pair(0) + step
+		c.blockIOFlags(value, byte(hl+step))
+	}
+	c.setPair(2, hl+step)
+	if z == 3 {
+		again = c.B != 0
+	}
+
+	if y >= 6 && again {
+		c.PC -= 2
+		c.WZ = c.PC + 1
+		c.AddCycles(5)
+	}
+}
+
+// blockIOFlags sets the flags after INI, OUTI and their variants from the byte
+// transferred and the low byte of the register it was added to
+func (c *Z80) blockIOFlags(value, addend byte) {
+	sum := uint16(value) + uint16(addend)
+	c.setSZXY(c.B)
+	c.Status.Subtract = value&0x80 != 0
+	c.Status.HalfCarry, c.Status.Carry = sum > 0xFF, sum > 0xFF
+	c.Status.ParityOverflow = parity(byte(sum)&0x07 ^ c.B)
+}
+
+// register reads a register by its 3-bit code: B, C, D, E, H, L, (HL) or A.
+// After DD or FD, 6 is the memory byte at IX+d or IY+d, and 4 and 5 are the
+// halves of the index register unless the instruction also uses memory.
+func (c *Z80) register(r byte) byte {
+	switch r {
+	case 0:
+		return c.B
+	case 1:
+		return c.C
+	case 2:
+		return c.D
+	case 3:
+		return c.E
+	case 4:
+		if c.halves {
+			return byte(c.hl() >> 8)
+		}
+		return c.H
+	case 5:
+		if c.halves {
+			return byte(c.hl())
+		}
+		return c.L
+	case 6:
+		return c.load(c.memory)
+	}
+	return c.A
+}
+
+// setRegister writes a register by its 3-bit code, as register reads it
+func (c *Z80) setRegister(r byte, value byte) {
+	switch r {
+	case 0:
+		c.B = value
+	case 1:
+		c.C = value
+	case 2:
+		c.D = value
+	case 3:
+		c.E = value
+	case 4:
+		if c.halves {
+			c.setHL(uint16(value)<<8 | c.hl()&0x00FF)
+		} else {
+			c.H = value
+		}
+	case 5:
+		if c.halves {
+			c.setHL(c.hl()&0xFF00 | uint16(value))
+		} else {
+			c.L = value
+		}
+	case 6:
+		c.store(c.memory, value)
+	default:
+		c.A = value
+	}
+}
+
+// pair reads a register pair by its 2-bit code: BC, DE, HL (or IX or IY) or SP
+func (c *Z80) pair(rp byte) uint16 {
+	switch rp {
+	case 0:
+		return uint16(c.B)<<8 | uint16(c.C)
+	case 1:
+		return uint16(c.D)<<8 | uint16(c.E)
+	case 2:
+		return c.hl()
+	}
+	return c.SP
+}
+
+// setPair writes a register pair by its 2-bit code: BC, DE, HL (or IX or IY) or SP
+func (c *Z80) setPair(rp byte, value uint16) {
+	switch rp {
+	case 0:
+		c.B, c.C = byte(value>>8), byte(value)
+	case 1:
+		c.D, c.E = byte(value>>8), byte(value)
+	case 2:
+		c.setHL(value)
+	default:
+		c.SP = value
+	}
+}
+
+// hl returns HL, or the index register the instruction's prefix selects
+func (c *Z80) hl() uint16 {
+	switch c.index {
+	case 0xDD:
+		return c.IX
+	case 0xFD:
+		return c.IY
+	}
+	return uint16(c.H)<<8 | uint16(c.L)
+}
+
+// setHL sets HL, or the index register the instruction's prefix selects
+func (c *Z80) setHL(value uint16) {
+	switch c.index {
+	case 0xDD:
+		c.IX = value
+	case 0xFD:
+		c.IY = value
+	default:
+		c.H, c.L = byte(value>>8), byte(value)
+	}
+}
+
+// condition tests a condition by its 3-bit code: NZ, Z, NC, C, PO, PE, P or M
+func (c *Z80) condition(cc byte) bool {
+	switch cc {
+	case 0:
+		return !c.Status.Zero
+	case 1:
+		return c.Status.Zero
+	case 2:
+		return !c.Status.Carry
+	case 3:
+		return c.Status.Carry
+	case 4:
+		return !c.Status.ParityOverflow
+	case 5:
+		return c.Status.ParityOverflow
+	case 6:
+		return !c.Status.Sign
+	}
+	return c.Status.Sign
+}
+
+// jump adds a relative jump's signed offset to the PC, which points past the instruction
+func (c *Z80) jump(offset byte) {
+	c.PC += uint16(int8(offset))
+	c.WZ = c.PC
+}
+
+// alu performs an ALU operation by its 3-bit code on the accumulator:
+// ADD, ADC, SUB, SBC, AND, XOR, OR or CP
+func (c *Z80) alu(operation byte, value byte) {
+	carry := byte(boolToInt(c.Status.Carry))
+	switch operation {
+	case 0: // ADD
+		c.A = c.add(value, 0)
+	case 1: // ADC
+		c.A = c.add(value, carry)
+	case 2: // SUB
+		c.A = c.subtract(value, 0)
+	case 3: // SBC
+		c.A = c.subtract(value, carry)
+	case 4: // AND
+		c.A &= value
+		c.logicFlags(true)
+	case 5: // XOR
+		c.A ^= value
+		c.logicFlags(false)
+	case 6: // OR
+		c.A |= value
+		c.logicFlags(false)
+	default: // CP takes the undocumented flags from the operand
+		c.subtract(value, 0)
+		c.setXY(value)
+	}
+}
+
+// add returns A + value + carry and sets all flags
+func (c *Z80) add(value, carry byte) byte {
+	result := uint16(c.A) + uint16(value) + uint16(carry)
+	c.Status.HalfCarry = c.A&0x0F+value&0x0F+carry > 0x0F
+	c.Status.ParityOverflow = (c.A^value)&0x80 == 0 && (c.A^byte(result))&0x80 != 0
+	c.Status.Subtract = false
+	c.Status.Carry = result > 0xFF
+	c.setSZXY(byte(result))
+	return byte(result)
+}
+
+// subtract returns A - value - borrow and sets all flags
+func (c *Z80) subtract(value, borrow byte) byte {
+	result := uint16(c.A) - uint16(value) - uint16(borrow)
+	c.Status.HalfCarry = int(c.A&0x0F)-int(value&0x0F)-int(borrow) < 0
+	c.Status.ParityOverflow = (c.A^value)&0x80 != 0 && (c.A^byte(result))&0x80 != 0
+	c.Status.Subtract = true
+	c.Status.Carry = result > 0xFF
+	c.setSZXY(byte(result))
+	return byte(result)
+}
+
+// logicFlags sets the flags after AND, XOR and OR; only AND sets H
+func (c *Z80) logicFlags(halfCarry bool) {
+	c.setSZP(c.A)
+	c.Status.HalfCarry = halfCarry
+	c.Status.Subtract, c.Status.Carry = false, false
+}
+
+// increment returns value + 1 and sets the flags of INC; C is unchanged
+func (c *Z80) increment(value byte) byte {
+	result := value + 1
+	c.Status.HalfCarry = value&0x0F == 0x0F
+	c.Status.ParityOverflow = value == 0x7F
+	c.Status.Subtract = false
+	c.setSZXY(result)
+	return result
+}
+
+// decrement returns value - 1 and sets the flags of DEC; C is unchanged
+func (c *Z80) decrement(value byte) byte {
+	result := value - 1
+	c.Status.HalfCarry = value&0x0F == 0
+	c.Status.ParityOverflow = value == 0x80
+	c.Status.Subtract = true
+	c.setSZXY(result)
+	return result
+}
+
+// add16 returns a + b for ADD HL,rp, which sets H, N and C and the
+// undocumented flags from the high byte
+func (c *Z80) add16(a, b uint16) uint16 {
+	result := uint32(a) + uint32(b)
+	c.Status.HalfCarry = a&0x0FFF+b&0x0FFF > 0x0FFF
+	c.Status.Subtract = false
+	c.Status.Carry = result > 0xFFFF
+	c.setXY(byte(result >> 8))
+	c.WZ = a + 1
+	return uint16(result)
+}
+
+// addCarry16 returns a + b + carry for ADC HL,rp and sets all flags
+func (c *Z80) addCarry16(a, b uint16) uint16 {
+	carry := uint32(boolToInt(c.Status.Carry))
+	result := uint32(a) + uint32(b) + carry
+	c.Status.HalfCarry = uint32(a&0x0FFF)+uint32(b&0x0FFF)+carry > 0x0FFF
+	c.Status.ParityOverflow = (a^b)&0x8000 == 0 && (a^uint16(result))&0x8000 != 0
+	c.Status.Subtract = false
+	c.Status.Carry = result > 0xFFFF
+	c.setSZXY(byte(result >> 8))
+	c.Status.Zero = uint16(result) == 0
+	c.WZ = a + 1
+	return uint16(result)
+}
+
+// subtract16 returns a - b - carry for SBC HL,rp and sets all flags
+func (c *Z80) subtract16(a, b uint16) uint16 {
+	borrow := uint32(boolToInt(c.Status.Carry))
+	result := uint32(a) - uint32(b) - borrow
+	c.Status.HalfCarry = int(a&0x0FFF)-int(b&0x0FFF)-int(borrow) < 0
+	c.Status.ParityOverflow = (a^b)&0x8000 != 0 && (a^uint16(result))&0x8000 != 0
+	c.Status.Subtract = true
+	c.Status.Carry = result > 0xFFFF
+	c.setSZXY(byte(result >> 8))
+	c.Status.Zero = uint16(result) == 0
+	c.WZ = a + 1
+	return uint16(result)
+}
+
+// rotate performs a rotate or shift by its 3-bit code and sets the flags:
+// RLC, RRC, RL, RR, SLA, SRA, SLL (undocumented, shifts in a 1) or SRL
+func (c *Z80) rotate(operation byte, value byte) byte {
+	carry := byte(boolToInt(c.Status.Carry))
+	var result byte
+	switch operation {
+	case 0: // RLC
+		result = value<<1 | value>>7
+	case 1: // RRC
+		result = value>>1 | value<<7
+	case 2: // RL
+		result = value<<1 | carry
+	case 3: // RR
+		result = value>>1 | carry<<7
+	case 4: // SLA
+		result = value << 1
+	case 5: // SRA
+		result = value>>1 | value&0x80
+	case 6: // SLL
+		result = value<<1 | 0x01
+	default: // SRL
+		result = value >> 1
+	}
+	if operation&1 == 0 {
+		c.Status.Carry = value&0x80 != 0
+	} else {
+		c.Status.Carry = value&0x01 != 0
+	}
+	c.Status.HalfCarry, c.Status.Subtract = false, false
+	c.setSZP(result)
+	return result
+}
+
+// testBit sets the flags of BIT n: Z and P/V when the bit is clear, S when
+// it is bit 7 and set, and H
+func (c *Z80) testBit(bit byte, value byte) {
+	set := value&(1<<bit) != 0
+	c.Status.Zero, c.Status.ParityOverflow = !set, !set
+	c.Status.Sign = bit == 7 && set
+	c.Status.HalfCarry, c.Status.Subtract = true, false
+}
+
+// decimalAdjust corrects the accumulator after adding or subtracting two BCD numbers (DAA)
+func (c *Z80) decimalAdjust() {
+	var correction byte
+	carry := c.Status.Carry
+	if c.Status.HalfCarry || c.A&0x0F > 9 {
+		correction |= 0x06
+	}
+	if c.Status.Carry || c.A > 0x99 {
+		correction |= 0x60
+		carry = true
+	}
+	if c.Status.Subtract {
+		c.Status.HalfCarry = c.Status.HalfCarry && c.A&0x0F < 6
+		c.A -= correction
+	} else {
+		c.Status.HalfCarry = c.A&0x0F > 9
+		c.A += correction
+	}
+	c.Status.Carry = carry
+	c.setSZP(c.A)
+}
+
+// rotateDigitFlags sets the flags after RLD and RRD; C is unchanged
+func (c *Z80) rotateDigitFlags() {
+	c.Status.HalfCarry, c.Status.Subtract = false, false
+	c.setSZP(c.A)
+	c.WZ = c.hl() + 1
+}
+
+// setSZP sets S, Z, P/V as parity and the undocumented flags from a result
+func (c *Z80) setSZP(value byte) {
+	c.setSZXY(value)
+	c.Status.ParityOverflow = parity(value)
+}
+
+// setSZXY sets S, Z and the undocumented flags from a result
+func (c *Z80) setSZXY(value byte) {
+	c.Status.Sign = value&0x80 != 0
+	c.Status.Zero = value == 0
+	c.setXY(value)
+}
+
+// setXY copies bits 5 and 3 of a value to the undocumented flags
+func (c *Z80) setXY(value byte) {
+	c.Status.Y = value&z80Y != 0
+	c.Status.X = value&z80X != 0
+}
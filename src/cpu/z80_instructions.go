@@ -0,0 +1,1938 @@
+package cpu
+
+// Z80Instructions is the unprefixed Z80 instruction set. Conditional jumps, calls and returns
+// list the cycles when the condition fails; DJNZ lists the cycles when B reaches zero.
+var Z80Instructions = map[byte]Instruction{
+
+	// 8-Bit Load Group
+	0x02: {0x02, "LD", Implied, 1, 7, "Store A at the address in BC"},
+	0x06: {0x06, "LD", Immediate, 2, 7, "Load B with immediate data"},
+	0x0A: {0x0A, "LD", Implied, 1, 7, "Load A from the address in BC"},
+	0x0E: {0x0E, "LD", Immediate, 2, 7, "Load C with immediate data"},
+	0x12: {0x12, "LD", Implied, 1, 7, "Store A at the address in DE"},
+	0x16: {0x16, "LD", Immediate, 2, 7, "Load D with immediate data"},
+	0x1A: {0x1A, "LD", Implied, 1, 7, "Load A from the address in DE"},
+	0x1E: {0x1E, "LD", Immediate, 2, 7, "Load E with immediate data"},
+	0x26: {0x26, "LD", Immediate, 2, 7, "Load H with immediate data"},
+	0x2E: {0x2E, "LD", Immediate, 2, 7, "Load L with immediate data"},
+	0x32: {0x32, "LD", Absolute, 3, 13, "Store A at the address"},
+	0x36: {0x36, "LD", Immediate, 2, 10, "Load memory at HL with immediate data"},
+	0x3A: {0x3A, "LD", Absolute, 3, 13, "Load A from the address"},
+	0x3E: {0x3E, "LD", Immediate, 2, 7, "Load A with immediate data"},
+	0x40: {0x40, "LD", Implied, 1, 4, "Load B with B"},
+	0x41: {0x41, "LD", Implied, 1, 4, "Load B with C"},
+	0x42: {0x42, "LD", Implied, 1, 4, "Load B with D"},
+	0x43: {0x43, "LD", Implied, 1, 4, "Load B with E"},
+	0x44: {0x44, "LD", Implied, 1, 4, "Load B with H"},
+	0x45: {0x45, "LD", Implied, 1, 4, "Load B with L"},
+	0x46: {0x46, "LD", Implied, 1, 7, "Load B with memory at HL"},
+	0x47: {0x47, "LD", Implied, 1, 4, "Load B with A"},
+	0x48: {0x48, "LD", Implied, 1, 4, "Load C with B"},
+	0x49: {0x49, "LD", Implied, 1, 4, "Load C with C"},
+	0x4A: {0x4A, "LD", Implied, 1, 4, "Load C with D"},
+	0x4B: {0x4B, "LD", Implied, 1, 4, "Load C with E"},
+	0x4C: {0x4C, "LD", Implied, 1, 4, "Load C with H"},
+	0x4D: {0x4D, "LD", Implied, 1, 4, "Load C with L"},
+	0x4E: {0x4E, "LD", Implied, 1, 7, "Load C with memory at HL"},
+	0x4F: {0x4F, "LD", Implied, 1, 4, "Load C with A"},
+	0x50: {0x50, "LD", Implied, 1, 4, "Load D with B"},
+	0x51: {0x51, "LD", Implied, 1, 4, "Load D with C"},
+	0x52: {0x52, "LD", Implied, 1, 4, "Load D with D"},
+	0x53: {0x53, "LD", Implied, 1, 4, "Load D with E"},
+	0x54: {0x54, "LD", Implied, 1, 4, "Load D with H"},
+	0x55: {0x55, "LD", Implied, 1, 4, "Load D with L"},
+	0x56: {0x56, "LD", Implied, 1, 7, "Load D with memory at HL"},
+	0x57: {0x57, "LD", Implied, 1, 4, "Load D with A"},
+	0x58: {0x58, "LD", Implied, 1, 4, "Load E with B"},
+	0x59: {0x59, "LD", Implied, 1, 4, "Load E with C"},
+	0x5A: {0x5A, "LD", Implied, 1, 4, "Load E with D"},
+	0x5B: {0x5B, "LD", Implied, 1, 4, "Load E with E"},
+	0x5C: {0x5C, "LD", Implied, 1, 4, "Load E with H"},
+	0x5D: {0x5D, "LD", Implied, 1, 4, "Load E with L"},
+	0x5E: {0x5E, "LD", Implied, 1, 7, "Load E with memory at HL"},
+	0x5F: {0x5F, "LD", Implied, 1, 4, "Load E with A"},
+	0x60: {0x60, "LD", Implied, 1, 4, "Load H with B"},
+	0x61: {0x61, "LD", Implied, 1, 4, "Load H with C"},
+	0x62: {0x62, "LD", Implied, 1, 4, "Load H with D"},
+	0x63: {0x63, "LD", Implied, 1, 4, "Load H with E"},
+	0x64: {0x64, "LD", Implied, 1, 4, "Load H with H"},
+	0x65: {0x65, "LD", Implied, 1, 4, "Load H with L"},
+	0x66: {0x66, "LD", Implied, 1, 7, "Load H with memory at HL"},
+	0x67: {0x67, "LD", Implied, 1, 4, "Load H with A"},
+	0x68: {0x68, "LD", Implied, 1, 4, "Load L with B"},
+	0x69: {0x69, "LD", Implied, 1, 4, "Load L with C"},
+	0x6A: {0x6A, "LD", Implied, 1, 4, "Load L with D"},
+	0x6B: {0x6B, "LD", Implied, 1, 4, "Load L with E"},
+	0x6C: {0x6C, "LD", Implied, 1, 4, "Load L with H"},
+	0x6D: {0x6D, "LD", Implied, 1, 4, "Load L with L"},
+	0x6E: {0x6E, "LD", Implied, 1, 7, "Load L with memory at HL"},
+	0x6F: {0x6F, "LD", Implied, 1, 4, "Load L with A"},
+	0x70: {0x70, "LD", Implied, 1, 7, "Load memory at HL with B"},
+	0x71: {0x71, "LD", Implied, 1, 7, "Load memory at HL with C"},
+	0x72: {0x72, "LD", Implied, 1, 7, "Load memory at HL with D"},
+	0x73: {0x73, "LD", Implied, 1, 7, "Load memory at HL with E"},
+	0x74: {0x74, "LD", Implied, 1, 7, "Load memory at HL with H"},
+	0x75: {0x75, "LD", Implied, 1, 7, "Load memory at HL with L"},
+	0x77: {0x77, "LD", Implied, 1, 7, "Load memory at HL with A"},
+	0x78: {0x78, "LD", Implied, 1, 4, "Load A with B"},
+	0x79: {0x79, "LD", Implied, 1, 4, "Load A with C"},
+	0x7A: {0x7A, "LD", Implied, 1, 4, "Load A with D"},
+	0x7B: {0x7B, "LD", Implied, 1, 4, "Load A with E"},
+	0x7C: {0x7C, "LD", Implied, 1, 4, "Load A with H"},
+	0x7D: {0x7D, "LD", Implied, 1, 4, "Load A with L"},
+	0x7E: {0x7E, "LD", Implied, 1, 7, "Load A with memory at HL"},
+	0x7F: {0x7F, "LD", Implied, 1, 4, "Load A with A"},
+
+	// 16-Bit Load Group
+	0x01: {0x01, "LD", Immediate16, 3, 10, "Load BC with immediate data"},
+	0x11: {0x11, "LD", Immediate16, 3, 10, "Load DE with immediate data"},
+	0x21: {0x21, "LD", Immediate16, 3, 10, "Load HL with immediate data"},
+	0x22: {0x22, "LD", Absolute, 3, 16, "Store HL at the address"},
+	0x2A: {0x2A, "LD", Absolute, 3, 16, "Load HL from the address"},
+	0x31: {0x31, "LD", Immediate16, 3, 10, "Load SP with immediate data"},
+	0xF9: {0xF9, "LD", Implied, 1, 6, "Load SP with HL"},
+
+	// Stack Group
+	0xC1: {0xC1, "POP", Implied, 1, 10, "Pop BC from the stack"},
+	0xC5: {0xC5, "PUSH", Implied, 1, 11, "Push BC on the stack"},
+	0xD1: {0xD1, "POP", Implied, 1, 10, "Pop DE from the stack"},
+	0xD5: {0xD5, "PUSH", Implied, 1, 11, "Push DE on the stack"},
+	0xE1: {0xE1, "POP", Implied, 1, 10, "Pop HL from the stack"},
+	0xE5: {0xE5, "PUSH", Implied, 1, 11, "Push HL on the stack"},
+	0xF1: {0xF1, "POP", Implied, 1, 10, "Pop AF from the stack"},
+	0xF5: {0xF5, "PUSH", Implied, 1, 11, "Push AF on the stack"},
+
+	// Exchange Group
+	0x08: {0x08, "EX", Implied, 1, 4, "Exchange AF with the alternate AF'"},
+	0xD9: {0xD9, "EXX", Implied, 1, 4, "Exchange BC, DE and HL with the alternate registers"},
+	0xE3: {0xE3, "EX", Implied, 1, 19, "Exchange HL with the top of the stack"},
+	0xEB: {0xEB, "EX", Implied, 1, 4, "Exchange DE with HL"},
+
+	// 8-Bit Arithmetic and Logical Group
+	0x04: {0x04, "INC", Implied, 1, 4, "Increment B"},
+	0x05: {0x05, "DEC", Implied, 1, 4, "Decrement B"},
+	0x0C: {0x0C, "INC", Implied, 1, 4, "Increment C"},
+	0x0D: {0x0D, "DEC", Implied, 1, 4, "Decrement C"},
+	0x14: {0x14, "INC", Implied, 1, 4, "Increment D"},
+	0x15: {0x15, "DEC", Implied, 1, 4, "Decrement D"},
+	0x1C: {0x1C, "INC", Implied, 1, 4, "Increment E"},
+	0x1D: {0x1D, "DEC", Implied, 1, 4, "Decrement E"},
+	0x24: {0x24, "INC", Implied, 1, 4, "Increment H"},
+	0x25: {0x25, "DEC", Implied, 1, 4, "Decrement H"},
+	0x2C: {0x2C, "INC", Implied, 1, 4, "Increment L"},
+	0x2D: {0x2D, "DEC", Implied, 1, 4, "Decrement L"},
+	0x34: {0x34, "INC", Implied, 1, 11, "Increment memory at HL"},
+	0x35: {0x35, "DEC", Implied, 1, 11, "Decrement memory at HL"},
+	0x3C: {0x3C, "INC", Implied, 1, 4, "Increment A"},
+	0x3D: {0x3D, "DEC", Implied, 1, 4, "Decrement A"},
+	0x80: {0x80, "ADD", Implied, 1, 4, "Add B to A"},
+	0x81: {0x81, "ADD", Implied, 1, 4, "Add C to A"},
+	0x82: {0x82, "ADD", Implied, 1, 4, "Add D to A"},
+	0x83: {0x83, "ADD", Implied, 1, 4, "Add E to A"},
+	0x84: {0x84, "ADD", Implied, 1, 4, "Add H to A"},
+	0x85: {0x85, "ADD", Implied, 1, 4, "Add L to A"},
+	0x86: {0x86, "ADD", Implied, 1, 7, "Add memory at HL to A"},
+	0x87: {0x87, "ADD", Implied, 1, 4, "Add A to A"},
+	0x88: {0x88, "ADC", Implied, 1, 4, "Add B and the carry to A"},
+	0x89: {0x89, "ADC", Implied, 1, 4, "Add C and the carry to A"},
+	0x8A: {0x8A, "ADC", Implied, 1, 4, "Add D and the carry to A"},
+	0x8B: {0x8B, "ADC", Implied, 1, 4, "Add E and the carry to A"},
+	0x8C: {0x8C, "ADC", Implied, 1, 4, "Add H and the carry to A"},
+	0x8D: {0x8D, "ADC", Implied, 1, 4, "Add L and the carry to A"},
+	0x8E: {0x8E, "ADC", Implied, 1, 7, "Add memory at HL and the carry to A"},
+	0x8F: {0x8F, "ADC", Implied, 1, 4, "Add A and the carry to A"},
+	0x90: {0x90, "SUB", Implied, 1, 4, "Subtract B from A"},
+	0x91: {0x91, "SUB", Implied, 1, 4, "Subtract C from A"},
+	0x92: {0x92, "SUB", Implied, 1, 4, "Subtract D from A"},
+	0x93: {0x93, "SUB", Implied, 1, 4, "Subtract E from A"},
+	0x94: {0x94, "SUB", Implied, 1, 4, "Subtract H from A"},
+	0x95: {0x95, "SUB", Implied, 1, 4, "Subtract L from A"},
+	0x96: {0x96, "SUB", Implied, 1, 7, "Subtract memory at HL from A"},
+	0x97: {0x97, "SUB", Implied, 1, 4, "Subtract A from A"},
+	0x98: {0x98, "SBC", Implied, 1, 4, "Subtract B and the carry from A"},
+	0x99: {0x99, "SBC", Implied, 1, 4, "Subtract C and the carry from A"},
+	0x9A: {0x9A, "SBC", Implied, 1, 4, "Subtract D and the carry from A"},
+	0x9B: {0x9B, "SBC", Implied, 1, 4, "Subtract E and the carry from A"},
+	0x9C: {0x9C, "SBC", Implied, 1, 4, "Subtract H and the carry from A"},
+	0x9D: {0x9D, "SBC", Implied, 1, 4, "Subtract L and the carry from A"},
+	0x9E: {0x9E, "SBC", Implied, 1, 7, "Subtract memory at HL and the carry from A"},
+	0x9F: {0x9F, "SBC", Implied, 1, 4, "Subtract A and the carry from A"},
+	0xA0: {0xA0, "AND", Implied, 1, 4, "AND B with A"},
+	0xA1: {0xA1, "AND", Implied, 1, 4, "AND C with A"},
+	0xA2: {0xA2, "AND", Implied, 1, 4, "AND D with A"},
+	0xA3: {0xA3, "AND", Implied, 1, 4, "AND E with A"},
+	0xA4: {0xA4, "AND", Implied, 1, 4, "AND H with A"},
+	0xA5: {0xA5, "AND", Implied, 1, 4, "AND L with A"},
+	0xA6: {0xA6, "AND", Implied, 1, 7, "AND memory at HL with A"},
+	0xA7: {0xA7, "AND", Implied, 1, 4, "AND A with A"},
+	0xA8: {0xA8, "XOR", Implied, 1, 4, "Exclusive OR B with A"},
+	0xA9: {0xA9, "XOR", Implied, 1, 4, "Exclusive OR C with A"},
+	0xAA: {0xAA, "XOR", Implied, 1, 4, "Exclusive OR D with A"},
+	0xAB: {0xAB, "XOR", Implied, 1, 4, "Exclusive OR E with A"},
+	0xAC: {0xAC, "XOR", Implied, 1, 4, "Exclusive OR H with A"},
+	0xAD: {0xAD, "XOR", Implied, 1, 4, "Exclusive OR L with A"},
+	0xAE: {0xAE, "XOR", Implied, 1, 7, "Exclusive OR memory at HL with A"},
+	0xAF: {0xAF, "XOR", Implied, 1, 4, "Exclusive OR A with A"},
+	0xB0: {0xB0, "OR", Implied, 1, 4, "OR B with A"},
+	0xB1: {0xB1, "OR", Implied, 1, 4, "OR C with A"},
+	0xB2: {0xB2, "OR", Implied, 1, 4, "OR D with A"},
+	0xB3: {0xB3, "OR", Implied, 1, 4, "OR E with A"},
+	0xB4: {0xB4, "OR", Implied, 1, 4, "OR H with A"},
+	0xB5: {0xB5, "OR", Implied, 1, 4, "OR L with A"},
+	0xB6: {0xB6, "OR", Implied, 1, 7, "OR memory at HL with A"},
+	0xB7: {0xB7, "OR", Implied, 1, 4, "OR A with A"},
+	0xB8: {0xB8, "CP", Implied, 1, 4, "Compare B with A"},
+	0xB9: {0xB9, "CP", Implied, 1, 4, "Compare C with A"},
+	0xBA: {0xBA, "CP", Implied, 1, 4, "Compare D with A"},
+	0xBB: {0xBB, "CP", Implied, 1, 4, "Compare E with A"},
+	0xBC: {0xBC, "CP", Implied, 1, 4, "Compare H with A"},
+	0xBD: {0xBD, "CP", Implied, 1, 4, "Compare L with A"},
+	0xBE: {0xBE, "CP", Implied, 1, 7, "Compare memory at HL with A"},
+	0xBF: {0xBF, "CP", Implied, 1, 4, "Compare A with A"},
+	0xC6: {0xC6, "ADD", Immediate, 2, 7, "Add immediate data to A"},
+	0xCE: {0xCE, "ADC", Immediate, 2, 7, "Add immediate data and the carry to A"},
+	0xD6: {0xD6, "SUB", Immediate, 2, 7, "Subtract immediate data from A"},
+	0xDE: {0xDE, "SBC", Immediate, 2, 7, "Subtract immediate data and the carry from A"},
+	0xE6: {0xE6, "AND", Immediate, 2, 7, "AND immediate data with A"},
+	0xEE: {0xEE, "XOR", Immediate, 2, 7, "Exclusive OR immediate data with A"},
+	0xF6: {0xF6, "OR", Immediate, 2, 7, "OR immediate data with A"},
+	0xFE: {0xFE, "CP", Immediate, 2, 7, "Compare immediate data with A"},
+
+	// 16-Bit Arithmetic Group
+	0x03: {0x03, "INC", Implied, 1, 6, "Increment BC"},
+	0x09: {0x09, "ADD", Implied, 1, 11, "Add BC to HL"},
+	0x0B: {0x0B, "DEC", Implied, 1, 6, "Decrement BC"},
+	0x13: {0x13, "INC", Implied, 1, 6, "Increment DE"},
+	0x19: {0x19, "ADD", Implied, 1, 11, "Add DE to HL"},
+	0x1B: {0x1B, "DEC", Implied, 1, 6, "Decrement DE"},
+	0x23: {0x23, "INC", Implied, 1, 6, "Increment HL"},
+	0x29: {0x29, "ADD", Implied, 1, 11, "Add HL to HL"},
+	0x2B: {0x2B, "DEC", Implied, 1, 6, "Decrement HL"},
+	0x33: {0x33, "INC", Implied, 1, 6, "Increment SP"},
+	0x39: {0x39, "ADD", Implied, 1, 11, "Add SP to HL"},
+	0x3B: {0x3B, "DEC", Implied, 1, 6, "Decrement SP"},
+
+	// General-Purpose Arithmetic and CPU Control Group
+	0x00: {0x00, "NOP", Implied, 1, 4, "No operation"},
+	0x27: {0x27, "DAA", Implied, 1, 4, "Decimal adjust A"},
+	0x2F: {0x2F, "CPL", Implied, 1, 4, "Complement A"},
+	0x37: {0x37, "SCF", Implied, 1, 4, "Set the carry flag"},
+	0x3F: {0x3F, "CCF", Implied, 1, 4, "Complement the carry flag"},
+	0x76: {0x76, "HALT", Implied, 1, 4, "Halt until an interrupt"},
+	0xF3: {0xF3, "DI", Implied, 1, 4, "Disable interrupts"},
+	0xFB: {0xFB, "EI", Implied, 1, 4, "Enable interrupts after the next instruction"},
+
+	// Rotate and Shift Group
+	0x07: {0x07, "RLCA", Implied, 1, 4, "Rotate A left circular"},
+	0x0F: {0x0F, "RRCA", Implied, 1, 4, "Rotate A right circular"},
+	0x17: {0x17, "RLA", Implied, 1, 4, "Rotate A left through the carry"},
+	0x1F: {0x1F, "RRA", Implied, 1, 4, "Rotate A right through the carry"},
+
+	// Jump Group
+	0x10: {0x10, "DJNZ", Relative, 2, 8, "Decrement B and jump relative if not zero"},
+	0x18: {0x18, "JR", Relative, 2, 12, "Jump relative"},
+	0x20: {0x20, "JR", Relative, 2, 7, "Jump relative if not zero"},
+	0x28: {0x28, "JR", Relative, 2, 7, "Jump relative if zero"},
+	0x30: {0x30, "JR", Relative, 2, 7, "Jump relative if no carry"},
+	0x38: {0x38, "JR", Relative, 2, 7, "Jump relative if carry"},
+	0xC2: {0xC2, "JP", Absolute, 3, 10, "Jump if not zero"},
+	0xC3: {0xC3, "JP", Absolute, 3, 10, "Jump to the address"},
+	0xCA: {0xCA, "JP", Absolute, 3, 10, "Jump if zero"},
+	0xD2: {0xD2, "JP", Absolute, 3, 10, "Jump if no carry"},
+	0xDA: {0xDA, "JP", Absolute, 3, 10, "Jump if carry"},
+	0xE2: {0xE2, "JP", Absolute, 3, 10, "Jump if parity odd"},
+	0xE9: {0xE9, "JP", Implied, 1, 4, "Jump to the address in HL"},
+	0xEA: {0xEA, "JP", Absolute, 3, 10, "Jump if parity even"},
+	0xF2: {0xF2, "JP", Absolute, 3, 10, "Jump if plus"},
+	0xFA: {0xFA, "JP", Absolute, 3, 10, "Jump if minus"},
+
+	// Call and Return Group
+	0xC0: {0xC0, "RET", Implied, 1, 5, "Return if not zero"},
+	0xC4: {0xC4, "CALL", Absolute, 3, 10, "Call if not zero"},
+	0xC7: {0xC7, "RST", Implied, 1, 11, "Call the restart at $0000"},
+	0xC8: {0xC8, "RET", Implied, 1, 5, "Return if zero"},
+	0xC9: {0xC9, "RET", Implied, 1, 10, "Return from a subroutine"},
+	0xCC: {0xCC, "CALL", Absolute, 3, 10, "Call if zero"},
+	0xCD: {0xCD, "CALL", Absolute, 3, 17, "Call the subroutine at the address"},
+	0xCF: {0xCF, "RST", Implied, 1, 11, "Call the restart at $0008"},
+	0xD0: {0xD0, "RET", Implied, 1, 5, "Return if no carry"},
+	0xD4: {0xD4, "CALL", Absolute, 3, 10, "Call if no carry"},
+	0xD7: {0xD7, "RST", Implied, 1, 11, "Call the restart at $0010"},
+	0xD8: {0xD8, "RET", Implied, 1, 5, "Return if carry"},
+	0xDC: {0xDC, "CALL", Absolute, 3, 10, "Call if carry"},
+	0xDF: {0xDF, "RST", Implied, 1, 11, "Call the restart at $0018"},
+	0xE0: {0xE0, "RET", Implied, 1, 5, "Return if parity odd"},
+	0xE4: {0xE4, "CALL", Absolute, 3, 10, "Call if parity odd"},
+	0xE7: {0xE7, "RST", Implied, 1, 11, "Call the restart at $0020"},
+	0xE8: {0xE8, "RET", Implied, 1, 5, "Return if parity even"},
+	0xEC: {0xEC, "CALL", Absolute, 3, 10, "Call if parity even"},
+	0xEF: {0xEF, "RST", Implied, 1, 11, "Call the restart at $0028"},
+	0xF0: {0xF0, "RET", Implied, 1, 5, "Return if plus"},
+	0xF4: {0xF4, "CALL", Absolute, 3, 10, "Call if plus"},
+	0xF7: {0xF7, "RST", Implied, 1, 11, "Call the restart at $0030"},
+	0xF8: {0xF8, "RET", Implied, 1, 5, "Return if minus"},
+	0xFC: {0xFC, "CALL", Absolute, 3, 10, "Call if minus"},
+	0xFF: {0xFF, "RST", Implied, 1, 11, "Call the restart at $0038"},
+
+	// Input and Output Group
+	0xD3: {0xD3, "OUT", Immediate, 2, 11, "Output A to the port"},
+	0xDB: {0xDB, "IN", Immediate, 2, 11, "Input A from the port"},
+
+	// Prefixes
+	0xCB: {0xCB, "", Prefix, 1, 0, "Prefix of the bit instructions"},
+	0xDD: {0xDD, "", Prefix, 1, 0, "Prefix of the IX instructions"},
+	0xED: {0xED, "", Prefix, 1, 0, "Prefix of the extended instructions"},
+	0xFD: {0xFD, "", Prefix, 1, 0, "Prefix of the IY instructions"},
+}
+
+// Z80CBInstructions are the rotate, shift and bit instructions after the CB prefix
+var Z80CBInstructions = map[byte]Instruction{
+
+	// Rotate and Shift Group
+	0x00: {0x00, "RLC", Implied, 2, 8, "Rotate B left circular"},
+	0x01: {0x01, "RLC", Implied, 2, 8, "Rotate C left circular"},
+	0x02: {0x02, "RLC", Implied, 2, 8, "Rotate D left circular"},
+	0x03: {0x03, "RLC", Implied, 2, 8, "Rotate E left circular"},
+	0x04: {0x04, "RLC", Implied, 2, 8, "Rotate H left circular"},
+	0x05: {0x05, "RLC", Implied, 2, 8, "Rotate L left circular"},
+	0x06: {0x06, "RLC", Implied, 2, 15, "Rotate memory at HL left circular"},
+	0x07: {0x07, "RLC", Implied, 2, 8, "Rotate A left circular"},
+	0x08: {0x08, "RRC", Implied, 2, 8, "Rotate B right circular"},
+	0x09: {0x09, "RRC", Implied, 2, 8, "Rotate C right circular"},
+	0x0A: {0x0A, "RRC", Implied, 2, 8, "Rotate D right circular"},
+	0x0B: {0x0B, "RRC", Implied, 2, 8, "Rotate E right circular"},
+	0x0C: {0x0C, "RRC", Implied, 2, 8, "Rotate H right circular"},
+	0x0D: {0x0D, "RRC", Implied, 2, 8, "Rotate L right circular"},
+	0x0E: {0x0E, "RRC", Implied, 2, 15, "Rotate memory at HL right circular"},
+	0x0F: {0x0F, "RRC", Implied, 2, 8, "Rotate A right circular"},
+	0x10: {0x10, "RL", Implied, 2, 8, "Rotate B left through the carry"},
+	0x11: {0x11, "RL", Implied, 2, 8, "Rotate C left through the carry"},
+	0x12: {0x12, "RL", Implied, 2, 8, "Rotate D left through the carry"},
+	0x13: {0x13, "RL", Implied, 2, 8, "Rotate E left through the carry"},
+	0x14: {0x14, "RL", Implied, 2, 8, "Rotate H left through the carry"},
+	0x15: {0x15, "RL", Implied, 2, 8, "Rotate L left through the carry"},
+	0x16: {0x16, "RL", Implied, 2, 15, "Rotate memory at HL left through the carry"},
+	0x17: {0x17, "RL", Implied, 2, 8, "Rotate A left through the carry"},
+	0x18: {0x18, "RR", Implied, 2, 8, "Rotate B right through the carry"},
+	0x19: {0x19, "RR", Implied, 2, 8, "Rotate C right through the carry"},
+	0x1A: {0x1A, "RR", Implied, 2, 8, "Rotate D right through the carry"},
+	0x1B: {0x1B, "RR", Implied, 2, 8, "Rotate E right through the carry"},
+	0x1C: {0x1C, "RR", Implied, 2, 8, "Rotate H right through the carry"},
+	0x1D: {0x1D, "RR", Implied, 2, 8, "Rotate L right through the carry"},
+	0x1E: {0x1E, "RR", Implied, 2, 15, "Rotate memory at HL right through the carry"},
+	0x1F: {0x1F, "RR", Implied, 2, 8, "Rotate A right through the carry"},
+	0x20: {0x20, "SLA", Implied, 2, 8, "Shift B left arithmetic"},
+	0x21: {0x21, "SLA", Implied, 2, 8, "Shift C left arithmetic"},
+	0x22: {0x22, "SLA", Implied, 2, 8, "Shift D left arithmetic"},
+	0x23: {0x23, "SLA", Implied, 2, 8, "Shift E left arithmetic"},
+	0x24: {0x24, "SLA", Implied, 2, 8, "Shift H left arithmetic"},
+	0x25: {0x25, "SLA", Implied, 2, 8, "Shift L left arithmetic"},
+	0x26: {0x26, "SLA", Implied, 2, 15, "Shift memory at HL left arithmetic"},
+	0x27: {0x27, "SLA", Implied, 2, 8, "Shift A left arithmetic"},
+	0x28: {0x28, "SRA", Implied, 2, 8, "Shift B right arithmetic"},
+	0x29: {0x29, "SRA", Implied, 2, 8, "Shift C right arithmetic"},
+	0x2A: {0x2A, "SRA", Implied, 2, 8, "Shift D right arithmetic"},
+	0x2B: {0x2B, "SRA", Implied, 2, 8, "Shift E right arithmetic"},
+	0x2C: {0x2C, "SRA", Implied, 2, 8, "Shift H right arithmetic"},
+	0x2D: {0x2D, "SRA", Implied, 2, 8, "Shift L right arithmetic"},
+	0x2E: {0x2E, "SRA", Implied, 2, 15, "Shift memory at HL right arithmetic"},
+	0x2F: {0x2F, "SRA", Implied, 2, 8, "Shift A right arithmetic"},
+	0x30: {0x30, "SLL", Implied, 2, 8, "Shift B left and set bit 0 (undocumented)"},
+	0x31: {0x31, "SLL", Implied, 2, 8, "Shift C left and set bit 0 (undocumented)"},
+	0x32: {0x32, "SLL", Implied, 2, 8, "Shift D left and set bit 0 (undocumented)"},
+	0x33: {0x33, "SLL", Implied, 2, 8, "Shift E left and set bit 0 (undocumented)"},
+	0x34: {0x34, "SLL", Implied, 2, 8, "Shift H left and set bit 0 (undocumented)"},
+	0x35: {0x35, "SLL", Implied, 2, 8, "Shift L left and set bit 0 (undocumented)"},
+	0x36: {0x36, "SLL", Implied, 2, 15, "Shift memory at HL left and set bit 0 (undocumented)"},
+	0x37: {0x37, "SLL", Implied, 2, 8, "Shift A left and set bit 0 (undocumented)"},
+	0x38: {0x38, "SRL", Implied, 2, 8, "Shift B right logical"},
+	0x39: {0x39, "SRL", Implied, 2, 8, "Shift C right logical"},
+	0x3A: {0x3A, "SRL", Implied, 2, 8, "Shift D right logical"},
+	0x3B: {0x3B, "SRL", Implied, 2, 8, "Shift E right logical"},
+	0x3C: {0x3C, "SRL", Implied, 2, 8, "Shift H right logical"},
+	0x3D: {0x3D, "SRL", Implied, 2, 8, "Shift L right logical"},
+	0x3E: {0x3E, "SRL", Implied, 2, 15, "Shift memory at HL right logical"},
+	0x3F: {0x3F, "SRL", Implied, 2, 8, "Shift A right logical"},
+
+	// Bit Set, Reset and Test Group
+	0x40: {0x40, "BIT", Implied, 2, 8, "Test bit 0 of B"},
+	0x41: {0x41, "BIT", Implied, 2, 8, "Test bit 0 of C"},
+	0x42: {0x42, "BIT", Implied, 2, 8, "Test bit 0 of D"},
+	0x43: {0x43, "BIT", Implied, 2, 8, "Test bit 0 of E"},
+	0x44: {0x44, "BIT", Implied, 2, 8, "Test bit 0 of H"},
+	0x45: {0x45, "BIT", Implied, 2, 8, "Test bit 0 of L"},
+	0x46: {0x46, "BIT", Implied, 2, 12, "Test bit 0 of memory at HL"},
+	0x47: {0x47, "BIT", Implied, 2, 8, "Test bit 0 of A"},
+	0x48: {0x48, "BIT", Implied, 2, 8, "Test bit 1 of B"},
+	0x49: {0x49, "BIT", Implied, 2, 8, "Test bit 1 of C"},
+	0x4A: {0x4A, "BIT", Implied, 2, 8, "Test bit 1 of D"},
+	0x4B: {0x4B, "BIT", Implied, 2, 8, "Test bit 1 of E"},
+	0x4C: {0x4C, "BIT", Implied, 2, 8, "Test bit 1 of H"},
+	0x4D: {0x4D, "BIT", Implied, 2, 8, "Test bit 1 of L"},
+	0x4E: {0x4E, "BIT", Implied, 2, 12, "Test bit 1 of memory at HL"},
+	0x4F: {0x4F, "BIT", Implied, 2, 8, "Test bit 1 of A"},
+	0x50: {0x50, "BIT", Implied, 2, 8, "Test bit 2 of B"},
+	0x51: {0x51, "BIT", Implied, 2, 8, "Test bit 2 of C"},
+	0x52: {0x52, "BIT", Implied, 2, 8, "Test bit 2 of D"},
+	0x53: {0x53, "BIT", Implied, 2, 8, "Test bit 2 of E"},
+	0x54: {0x54, "BIT", Implied, 2, 8, "Test bit 2 of H"},
+	0x55: {0x55, "BIT", Implied, 2, 8, "Test bit 2 of L"},
+	0x56: {0x56, "BIT", Implied, 2, 12, "Test bit 2 of memory at HL"},
+	0x57: {0x57, "BIT", Implied, 2, 8, "Test bit 2 of A"},
+	0x58: {0x58, "BIT", Implied, 2, 8, "Test bit 3 of B"},
+	0x59: {0x59, "BIT", Implied, 2, 8, "Test bit 3 of C"},
+	0x5A: {0x5A, "BIT", Implied, 2, 8, "Test bit 3 of D"},
+	0x5B: {0x5B, "BIT", Implied, 2, 8, "Test bit 3 of E"},
+	0x5C: {0x5C, "BIT", Implied, 2, 8, "Test bit 3 of H"},
+	0x5D: {0x5D, "BIT", Implied, 2, 8, "Test bit 3 of L"},
+	0x5E: {0x5E, "BIT", Implied, 2, 12, "Test bit 3 of memory at HL"},
+	0x5F: {0x5F, "BIT", Implied, 2, 8, "Test bit 3 of A"},
+	0x60: {0x60, "BIT", Implied, 2, 8, "Test bit 4 of B"},
+	0x61: {0x61, "BIT", Implied, 2, 8, "Test bit 4 of C"},
+	0x62: {0x62, "BIT", Implied, 2, 8, "Test bit 4 of D"},
+	0x63: {0x63, "BIT", Implied, 2, 8, "Test bit 4 of E"},
+	0x64: {0x64, "BIT", Implied, 2, 8, "Test bit 4 of H"},
+	0x65: {0x65, "BIT", Implied, 2, 8, "Test bit 4 of L"},
+	0x66: {0x66, "BIT", Implied, 2, 12, "Test bit 4 of memory at HL"},
+	0x67: {0x67, "BIT", Implied, 2, 8, "Test bit 4 of A"},
+	0x68: {0x68, "BIT", Implied, 2, 8, "Test bit 5 of B"},
+	0x69: {0x69, "BIT", Implied, 2, 8, "Test bit 5 of C"},
+	0x6A: {0x6A, "BIT", Implied, 2, 8, "Test bit 5 of D"},
+	0x6B: {0x6B, "BIT", Implied, 2, 8, "Test bit 5 of E"},
+	0x6C: {0x6C, "BIT", Implied, 2, 8, "Test bit 5 of H"},
+	0x6D: {0x6D, "BIT", Implied, 2, 8, "Test bit 5 of L"},
+	0x6E: {0x6E, "BIT", Implied, 2, 12, "Test bit 5 of memory at HL"},
+	0x6F: {0x6F, "BIT", Implied, 2, 8, "Test bit 5 of A"},
+	0x70: {0x70, "BIT", Implied, 2, 8, "Test bit 6 of B"},
+	0x71: {0x71, "BIT", Implied, 2, 8, "Test bit 6 of C"},
+	0x72: {0x72, "BIT", Implied, 2, 8, "Test bit 6 of D"},
+	0x73: {0x73, "BIT", Implied, 2, 8, "Test bit 6 of E"},
+	0x74: {0x74, "BIT", Implied, 2, 8, "Test bit 6 of H"},
+	0x75: {0x75, "BIT", Implied, 2, 8, "Test bit 6 of L"},
+	0x76: {0x76, "BIT", Implied, 2, 12, "Test bit 6 of memory at HL"},
+	0x77: {0x77, "BIT", Implied, 2, 8, "Test bit 6 of A"},
+	0x78: {0x78, "BIT", Implied, 2, 8, "Test bit 7 of B"},
+	0x79: {0x79, "BIT", Implied, 2, 8, "Test bit 7 of C"},
+	0x7A: {0x7A, "BIT", Implied, 2, 8, "Test bit 7 of D"},
+	0x7B: {0x7B, "BIT", Implied, 2, 8, "Test bit 7 of E"},
+	0x7C: {0x7C, "BIT", Implied, 2, 8, "Test bit 7 of H"},
+	0x7D: {0x7D, "BIT", Implied, 2, 8, "Test bit 7 of L"},
+	0x7E: {0x7E, "BIT", Implied, 2, 12, "Test bit 7 of memory at HL"},
+	0x7F: {0x7F, "BIT", Implied, 2, 8, "Test bit 7 of A"},
+	0x80: {0x80, "RES", Implied, 2, 8, "Reset bit 0 of B"},
+	0x81: {0x81, "RES", Implied, 2, 8, "Reset bit 0 of C"},
+	0x82: {0x82, "RES", Implied, 2, 8, "Reset bit 0 of D"},
+	0x83: {0x83, "RES", Implied, 2, 8, "Reset bit 0 of E"},
+	0x84: {0x84, "RES", Implied, 2, 8, "Reset bit 0 of H"},
+	0x85: {0x85, "RES", Implied, 2, 8, "Reset bit 0 of L"},
+	0x86: {0x86, "RES", Implied, 2, 15, "Reset bit 0 of memory at HL"},
+	0x87: {0x87, "RES", Implied, 2, 8, "Reset bit 0 of A"},
+	0x88: {0x88, "RES", Implied, 2, 8, "Reset bit 1 of B"},
+	0x89: {0x89, "RES", Implied, 2, 8, "Reset bit 1 of C"},
+	0x8A: {0x8A, "RES", Implied, 2, 8, "Reset bit 1 of D"},
+	0x8B: {0x8B, "RES", Implied, 2, 8, "Reset bit 1 of E"},
+	0x8C: {0x8C, "RES", Implied, 2, 8, "Reset bit 1 of H"},
+	0x8D: {0x8D, "RES", Implied, 2, 8, "Reset bit 1 of L"},
+	0x8E: {0x8E, "RES", Implied, 2, 15, "Reset bit 1 of memory at HL"},
+	0x8F: {0x8F, "RES", Implied, 2, 8, "Reset bit 1 of A"},
+	0x90: {0x90, "RES", Implied, 2, 8, "Reset bit 2 of B"},
+	0x91: {0x91, "RES", Implied, 2, 8, "Reset bit 2 of C"},
+	0x92: {0x92, "RES", Implied, 2, 8, "Reset bit 2 of D"},
+	0x93: {0x93, "RES", Implied, 2, 8, "Reset bit 2 of E"},
+	0x94: {0x94, "RES", Implied, 2, 8, "Reset bit 2 of H"},
+	0x95: {0x95, "RES", Implied, 2, 8, "Reset bit 2 of L"},
+	0x96: {0x96, "RES", Implied, 2, 15, "Reset bit 2 of memory at HL"},
+	0x97: {0x97, "RES", Implied, 2, 8, "Reset bit 2 of A"},
+	0x98: {0x98, "RES", Implied, 2, 8, "Reset bit 3 of B"},
+	0x99: {0x99, "RES", Implied, 2, 8, "Reset bit 3 of C"},
+	0x9A: {0x9A, "RES", Implied, 2, 8, "Reset bit 3 of D"},
+	0x9B: {0x9B, "RES", Implied, 2, 8, "Reset bit 3 of E"},
+	0x9C: {0x9C, "RES", Implied, 2, 8, "Reset bit 3 of H"},
+	0x9D: {0x9D, "RES", Implied, 2, 8, "Reset bit 3 of L"},
+	0x9E: {0x9E, "RES", Implied, 2, 15, "Reset bit 3 of memory at HL"},
+	0x9F: {0x9F, "RES", Implied, 2, 8, "Reset bit 3 of A"},
+	0xA0: {0xA0, "RES", Implied, 2, 8, "Reset bit 4 of B"},
+	0xA1: {0xA1, "RES", Implied, 2, 8, "Reset bit 4 of C"},
+	0xA2: {0xA2, "RES", Implied, 2, 8, "Reset bit 4 of D"},
+	0xA3: {0xA3, "RES", Implied, 2, 8, "Reset bit 4 of E"},
+	0xA4: {0xA4, "RES", Implied, 2, 8, "Reset bit 4 of H"},
+	0xA5: {0xA5, "RES", Implied, 2, 8, "Reset bit 4 of L"},
+	0xA6: {0xA6, "RES", Implied, 2, 15, "Reset bit 4 of memory at HL"},
+	0xA7: {0xA7, "RES", Implied, 2, 8, "Reset bit 4 of A"},
+	0xA8: {0xA8, "RES", Implied, 2, 8, "Reset bit 5 of B"},
+	0xA9: {0xA9, "RES", Implied, 2, 8, "Reset bit 5 of C"},
+	0xAA: {0xAA, "RES", Implied, 2, 8, "Reset bit 5 of D"},
+	0xAB: {0xAB, "RES", Implied, 2, 8, "Reset bit 5 of E"},
+	0xAC: {0xAC, "RES", Implied, 2, 8, "Reset bit 5 of H"},
+	0xAD: {0xAD, "RES", Implied, 2, 8, "Reset bit 5 of L"},
+	0xAE: {0xAE, "RES", Implied, 2, 15, "Reset bit 5 of memory at HL"},
+	0xAF: {0xAF, "RES", Implied, 2, 8, "Reset bit 5 of A"},
+	0xB0: {0xB0, "RES", Implied, 2, 8, "Reset bit 6 of B"},
+	0xB1: {0xB1, "RES", Implied, 2, 8, "Reset bit 6 of C"},
+	0xB2: {0xB2, "RES", Implied, 2, 8, "Reset bit 6 of D"},
+	0xB3: {0xB3, "RES", Implied, 2, 8, "Reset bit 6 of E"},
+	0xB4: {0xB4, "RES", Implied, 2, 8, "Reset bit 6 of H"},
+	0xB5: {0xB5, "RES", Implied, 2, 8, "Reset bit 6 of L"},
+	0xB6: {0xB6, "RES", Implied, 2, 15, "Reset bit 6 of memory at HL"},
+	0xB7: {0xB7, "RES", Implied, 2, 8, "Reset bit 6 of A"},
+	0xB8: {0xB8, "RES", Implied, 2, 8, "Reset bit 7 of B"},
+	0xB9: {0xB9, "RES", Implied, 2, 8, "Reset bit 7 of C"},
+	0xBA: {0xBA, "RES", Implied, 2, 8, "Reset bit 7 of D"},
+	0xBB: {0xBB, "RES", Implied, 2, 8, "Reset bit 7 of E"},
+	0xBC: {0xBC, "RES", Implied, 2, 8, "Reset bit 7 of H"},
+	0xBD: {0xBD, "RES", Implied, 2, 8, "Reset bit 7 of L"},
+	0xBE: {0xBE, "RES", Implied, 2, 15, "Reset bit 7 of memory at HL"},
+	0xBF: {0xBF, "RES", Implied, 2, 8, "Reset bit 7 of A"},
+	0xC0: {0xC0, "SET", Implied, 2, 8, "Set bit 0 of B"},
+	0xC1: {0xC1, "SET", Implied, 2, 8, "Set bit 0 of C"},
+	0xC2: {0xC2, "SET", Implied, 2, 8, "Set bit 0 of D"},
+	0xC3: {0xC3, "SET", Implied, 2, 8, "Set bit 0 of E"},
+	0xC4: {0xC4, "SET", Implied, 2, 8, "Set bit 0 of H"},
+	0xC5: {0xC5, "SET", Implied, 2, 8, "Set bit 0 of L"},
+	0xC6: {0xC6, "SET", Implied, 2, 15, "Set bit 0 of memory at HL"},
+	0xC7: {0xC7, "SET", Implied, 2, 8, "Set bit 0 of A"},
+	0xC8: {0xC8, "SET", Implied, 2, 8, "Set bit 1 of B"},
+	0xC9: {0xC9, "SET", Implied, 2, 8, "Set bit 1 of C"},
+	0xCA: {0xCA, "SET", Implied, 2, 8, "Set bit 1 of D"},
+	0xCB: {0xCB, "SET", Implied, 2, 8, "Set bit 1 of E"},
+	0xCC: {0xCC, "SET", Implied, 2, 8, "Set bit 1 of H"},
+	0xCD: {0xCD, "SET", Implied, 2, 8, "Set bit 1 of L"},
+	0xCE: {0xCE, "SET", Implied, 2, 15, "Set bit 1 of memory at HL"},
+	0xCF: {0xCF, "SET", Implied, 2, 8, "Set bit 1 of A"},
+	0xD0: {0xD0, "SET", Implied, 2, 8, "Set bit 2 of B"},
+	0xD1: {0xD1, "SET", Implied, 2, 8, "Set bit 2 of C"},
+	0xD2: {0xD2, "SET", Implied, 2, 8, "Set bit 2 of D"},
+	0xD3: {0xD3, "SET", Implied, 2, 8, "Set bit 2 of E"},
+	0xD4: {0xD4, "SET", Implied, 2, 8, "Set bit 2 of H"},
+	0xD5: {0xD5, "SET", Implied, 2, 8, "Set bit 2 of L"},
+	0xD6: {0xD6, "SET", Implied, 2, 15, "Set bit 2 of memory at HL"},
+	0xD7: {0xD7, "SET", Implied, 2, 8, "Set bit 2 of A"},
+	0xD8: {0xD8, "SET", Implied, 2, 8, "Set bit 3 of B"},
+	0xD9: {0xD9, "SET", Implied, 2, 8, "Set bit 3 of C"},
+	0xDA: {0xDA, "SET", Implied, 2, 8, "Set bit 3 of D"},
+	0xDB: {0xDB, "SET", Implied, 2, 8, "Set bit 3 of E"},
+	0xDC: {0xDC, "SET", Implied, 2, 8, "Set bit 3 of H"},
+	0xDD: {0xDD, "SET", Implied, 2, 8, "Set bit 3 of L"},
+	0xDE: {0xDE, "SET", Implied, 2, 15, "Set bit 3 of memory at HL"},
+	0xDF: {0xDF, "SET", Implied, 2, 8, "Set bit 3 of A"},
+	0xE0: {0xE0, "SET", Implied, 2, 8, "Set bit 4 of B"},
+	0xE1: {0xE1, "SET", Implied, 2, 8, "Set bit 4 of C"},
+	0xE2: {0xE2, "SET", Implied, 2, 8, "Set bit 4 of D"},
+	0xE3: {0xE3, "SET", Implied, 2, 8, "Set bit 4 of E"},
+	0xE4: {0xE4, "SET", Implied, 2, 8, "Set bit 4 of H"},
+	0xE5: {0xE5, "SET", Implied, 2, 8, "Set bit 4 of L"},
+	0xE6: {0xE6, "SET", Implied, 2, 15, "Set bit 4 of memory at HL"},
+	0xE7: {0xE7, "SET", Implied, 2, 8, "Set bit 4 of A"},
+	0xE8: {0xE8, "SET", Implied, 2, 8, "Set bit 5 of B"},
+	0xE9: {0xE9, "SET", Implied, 2, 8, "Set bit 5 of C"},
+	0xEA: {0xEA, "SET", Implied, 2, 8, "Set bit 5 of D"},
+	0xEB: {0xEB, "SET", Implied, 2, 8, "Set bit 5 of E"},
+	0xEC: {0xEC, "SET", Implied, 2, 8, "Set bit 5 of H"},
+	0xED: {0xED, "SET", Implied, 2, 8, "Set bit 5 of L"},
+	0xEE: {0xEE, "SET", Implied, 2, 15, "Set bit 5 of memory at HL"},
+	0xEF: {0xEF, "SET", Implied, 2, 8, "Set bit 5 of A"},
+	0xF0: {0xF0, "SET", Implied, 2, 8, "Set bit 6 of B"},
+	0xF1: {0xF1, "SET", Implied, 2, 8, "Set bit 6 of C"},
+	0xF2: {0xF2, "SET", Implied, 2, 8, "Set bit 6 of D"},
+	0xF3: {0xF3, "SET", Implied, 2, 8, "Set bit 6 of E"},
+	0xF4: {0xF4, "SET", Implied, 2, 8, "Set bit 6 of H"},
+	0xF5: {0xF5, "SET", Implied, 2, 8, "Set bit 6 of L"},
+	0xF6: {0xF6, "SET", Implied, 2, 15, "Set bit 6 of memory at HL"},
+	0xF7: {0xF7, "SET", Implied, 2, 8, "Set bit 6 of A"},
+	0xF8: {0xF8, "SET", Implied, 2, 8, "Set bit 7 of B"},
+	0xF9: {0xF9, "SET", Implied, 2, 8, "Set bit 7 of C"},
+	0xFA: {0xFA, "SET", Implied, 2, 8, "Set bit 7 of D"},
+	0xFB: {0xFB, "SET", Implied, 2, 8, "Set bit 7 of E"},
+	0xFC: {0xFC, "SET", Implied, 2, 8, "Set bit 7 of H"},
+	0xFD: {0xFD, "SET", Implied, 2, 8, "Set bit 7 of L"},
+	0xFE: {0xFE, "SET", Implied, 2, 15, "Set bit 7 of memory at HL"},
+	0xFF: {0xFF, "SET", Implied, 2, 8, "Set bit 7 of A"},
+}
+
+// Z80EDInstructions are the extended instructions after the ED prefix. Opcodes the Z80 does
+// not define execute as two-byte NOPs. Repeating block instructions list the cycles of the last iteration.
+var Z80EDInstructions = map[byte]Instruction{
+
+	// 8-Bit Load Group
+	0x47: {0x47, "LD", Implied, 2, 9, "Load I with A"},
+	0x4F: {0x4F, "LD", Implied, 2, 9, "Load R with A"},
+	0x57: {0x57, "LD", Implied, 2, 9, "Load A with I"},
+	0x5F: {0x5F, "LD", Implied, 2, 9, "Load A with R"},
+
+	// 16-Bit Load Group
+	0x43: {0x43, "LD", Absolute, 4, 20, "Store BC at the address"},
+	0x4B: {0x4B, "LD", Absolute, 4, 20, "Load BC from the address"},
+	0x53: {0x53, "LD", Absolute, 4, 20, "Store DE at the address"},
+	0x5B: {0x5B, "LD", Absolute, 4, 20, "Load DE from the address"},
+	0x63: {0x63, "LD", Absolute, 4, 20, "Store HL at the address"},
+	0x6B: {0x6B, "LD", Absolute, 4, 20, "Load HL from the address"},
+	0x73: {0x73, "LD", Absolute, 4, 20, "Store SP at the address"},
+	0x7B: {0x7B, "LD", Absolute, 4, 20, "Load SP from the address"},
+
+	// Block Transfer and Search Group
+	0xA0: {0xA0, "LDI", Implied, 2, 16, "Copy memory at HL to DE, increment HL and DE, decrement BC"},
+	0xA1: {0xA1, "CPI", Implied, 2, 16, "Compare memory at HL with A, increment HL, decrement BC"},
+	0xA2: {0xA2, "INI", Implied, 2, 16, "Input from the port in C to memory at HL, increment HL, decrement B"},
+	0xA3: {0xA3, "OUTI", Implied, 2, 16, "Output memory at HL to the port in C, increment HL, decrement B"},
+	0xA8: {0xA8, "LDD", Implied, 2, 16, "Copy memory at HL to DE, decrement HL and DE, decrement BC"},
+	0xA9: {0xA9, "CPD", Implied, 2, 16, "Compare memory at HL with A, decrement HL, decrement BC"},
+	0xAA: {0xAA, "IND", Implied, 2, 16, "Input from the port in C to memory at HL, decrement HL, decrement B"},
+	0xAB: {0xAB, "OUTD", Implied, 2, 16, "Output memory at HL to the port in C, decrement HL, decrement B"},
+	0xB0: {0xB0, "LDIR", Implied, 2, 16, "Copy memory at HL to DE, increment HL and DE, decrement BC, repeat until BC is 0"},
+	0xB1: {0xB1, "CPIR", Implied, 2, 16, "Compare memory at HL with A, increment HL, decrement BC, repeat until BC is 0 or A matches"},
+	0xB2: {0xB2, "INIR", Implied, 2, 16, "Input from the port in C to memory at HL, increment HL, decrement B, repeat until B is 0"},
+	0xB3: {0xB3, "OTIR", Implied, 2, 16, "Output memory at HL to the port in C, increment HL, decrement B, repeat until B is 0"},
+	0xB8: {0xB8, "LDDR", Implied, 2, 16, "Copy memory at HL to DE, decrement HL and DE, decrement BC, repeat until BC is 0"},
+	0xB9: {0xB9, "CPDR", Implied, 2, 16, "Compare memory at HL with A, decrement HL, decrement BC, repeat until BC is 0 or A matches"},
+	0xBA: {0xBA, "INDR", Implied, 2, 16, "Input from the port in C to memory at HL, decrement HL, decrement B, repeat until B is 0"},
+	0xBB: {0xBB, "OTDR", Implied, 2, 16, "Output memory at HL to the port in C, decrement HL, decrement B, repeat until B is 0"},
+
+	// 8-Bit Arithmetic and Logical Group
+	0x44: {0x44, "NEG", Implied, 2, 8, "Negate A"},
+	0x4C: {0x4C, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+	0x54: {0x54, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+	0x5C: {0x5C, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+	0x64: {0x64, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+	0x6C: {0x6C, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+	0x74: {0x74, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+	0x7C: {0x7C, "NEG", Implied, 2, 8, "Negate A (undocumented)"},
+
+	// 16-Bit Arithmetic Group
+	0x42: {0x42, "SBC", Implied, 2, 15, "Subtract BC and the carry from HL"},
+	0x4A: {0x4A, "ADC", Implied, 2, 15, "Add BC and the carry to HL"},
+	0x52: {0x52, "SBC", Implied, 2, 15, "Subtract DE and the carry from HL"},
+	0x5A: {0x5A, "ADC", Implied, 2, 15, "Add DE and the carry to HL"},
+	0x62: {0x62, "SBC", Implied, 2, 15, "Subtract HL and the carry from HL"},
+	0x6A: {0x6A, "ADC", Implied, 2, 15, "Add HL and the carry to HL"},
+	0x72: {0x72, "SBC", Implied, 2, 15, "Subtract SP and the carry from HL"},
+	0x7A: {0x7A, "ADC", Implied, 2, 15, "Add SP and the carry to HL"},
+
+	// General-Purpose Arithmetic and CPU Control Group
+	0x00: {0x00, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x01: {0x01, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x02: {0x02, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x03: {0x03, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x04: {0x04, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x05: {0x05, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x06: {0x06, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x07: {0x07, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x08: {0x08, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x09: {0x09, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x0A: {0x0A, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x0B: {0x0B, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x0C: {0x0C, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x0D: {0x0D, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x0E: {0x0E, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x0F: {0x0F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x10: {0x10, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x11: {0x11, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x12: {0x12, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x13: {0x13, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x14: {0x14, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x15: {0x15, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x16: {0x16, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x17: {0x17, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x18: {0x18, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x19: {0x19, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x1A: {0x1A, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x1B: {0x1B, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x1C: {0x1C, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x1D: {0x1D, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x1E: {0x1E, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x1F: {0x1F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x20: {0x20, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x21: {0x21, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x22: {0x22, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x23: {0x23, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x24: {0x24, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x25: {0x25, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x26: {0x26, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x27: {0x27, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x28: {0x28, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x29: {0x29, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x2A: {0x2A, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x2B: {0x2B, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x2C: {0x2C, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x2D: {0x2D, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x2E: {0x2E, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x2F: {0x2F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x30: {0x30, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x31: {0x31, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x32: {0x32, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x33: {0x33, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x34: {0x34, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x35: {0x35, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x36: {0x36, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x37: {0x37, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x38: {0x38, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x39: {0x39, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x3A: {0x3A, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x3B: {0x3B, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x3C: {0x3C, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x3D: {0x3D, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x3E: {0x3E, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x3F: {0x3F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x46: {0x46, "IM", Implied, 2, 8, "Set interrupt mode 0"},
+	0x4E: {0x4E, "IM", Implied, 2, 8, "Set interrupt mode 0 (undocumented)"},
+	0x56: {0x56, "IM", Implied, 2, 8, "Set interrupt mode 1"},
+	0x5E: {0x5E, "IM", Implied, 2, 8, "Set interrupt mode 2"},
+	0x66: {0x66, "IM", Implied, 2, 8, "Set interrupt mode 0 (undocumented)"},
+	0x6E: {0x6E, "IM", Implied, 2, 8, "Set interrupt mode 0 (undocumented)"},
+	0x76: {0x76, "IM", Implied, 2, 8, "Set interrupt mode 1 (undocumented)"},
+	0x77: {0x77, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x7E: {0x7E, "IM", Implied, 2, 8, "Set interrupt mode 2 (undocumented)"},
+	0x7F: {0x7F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x80: {0x80, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x81: {0x81, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x82: {0x82, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x83: {0x83, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x84: {0x84, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x85: {0x85, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x86: {0x86, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x87: {0x87, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x88: {0x88, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x89: {0x89, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x8A: {0x8A, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x8B: {0x8B, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x8C: {0x8C, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x8D: {0x8D, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x8E: {0x8E, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x8F: {0x8F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x90: {0x90, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x91: {0x91, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x92: {0x92, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x93: {0x93, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x94: {0x94, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x95: {0x95, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x96: {0x96, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x97: {0x97, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x98: {0x98, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x99: {0x99, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x9A: {0x9A, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x9B: {0x9B, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x9C: {0x9C, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x9D: {0x9D, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x9E: {0x9E, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0x9F: {0x9F, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xA4: {0xA4, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xA5: {0xA5, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xA6: {0xA6, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xA7: {0xA7, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xAC: {0xAC, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xAD: {0xAD, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xAE: {0xAE, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xAF: {0xAF, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xB4: {0xB4, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xB5: {0xB5, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xB6: {0xB6, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xB7: {0xB7, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xBC: {0xBC, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xBD: {0xBD, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xBE: {0xBE, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xBF: {0xBF, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC0: {0xC0, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC1: {0xC1, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC2: {0xC2, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC3: {0xC3, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC4: {0xC4, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC5: {0xC5, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC6: {0xC6, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC7: {0xC7, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC8: {0xC8, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xC9: {0xC9, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xCA: {0xCA, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xCB: {0xCB, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xCC: {0xCC, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xCD: {0xCD, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xCE: {0xCE, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xCF: {0xCF, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD0: {0xD0, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD1: {0xD1, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD2: {0xD2, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD3: {0xD3, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD4: {0xD4, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD5: {0xD5, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD6: {0xD6, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD7: {0xD7, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD8: {0xD8, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xD9: {0xD9, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xDA: {0xDA, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xDB: {0xDB, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xDC: {0xDC, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xDD: {0xDD, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xDE: {0xDE, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xDF: {0xDF, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE0: {0xE0, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE1: {0xE1, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE2: {0xE2, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE3: {0xE3, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE4: {0xE4, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE5: {0xE5, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE6: {0xE6, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE7: {0xE7, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE8: {0xE8, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xE9: {0xE9, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xEA: {0xEA, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xEB: {0xEB, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xEC: {0xEC, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xED: {0xED, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xEE: {0xEE, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xEF: {0xEF, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF0: {0xF0, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF1: {0xF1, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF2: {0xF2, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF3: {0xF3, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF4: {0xF4, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF5: {0xF5, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF6: {0xF6, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF7: {0xF7, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF8: {0xF8, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xF9: {0xF9, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xFA: {0xFA, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xFB: {0xFB, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xFC: {0xFC, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xFD: {0xFD, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xFE: {0xFE, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+	0xFF: {0xFF, "NOP", Implied, 2, 8, "No operation (undocumented)"},
+
+	// Rotate and Shift Group
+	0x67: {0x67, "RRD", Implied, 2, 18, "Rotate the BCD digit right between A and memory at HL"},
+	0x6F: {0x6F, "RLD", Implied, 2, 18, "Rotate the BCD digit left between A and memory at HL"},
+
+	// Call and Return Group
+	0x45: {0x45, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt"},
+	0x4D: {0x4D, "RETI", Implied, 2, 14, "Return from an interrupt"},
+	0x55: {0x55, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt (undocumented)"},
+	0x5D: {0x5D, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt (undocumented)"},
+	0x65: {0x65, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt (undocumented)"},
+	0x6D: {0x6D, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt (undocumented)"},
+	0x75: {0x75, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt (undocumented)"},
+	0x7D: {0x7D, "RETN", Implied, 2, 14, "Return from a non-maskable interrupt (undocumented)"},
+
+	// Input and Output Group
+	0x40: {0x40, "IN", Implied, 2, 12, "Input B from the port in C"},
+	0x41: {0x41, "OUT", Implied, 2, 12, "Output B to the port in C"},
+	0x48: {0x48, "IN", Implied, 2, 12, "Input C from the port in C"},
+	0x49: {0x49, "OUT", Implied, 2, 12, "Output C to the port in C"},
+	0x50: {0x50, "IN", Implied, 2, 12, "Input D from the port in C"},
+	0x51: {0x51, "OUT", Implied, 2, 12, "Output D to the port in C"},
+	0x58: {0x58, "IN", Implied, 2, 12, "Input E from the port in C"},
+	0x59: {0x59, "OUT", Implied, 2, 12, "Output E to the port in C"},
+	0x60: {0x60, "IN", Implied, 2, 12, "Input H from the port in C"},
+	0x61: {0x61, "OUT", Implied, 2, 12, "Output H to the port in C"},
+	0x68: {0x68, "IN", Implied, 2, 12, "Input L from the port in C"},
+	0x69: {0x69, "OUT", Implied, 2, 12, "Output L to the port in C"},
+	0x70: {0x70, "IN", Implied, 2, 12, "Input from the port in C, setting only the flags (undocumented)"},
+	0x71: {0x71, "OUT", Implied, 2, 12, "Output 0 to the port in C (undocumented)"},
+	0x78: {0x78, "IN", Implied, 2, 12, "Input A from the port in C"},
+	0x79: {0x79, "OUT", Implied, 2, 12, "Output A to the port in C"},
+}
+
+// Z80DDInstructions are the IX instructions after the DD prefix. HL becomes IX, H and L the
+// undocumented IXH and IXL halves, and (HL) becomes (IX+d) with a displacement after the opcode.
+// On other instructions the prefix is ignored and costs 4 cycles.
+var Z80DDInstructions = map[byte]Instruction{
+
+	// 8-Bit Load Group
+	0x02: {0x02, "LD", Implied, 2, 11, "Store A at the address in BC (undocumented, the prefix is ignored)"},
+	0x06: {0x06, "LD", Immediate, 3, 11, "Load B with immediate data (undocumented, the prefix is ignored)"},
+	0x0A: {0x0A, "LD", Implied, 2, 11, "Load A from the address in BC (undocumented, the prefix is ignored)"},
+	0x0E: {0x0E, "LD", Immediate, 3, 11, "Load C with immediate data (undocumented, the prefix is ignored)"},
+	0x12: {0x12, "LD", Implied, 2, 11, "Store A at the address in DE (undocumented, the prefix is ignored)"},
+	0x16: {0x16, "LD", Immediate, 3, 11, "Load D with immediate data (undocumented, the prefix is ignored)"},
+	0x1A: {0x1A, "LD", Implied, 2, 11, "Load A from the address in DE (undocumented, the prefix is ignored)"},
+	0x1E: {0x1E, "LD", Immediate, 3, 11, "Load E with immediate data (undocumented, the prefix is ignored)"},
+	0x26: {0x26, "LD", Immediate, 3, 11, "Load IXH with immediate data (undocumented)"},
+	0x2E: {0x2E, "LD", Immediate, 3, 11, "Load IXL with immediate data (undocumented)"},
+	0x32: {0x32, "LD", Absolute, 4, 17, "Store A at the address (undocumented, the prefix is ignored)"},
+	0x36: {0x36, "LD", IndexedImmediate, 4, 19, "Load memory at IX+d with immediate data"},
+	0x3A: {0x3A, "LD", Absolute, 4, 17, "Load A from the address (undocumented, the prefix is ignored)"},
+	0x3E: {0x3E, "LD", Immediate, 3, 11, "Load A with immediate data (undocumented, the prefix is ignored)"},
+	0x40: {0x40, "LD", Implied, 2, 8, "Load B with B (undocumented, the prefix is ignored)"},
+	0x41: {0x41, "LD", Implied, 2, 8, "Load B with C (undocumented, the prefix is ignored)"},
+	0x42: {0x42, "LD", Implied, 2, 8, "Load B with D (undocumented, the prefix is ignored)"},
+	0x43: {0x43, "LD", Implied, 2, 8, "Load B with E (undocumented, the prefix is ignored)"},
+	0x44: {0x44, "LD", Implied, 2, 8, "Load B with IXH (undocumented)"},
+	0x45: {0x45, "LD", Implied, 2, 8, "Load B with IXL (undocumented)"},
+	0x46: {0x46, "LD", Indexed, 3, 19, "Load B with memory at IX+d"},
+	0x47: {0x47, "LD", Implied, 2, 8, "Load B with A (undocumented, the prefix is ignored)"},
+	0x48: {0x48, "LD", Implied, 2, 8, "Load C with B (undocumented, the prefix is ignored)"},
+	0x49: {0x49, "LD", Implied, 2, 8, "Load C with C (undocumented, the prefix is ignored)"},
+	0x4A: {0x4A, "LD", Implied, 2, 8, "Load C with D (undocumented, the prefix is ignored)"},
+	0x4B: {0x4B, "LD", Implied, 2, 8, "Load C with E (undocumented, the prefix is ignored)"},
+	0x4C: {0x4C, "LD", Implied, 2, 8, "Load C with IXH (undocumented)"},
+	0x4D: {0x4D, "LD", Implied, 2, 8, "Load C with IXL (undocumented)"},
+	0x4E: {0x4E, "LD", Indexed, 3, 19, "Load C with memory at IX+d"},
+	0x4F: {0x4F, "LD", Implied, 2, 8, "Load C with A (undocumented, the prefix is ignored)"},
+	0x50: {0x50, "LD", Implied, 2, 8, "Load D with B (undocumented, the prefix is ignored)"},
+	0x51: {0x51, "LD", Implied, 2, 8, "Load D with C (undocumented, the prefix is ignored)"},
+	0x52: {0x52, "LD", Implied, 2, 8, "Load D with D (undocumented, the prefix is ignored)"},
+	0x53: {0x53, "LD", Implied, 2, 8, "Load D with E (undocumented, the prefix is ignored)"},
+	0x54: {0x54, "LD", Implied, 2, 8, "Load D with IXH (undocumented)"},
+	0x55: {0x55, "LD", Implied, 2, 8, "Load D with IXL (undocumented)"},
+	0x56: {0x56, "LD", Indexed, 3, 19, "Load D with memory at IX+d"},
+	0x57: {0x57, "LD", Implied, 2, 8, "Load D with A (undocumented, the prefix is ignored)"},
+	0x58: {0x58, "LD", Implied, 2, 8, "Load E with B (undocumented, the prefix is ignored)"},
+	0x59: {0x59, "LD", Implied, 2, 8, "Load E with C (undocumented, the prefix is ignored)"},
+	0x5A: {0x5A, "LD", Implied, 2, 8, "Load E with D (undocumented, the prefix is ignored)"},
+	0x5B: {0x5B, "LD", Implied, 2, 8, "Load E with E (undocumented, the prefix is ignored)"},
+	0x5C: {0x5C, "LD", Implied, 2, 8, "Load E with IXH (undocumented)"},
+	0x5D: {0x5D, "LD", Implied, 2, 8, "Load E with IXL (undocumented)"},
+	0x5E: {0x5E, "LD", Indexed, 3, 19, "Load E with memory at IX+d"},
+	0x5F: {0x5F, "LD", Implied, 2, 8, "Load E with A (undocumented, the prefix is ignored)"},
+	0x60: {0x60, "LD", Implied, 2, 8, "Load IXH with B (undocumented)"},
+	0x61: {0x61, "LD", Implied, 2, 8, "Load IXH with C (undocumented)"},
+	0x62: {0x62, "LD", Implied, 2, 8, "Load IXH with D (undocumented)"},
+	0x63: {0x63, "LD", Implied, 2, 8, "Load IXH with E (undocumented)"},
+	0x64: {0x64, "LD", Implied, 2, 8, "Load IXH with IXH (undocumented)"},
+	0x65: {0x65, "LD", Implied, 2, 8, "Load IXH with IXL (undocumented)"},
+	0x66: {0x66, "LD", Indexed, 3, 19, "Load H with memory at IX+d"},
+	0x67: {0x67, "LD", Implied, 2, 8, "Load IXH with A (undocumented)"},
+	0x68: {0x68, "LD", Implied, 2, 8, "Load IXL with B (undocumented)"},
+	0x69: {0x69, "LD", Implied, 2, 8, "Load IXL with C (undocumented)"},
+	0x6A: {0x6A, "LD", Implied, 2, 8, "Load IXL with D (undocumented)"},
+	0x6B: {0x6B, "LD", Implied, 2, 8, "Load IXL with E (undocumented)"},
+	0x6C: {0x6C, "LD", Implied, 2, 8, "Load IXL with IXH (undocumented)"},
+	0x6D: {0x6D, "LD", Implied, 2, 8, "Load IXL with IXL (undocumented)"},
+	0x6E: {0x6E, "LD", Indexed, 3, 19, "Load L with memory at IX+d"},
+	0x6F: {0x6F, "LD", Implied, 2, 8, "Load IXL with A (undocumented)"},
+	0x70: {0x70, "LD", Indexed, 3, 19, "Load memory at IX+d with B"},
+	0x71: {0x71, "LD", Indexed, 3, 19, "Load memory at IX+d with C"},
+	0x72: {0x72, "LD", Indexed, 3, 19, "Load memory at IX+d with D"},
+	0x73: {0x73, "LD", Indexed, 3, 19, "Load memory at IX+d with E"},
+	0x74: {0x74, "LD", Indexed, 3, 19, "Load memory at IX+d with H"},
+	0x75: {0x75, "LD", Indexed, 3, 19, "Load memory at IX+d with L"},
+	0x77: {0x77, "LD", Indexed, 3, 19, "Load memory at IX+d with A"},
+	0x78: {0x78, "LD", Implied, 2, 8, "Load A with B (undocumented, the prefix is ignored)"},
+	0x79: {0x79, "LD", Implied, 2, 8, "Load A with C (undocumented, the prefix is ignored)"},
+	0x7A: {0x7A, "LD", Implied, 2, 8, "Load A with D (undocumented, the prefix is ignored)"},
+	0x7B: {0x7B, "LD", Implied, 2, 8, "Load A with E (undocumented, the prefix is ignored)"},
+	0x7C: {0x7C, "LD", Implied, 2, 8, "Load A with IXH (undocumented)"},
+	0x7D: {0x7D, "LD", Implied, 2, 8, "Load A with IXL (undocumented)"},
+	0x7E: {0x7E, "LD", Indexed, 3, 19, "Load A with memory at IX+d"},
+	0x7F: {0x7F, "LD", Implied, 2, 8, "Load A with A (undocumented, the prefix is ignored)"},
+
+	// 16-Bit Load Group
+	0x01: {0x01, "LD", Immediate16, 4, 14, "Load BC with immediate data (undocumented, the prefix is ignored)"},
+	0x11: {0x11, "LD", Immediate16, 4, 14, "Load DE with immediate data (undocumented, the prefix is ignored)"},
+	0x21: {0x21, "LD", Immediate16, 4, 14, "Load IX with immediate data"},
+	0x22: {0x22, "LD", Absolute, 4, 20, "Store IX at the address"},
+	0x2A: {0x2A, "LD", Absolute, 4, 20, "Load IX from the address"},
+	0x31: {0x31, "LD", Immediate16, 4, 14, "Load SP with immediate data (undocumented, the prefix is ignored)"},
+	0xF9: {0xF9, "LD", Implied, 2, 10, "Load SP with IX"},
+
+	// Stack Group
+	0xC1: {0xC1, "POP", Implied, 2, 14, "Pop BC from the stack (undocumented, the prefix is ignored)"},
+	0xC5: {0xC5, "PUSH", Implied, 2, 15, "Push BC on the stack (undocumented, the prefix is ignored)"},
+	0xD1: {0xD1, "POP", Implied, 2, 14, "Pop DE from the stack (undocumented, the prefix is ignored)"},
+	0xD5: {0xD5, "PUSH", Implied, 2, 15, "Push DE on the stack (undocumented, the prefix is ignored)"},
+	0xE1: {0xE1, "POP", Implied, 2, 14, "Pop IX from the stack"},
+	0xE5: {0xE5, "PUSH", Implied, 2, 15, "Push IX on the stack"},
+	0xF1: {0xF1, "POP", Implied, 2, 14, "Pop AF from the stack (undocumented, the prefix is ignored)"},
+	0xF5: {0xF5, "PUSH", Implied, 2, 15, "Push AF on the stack (undocumented, the prefix is ignored)"},
+
+	// Exchange Group
+	0x08: {0x08, "EX", Implied, 2, 8, "Exchange AF with the alternate AF' (undocumented, the prefix is ignored)"},
+	0xD9: {0xD9, "EXX", Implied, 2, 8, "Exchange BC, DE and HL with the alternate registers (undocumented, the prefix is ignored)"},
+	0xE3: {0xE3, "EX", Implied, 2, 23, "Exchange IX with the top of the stack"},
+	0xEB: {0xEB, "EX", Implied, 2, 8, "Exchange DE with HL (undocumented, the prefix is ignored)"},
+
+	// 8-Bit Arithmetic and Logical Group
+	0x04: {0x04, "INC", Implied, 2, 8, "Increment B (undocumented, the prefix is ignored)"},
+	0x05: {0x05, "DEC", Implied, 2, 8, "Decrement B (undocumented, the prefix is ignored)"},
+	0x0C: {0x0C, "INC", Implied, 2, 8, "Increment C (undocumented, the prefix is ignored)"},
+	0x0D: {0x0D, "DEC", Implied, 2, 8, "Decrement C (undocumented, the prefix is ignored)"},
+	0x14: {0x14, "INC", Implied, 2, 8, "Increment D (undocumented, the prefix is ignored)"},
+	0x15: {0x15, "DEC", Implied, 2, 8, "Decrement D (undocumented, the prefix is ignored)"},
+	0x1C: {0x1C, "INC", Implied, 2, 8, "Increment E (undocumented, the prefix is ignored)"},
+	0x1D: {0x1D, "DEC", Implied, 2, 8, "Decrement E (undocumented, the prefix is ignored)"},
+	0x24: {0x24, "INC", Implied, 2, 8, "Increment IXH (undocumented)"},
+	0x25: {0x25, "DEC", Implied, 2, 8, "Decrement IXH (undocumented)"},
+	0x2C: {0x2C, "INC", Implied, 2, 8, "Increment IXL (undocumented)"},
+	0x2D: {0x2D, "DEC", Implied, 2, 8, "Decrement IXL (undocumented)"},
+	0x34: {0x34, "INC", Indexed, 3, 23, "Increment memory at IX+d"},
+	0x35: {0x35, "DEC", Indexed, 3, 23, "Decrement memory at IX+d"},
+	0x3C: {0x3C, "INC", Implied, 2, 8, "Increment A (undocumented, the prefix is ignored)"},
+	0x3D: {0x3D, "DEC", Implied, 2, 8, "Decrement A (undocumented, the prefix is ignored)"},
+	0x80: {0x80, "ADD", Implied, 2, 8, "Add B to A (undocumented, the prefix is ignored)"},
+	0x81: {0x81, "ADD", Implied, 2, 8, "Add C to A (undocumented, the prefix is ignored)"},
+	0x82: {0x82, "ADD", Implied, 2, 8, "Add D to A (undocumented, the prefix is ignored)"},
+	0x83: {0x83, "ADD", Implied, 2, 8, "Add E to A (undocumented, the prefix is ignored)"},
+	0x84: {0x84, "ADD", Implied, 2, 8, "Add IXH to A (undocumented)"},
+	0x85: {0x85, "ADD", Implied, 2, 8, "Add IXL to A (undocumented)"},
+	0x86: {0x86, "ADD", Indexed, 3, 19, "Add memory at IX+d to A"},
+	0x87: {0x87, "ADD", Implied, 2, 8, "Add A to A (undocumented, the prefix is ignored)"},
+	0x88: {0x88, "ADC", Implied, 2, 8, "Add B and the carry to A (undocumented, the prefix is ignored)"},
+	0x89: {0x89, "ADC", Implied, 2, 8, "Add C and the carry to A (undocumented, the prefix is ignored)"},
+	0x8A: {0x8A, "ADC", Implied, 2, 8, "Add D and the carry to A (undocumented, the prefix is ignored)"},
+	0x8B: {0x8B, "ADC", Implied, 2, 8, "Add E and the carry to A (undocumented, the prefix is ignored)"},
+	0x8C: {0x8C, "ADC", Implied, 2, 8, "Add IXH and the carry to A (undocumented)"},
+	0x8D: {0x8D, "ADC", Implied, 2, 8, "Add IXL and the carry to A (undocumented)"},
+	0x8E: {0x8E, "ADC", Indexed, 3, 19, "Add memory at IX+d and the carry to A"},
+	0x8F: {0x8F, "ADC", Implied, 2, 8, "Add A and the carry to A (undocumented, the prefix is ignored)"},
+	0x90: {0x90, "SUB", Implied, 2, 8, "Subtract B from A (undocumented, the prefix is ignored)"},
+	0x91: {0x91, "SUB", Implied, 2, 8, "Subtract C from A (undocumented, the prefix is ignored)"},
+	0x92: {0x92, "SUB", Implied, 2, 8, "Subtract D from A (undocumented, the prefix is ignored)"},
+	0x93: {0x93, "SUB", Implied, 2, 8, "Subtract E from A (undocumented, the prefix is ignored)"},
+	0x94: {0x94, "SUB", Implied, 2, 8, "Subtract IXH from A (undocumented)"},
+	0x95: {0x95, "SUB", Implied, 2, 8, "Subtract IXL from A (undocumented)"},
+	0x96: {0x96, "SUB", Indexed, 3, 19, "Subtract memory at IX+d from A"},
+	0x97: {0x97, "SUB", Implied, 2, 8, "Subtract A from A (undocumented, the prefix is ignored)"},
+	0x98: {0x98, "SBC", Implied, 2, 8, "Subtract B and the carry from A (undocumented, the prefix is ignored)"},
+	0x99: {0x99, "SBC", Implied, 2, 8, "Subtract C and the carry from A (undocumented, the prefix is ignored)"},
+	0x9A: {0x9A, "SBC", Implied, 2, 8, "Subtract D and the carry from A (undocumented, the prefix is ignored)"},
+	0x9B: {0x9B, "SBC", Implied, 2, 8, "Subtract E and the carry from A (undocumented, the prefix is ignored)"},
+	0x9C: {0x9C, "SBC", Implied, 2, 8, "Subtract IXH and the carry from A (undocumented)"},
+	0x9D: {0x9D, "SBC", Implied, 2, 8, "Subtract IXL and the carry from A (undocumented)"},
+	0x9E: {0x9E, "SBC", Indexed, 3, 19, "Subtract memory at IX+d and the carry from A"},
+	0x9F: {0x9F, "SBC", Implied, 2, 8, "Subtract A and the carry from A (undocumented, the prefix is ignored)"},
+	0xA0: {0xA0, "AND", Implied, 2, 8, "AND B with A (undocumented, the prefix is ignored)"},
+	0xA1: {0xA1, "AND", Implied, 2, 8, "AND C with A (undocumented, the prefix is ignored)"},
+	0xA2: {0xA2, "AND", Implied, 2, 8, "AND D with A (undocumented, the prefix is ignored)"},
+	0xA3: {0xA3, "AND", Implied, 2, 8, "AND E with A (undocumented, the prefix is ignored)"},
+	0xA4: {0xA4, "AND", Implied, 2, 8, "AND IXH with A (undocumented)"},
+	0xA5: {0xA5, "AND", Implied, 2, 8, "AND IXL with A (undocumented)"},
+	0xA6: {0xA6, "AND", Indexed, 3, 19, "AND memory at IX+d with A"},
+	0xA7: {0xA7, "AND", Implied, 2, 8, "AND A with A (undocumented, the prefix is ignored)"},
+	0xA8: {0xA8, "XOR", Implied, 2, 8, "Exclusive OR B with A (undocumented, the prefix is ignored)"},
+	0xA9: {0xA9, "XOR", Implied, 2, 8, "Exclusive OR C with A (undocumented, the prefix is ignored)"},
+	0xAA: {0xAA, "XOR", Implied, 2, 8, "Exclusive OR D with A (undocumented, the prefix is ignored)"},
+	0xAB: {0xAB, "XOR", Implied, 2, 8, "Exclusive OR E with A (undocumented, the prefix is ignored)"},
+	0xAC: {0xAC, "XOR", Implied, 2, 8, "Exclusive OR IXH with A (undocumented)"},
+	0xAD: {0xAD, "XOR", Implied, 2, 8, "Exclusive OR IXL with A (undocumented)"},
+	0xAE: {0xAE, "XOR", Indexed, 3, 19, "Exclusive OR memory at IX+d with A"},
+	0xAF: {0xAF, "XOR", Implied, 2, 8, "Exclusive OR A with A (undocumented, the prefix is ignored)"},
+	0xB0: {0xB0, "OR", Implied, 2, 8, "OR B with A (undocumented, the prefix is ignored)"},
+	0xB1: {0xB1, "OR", Implied, 2, 8, "OR C with A (undocumented, the prefix is ignored)"},
+	0xB2: {0xB2, "OR", Implied, 2, 8, "OR D with A (undocumented, the prefix is ignored)"},
+	0xB3: {0xB3, "OR", Implied, 2, 8, "OR E with A (undocumented, the prefix is ignored)"},
+	0xB4: {0xB4, "OR", Implied, 2, 8, "OR IXH with A (undocumented)"},
+	0xB5: {0xB5, "OR", Implied, 2, 8, "OR IXL with A (undocumented)"},
+	0xB6: {0xB6, "OR", Indexed, 3, 19, "OR memory at IX+d with A"},
+	0xB7: {0xB7, "OR", Implied, 2, 8, "OR A with A (undocumented, the prefix is ignored)"},
+	0xB8: {0xB8, "CP", Implied, 2, 8, "Compare B with A (undocumented, the prefix is ignored)"},
+	0xB9: {0xB9, "CP", Implied, 2, 8, "Compare C with A (undocumented, the prefix is ignored)"},
+	0xBA: {0xBA, "CP", Implied, 2, 8, "Compare D with A (undocumented, the prefix is ignored)"},
+	0xBB: {0xBB, "CP", Implied, 2, 8, "Compare E with A (undocumented, the prefix is ignored)"},
+	0xBC: {0xBC, "CP", Implied, 2, 8, "Compare IXH with A (undocumented)"},
+	0xBD: {0xBD, "CP", Implied, 2, 8, "Compare IXL with A (undocumented)"},
+	0xBE: {0xBE, "CP", Indexed, 3, 19, "Compare memory at IX+d with A"},
+	0xBF: {0xBF, "CP", Implied, 2, 8, "Compare A with A (undocumented, the prefix is ignored)"},
+	0xC6: {0xC6, "ADD", Immediate, 3, 11, "Add immediate data to A (undocumented, the prefix is ignored)"},
+	0xCE: {0xCE, "ADC", Immediate, 3, 11, "Add immediate data and the carry to A (undocumented, the prefix is ignored)"},
+	0xD6: {0xD6, "SUB", Immediate, 3, 11, "Subtract immediate data from A (undocumented, the prefix is ignored)"},
+	0xDE: {0xDE, "SBC", Immediate, 3, 11, "Subtract immediate data and the carry from A (undocumented, the prefix is ignored)"},
+	0xE6: {0xE6, "AND", Immediate, 3, 11, "AND immediate data with A (undocumented, the prefix is ignored)"},
+	0xEE: {0xEE, "XOR", Immediate, 3, 11, "Exclusive OR immediate data with A (undocumented, the prefix is ignored)"},
+	0xF6: {0xF6, "OR", Immediate, 3, 11, "OR immediate data with A (undocumented, the prefix is ignored)"},
+	0xFE: {0xFE, "CP", Immediate, 3, 11, "Compare immediate data with A (undocumented, the prefix is ignored)"},
+
+	// 16-Bit Arithmetic Group
+	0x03: {0x03, "INC", Implied, 2, 10, "Increment BC (undocumented, the prefix is ignored)"},
+	0x09: {0x09, "ADD", Implied, 2, 15, "Add BC to IX"},
+	0x0B: {0x0B, "DEC", Implied, 2, 10, "Decrement BC (undocumented, the prefix is ignored)"},
+	0x13: {0x13, "INC", Implied, 2, 10, "Increment DE (undocumented, the prefix is ignored)"},
+	0x19: {0x19, "ADD", Implied, 2, 15, "Add DE to IX"},
+	0x1B: {0x1B, "DEC", Implied, 2, 10, "Decrement DE (undocumented, the prefix is ignored)"},
+	0x23: {0x23, "INC", Implied, 2, 10, "Increment IX"},
+	0x29: {0x29, "ADD", Implied, 2, 15, "Add IX to IX"},
+	0x2B: {0x2B, "DEC", Implied, 2, 10, "Decrement IX"},
+	0x33: {0x33, "INC", Implied, 2, 10, "Increment SP (undocumented, the prefix is ignored)"},
+	0x39: {0x39, "ADD", Implied, 2, 15, "Add SP to IX"},
+	0x3B: {0x3B, "DEC", Implied, 2, 10, "Decrement SP (undocumented, the prefix is ignored)"},
+
+	// General-Purpose Arithmetic and CPU Control Group
+	0x00: {0x00, "NOP", Implied, 2, 8, "No operation (undocumented, the prefix is ignored)"},
+	0x27: {0x27, "DAA", Implied, 2, 8, "Decimal adjust A (undocumented, the prefix is ignored)"},
+	0x2F: {0x2F, "CPL", Implied, 2, 8, "Complement A (undocumented, the prefix is ignored)"},
+	0x37: {0x37, "SCF", Implied, 2, 8, "Set the carry flag (undocumented, the prefix is ignored)"},
+	0x3F: {0x3F, "CCF", Implied, 2, 8, "Complement the carry flag (undocumented, the prefix is ignored)"},
+	0x76: {0x76, "HALT", Implied, 2, 8, "Halt until an interrupt (undocumented, the prefix is ignored)"},
+	0xDD: {0xDD, "NOP", Implied, 1, 4, "Ignored prefix (undocumented)"},
+	0xED: {0xED, "NOP", Implied, 1, 4, "Ignored prefix (undocumented)"},
+	0xF3: {0xF3, "DI", Implied, 2, 8, "Disable interrupts (undocumented, the prefix is ignored)"},
+	0xFB: {0xFB, "EI", Implied, 2, 8, "Enable interrupts after the next instruction (undocumented, the prefix is ignored)"},
+	0xFD: {0xFD, "NOP", Implied, 1, 4, "Ignored prefix (undocumented)"},
+
+	// Rotate and Shift Group
+	0x07: {0x07, "RLCA", Implied, 2, 8, "Rotate A left circular (undocumented, the prefix is ignored)"},
+	0x0F: {0x0F, "RRCA", Implied, 2, 8, "Rotate A right circular (undocumented, the prefix is ignored)"},
+	0x17: {0x17, "RLA", Implied, 2, 8, "Rotate A left through the carry (undocumented, the prefix is ignored)"},
+	0x1F: {0x1F, "RRA", Implied, 2, 8, "Rotate A right through the carry (undocumented, the prefix is ignored)"},
+
+	// Jump Group
+	0x10: {0x10, "DJNZ", Relative, 3, 12, "Decrement B and jump relative if not zero (undocumented, the prefix is ignored)"},
+	0x18: {0x18, "JR", Relative, 3, 16, "Jump relative (undocumented, the prefix is ignored)"},
+	0x20: {0x20, "JR", Relative, 3, 11, "Jump relative if not zero (undocumented, the prefix is ignored)"},
+	0x28: {0x28, "JR", Relative, 3, 11, "Jump relative if zero (undocumented, the prefix is ignored)"},
+	0x30: {0x30, "JR", Relative, 3, 11, "Jump relative if no carry (undocumented, the prefix is ignored)"},
+	0x38: {0x38, "JR", Relative, 3, 11, "Jump relative if carry (undocumented, the prefix is ignored)"},
+	0xC2: {0xC2, "JP", Absolute, 4, 14, "Jump if not zero (undocumented, the prefix is ignored)"},
+	0xC3: {0xC3, "JP", Absolute, 4, 14, "Jump to the address (undocumented, the prefix is ignored)"},
+	0xCA: {0xCA, "JP", Absolute, 4, 14, "Jump if zero (undocumented, the prefix is ignored)"},
+	0xD2: {0xD2, "JP", Absolute, 4, 14, "Jump if no carry (undocumented, the prefix is ignored)"},
+	0xDA: {0xDA, "JP", Absolute, 4, 14, "Jump if carry (undocumented, the prefix is ignored)"},
+	0xE2: {0xE2, "JP", Absolute, 4, 14, "Jump if parity odd (undocumented, the prefix is ignored)"},
+	0xE9: {0xE9, "JP", Implied, 2, 8, "Jump to the address in IX"},
+	0xEA: {0xEA, "JP", Absolute, 4, 14, "Jump if parity even (undocumented, the prefix is ignored)"},
+	0xF2: {0xF2, "JP", Absolute, 4, 14, "Jump if plus (undocumented, the prefix is ignored)"},
+	0xFA: {0xFA, "JP", Absolute, 4, 14, "Jump if minus (undocumented, the prefix is ignored)"},
+
+	// Call and Return Group
+	0xC0: {0xC0, "RET", Implied, 2, 9, "Return if not zero (undocumented, the prefix is ignored)"},
+	0xC4: {0xC4, "CALL", Absolute, 4, 14, "Call if not zero (undocumented, the prefix is ignored)"},
+	0xC7: {0xC7, "RST", Implied, 2, 15, "Call the restart at $0000 (undocumented, the prefix is ignored)"},
+	0xC8: {0xC8, "RET", Implied, 2, 9, "Return if zero (undocumented, the prefix is ignored)"},
+	0xC9: {0xC9, "RET", Implied, 2, 14, "Return from a subroutine (undocumented, the prefix is ignored)"},
+	0xCC: {0xCC, "CALL", Absolute, 4, 14, "Call if zero (undocumented, the prefix is ignored)"},
+	0xCD: {0xCD, "CALL", Absolute, 4, 21, "Call the subroutine at the address (undocumented, the prefix is ignored)"},
+	0xCF: {0xCF, "RST", Implied, 2, 15, "Call the restart at $0008 (undocumented, the prefix is ignored)"},
+	0xD0: {0xD0, "RET", Implied, 2, 9, "Return if no carry (undocumented, the prefix is ignored)"},
+	0xD4: {0xD4, "CALL", Absolute, 4, 14, "Call if no carry (undocumented, the prefix is ignored)"},
+	0xD7: {0xD7, "RST", Implied, 2, 15, "Call the restart at $0010 (undocumented, the prefix is ignored)"},
+	0xD8: {0xD8, "RET", Implied, 2, 9, "Return if carry (undocumented, the prefix is ignored)"},
+	0xDC: {0xDC, "CALL", Absolute, 4, 14, "Call if carry (undocumented, the prefix is ignored)"},
+	0xDF: {0xDF, "RST", Implied, 2, 15, "Call the restart at $0018 (undocumented, the prefix is ignored)"},
+	0xE0: {0xE0, "RET", Implied, 2, 9, "Return if parity odd (undocumented, the prefix is ignored)"},
+	0xE4: {0xE4, "CALL", Absolute, 4, 14, "Call if parity odd (undocumented, the prefix is ignored)"},
+	0xE7: {0xE7, "RST", Implied, 2, 15, "Call the restart at $0020 (undocumented, the prefix is ignored)"},
+	0xE8: {0xE8, "RET", Implied, 2, 9, "Return if parity even (undocumented, the prefix is ignored)"},
+	0xEC: {0xEC, "CALL", Absolute, 4, 14, "Call if parity even (undocumented, the prefix is ignored)"},
+	0xEF: {0xEF, "RST", Implied, 2, 15, "Call the restart at $0028 (undocumented, the prefix is ignored)"},
+	0xF0: {0xF0, "RET", Implied, 2, 9, "Return if plus (undocumented, the prefix is ignored)"},
+	0xF4: {0xF4, "CALL", Absolute, 4, 14, "Call if plus (undocumented, the prefix is ignored)"},
+	0xF7: {0xF7, "RST", Implied, 2, 15, "Call the restart at $0030 (undocumented, the prefix is ignored)"},
+	0xF8: {0xF8, "RET", Implied, 2, 9, "Return if minus (undocumented, the prefix is ignored)"},
+	0xFC: {0xFC, "CALL", Absolute, 4, 14, "Call if minus (undocumented, the prefix is ignored)"},
+	0xFF: {0xFF, "RST", Implied, 2, 15, "Call the restart at $0038 (undocumented, the prefix is ignored)"},
+
+	// Input and Output Group
+	0xD3: {0xD3, "OUT", Immediate, 3, 15, "Output A to the port (undocumented, the prefix is ignored)"},
+	0xDB: {0xDB, "IN", Immediate, 3, 15, "Input A from the port (undocumented, the prefix is ignored)"},
+
+	// Prefixes
+	0xCB: {0xCB, "", IndexedPrefix, 2, 0, "Prefix of the IX bit instructions"},
+}
+
+// Z80FDInstructions are the IY instructions after the FD prefix, decoded as the DD instructions
+var Z80FDInstructions = map[byte]Instruction{
+
+	// 8-Bit Load Group
+	0x02: {0x02, "LD", Implied, 2, 11, "Store A at the address in BC (undocumented, the prefix is ignored)"},
+	0x06: {0x06, "LD", Immediate, 3, 11, "Load B with immediate data (undocumented, the prefix is ignored)"},
+	0x0A: {0x0A, "LD", Implied, 2, 11, "Load A from the address in BC (undocumented, the prefix is ignored)"},
+	0x0E: {0x0E, "LD", Immediate, 3, 11, "Load C with immediate data (undocumented, the prefix is ignored)"},
+	0x12: {0x12, "LD", Implied, 2, 11, "Store A at the address in DE (undocumented, the prefix is ignored)"},
+	0x16: {0x16, "LD", Immediate, 3, 11, "Load D with immediate data (undocumented, the prefix is ignored)"},
+	0x1A: {0x1A, "LD", Implied, 2, 11, "Load A from the address in DE (undocumented, the prefix is ignored)"},
+	0x1E: {0x1E, "LD", Immediate, 3, 11, "Load E with immediate data (undocumented, the prefix is ignored)"},
+	0x26: {0x26, "LD", Immediate, 3, 11, "Load IYH with immediate data (undocumented)"},
+	0x2E: {0x2E, "LD", Immediate, 3, 11, "Load IYL with immediate data (undocumented)"},
+	0x32: {0x32, "LD", Absolute, 4, 17, "Store A at the address (undocumented, the prefix is ignored)"},
+	0x36: {0x36, "LD", IndexedImmediate, 4, 19, "Load memory at IY+d with immediate data"},
+	0x3A: {0x3A, "LD", Absolute, 4, 17, "Load A from the address (undocumented, the prefix is ignored)"},
+	0x3E: {0x3E, "LD", Immediate, 3, 11, "Load A with immediate data (undocumented, the prefix is ignored)"},
+	0x40: {0x40, "LD", Implied, 2, 8, "Load B with B (undocumented, the prefix is ignored)"},
+	0x41: {0x41, "LD", Implied, 2, 8, "Load B with C (undocumented, the prefix is ignored)"},
+	0x42: {0x42, "LD", Implied, 2, 8, "Load B with D (undocumented, the prefix is ignored)"},
+	0x43: {0x43, "LD", Implied, 2, 8, "Load B with E (undocumented, the prefix is ignored)"},
+	0x44: {0x44, "LD", Implied, 2, 8, "Load B with IYH (undocumented)"},
+	0x45: {0x45, "LD", Implied, 2, 8, "Load B with IYL (undocumented)"},
+	0x46: {0x46, "LD", Indexed, 3, 19, "Load B with memory at IY+d"},
+	0x47: {0x47, "LD", Implied, 2, 8, "Load B with A (undocumented, the prefix is ignored)"},
+	0x48: {0x48, "LD", Implied, 2, 8, "Load C with B (undocumented, the prefix is ignored)"},
+	0x49: {0x49, "LD", Implied, 2, 8, "Load C with C (undocumented, the prefix is ignored)"},
+	0x4A: {0x4A, "LD", Implied, 2, 8, "Load C with D (undocumented, the prefix is ignored)"},
+	0x4B: {0x4B, "LD", Implied, 2, 8, "Load C with E (undocumented, the prefix is ignored)"},
+	0x4C: {0x4C, "LD", Implied, 2, 8, "Load C with IYH (undocumented)"},
+	0x4D: {0x4D, "LD", Implied, 2, 8, "Load C with IYL (undocumented)"},
+	0x4E: {0x4E, "LD", Indexed, 3, 19, "Load C with memory at IY+d"},
+	0x4F: {0x4F, "LD", Implied, 2, 8, "Load C with A (undocumented, the prefix is ignored)"},
+	0x50: {0x50, "LD", Implied, 2, 8, "Load D with B (undocumented, the prefix is ignored)"},
+	0x51: {0x51, "LD", Implied, 2, 8, "Load D with C (undocumented, the prefix is ignored)"},
+	0x52: {0x52, "LD", Implied, 2, 8, "Load D with D (undocumented, the prefix is ignored)"},
+	0x53: {0x53, "LD", Implied, 2, 8, "Load D with E (undocumented, the prefix is ignored)"},
+	0x54: {0x54, "LD", Implied, 2, 8, "Load D with IYH (undocumented)"},
+	0x55: {0x55, "LD", Implied, 2, 8, "Load D with IYL (undocumented)"},
+	0x56: {0x56, "LD", Indexed, 3, 19, "Load D with memory at IY+d"},
+	0x57: {0x57, "LD", Implied, 2, 8, "Load D with A (undocumented, the prefix is ignored)"},
+	0x58: {0x58, "LD", Implied, 2, 8, "Load E with B (undocumented, the prefix is ignored)"},
+	0x59: {0x59, "LD", Implied, 2, 8, "Load E with C (undocumented, the prefix is ignored)"},
+	0x5A: {0x5A, "LD", Implied, 2, 8, "Load E with D (undocumented, the prefix is ignored)"},
+	0x5B: {0x5B, "LD", Implied, 2, 8, "Load E with E (undocumented, the prefix is ignored)"},
+	0x5C: {0x5C, "LD", Implied, 2, 8, "Load E with IYH (undocumented)"},
+	0x5D: {0x5D, "LD", Implied, 2, 8, "Load E with IYL (undocumented)"},
+	0x5E: {0x5E, "LD", Indexed, 3, 19, "Load E with memory at IY+d"},
+	0x5F: {0x5F, "LD", Implied, 2, 8, "Load E with A (undocumented, the prefix is ignored)"},
+	0x60: {0x60, "LD", Implied, 2, 8, "Load IYH with B (undocumented)"},
+	0x61: {0x61, "LD", Implied, 2, 8, "Load IYH with C (undocumented)"},
+	0x62: {0x62, "LD", Implied, 2, 8, "Load IYH with D (undocumented)"},
+	0x63: {0x63, "LD", Implied, 2, 8, "Load IYH with E (undocumented)"},
+	0x64: {0x64, "LD", Implied, 2, 8, "Load IYH with IYH (undocumented)"},
+	0x65: {0x65, "LD", Implied, 2, 8, "Load IYH with IYL (undocumented)"},
+	0x66: {0x66, "LD", Indexed, 3, 19, "Load H with memory at IY+d"},
+	0x67: {0x67, "LD", Implied, 2, 8, "Load IYH with A (undocumented)"},
+	0x68: {0x68, "LD", Implied, 2, 8, "Load IYL with B (undocumented)"},
+	0x69: {0x69, "LD", Implied, 2, 8, "Load IYL with C (undocumented)"},
+	0x6A: {0x6A, "LD", Implied, 2, 8, "Load IYL with D (undocumented)"},
+	0x6B: {0x6B, "LD", Implied, 2, 8, "Load IYL with E (undocumented)"},
+	0x6C: {0x6C, "LD", Implied, 2, 8, "Load IYL with IYH (undocumented)"},
+	0x6D: {0x6D, "LD", Implied, 2, 8, "Load IYL with IYL (undocumented)"},
+	0x6E: {0x6E, "LD", Indexed, 3, 19, "Load L with memory at IY+d"},
+	0x6F: {0x6F, "LD", Implied, 2, 8, "Load IYL with A (undocumented)"},
+	0x70: {0x70, "LD", Indexed, 3, 19, "Load memory at IY+d with B"},
+	0x71: {0x71, "LD", Indexed, 3, 19, "Load memory at IY+d with C"},
+	0x72: {0x72, "LD", Indexed, 3, 19, "Load memory at IY+d with D"},
+	0x73: {0x73, "LD", Indexed, 3, 19, "Load memory at IY+d with E"},
+	0x74: {0x74, "LD", Indexed, 3, 19, "Load memory at IY+d with H"},
+	0x75: {0x75, "LD", Indexed, 3, 19, "Load memory at IY+d with L"},
+	0x77: {0x77, "LD", Indexed, 3, 19, "Load memory at IY+d with A"},
+	0x78: {0x78, "LD", Implied, 2, 8, "Load A with B (undocumented, the prefix is ignored)"},
+	0x79: {0x79, "LD", Implied, 2, 8, "Load A with C (undocumented, the prefix is ignored)"},
+	0x7A: {0x7A, "LD", Implied, 2, 8, "Load A with D (undocumented, the prefix is ignored)"},
+	0x7B: {0x7B, "LD", Implied, 2, 8, "Load A with E (undocumented, the prefix is ignored)"},
+	0x7C: {0x7C, "LD", Implied, 2, 8, "Load A with IYH (undocumented)"},
+	0x7D: {0x7D, "LD", Implied, 2, 8, "Load A with IYL (undocumented)"},
+	0x7E: {0x7E, "LD", Indexed, 3, 19, "Load A with memory at IY+d"},
+	0x7F: {0x7F, "LD", Implied, 2, 8, "Load A with A (undocumented, the prefix is ignored)"},
+
+	// 16-Bit Load Group
+	0x01: {0x01, "LD", Immediate16, 4, 14, "Load BC with immediate data (undocumented, the prefix is ignored)"},
+	0x11: {0x11, "LD", Immediate16, 4, 14, "Load DE with immediate data (undocumented, the prefix is ignored)"},
+	0x21: {0x21, "LD", Immediate16, 4, 14, "Load IY with immediate data"},
+	0x22: {0x22, "LD", Absolute, 4, 20, "Store IY at the address"},
+	0x2A: {0x2A, "LD", Absolute, 4, 20, "Load IY from the address"},
+	0x31: {0x31, "LD", Immediate16, 4, 14, "Load SP with immediate data (undocumented, the prefix is ignored)"},
+	0xF9: {0xF9, "LD", Implied, 2, 10, "Load SP with IY"},
+
+	// Stack Group
+	0xC1: {0xC1, "POP", Implied, 2, 14, "Pop BC from the stack (undocumented, the prefix is ignored)"},
+	0xC5: {0xC5, "PUSH", Implied, 2, 15, "Push BC on the stack (undocumented, the prefix is ignored)"},
+	0xD1: {0xD1, "POP", Implied, 2, 14, "Pop DE from the stack (undocumented, the prefix is ignored)"},
+	0xD5: {0xD5, "PUSH", Implied, 2, 15, "Push DE on the stack (undocumented, the prefix is ignored)"},
+	0xE1: {0xE1, "POP", Implied, 2, 14, "Pop IY from the stack"},
+	0xE5: {0xE5, "PUSH", Implied, 2, 15, "Push IY on the stack"},
+	0xF1: {0xF1, "POP", Implied, 2, 14, "Pop AF from the stack (undocumented, the prefix is ignored)"},
+	0xF5: {0xF5, "PUSH", Implied, 2, 15, "Push AF on the stack (undocumented, the prefix is ignored)"},
+
+	// Exchange Group
+	0x08: {0x08, "EX", Implied, 2, 8, "Exchange AF with the alternate AF' (undocumented, the prefix is ignored)"},
+	0xD9: {0xD9, "EXX", Implied, 2, 8, "Exchange BC, DE and HL with the alternate registers (undocumented, the prefix is ignored)"},
+	0xE3: {0xE3, "EX", Implied, 2, 23, "Exchange IY with the top of the stack"},
+	0xEB: {0xEB, "EX", Implied, 2, 8, "Exchange DE with HL (undocumented, the prefix is ignored)"},
+
+	// 8-Bit Arithmetic and Logical Group
+	0x04: {0x04, "INC", Implied, 2, 8, "Increment B (undocumented, the prefix is ignored)"},
+	0x05: {0x05, "DEC", Implied, 2, 8, "Decrement B (undocumented, the prefix is ignored)"},
+	0x0C: {0x0C, "INC", Implied, 2, 8, "Increment C (undocumented, the prefix is ignored)"},
+	0x0D: {0x0D, "DEC", Implied, 2, 8, "Decrement C (undocumented, the prefix is ignored)"},
+	0x14: {0x14, "INC", Implied, 2, 8, "Increment D (undocumented, the prefix is ignored)"},
+	0x15: {0x15, "DEC", Implied, 2, 8, "Decrement D (undocumented, the prefix is ignored)"},
+	0x1C: {0x1C, "INC", Implied, 2, 8, "Increment E (undocumented, the prefix is ignored)"},
+	0x1D: {0x1D, "DEC", Implied, 2, 8, "Decrement E (undocumented, the prefix is ignored)"},
+	0x24: {0x24, "INC", Implied, 2, 8, "Increment IYH (undocumented)"},
+	0x25: {0x25, "DEC", Implied, 2, 8, "Decrement IYH (undocumented)"},
+	0x2C: {0x2C, "INC", Implied, 2, 8, "Increment IYL (undocumented)"},
+	0x2D: {0x2D, "DEC", Implied, 2, 8, "Decrement IYL (undocumented)"},
+	0x34: {0x34, "INC", Indexed, 3, 23, "Increment memory at IY+d"},
+	0x35: {0x35, "DEC", Indexed, 3, 23, "Decrement memory at IY+d"},
+	0x3C: {0x3C, "INC", Implied, 2, 8, "Increment A (undocumented, the prefix is ignored)"},
+	0x3D: {0x3D, "DEC", Implied, 2, 8, "Decrement A (undocumented, the prefix is ignored)"},
+	0x80: {0x80, "ADD", Implied, 2, 8, "Add B to A (undocumented, the prefix is ignored)"},
+	0x81: {0x81, "ADD", Implied, 2, 8, "Add C to A (undocumented, the prefix is ignored)"},
+	0x82: {0x82, "ADD", Implied, 2, 8, "Add D to A (undocumented, the prefix is ignored)"},
+	0x83: {0x83, "ADD", Implied, 2, 8, "Add E to A (undocumented, the prefix is ignored)"},
+	0x84: {0x84, "ADD", Implied, 2, 8, "Add IYH to A (undocumented)"},
+	0x85: {0x85, "ADD", Implied, 2, 8, "Add IYL to A (undocumented)"},
+	0x86: {0x86, "ADD", Indexed, 3, 19, "Add memory at IY+d to A"},
+	0x87: {0x87, "ADD", Implied, 2, 8, "Add A to A (undocumented, the prefix is ignored)"},
+	0x88: {0x88, "ADC", Implied, 2, 8, "Add B and the carry to A (undocumented, the prefix is ignored)"},
+	0x89: {0x89, "ADC", Implied, 2, 8, "Add C and the carry to A (undocumented, the prefix is ignored)"},
+	0x8A: {0x8A, "ADC", Implied, 2, 8, "Add D and the carry to A (undocumented, the prefix is ignored)"},
+	0x8B: {0x8B, "ADC", Implied, 2, 8, "Add E and the carry to A (undocumented, the prefix is ignored)"},
+	0x8C: {0x8C, "ADC", Implied, 2, 8, "Add IYH and the carry to A (undocumented)"},
+	0x8D: {0x8D, "ADC", Implied, 2, 8, "Add IYL and the carry to A (undocumented)"},
+	0x8E: {0x8E, "ADC", Indexed, 3, 19, "Add memory at IY+d and the carry to A"},
+	0x8F: {0x8F, "ADC", Implied, 2, 8, "Add A and the carry to A (undocumented, the prefix is ignored)"},
+	0x90: {0x90, "SUB", Implied, 2, 8, "Subtract B from A (undocumented, the prefix is ignored)"},
+	0x91: {0x91, "SUB", Implied, 2, 8, "Subtract C from A (undocumented, the prefix is ignored)"},
+	0x92: {0x92, "SUB", Implied, 2, 8, "Subtract D from A (undocumented, the prefix is ignored)"},
+	0x93: {0x93, "SUB", Implied, 2, 8, "Subtract E from A (undocumented, the prefix is ignored)"},
+	0x94: {0x94, "SUB", Implied, 2, 8, "Subtract IYH from A (undocumented)"},
+	0x95: {0x95, "SUB", Implied, 2, 8, "Subtract IYL from A (undocumented)"},
+	0x96: {0x96, "SUB", Indexed, 3, 19, "Subtract memory at IY+d from A"},
+	0x97: {0x97, "SUB", Implied, 2, 8, "Subtract A from A (undocumented, the prefix is ignored)"},
+	0x98: {0x98, "SBC", Implied, 2, 8, "Subtract B and the carry from A (undocumented, the prefix is ignored)"},
+	0x99: {0x99, "SBC", Implied, 2, 8, "Subtract C and the carry from A (undocumented, the prefix is ignored)"},
+	0x9A: {0x9A, "SBC", Implied, 2, 8, "Subtract D and the carry from A (undocumented, the prefix is ignored)"},
+	0x9B: {0x9B, "SBC", Implied, 2, 8, "Subtract E and the carry from A (undocumented, the prefix is ignored)"},
+	0x9C: {0x9C, "SBC", Implied, 2, 8, "Subtract IYH and the carry from A (undocumented)"},
+	0x9D: {0x9D, "SBC", Implied, 2, 8, "Subtract IYL and the carry from A (undocumented)"},
+	0x9E: {0x9E, "SBC", Indexed, 3, 19, "Subtract memory at IY+d and the carry from A"},
+	0x9F: {0x9F, "SBC", Implied, 2, 8, "Subtract A and the carry from A (undocumented, the prefix is ignored)"},
+	0xA0: {0xA0, "AND", Implied, 2, 8, "AND B with A (undocumented, the prefix is ignored)"},
+	0xA1: {0xA1, "AND", Implied, 2, 8, "AND C with A (undocumented, the prefix is ignored)"},
+	0xA2: {0xA2, "AND", Implied, 2, 8, "AND D with A (undocumented, the prefix is ignored)"},
+	0xA3: {0xA3, "AND", Implied, 2, 8, "AND E with A (undocumented, the prefix is ignored)"},
+	0xA4: {0xA4, "AND", Implied, 2, 8, "AND IYH with A (undocumented)"},
+	0xA5: {0xA5, "AND", Implied, 2, 8, "AND IYL with A (undocumented)"},
+	0xA6: {0xA6, "AND", Indexed, 3, 19, "AND memory at IY+d with A"},
+	0xA7: {0xA7, "AND", Implied, 2, 8, "AND A with A (undocumented, the prefix is ignored)"},
+	0xA8: {0xA8, "XOR", Implied, 2, 8, "Exclusive OR B with A (undocumented, the prefix is ignored)"},
+	0xA9: {0xA9, "XOR", Implied, 2, 8, "Exclusive OR C with A (undocumented, the prefix is ignored)"},
+	0xAA: {0xAA, "XOR", Implied, 2, 8, "Exclusive OR D with A (undocumented, the prefix is ignored)"},
+	0xAB: {0xAB, "XOR", Implied, 2, 8, "Exclusive OR E with A (undocumented, the prefix is ignored)"},
+	0xAC: {0xAC, "XOR", Implied, 2, 8, "Exclusive OR IYH with A (undocumented)"},
+	0xAD: {0xAD, "XOR", Implied, 2, 8, "Exclusive OR IYL with A (undocumented)"},
+	0xAE: {0xAE, "XOR", Indexed, 3, 19, "Exclusive OR memory at IY+d with A"},
+	0xAF: {0xAF, "XOR", Implied, 2, 8, "Exclusive OR A with A (undocumented, the prefix is ignored)"},
+	0xB0: {0xB0, "OR", Implied, 2, 8, "OR B with A (undocumented, the prefix is ignored)"},
+	0xB1: {0xB1, "OR", Implied, 2, 8, "OR C with A (undocumented, the prefix is ignored)"},
+	0xB2: {0xB2, "OR", Implied, 2, 8, "OR D with A (undocumented, the prefix is ignored)"},
+	0xB3: {0xB3, "OR", Implied, 2, 8, "OR E with A (undocumented, the prefix is ignored)"},
+	0xB4: {0xB4, "OR", Implied, 2, 8, "OR IYH with A (undocumented)"},
+	0xB5: {0xB5, "OR", Implied, 2, 8, "OR IYL with A (undocumented)"},
+	0xB6: {0xB6, "OR", Indexed, 3, 19, "OR memory at IY+d with A"},
+	0xB7: {0xB7, "OR", Implied, 2, 8, "OR A with A (undocumented, the prefix is ignored)"},
+	0xB8: {0xB8, "CP", Implied, 2, 8, "Compare B with A (undocumented, the prefix is ignored)"},
+	0xB9: {0xB9, "CP", Implied, 2, 8, "Compare C with A (undocumented, the prefix is ignored)"},
+	0xBA: {0xBA, "CP", Implied, 2, 8, "Compare D with A (undocumented, the prefix is ignored)"},
+	0xBB: {0xBB, "CP", Implied, 2, 8, "Compare E with A (undocumented, the prefix is ignored)"},
+	0xBC: {0xBC, "CP", Implied, 2, 8, "Compare IYH with A (undocumented)"},
+	0xBD: {0xBD, "CP", Implied, 2, 8, "Compare IYL with A (undocumented)"},
+	0xBE: {0xBE, "CP", Indexed, 3, 19, "Compare memory at IY+d with A"},
+	0xBF: {0xBF, "CP", Implied, 2, 8, "Compare A with A (undocumented, the prefix is ignored)"},
+	0xC6: {0xC6, "ADD", Immediate, 3, 11, "Add immediate data to A (undocumented, the prefix is ignored)"},
+	0xCE: {0xCE, "ADC", Immediate, 3, 11, "Add immediate data and the carry to A (undocumented, the prefix is ignored)"},
+	0xD6: {0xD6, "SUB", Immediate, 3, 11, "Subtract immediate data from A (undocumented, the prefix is ignored)"},
+	0xDE: {0xDE, "SBC", Immediate, 3, 11, "Subtract immediate data and the carry from A (undocumented, the prefix is ignored)"},
+	0xE6: {0xE6, "AND", Immediate, 3, 11, "AND immediate data with A (undocumented, the prefix is ignored)"},
+	0xEE: {0xEE, "XOR", Immediate, 3, 11, "Exclusive OR immediate data with A (undocumented, the prefix is ignored)"},
+	0xF6: {0xF6, "OR", Immediate, 3, 11, "OR immediate data with A (undocumented, the prefix is ignored)"},
+	0xFE: {0xFE, "CP", Immediate, 3, 11, "Compare immediate data with A (undocumented, the prefix is ignored)"},
+
+	// 16-Bit Arithmetic Group
+	0x03: {0x03, "INC", Implied, 2, 10, "Increment BC (undocumented, the prefix is ignored)"},
+	0x09: {0x09, "ADD", Implied, 2, 15, "Add BC to IY"},
+	0x0B: {0x0B, "DEC", Implied, 2, 10, "Decrement BC (undocumented, the prefix is ignored)"},
+	0x13: {0x13, "INC", Implied, 2, 10, "Increment DE (undocumented, the prefix is ignored)"},
+	0x19: {0x19, "ADD", Implied, 2, 15, "Add DE to IY"},
+	0x1B: {0x1B, "DEC", Implied, 2, 10, "Decrement DE (undocumented, the prefix is ignored)"},
+	0x23: {0x23, "INC", Implied, 2, 10, "Increment IY"},
+	0x29: {0x29, "ADD", Implied, 2, 15, "Add IY to IY"},
+	0x2B: {0x2B, "DEC", Implied, 2, 10, "Decrement IY"},
+	0x33: {0x33, "INC", Implied, 2, 10, "Increment SP (undocumented, the prefix is ignored)"},
+	0x39: {0x39, "ADD", Implied, 2, 15, "Add SP to IY"},
+	0x3B: {0x3B, "DEC", Implied, 2, 10, "Decrement SP (undocumented, the prefix is ignored)"},
+
+	// General-Purpose Arithmetic and CPU Control Group
+	0x00: {0x00, "NOP", Implied, 2, 8, "No operation (undocumented, the prefix is ignored)"},
+	0x27: {0x27, "DAA", Implied, 2, 8, "Decimal adjust A (undocumented, the prefix is ignored)"},
+	0x2F: {0x2F, "CPL", Implied, 2, 8, "Complement A (undocumented, the prefix is ignored)"},
+	0x37: {0x37, "SCF", Implied, 2, 8, "Set the carry flag (undocumented, the prefix is ignored)"},
+	0x3F: {0x3F, "CCF", Implied, 2, 8, "Complement the carry flag (undocumented, the prefix is ignored)"},
+	0x76: {0x76, "HALT", Implied, 2, 8, "Halt until an interrupt (undocumented, the prefix is ignored)"},
+	0xDD: {0xDD, "NOP", Implied, 1, 4, "Ignored prefix (undocumented)"},
+	0xED: {0xED, "NOP", Implied, 1, 4, "Ignored prefix (undocumented)"},
+	0xF3: {0xF3, "DI", Implied, 2, 8, "Disable interrupts (undocumented, the prefix is ignored)"},
+	0xFB: {0xFB, "EI", Implied, 2, 8, "Enable interrupts after the next instruction (undocumented, the prefix is ignored)"},
+	0xFD: {0xFD, "NOP", Implied, 1, 4, "Ignored prefix (undocumented)"},
+
+	// Rotate and Shift Group
+	0x07: {0x07, "RLCA", Implied, 2, 8, "Rotate A left circular (undocumented, the prefix is ignored)"},
+	0x0F: {0x0F, "RRCA", Implied, 2, 8, "Rotate A right circular (undocumented, the prefix is ignored)"},
+	0x17: {0x17, "RLA", Implied, 2, 8, "Rotate A left through the carry (undocumented, the prefix is ignored)"},
+	0x1F: {0x1F, "RRA", Implied, 2, 8, "Rotate A right through the carry (undocumented, the prefix is ignored)"},
+
+	// Jump Group
+	0x10: {0x10, "DJNZ", Relative, 3, 12, "Decrement B and jump relative if not zero (undocumented, the prefix is ignored)"},
+	0x18: {0x18, "JR", Relative, 3, 16, "Jump relative (undocumented, the prefix is ignored)"},
+	0x20: {0x20, "JR", Relative, 3, 11, "Jump relative if not zero (undocumented, the prefix is ignored)"},
+	0x28: {0x28, "JR", Relative, 3, 11, "Jump relative if zero (undocumented, the prefix is ignored)"},
+	0x30: {0x30, "JR", Relative, 3, 11, "Jump relative if no carry (undocumented, the prefix is ignored)"},
+	0x38: {0x38, "JR", Relative, 3, 11, "Jump relative if carry (undocumented, the prefix is ignored)"},
+	0xC2: {0xC2, "JP", Absolute, 4, 14, "Jump if not zero (undocumented, the prefix is ignored)"},
+	0xC3: {0xC3, "JP", Absolute, 4, 14, "Jump to the address (undocumented, the prefix is ignored)"},
+	0xCA: {0xCA, "JP", Absolute, 4, 14, "Jump if zero (undocumented, the prefix is ignored)"},
+	0xD2: {0xD2, "JP", Absolute, 4, 14, "Jump if no carry (undocumented, the prefix is ignored)"},
+	0xDA: {0xDA, "JP", Absolute, 4, 14, "Jump if carry (undocumented, the prefix is ignored)"},
+	0xE2: {0xE2, "JP", Absolute, 4, 14, "Jump if parity odd (undocumented, the prefix is ignored)"},
+	0xE9: {0xE9, "JP", Implied, 2, 8, "Jump to the address in IY"},
+	0xEA: {0xEA, "JP", Absolute, 4, 14, "Jump if parity even (undocumented, the prefix is ignored)"},
+	0xF2: {0xF2, "JP", Absolute, 4, 14, "Jump if plus (undocumented, the prefix is ignored)"},
+	0xFA: {0xFA, "JP", Absolute, 4, 14, "Jump if minus (undocumented, the prefix is ignored)"},
+
+	// Call and Return Group
+	0xC0: {0xC0, "RET", Implied, 2, 9, "Return if not zero (undocumented, the prefix is ignored)"},
+	0xC4: {0xC4, "CALL", Absolute, 4, 14, "Call if not zero (undocumented, the prefix is ignored)"},
+	0xC7: {0xC7, "RST", Implied, 2, 15, "Call the restart at $0000 (undocumented, the prefix is ignored)"},
+	0xC8: {0xC8, "RET", Implied, 2, 9, "Return if zero (undocumented, the prefix is ignored)"},
+	0xC9: {0xC9, "RET", Implied, 2, 14, "Return from a subroutine (undocumented, the prefix is ignored)"},
+	0xCC: {0xCC, "CALL", Absolute, 4, 14, "Call if zero (undocumented, the prefix is ignored)"},
+	0xCD: {0xCD, "CALL", Absolute, 4, 21, "Call the subroutine at the address (undocumented, the prefix is ignored)"},
+	0xCF: {0xCF, "RST", Implied, 2, 15, "Call the restart at $0008 (undocumented, the prefix is ignored)"},
+	0xD0: {0xD0, "RET", Implied, 2, 9, "Return if no carry (undocumented, the prefix is ignored)"},
+	0xD4: {0xD4, "CALL", Absolute, 4, 14, "Call if no carry (undocumented, the prefix is ignored)"},
+	0xD7: {0xD7, "RST", Implied, 2, 15, "Call the restart at $0010 (undocumented, the prefix is ignored)"},
+	0xD8: {0xD8, "RET", Implied, 2, 9, "Return if carry (undocumented, the prefix is ignored)"},
+	0xDC: {0xDC, "CALL", Absolute, 4, 14, "Call if carry (undocumented, the prefix is ignored)"},
+	0xDF: {0xDF, "RST", Implied, 2, 15, "Call the restart at $0018 (undocumented, the prefix is ignored)"},
+	0xE0: {0xE0, "RET", Implied, 2, 9, "Return if parity odd (undocumented, the prefix is ignored)"},
+	0xE4: {0xE4, "CALL", Absolute, 4, 14, "Call if parity odd (undocumented, the prefix is ignored)"},
+	0xE7: {0xE7, "RST", Implied, 2, 15, "Call the restart at $0020 (undocumented, the prefix is ignored)"},
+	0xE8: {0xE8, "RET", Implied, 2, 9, "Return if parity even (undocumented, the prefix is ignored)"},
+	0xEC: {0xEC, "CALL", Absolute, 4, 14, "Call if parity even (undocumented, the prefix is ignored)"},
+	0xEF: {0xEF, "RST", Implied, 2, 15, "Call the restart at $0028 (undocumented, the prefix is ignored)"},
+	0xF0: {0xF0, "RET", Implied, 2, 9, "Return if plus (undocumented, the prefix is ignored)"},
+	0xF4: {0xF4, "CALL", Absolute, 4, 14, "Call if plus (undocumented, the prefix is ignored)"},
+	0xF7: {0xF7, "RST", Implied, 2, 15, "Call the restart at $0030 (undocumented, the prefix is ignored)"},
+	0xF8: {0xF8, "RET", Implied, 2, 9, "Return if minus (undocumented, the prefix is ignored)"},
+	0xFC: {0xFC, "CALL", Absolute, 4, 14, "Call if minus (undocumented, the prefix is ignored)"},
+	0xFF: {0xFF, "RST", Implied, 2, 15, "Call the restart at $0038 (undocumented, the prefix is ignored)"},
+
+	// Input and Output Group
+	0xD3: {0xD3, "OUT", Immediate, 3, 15, "Output A to the port (undocumented, the prefix is ignored)"},
+	0xDB: {0xDB, "IN", Immediate, 3, 15, "Input A from the port (undocumented, the prefix is ignored)"},
+
+	// Prefixes
+	0xCB: {0xCB, "", IndexedPrefix, 2, 0, "Prefix of the IY bit instructions"},
+}
+
+// Z80DDCBInstructions are the bit instructions on (IX+d) after DD CB. The displacement comes
+// before the opcode. Apart from BIT, the undocumented forms with a register also copy the result to it.
+var Z80DDCBInstructions = map[byte]Instruction{
+
+	// Rotate and Shift Group
+	0x00: {0x00, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to B (undocumented)"},
+	0x01: {0x01, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to C (undocumented)"},
+	0x02: {0x02, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to D (undocumented)"},
+	0x03: {0x03, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to E (undocumented)"},
+	0x04: {0x04, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to H (undocumented)"},
+	0x05: {0x05, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to L (undocumented)"},
+	0x06: {0x06, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular"},
+	0x07: {0x07, "RLC", Indexed, 4, 23, "Rotate memory at IX+d left circular, copying the result to A (undocumented)"},
+	0x08: {0x08, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to B (undocumented)"},
+	0x09: {0x09, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to C (undocumented)"},
+	0x0A: {0x0A, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to D (undocumented)"},
+	0x0B: {0x0B, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to E (undocumented)"},
+	0x0C: {0x0C, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to H (undocumented)"},
+	0x0D: {0x0D, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to L (undocumented)"},
+	0x0E: {0x0E, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular"},
+	0x0F: {0x0F, "RRC", Indexed, 4, 23, "Rotate memory at IX+d right circular, copying the result to A (undocumented)"},
+	0x10: {0x10, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to B (undocumented)"},
+	0x11: {0x11, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to C (undocumented)"},
+	0x12: {0x12, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to D (undocumented)"},
+	0x13: {0x13, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to E (undocumented)"},
+	0x14: {0x14, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to H (undocumented)"},
+	0x15: {0x15, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to L (undocumented)"},
+	0x16: {0x16, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry"},
+	0x17: {0x17, "RL", Indexed, 4, 23, "Rotate memory at IX+d left through the carry, copying the result to A (undocumented)"},
+	0x18: {0x18, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to B (undocumented)"},
+	0x19: {0x19, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to C (undocumented)"},
+	0x1A: {0x1A, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to D (undocumented)"},
+	0x1B: {0x1B, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to E (undocumented)"},
+	0x1C: {0x1C, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to H (undocumented)"},
+	0x1D: {0x1D, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to L (undocumented)"},
+	0x1E: {0x1E, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry"},
+	0x1F: {0x1F, "RR", Indexed, 4, 23, "Rotate memory at IX+d right through the carry, copying the result to A (undocumented)"},
+	0x20: {0x20, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to B (undocumented)"},
+	0x21: {0x21, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to C (undocumented)"},
+	0x22: {0x22, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to D (undocumented)"},
+	0x23: {0x23, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to E (undocumented)"},
+	0x24: {0x24, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to H (undocumented)"},
+	0x25: {0x25, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to L (undocumented)"},
+	0x26: {0x26, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic"},
+	0x27: {0x27, "SLA", Indexed, 4, 23, "Shift memory at IX+d left arithmetic, copying the result to A (undocumented)"},
+	0x28: {0x28, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to B (undocumented)"},
+	0x29: {0x29, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to C (undocumented)"},
+	0x2A: {0x2A, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to D (undocumented)"},
+	0x2B: {0x2B, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to E (undocumented)"},
+	0x2C: {0x2C, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to H (undocumented)"},
+	0x2D: {0x2D, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to L (undocumented)"},
+	0x2E: {0x2E, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic"},
+	0x2F: {0x2F, "SRA", Indexed, 4, 23, "Shift memory at IX+d right arithmetic, copying the result to A (undocumented)"},
+	0x30: {0x30, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to B (undocumented)"},
+	0x31: {0x31, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to C (undocumented)"},
+	0x32: {0x32, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to D (undocumented)"},
+	0x33: {0x33, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to E (undocumented)"},
+	0x34: {0x34, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to H (undocumented)"},
+	0x35: {0x35, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to L (undocumented)"},
+	0x36: {0x36, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented)"},
+	0x37: {0x37, "SLL", Indexed, 4, 23, "Shift memory at IX+d left and set bit 0 (undocumented), copying the result to A (undocumented)"},
+	0x38: {0x38, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to B (undocumented)"},
+	0x39: {0x39, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to C (undocumented)"},
+	0x3A: {0x3A, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to D (undocumented)"},
+	0x3B: {0x3B, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to E (undocumented)"},
+	0x3C: {0x3C, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to H (undocumented)"},
+	0x3D: {0x3D, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to L (undocumented)"},
+	0x3E: {0x3E, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical"},
+	0x3F: {0x3F, "SRL", Indexed, 4, 23, "Shift memory at IX+d right logical, copying the result to A (undocumented)"},
+
+	// Bit Set, Reset and Test Group
+	0x40: {0x40, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x41: {0x41, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x42: {0x42, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x43: {0x43, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x44: {0x44, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x45: {0x45, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x46: {0x46, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d"},
+	0x47: {0x47, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IX+d (undocumented)"},
+	0x48: {0x48, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x49: {0x49, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x4A: {0x4A, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x4B: {0x4B, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x4C: {0x4C, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x4D: {0x4D, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x4E: {0x4E, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d"},
+	0x4F: {0x4F, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IX+d (undocumented)"},
+	0x50: {0x50, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x51: {0x51, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x52: {0x52, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x53: {0x53, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x54: {0x54, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x55: {0x55, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x56: {0x56, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d"},
+	0x57: {0x57, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IX+d (undocumented)"},
+	0x58: {0x58, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x59: {0x59, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x5A: {0x5A, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x5B: {0x5B, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x5C: {0x5C, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x5D: {0x5D, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x5E: {0x5E, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d"},
+	0x5F: {0x5F, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IX+d (undocumented)"},
+	0x60: {0x60, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x61: {0x61, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x62: {0x62, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x63: {0x63, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x64: {0x64, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x65: {0x65, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x66: {0x66, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d"},
+	0x67: {0x67, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IX+d (undocumented)"},
+	0x68: {0x68, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x69: {0x69, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x6A: {0x6A, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x6B: {0x6B, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x6C: {0x6C, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x6D: {0x6D, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x6E: {0x6E, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d"},
+	0x6F: {0x6F, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IX+d (undocumented)"},
+	0x70: {0x70, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x71: {0x71, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x72: {0x72, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x73: {0x73, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x74: {0x74, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x75: {0x75, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x76: {0x76, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d"},
+	0x77: {0x77, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IX+d (undocumented)"},
+	0x78: {0x78, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x79: {0x79, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x7A: {0x7A, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x7B: {0x7B, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x7C: {0x7C, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x7D: {0x7D, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x7E: {0x7E, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d"},
+	0x7F: {0x7F, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IX+d (undocumented)"},
+	0x80: {0x80, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to B (undocumented)"},
+	0x81: {0x81, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to C (undocumented)"},
+	0x82: {0x82, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to D (undocumented)"},
+	0x83: {0x83, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to E (undocumented)"},
+	0x84: {0x84, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to H (undocumented)"},
+	0x85: {0x85, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to L (undocumented)"},
+	0x86: {0x86, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d"},
+	0x87: {0x87, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IX+d, copying the result to A (undocumented)"},
+	0x88: {0x88, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to B (undocumented)"},
+	0x89: {0x89, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to C (undocumented)"},
+	0x8A: {0x8A, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to D (undocumented)"},
+	0x8B: {0x8B, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to E (undocumented)"},
+	0x8C: {0x8C, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to H (undocumented)"},
+	0x8D: {0x8D, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to L (undocumented)"},
+	0x8E: {0x8E, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d"},
+	0x8F: {0x8F, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IX+d, copying the result to A (undocumented)"},
+	0x90: {0x90, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to B (undocumented)"},
+	0x91: {0x91, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to C (undocumented)"},
+	0x92: {0x92, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to D (undocumented)"},
+	0x93: {0x93, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to E (undocumented)"},
+	0x94: {0x94, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to H (undocumented)"},
+	0x95: {0x95, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to L (undocumented)"},
+	0x96: {0x96, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d"},
+	0x97: {0x97, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IX+d, copying the result to A (undocumented)"},
+	0x98: {0x98, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to B (undocumented)"},
+	0x99: {0x99, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to C (undocumented)"},
+	0x9A: {0x9A, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to D (undocumented)"},
+	0x9B: {0x9B, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to E (undocumented)"},
+	0x9C: {0x9C, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to H (undocumented)"},
+	0x9D: {0x9D, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to L (undocumented)"},
+	0x9E: {0x9E, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d"},
+	0x9F: {0x9F, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IX+d, copying the result to A (undocumented)"},
+	0xA0: {0xA0, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to B (undocumented)"},
+	0xA1: {0xA1, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to C (undocumented)"},
+	0xA2: {0xA2, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to D (undocumented)"},
+	0xA3: {0xA3, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to E (undocumented)"},
+	0xA4: {0xA4, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to H (undocumented)"},
+	0xA5: {0xA5, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to L (undocumented)"},
+	0xA6: {0xA6, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d"},
+	0xA7: {0xA7, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IX+d, copying the result to A (undocumented)"},
+	0xA8: {0xA8, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to B (undocumented)"},
+	0xA9: {0xA9, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to C (undocumented)"},
+	0xAA: {0xAA, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to D (undocumented)"},
+	0xAB: {0xAB, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to E (undocumented)"},
+	0xAC: {0xAC, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to H (undocumented)"},
+	0xAD: {0xAD, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to L (undocumented)"},
+	0xAE: {0xAE, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d"},
+	0xAF: {0xAF, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IX+d, copying the result to A (undocumented)"},
+	0xB0: {0xB0, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to B (undocumented)"},
+	0xB1: {0xB1, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to C (undocumented)"},
+	0xB2: {0xB2, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to D (undocumented)"},
+	0xB3: {0xB3, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to E (undocumented)"},
+	0xB4: {0xB4, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to H (undocumented)"},
+	0xB5: {0xB5, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to L (undocumented)"},
+	0xB6: {0xB6, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d"},
+	0xB7: {0xB7, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IX+d, copying the result to A (undocumented)"},
+	0xB8: {0xB8, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to B (undocumented)"},
+	0xB9: {0xB9, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to C (undocumented)"},
+	0xBA: {0xBA, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to D (undocumented)"},
+	0xBB: {0xBB, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to E (undocumented)"},
+	0xBC: {0xBC, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to H (undocumented)"},
+	0xBD: {0xBD, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to L (undocumented)"},
+	0xBE: {0xBE, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d"},
+	0xBF: {0xBF, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IX+d, copying the result to A (undocumented)"},
+	0xC0: {0xC0, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to B (undocumented)"},
+	0xC1: {0xC1, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to C (undocumented)"},
+	0xC2: {0xC2, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to D (undocumented)"},
+	0xC3: {0xC3, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to E (undocumented)"},
+	0xC4: {0xC4, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to H (undocumented)"},
+	0xC5: {0xC5, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to L (undocumented)"},
+	0xC6: {0xC6, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d"},
+	0xC7: {0xC7, "SET", Indexed, 4, 23, "Set bit 0 of memory at IX+d, copying the result to A (undocumented)"},
+	0xC8: {0xC8, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to B (undocumented)"},
+	0xC9: {0xC9, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to C (undocumented)"},
+	0xCA: {0xCA, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to D (undocumented)"},
+	0xCB: {0xCB, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to E (undocumented)"},
+	0xCC: {0xCC, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to H (undocumented)"},
+	0xCD: {0xCD, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to L (undocumented)"},
+	0xCE: {0xCE, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d"},
+	0xCF: {0xCF, "SET", Indexed, 4, 23, "Set bit 1 of memory at IX+d, copying the result to A (undocumented)"},
+	0xD0: {0xD0, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to B (undocumented)"},
+	0xD1: {0xD1, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to C (undocumented)"},
+	0xD2: {0xD2, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to D (undocumented)"},
+	0xD3: {0xD3, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to E (undocumented)"},
+	0xD4: {0xD4, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to H (undocumented)"},
+	0xD5: {0xD5, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to L (undocumented)"},
+	0xD6: {0xD6, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d"},
+	0xD7: {0xD7, "SET", Indexed, 4, 23, "Set bit 2 of memory at IX+d, copying the result to A (undocumented)"},
+	0xD8: {0xD8, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to B (undocumented)"},
+	0xD9: {0xD9, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to C (undocumented)"},
+	0xDA: {0xDA, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to D (undocumented)"},
+	0xDB: {0xDB, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to E (undocumented)"},
+	0xDC: {0xDC, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to H (undocumented)"},
+	0xDD: {0xDD, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to L (undocumented)"},
+	0xDE: {0xDE, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d"},
+	0xDF: {0xDF, "SET", Indexed, 4, 23, "Set bit 3 of memory at IX+d, copying the result to A (undocumented)"},
+	0xE0: {0xE0, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to B (undocumented)"},
+	0xE1: {0xE1, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to C (undocumented)"},
+	0xE2: {0xE2, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to D (undocumented)"},
+	0xE3: {0xE3, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to E (undocumented)"},
+	0xE4: {0xE4, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to H (undocumented)"},
+	0xE5: {0xE5, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to L (undocumented)"},
+	0xE6: {0xE6, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d"},
+	0xE7: {0xE7, "SET", Indexed, 4, 23, "Set bit 4 of memory at IX+d, copying the result to A (undocumented)"},
+	0xE8: {0xE8, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to B (undocumented)"},
+	0xE9: {0xE9, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to C (undocumented)"},
+	0xEA: {0xEA, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to D (undocumented)"},
+	0xEB: {0xEB, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to E (undocumented)"},
+	0xEC: {0xEC, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to H (undocumented)"},
+	0xED: {0xED, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to L (undocumented)"},
+	0xEE: {0xEE, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d"},
+	0xEF: {0xEF, "SET", Indexed, 4, 23, "Set bit 5 of memory at IX+d, copying the result to A (undocumented)"},
+	0xF0: {0xF0, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to B (undocumented)"},
+	0xF1: {0xF1, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to C (undocumented)"},
+	0xF2: {0xF2, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to D (undocumented)"},
+	0xF3: {0xF3, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to E (undocumented)"},
+	0xF4: {0xF4, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to H (undocumented)"},
+	0xF5: {0xF5, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to L (undocumented)"},
+	0xF6: {0xF6, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d"},
+	0xF7: {0xF7, "SET", Indexed, 4, 23, "Set bit 6 of memory at IX+d, copying the result to A (undocumented)"},
+	0xF8: {0xF8, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to B (undocumented)"},
+	0xF9: {0xF9, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to C (undocumented)"},
+	0xFA: {0xFA, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to D (undocumented)"},
+	0xFB: {0xFB, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to E (undocumented)"},
+	0xFC: {0xFC, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to H (undocumented)"},
+	0xFD: {0xFD, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to L (undocumented)"},
+	0xFE: {0xFE, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d"},
+	0xFF: {0xFF, "SET", Indexed, 4, 23, "Set bit 7 of memory at IX+d, copying the result to A (undocumented)"},
+}
+
+// Z80FDCBInstructions are the bit instructions on (IY+d) after FD CB
+var Z80FDCBInstructions = map[byte]Instruction{
+
+	// Rotate and Shift Group
+	0x00: {0x00, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to B (undocumented)"},
+	0x01: {0x01, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to C (undocumented)"},
+	0x02: {0x02, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to D (undocumented)"},
+	0x03: {0x03, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to E (undocumented)"},
+	0x04: {0x04, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to H (undocumented)"},
+	0x05: {0x05, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to L (undocumented)"},
+	0x06: {0x06, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular"},
+	0x07: {0x07, "RLC", Indexed, 4, 23, "Rotate memory at IY+d left circular, copying the result to A (undocumented)"},
+	0x08: {0x08, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to B (undocumented)"},
+	0x09: {0x09, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to C (undocumented)"},
+	0x0A: {0x0A, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to D (undocumented)"},
+	0x0B: {0x0B, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to E (undocumented)"},
+	0x0C: {0x0C, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to H (undocumented)"},
+	0x0D: {0x0D, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to L (undocumented)"},
+	0x0E: {0x0E, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular"},
+	0x0F: {0x0F, "RRC", Indexed, 4, 23, "Rotate memory at IY+d right circular, copying the result to A (undocumented)"},
+	0x10: {0x10, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to B (undocumented)"},
+	0x11: {0x11, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to C (undocumented)"},
+	0x12: {0x12, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to D (undocumented)"},
+	0x13: {0x13, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to E (undocumented)"},
+	0x14: {0x14, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to H (undocumented)"},
+	0x15: {0x15, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to L (undocumented)"},
+	0x16: {0x16, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry"},
+	0x17: {0x17, "RL", Indexed, 4, 23, "Rotate memory at IY+d left through the carry, copying the result to A (undocumented)"},
+	0x18: {0x18, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to B (undocumented)"},
+	0x19: {0x19, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to C (undocumented)"},
+	0x1A: {0x1A, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to D (undocumented)"},
+	0x1B: {0x1B, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to E (undocumented)"},
+	0x1C: {0x1C, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to H (undocumented)"},
+	0x1D: {0x1D, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to L (undocumented)"},
+	0x1E: {0x1E, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry"},
+	0x1F: {0x1F, "RR", Indexed, 4, 23, "Rotate memory at IY+d right through the carry, copying the result to A (undocumented)"},
+	0x20: {0x20, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to B (undocumented)"},
+	0x21: {0x21, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to C (undocumented)"},
+	0x22: {0x22, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to D (undocumented)"},
+	0x23: {0x23, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to E (undocumented)"},
+	0x24: {0x24, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to H (undocumented)"},
+	0x25: {0x25, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to L (undocumented)"},
+	0x26: {0x26, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic"},
+	0x27: {0x27, "SLA", Indexed, 4, 23, "Shift memory at IY+d left arithmetic, copying the result to A (undocumented)"},
+	0x28: {0x28, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to B (undocumented)"},
+	0x29: {0x29, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to C (undocumented)"},
+	0x2A: {0x2A, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to D (undocumented)"},
+	0x2B: {0x2B, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to E (undocumented)"},
+	0x2C: {0x2C, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to H (undocumented)"},
+	0x2D: {0x2D, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to L (undocumented)"},
+	0x2E: {0x2E, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic"},
+	0x2F: {0x2F, "SRA", Indexed, 4, 23, "Shift memory at IY+d right arithmetic, copying the result to A (undocumented)"},
+	0x30: {0x30, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to B (undocumented)"},
+	0x31: {0x31, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to C (undocumented)"},
+	0x32: {0x32, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to D (undocumented)"},
+	0x33: {0x33, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to E (undocumented)"},
+	0x34: {0x34, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to H (undocumented)"},
+	0x35: {0x35, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to L (undocumented)"},
+	0x36: {0x36, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented)"},
+	0x37: {0x37, "SLL", Indexed, 4, 23, "Shift memory at IY+d left and set bit 0 (undocumented), copying the result to A (undocumented)"},
+	0x38: {0x38, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to B (undocumented)"},
+	0x39: {0x39, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to C (undocumented)"},
+	0x3A: {0x3A, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to D (undocumented)"},
+	0x3B: {0x3B, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to E (undocumented)"},
+	0x3C: {0x3C, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to H (undocumented)"},
+	0x3D: {0x3D, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to L (undocumented)"},
+	0x3E: {0x3E, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical"},
+	0x3F: {0x3F, "SRL", Indexed, 4, 23, "Shift memory at IY+d right logical, copying the result to A (undocumented)"},
+
+	// Bit Set, Reset and Test Group
+	0x40: {0x40, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x41: {0x41, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x42: {0x42, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x43: {0x43, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x44: {0x44, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x45: {0x45, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x46: {0x46, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d"},
+	0x47: {0x47, "BIT", Indexed, 4, 20, "Test bit 0 of memory at IY+d (undocumented)"},
+	0x48: {0x48, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x49: {0x49, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x4A: {0x4A, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x4B: {0x4B, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x4C: {0x4C, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x4D: {0x4D, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x4E: {0x4E, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d"},
+	0x4F: {0x4F, "BIT", Indexed, 4, 20, "Test bit 1 of memory at IY+d (undocumented)"},
+	0x50: {0x50, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x51: {0x51, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x52: {0x52, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x53: {0x53, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x54: {0x54, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x55: {0x55, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x56: {0x56, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d"},
+	0x57: {0x57, "BIT", Indexed, 4, 20, "Test bit 2 of memory at IY+d (undocumented)"},
+	0x58: {0x58, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x59: {0x59, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x5A: {0x5A, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x5B: {0x5B, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x5C: {0x5C, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x5D: {0x5D, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x5E: {0x5E, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d"},
+	0x5F: {0x5F, "BIT", Indexed, 4, 20, "Test bit 3 of memory at IY+d (undocumented)"},
+	0x60: {0x60, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x61: {0x61, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x62: {0x62, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x63: {0x63, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x64: {0x64, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x65: {0x65, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x66: {0x66, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d"},
+	0x67: {0x67, "BIT", Indexed, 4, 20, "Test bit 4 of memory at IY+d (undocumented)"},
+	0x68: {0x68, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x69: {0x69, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x6A: {0x6A, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x6B: {0x6B, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x6C: {0x6C, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x6D: {0x6D, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x6E: {0x6E, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d"},
+	0x6F: {0x6F, "BIT", Indexed, 4, 20, "Test bit 5 of memory at IY+d (undocumented)"},
+	0x70: {0x70, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x71: {0x71, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x72: {0x72, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x73: {0x73, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x74: {0x74, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x75: {0x75, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x76: {0x76, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d"},
+	0x77: {0x77, "BIT", Indexed, 4, 20, "Test bit 6 of memory at IY+d (undocumented)"},
+	0x78: {0x78, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x79: {0x79, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x7A: {0x7A, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x7B: {0x7B, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x7C: {0x7C, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x7D: {0x7D, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x7E: {0x7E, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d"},
+	0x7F: {0x7F, "BIT", Indexed, 4, 20, "Test bit 7 of memory at IY+d (undocumented)"},
+	0x80: {0x80, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to B (undocumented)"},
+	0x81: {0x81, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to C (undocumented)"},
+	0x82: {0x82, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to D (undocumented)"},
+	0x83: {0x83, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to E (undocumented)"},
+	0x84: {0x84, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to H (undocumented)"},
+	0x85: {0x85, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to L (undocumented)"},
+	0x86: {0x86, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d"},
+	0x87: {0x87, "RES", Indexed, 4, 23, "Reset bit 0 of memory at IY+d, copying the result to A (undocumented)"},
+	0x88: {0x88, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to B (undocumented)"},
+	0x89: {0x89, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to C (undocumented)"},
+	0x8A: {0x8A, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to D (undocumented)"},
+	0x8B: {0x8B, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to E (undocumented)"},
+	0x8C: {0x8C, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to H (undocumented)"},
+	0x8D: {0x8D, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to L (undocumented)"},
+	0x8E: {0x8E, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d"},
+	0x8F: {0x8F, "RES", Indexed, 4, 23, "Reset bit 1 of memory at IY+d, copying the result to A (undocumented)"},
+	0x90: {0x90, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to B (undocumented)"},
+	0x91: {0x91, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to C (undocumented)"},
+	0x92: {0x92, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to D (undocumented)"},
+	0x93: {0x93, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to E (undocumented)"},
+	0x94: {0x94, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to H (undocumented)"},
+	0x95: {0x95, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to L (undocumented)"},
+	0x96: {0x96, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d"},
+	0x97: {0x97, "RES", Indexed, 4, 23, "Reset bit 2 of memory at IY+d, copying the result to A (undocumented)"},
+	0x98: {0x98, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to B (undocumented)"},
+	0x99: {0x99, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to C (undocumented)"},
+	0x9A: {0x9A, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to D (undocumented)"},
+	0x9B: {0x9B, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to E (undocumented)"},
+	0x9C: {0x9C, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to H (undocumented)"},
+	0x9D: {0x9D, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to L (undocumented)"},
+	0x9E: {0x9E, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d"},
+	0x9F: {0x9F, "RES", Indexed, 4, 23, "Reset bit 3 of memory at IY+d, copying the result to A (undocumented)"},
+	0xA0: {0xA0, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to B (undocumented)"},
+	0xA1: {0xA1, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to C (undocumented)"},
+	0xA2: {0xA2, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to D (undocumented)"},
+	0xA3: {0xA3, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to E (undocumented)"},
+	0xA4: {0xA4, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to H (undocumented)"},
+	0xA5: {0xA5, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to L (undocumented)"},
+	0xA6: {0xA6, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d"},
+	0xA7: {0xA7, "RES", Indexed, 4, 23, "Reset bit 4 of memory at IY+d, copying the result to A (undocumented)"},
+	0xA8: {0xA8, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to B (undocumented)"},
+	0xA9: {0xA9, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to C (undocumented)"},
+	0xAA: {0xAA, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to D (undocumented)"},
+	0xAB: {0xAB, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to E (undocumented)"},
+	0xAC: {0xAC, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to H (undocumented)"},
+	0xAD: {0xAD, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to L (undocumented)"},
+	0xAE: {0xAE, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d"},
+	0xAF: {0xAF, "RES", Indexed, 4, 23, "Reset bit 5 of memory at IY+d, copying the result to A (undocumented)"},
+	0xB0: {0xB0, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to B (undocumented)"},
+	0xB1: {0xB1, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to C (undocumented)"},
+	0xB2: {0xB2, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to D (undocumented)"},
+	0xB3: {0xB3, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to E (undocumented)"},
+	0xB4: {0xB4, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to H (undocumented)"},
+	0xB5: {0xB5, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to L (undocumented)"},
+	0xB6: {0xB6, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d"},
+	0xB7: {0xB7, "RES", Indexed, 4, 23, "Reset bit 6 of memory at IY+d, copying the result to A (undocumented)"},
+	0xB8: {0xB8, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to B (undocumented)"},
+	0xB9: {0xB9, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to C (undocumented)"},
+	0xBA: {0xBA, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to D (undocumented)"},
+	0xBB: {0xBB, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to E (undocumented)"},
+	0xBC: {0xBC, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to H (undocumented)"},
+	0xBD: {0xBD, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to L (undocumented)"},
+	0xBE: {0xBE, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d"},
+	0xBF: {0xBF, "RES", Indexed, 4, 23, "Reset bit 7 of memory at IY+d, copying the result to A (undocumented)"},
+	0xC0: {0xC0, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to B (undocumented)"},
+	0xC1: {0xC1, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to C (undocumented)"},
+	0xC2: {0xC2, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to D (undocumented)"},
+	0xC3: {0xC3, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to E (undocumented)"},
+	0xC4: {0xC4, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to H (undocumented)"},
+	0xC5: {0xC5, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to L (undocumented)"},
+	0xC6: {0xC6, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d"},
+	0xC7: {0xC7, "SET", Indexed, 4, 23, "Set bit 0 of memory at IY+d, copying the result to A (undocumented)"},
+	0xC8: {0xC8, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to B (undocumented)"},
+	0xC9: {0xC9, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to C (undocumented)"},
+	0xCA: {0xCA, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to D (undocumented)"},
+	0xCB: {0xCB, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to E (undocumented)"},
+	0xCC: {0xCC, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to H (undocumented)"},
+	0xCD: {0xCD, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to L (undocumented)"},
+	0xCE: {0xCE, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d"},
+	0xCF: {0xCF, "SET", Indexed, 4, 23, "Set bit 1 of memory at IY+d, copying the result to A (undocumented)"},
+	0xD0: {0xD0, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to B (undocumented)"},
+	0xD1: {0xD1, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to C (undocumented)"},
+	0xD2: {0xD2, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to D (undocumented)"},
+	0xD3: {0xD3, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to E (undocumented)"},
+	0xD4: {0xD4, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to H (undocumented)"},
+	0xD5: {0xD5, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to L (undocumented)"},
+	0xD6: {0xD6, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d"},
+	0xD7: {0xD7, "SET", Indexed, 4, 23, "Set bit 2 of memory at IY+d, copying the result to A (undocumented)"},
+	0xD8: {0xD8, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to B (undocumented)"},
+	0xD9: {0xD9, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to C (undocumented)"},
+	0xDA: {0xDA, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to D (undocumented)"},
+	0xDB: {0xDB, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to E (undocumented)"},
+	0xDC: {0xDC, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to H (undocumented)"},
+	0xDD: {0xDD, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to L (undocumented)"},
+	0xDE: {0xDE, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d"},
+	0xDF: {0xDF, "SET", Indexed, 4, 23, "Set bit 3 of memory at IY+d, copying the result to A (undocumented)"},
+	0xE0: {0xE0, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to B (undocumented)"},
+	0xE1: {0xE1, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to C (undocumented)"},
+	0xE2: {0xE2, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to D (undocumented)"},
+	0xE3: {0xE3, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to E (undocumented)"},
+	0xE4: {0xE4, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to H (undocumented)"},
+	0xE5: {0xE5, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to L (undocumented)"},
+	0xE6: {0xE6, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d"},
+	0xE7: {0xE7, "SET", Indexed, 4, 23, "Set bit 4 of memory at IY+d, copying the result to A (undocumented)"},
+	0xE8: {0xE8, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to B (undocumented)"},
+	0xE9: {0xE9, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to C (undocumented)"},
+	0xEA: {0xEA, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to D (undocumented)"},
+	0xEB: {0xEB, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to E (undocumented)"},
+	0xEC: {0xEC, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to H (undocumented)"},
+	0xED: {0xED, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to L (undocumented)"},
+	0xEE: {0xEE, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d"},
+	0xEF: {0xEF, "SET", Indexed, 4, 23, "Set bit 5 of memory at IY+d, copying the result to A (undocumented)"},
+	0xF0: {0xF0, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to B (undocumented)"},
+	0xF1: {0xF1, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to C (undocumented)"},
+	0xF2: {0xF2, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to D (undocumented)"},
+	0xF3: {0xF3, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to E (undocumented)"},
+	0xF4: {0xF4, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to H (undocumented)"},
+	0xF5: {0xF5, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to L (undocumented)"},
+	0xF6: {0xF6, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d"},
+	0xF7: {0xF7, "SET", Indexed, 4, 23, "Set bit 6 of memory at IY+d, copying the result to A (undocumented)"},
+	0xF8: {0xF8, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to B (undocumented)"},
+	0xF9: {0xF9, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to C (undocumented)"},
+	0xFA: {0xFA, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to D (undocumented)"},
+	0xFB: {0xFB, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to E (undocumented)"},
+	0xFC: {0xFC, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to H (undocumented)"},
+	0xFD: {0xFD, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to L (undocumented)"},
+	0xFE: {0xFE, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d"},
+	0xFF: {0xFF, "SET", Indexed, 4, 23, "Set bit 7 of memory at IY+d, copying the result to A (undocumented)"},
+}
+
+// Z80PrefixTables are the tables selected by the Z80 prefix opcodes
+var Z80PrefixTables = PrefixTables{
+	0xCB:   Z80CBInstructions,
+	0xED:   Z80EDInstructions,
+	0xDD:   Z80DDInstructions,
+	0xFD:   Z80FDInstructions,
+	0xDDCB: Z80DDCBInstructions,
+	0xFDCB: Z80FDCBInstructions,
+}
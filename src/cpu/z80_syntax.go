@@ -0,0 +1,219 @@
+package cpu
+
+import (
+	"fmt"
+)
+
+// Z80Syntax is the Zilog Z80 assembly language (LD A,(IX+5), JR NZ, DJNZ) with 0FFH numbers
+var Z80Syntax = &Syntax{
+	Name:        "z80",
+	Description: "Zilog Z80 mnemonics (LD A,(IX+5), JR NZ, DJNZ)",
+	Forms:       z80Forms(Z80Instructions, z80MainOperands),
+	PrefixForms: PrefixForms{
+		0xCB:   z80Forms(Z80CBInstructions, z80CBOperands),
+		0xED:   z80Forms(Z80EDInstructions, z80EDOperands),
+		0xDD:   z80IndexForms(Z80DDInstructions, "IX"),
+		0xFD:   z80IndexForms(Z80FDInstructions, "IY"),
+		0xDDCB: z80IndexBitForms(Z80DDCBInstructions, "IX"),
+		0xFDCB: z80IndexBitForms(Z80FDCBInstructions, "IY"),
+	},
+	HexSuffix: true,
+}
+
+// Z80Syntaxes lists the dialects the Z80 can be written in
+var Z80Syntaxes = []*Syntax{Z80Syntax}
+
+// Operands in the order of their codes in Z80 opcodes
+var (
+	z80Registers  = []string{"B", "C", "D", "E", "H", "L", "(HL)", "A"}
+	z80Pairs      = []string{"BC", "DE", "HL", "SP"}
+	z80StackPairs = []string{"BC", "DE", "HL", "AF"}
+	z80Conditions = []string{"NZ", "Z", "NC", "C", "PO", "PE", "P", "M"}
+	z80Modes      = []string{"0", "0", "1", "2", "0", "0", "1", "2"}
+)
+
+// z80Forms builds the forms of a Z80 table, taking the operands of each
+// opcode from its fields: x (bits 7-6), y (bits 5-3) and z (bits 2-0).
+// Prefix opcodes have no form of their own.
+func z80Forms(instructions map[byte]Instruction, operands func(x, y, z byte) []string) map[byte]Form {
+	forms := make(map[byte]Form)
+	for opcode, instruction := range instructions {
+		if instruction.Mode == Prefix || instruction.Mode == IndexedPrefix {
+			continue
+		}
+		forms[opcode] = Form{Mnemonic: instruction.Mnemonic, Operands: operands(opcode>>6, opcode>>3&7, opcode&7)}
+	}
+	return forms
+}
+
+// z80MainOperands returns the operands of an unprefixed opcode
+func z80MainOperands(x, y, z byte) []string {
+	p, q := y>>1, y&1
+	switch x {
+	case 0:
+		switch z {
+		case 0:
+			switch {
+			case y == 1:
+				return []string{"AF", "AF'"}
+			case y == 2, y == 3:
+				return []string{"e"}
+			case y >= 4:
+				return []string{z80Conditions[y-4], "e"}
+			}
+		case 1:
+			if q == 0 {
+				return []string{z80Pairs[p], "nn"}
+			}
+			return []string{"HL", z80Pairs[p]}
+		case 2:
+			memory := []string{"(BC)", "(DE)", "(nn)", "(nn)"}[p]
+			register := []string{"A", "A", "HL", "A"}[p]
+			if q == 0 {
+				return []string{memory, register}
+			}
+			return []string{register, memory}
+		case 3:
+			return []string{z80Pairs[p]}
+		case 4, 5:
+			return []string{z80Registers[y]}
+		case 6:
+			return []string{z80Registers[y], "n"}
+		}
+	case 1:
+		if y == 6 && z == 6 {
+			return nil // HALT
+		}
+		return []string{z80Registers[y], z80Registers[z]}
+	case 2:
+		return z80ALUOperands(y, z80Registers[z])
+	case 3:
+		switch z {
+		case 0:
+			return []string{z80Conditions[y]}
+		case 1:
+			if q == 0 {
+				return []string{z80StackPairs[p]}
+			}
+			return [][]string{nil, nil, {"(HL)"}, {"SP", "HL"}}[p]
+		case 2, 4:
+			return []string{z80Conditions[y], "nn"}
+		case 3:
+			return [][]string{{"nn"}, nil, {"(n)", "A"}, {"A", "(n)"}, {"(SP)", "HL"}, {"DE", "HL"}, nil, nil}[y]
+		case 5:
+			if q == 0 {
+				return []string{z80StackPairs[p]}
+			}
+			if p == 0 {
+				return []string{"nn"}
+			}
+		case 6:
+			return z80ALUOperands(y, "n")
+		case 7:
+			return []string{fmt.Sprintf("%02XH", y*8)}
+		}
+	}
+	return nil
+}
+
+// z80ALUOperands returns the operands of an arithmetic or logic instruction:
+// ADD, ADC and SBC name the accumulator, the others only their source
+func z80ALUOperands(y byte, source string) []string {
+	if y == 0 || y == 1 || y == 3 {
+		return []string{"A", source}
+	}
+	return []string{source}
+}
+
+// z80CBOperands returns the operands of an opcode after the CB prefix
+func z80CBOperands(x, y, z byte) []string {
+	if x == 0 {
+		return []string{z80Registers[z]}
+	}
+	return []string{fmt.Sprint(y), z80Registers[z]}
+}
+
+// z80EDOperands returns the operands of an opcode after the ED prefix
+func z80EDOperands(x, y, z byte) []string {
+	if x != 1 {
+		return nil // Block instructions and NOPs
+	}
+	p, q := y>>1, y&1
+	switch z {
+	case 0:
+		if y == 6 {
+			return []string{"F", "(C)"}
+		}
+		return []string{z80Registers[y], "(C)"}
+	case 1:
+		if y == 6 {
+			return []string{"(C)", "0"}
+		}
+		return []string{"(C)", z80Registers[y]}
+	case 2:
+		return []string{"HL", z80Pairs[p]}
+	case 3:
+		if q == 0 {
+			return []string{"(nn)", z80Pairs[p]}
+		}
+		return []string{z80Pairs[p], "(nn)"}
+	case 6:
+		return []string{z80Modes[y]}
+	case 7:
+		return [][]string{{"I", "A"}, {"R", "A"}, {"A", "I"}, {"A", "R"}, nil, nil, nil, nil}[y]
+	}
+	return nil
+}
+
+// z80IndexForms builds the forms after a DD or FD prefix from the unprefixed
+// ones: (HL) becomes (IX+d), or HL, H and L become IX, IXH and IXL. Opcodes
+// the prefix does not change keep their form, and EX DE,HL keeps HL.
+func z80IndexForms(instructions map[byte]Instruction, index string) map[byte]Form {
+	forms := z80Forms(instructions, z80MainOperands)
+	for opcode, form := range forms {
+		instruction := instructions[opcode]
+		switch {
+		case form.Mnemonic == "NOP":
+			// The DD, ED and FD that cancel the prefix
+			forms[opcode] = Form{Mnemonic: form.Mnemonic}
+			continue
+		case form.String() == "EX DE,HL":
+			continue
+		}
+		indexed := instruction.Mode == Indexed || instruction.Mode == IndexedImmediate
+		operands := make([]string, len(form.Operands))
+		for i, operand := range form.Operands {
+			switch {
+			case indexed:
+				if operand == "(HL)" {
+					operand = "(" + index + "+d)"
+				}
+			case operand == "(HL)":
+				operand = "(" + index + ")"
+			case operand == "HL":
+				operand = index
+			case operand == "H", operand == "L":
+				operand = index + operand
+			}
+			operands[i] = operand
+		}
+		forms[opcode] = Form{Mnemonic: form.Mnemonic, Operands: operands}
+	}
+	return forms
+}
+
+// z80IndexBitForms builds the forms after DD CB or FD CB. The undocumented
+// opcodes that name a register other than (HL) also copy the result to it.
+func z80IndexBitForms(instructions map[byte]Instruction, index string) map[byte]Form {
+	forms := make(map[byte]Form)
+	for opcode, instruction := range instructions {
+		x, y, z := opcode>>6, opcode>>3&7, opcode&7
+		operands := z80CBOperands(x, y, 6)
+		operands[len(operands)-1] = "(" + index + "+d)"
+		if x != 1 && z != 6 {
+			operands = append(operands, z80Registers[z])
+		}
+		forms[opcode] = Form{Mnemonic: instruction.Mnemonic, Operands: operands}
+	}
+	return forms
+}
@@ -17,19 +17,41 @@ type Debugger struct {
 	running     bool
 	stepMode    bool
 	lastPC      uint16
+	syntaxes    []*cpu.Syntax     // Dialects the CPU's code can be disassembled in
+	syntax      *cpu.Syntax       // Dialect used for disassembly
+	symbols     map[string]uint16 // Labels that addresses and values can name
 }
 
 // New creates a new debugger instance
-func New(cpu cpu.ICPU) *Debugger {
+func New(processor cpu.ICPU) *Debugger {
+	syntaxes := cpu.Syntaxes(processor)
 	return &Debugger{
-		cpu:         cpu,
+		cpu:         processor,
 		breakpoints: make(map[uint16]bool),
 		running:     true,
 		stepMode:    false,
-		lastPC:      cpu.GetPC(),
+		lastPC:      processor.GetPC(),
+		syntaxes:    syntaxes,
+		syntax:      syntaxes[0],
 	}
 }
 
+// SetSyntax selects the dialect used for disassembly
+func (d *Debugger) SetSyntax(name string) error {
+	syntax, err := cpu.FindSyntax(d.syntaxes, name)
+	if err != nil {
+		return err
+	}
+	d.syntax = syntax
+	return nil
+}
+
+// SetSymbols gives the labels of the program, so that commands accept them
+// wherever they take an address or a value
+func (d *Debugger) SetSymbols(symbols map[string]uint16) {
+	d.symbols = symbols
+}
+
 // Run starts the debugger's main loop
 func (d *Debugger) Run() {
 	scanner := bufio.NewScanner(os.Stdin)
@@ -73,6 +95,16 @@ func (d *Debugger) Run() {
 			d.disassemble(args)
 		case "watch", "w":
 			d.handleWatch(args)
+		case "syntax":
+			d.handleSyntax(args)
+		case "set":
+			d.handleSet(args)
+		case "poke":
+			d.poke(args)
+		case "fill":
+			d.fill(args)
+		case "load":
+			d.load(args)
 		case "quit", "q":
 			d.running = false
 		default:
@@ -91,9 +123,15 @@ func (d *Debugger) printHelp() {
 	fmt.Println("  continue, c          - Continue execution")
 	fmt.Println("  registers, reg       - Show CPU registers")
 	fmt.Println("  memory, m <addr>     - Show memory at address")
-	fmt.Println("  disassemble, d <addr>- Disassemble code at address")
+	fmt.Println("  disassemble, d <addr> [count] - Disassemble instructions at address")
+	fmt.Println("  syntax [name]        - Show or select the disassembly dialect")
 	fmt.Println("  watch, w <addr>      - Watch memory location")
+	fmt.Println("  set <reg>=<value>    - Set a register, PC, SP or flags.<name> (0 or 1)")
+	fmt.Println("  poke <addr> <bytes...> - Write bytes to memory")
+	fmt.Println("  fill <start>-<end> <byte> - Fill a memory range with a byte")
+	fmt.Println("  load <file> <addr>   - Copy a file into memory at address")
 	fmt.Println("  quit, q              - Exit debugger")
+	fmt.Println("Addresses and values are hex ($10, 0x10 or 10) or labels from -symbols")
 }
 
 // handleBreakpoint sets or removes a breakpoint
@@ -106,7 +144,7 @@ func (d *Debugger) handleBreakpoint(args []string) {
 		return
 	}
 
-	addr, err := parseAddress(args[0])
+	addr, err := d.parseValue(args[0])
 	if err != nil {
 		fmt.Printf("Invalid address: %v\n", err)
 		return
@@ -131,12 +169,14 @@ func (d *Debugger) run() {
 		}
 
 		opcode := d.cpu.Read(d.cpu.GetPC())
-		if opcode == 0x00 {
+		if instruction, ok := d.cpu.GetInstructions()[opcode]; ok && (instruction.Mnemonic == "HLT" || instruction.Mnemonic == "HALT") {
 			fmt.Println("Program halted")
 			return
 		}
 
-		d.executeInstruction()
+		if !d.executeInstruction() {
+			return
+		}
 	}
 }
 
@@ -151,63 +191,74 @@ func (d *Debugger) continueExecution() {
 	d.run()
 }
 
-// executeInstruction executes one instruction and prints debug info
-func (d *Debugger) executeInstruction() {
+// executeInstruction executes one instruction and prints debug info.
+// It returns false when execution cannot continue.
+func (d *Debugger) executeInstruction() bool {
 	opcode := d.cpu.Read(d.cpu.GetPC())
-	instruction, ok := d.cpu.GetInstructions()[opcode]
-	var mnemonic string
-	if ok {
-		mnemonic = instruction.Mnemonic
-	} else {
-		mnemonic = "???"
-	}
+	mnemonic, _ := cpu.Disassemble(d.syntax, d.cpu.GetInstructions(), d.cpu.GetPrefixTables(), d.cpu.Read, d.cpu.GetPC())
 
-	// Print CPU-specific debug info
-	switch c := d.cpu.(type) {
-	case *cpu.Intel8008:
-		fmt.Printf("PC: $%04X | Opcode: $%02X %-3s | A: $%02X B: $%02X C: $%02X | Flags(CZSP): %d%d%d%d\n",
-			c.GetPC(), opcode, mnemonic, c.GetA(), c.GetB(), c.GetC(),
-			boolToInt(c.Flags.Carry),
-			boolToInt(c.Flags.Zero),
-			boolToInt(c.Flags.Sign),
-			boolToInt(c.Flags.Parity))
-	default:
-		fmt.Printf("PC: $%04X | Opcode: $%02X %-3s\n",
-			d.cpu.GetPC(), opcode, mnemonic)
-	}
+	fmt.Printf("PC: $%04X | Opcode: $%02X %-14s | %s | Flags: %s\n",
+		d.cpu.GetPC(), opcode, mnemonic, d.registerSummary(), d.flagSummary())
 
 	d.lastPC = d.cpu.GetPC()
-	d.cpu.ExecuteInstruction()
+	if err := d.cpu.ExecuteInstruction(); err != nil {
+		if err != cpu.ErrHalted {
+			// Stay in the debugger so the state can be inspected
+			fmt.Printf("🆘 %v\n", err)
+			return false
+		}
+		fmt.Println("Program halted")
+		d.running = false
+		return false
+	}
 
 	if d.cpu.GetPC() == d.lastPC {
 		fmt.Println("Program halted")
 		d.running = false
+		return false
 	}
+	return true
 }
 
 // printRegisters displays CPU register values
 func (d *Debugger) printRegisters() {
 	fmt.Printf("PC: $%04X\n", d.cpu.GetPC())
-	fmt.Printf("SP: $%02X\n", d.cpu.GetSP())
-
-	// Print CPU-specific registers
-	switch c := d.cpu.(type) {
-	case *cpu.Intel8008:
-		fmt.Printf("A:  $%02X\n", c.GetA())
-		fmt.Printf("B:  $%02X\n", c.GetB())
-		fmt.Printf("C:  $%02X\n", c.GetC())
-		fmt.Printf("D:  $%02X\n", c.GetD())
-		fmt.Printf("E:  $%02X\n", c.GetE())
-		fmt.Printf("H:  $%02X\n", c.GetH())
-		fmt.Printf("L:  $%02X\n", c.GetL())
-		fmt.Printf("Flags: C:%d Z:%d S:%d P:%d\n",
-			boolToInt(c.Flags.Carry),
-			boolToInt(c.Flags.Zero),
-			boolToInt(c.Flags.Sign),
-			boolToInt(c.Flags.Parity))
-	default:
-		fmt.Printf("A:  $%02X\n", d.cpu.GetA())
+	for _, register := range d.cpu.Registers() {
+		value, _ := d.cpu.GetRegister(register.Name)
+		fmt.Printf("%-3s $%0*X\n", register.Name+":", hexDigits(register), value)
 	}
+	fmt.Printf("Flags: %s\n", d.flagSummary())
+
+	if describer, ok := d.cpu.(cpu.StateDescriber); ok {
+		for _, line := range describer.DescribeState() {
+			fmt.Println(line)
+		}
+	}
+}
+
+// registerSummary writes every register on one line, as in "A: $00 B: $12"
+func (d *Debugger) registerSummary() string {
+	var summary []string
+	for _, register := range d.cpu.Registers() {
+		value, _ := d.cpu.GetRegister(register.Name)
+		summary = append(summary, fmt.Sprintf("%s: $%0*X", register.Name, hexDigits(register), value))
+	}
+	return strings.Join(summary, " ")
+}
+
+// flagSummary writes every flag on one line, as in "C:1 Z:0"
+func (d *Debugger) flagSummary() string {
+	var summary []string
+	for _, flag := range d.cpu.Flags() {
+		set, _ := d.cpu.GetFlag(flag.Name)
+		summary = append(summary, fmt.Sprintf("%s:%d", flag.Name, boolToInt(set)))
+	}
+	return strings.Join(summary, " ")
+}
+
+// hexDigits returns the number of hex digits that show a register
+func hexDigits(register cpu.RegisterDesc) int {
+	return (register.Bits + 3) / 4
 }
 
 // printMemory displays memory contents
@@ -217,7 +268,7 @@ func (d *Debugger) printMemory(args []string) {
 		return
 	}
 
-	addr, err := parseAddress(args[0])
+	addr, err := d.parseValue(args[0])
 	if err != nil {
 		fmt.Printf("Invalid address: %v\n", err)
 		return
@@ -232,31 +283,54 @@ func (d *Debugger) printMemory(args []string) {
 // disassemble displays disassembled code
 func (d *Debugger) disassemble(args []string) {
 	if len(args) == 0 {
-		fmt.Println("Usage: disassemble <address>")
+		fmt.Println("Usage: disassemble <address> [count]")
 		return
 	}
 
-	addr, err := parseAddress(args[0])
+	addr, err := d.parseValue(args[0])
 	if err != nil {
 		fmt.Printf("Invalid address: %v\n", err)
 		return
 	}
+	count := 10
+	if len(args) > 1 {
+		if count, err = strconv.Atoi(args[1]); err != nil || count <= 0 {
+			fmt.Printf("Invalid count: %s\n", args[1])
+			return
+		}
+	}
 
-	fmt.Printf("Disassembly at $%04X:\n", addr)
-	for i := 0; i < 10; i++ {
-		opcode := d.cpu.Read(addr)
-		instruction, ok := d.cpu.GetInstructions()[opcode]
-		var mnemonic string
-		if ok {
-			mnemonic = instruction.Mnemonic
-		} else {
-			mnemonic = "???"
+	fmt.Printf("Disassembly at $%04X (%s syntax):\n", addr, d.syntax.Name)
+	for i := 0; i < count; i++ {
+		text, size := cpu.Disassemble(d.syntax, d.cpu.GetInstructions(), d.cpu.GetPrefixTables(), d.cpu.Read, addr)
+		var code []string
+		for j := 0; j < size; j++ {
+			code = append(code, fmt.Sprintf("%02X", d.cpu.Read(addr+uint16(j))))
 		}
-		fmt.Printf("$%04X: $%02X %s\n", addr, opcode, mnemonic)
-		addr++
+		fmt.Printf("$%04X: %-11s %s\n", addr, strings.Join(code, " "), text)
+		addr += uint16(size)
 	}
 }
 
+// handleSyntax shows or selects the disassembly dialect
+func (d *Debugger) handleSyntax(args []string) {
+	if len(args) == 0 {
+		for _, syntax := range d.syntaxes {
+			marker := " "
+			if syntax == d.syntax {
+				marker = "*"
+			}
+			fmt.Printf("%s %-6s %s\n", marker, syntax.Name, syntax.Description)
+		}
+		return
+	}
+	if err := d.SetSyntax(args[0]); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Disassembly syntax: %s\n", d.syntax.Name)
+}
+
 // handleWatch sets or removes a memory watch
 func (d *Debugger) handleWatch(args []string) {
 	if len(args) == 0 {
@@ -264,7 +338,7 @@ func (d *Debugger) handleWatch(args []string) {
 		return
 	}
 
-	addr, err := parseAddress(args[0])
+	addr, err := d.parseValue(args[0])
 	if err != nil {
 		fmt.Printf("Invalid address: %v\n", err)
 		return
@@ -273,13 +347,195 @@ func (d *Debugger) handleWatch(args []string) {
 	fmt.Printf("Watching memory at $%04X: $%02X\n", addr, d.cpu.Read(addr))
 }
 
-// parseAddress parses a hex address string
-func parseAddress(s string) (uint16, error) {
-	s = strings.TrimPrefix(s, "0x")
-	value, err := strconv.ParseUint(s, 16, 16)
+// handleSet sets a register, PC, SP or a flag, as in "set A=$10" or "set flags.C=1"
+func (d *Debugger) handleSet(args []string) {
+	target, text, ok := strings.Cut(strings.Join(args, ""), "=")
+	if !ok || target == "" || text == "" {
+		fmt.Println("Usage: set <register>=<value> or set flags.<name>=<0|1>")
+		return
+	}
+
+	value, err := d.parseValue(text)
+	if err != nil {
+		fmt.Printf("Invalid value: %v\n", err)
+		return
+	}
+
+	if len(target) > 6 && strings.EqualFold(target[:6], "flags.") {
+		name := target[6:]
+		if value > 1 {
+			fmt.Printf("Invalid value for flag %s: $%X (use 0 or 1)\n", name, value)
+			return
+		}
+		if err := d.cpu.SetFlag(name, value == 1); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Flags: %s\n", d.flagSummary())
+		return
+	}
+
+	register, ok := d.findRegister(target)
+	if !ok {
+		fmt.Printf("Unknown register: %s\n", target)
+		return
+	}
+	if register.Bits < 16 && value >= 1<<register.Bits {
+		fmt.Printf("Value $%X does not fit in the %d bits of register %s\n", value, register.Bits, register.Name)
+		return
+	}
+	if err := d.cpu.SetRegister(register.Name, value); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s: $%0*X\n", register.Name, hexDigits(register), value)
+}
+
+// findRegister describes a register by name, including PC, ignoring case
+func (d *Debugger) findRegister(name string) (cpu.RegisterDesc, bool) {
+	if strings.EqualFold(name, "PC") {
+		return cpu.RegisterDesc{Name: "PC", Bits: 16}, true
+	}
+	for _, register := range d.cpu.Registers() {
+		if strings.EqualFold(register.Name, name) {
+			return register, true
+		}
+	}
+	return cpu.RegisterDesc{}, false
+}
+
+// poke writes bytes to memory, starting at an address
+func (d *Debugger) poke(args []string) {
+	if len(args) < 2 {
+		fmt.Println("Usage: poke <address> <bytes...>")
+		return
+	}
+
+	addr, err := d.parseValue(args[0])
+	if err != nil {
+		fmt.Printf("Invalid address: %v\n", err)
+		return
+	}
+	data := make([]byte, len(args)-1)
+	for i, text := range args[1:] {
+		if data[i], err = d.parseByte(text); err != nil {
+			fmt.Printf("Invalid byte: %v\n", err)
+			return
+		}
+	}
+
+	if err := d.checkRange(addr, len(data)); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i, b := range data {
+		d.cpu.Write(addr+uint16(i), b)
+	}
+	fmt.Printf("Wrote %d bytes at $%04X\n", len(data), addr)
+}
+
+// fill writes one byte to every address of an inclusive range, as in "fill 2000-20FF 00"
+func (d *Debugger) fill(args []string) {
+	if len(args) != 2 {
+		fmt.Println("Usage: fill <start>-<end> <byte>")
+		return
+	}
+
+	startText, endText, ok := strings.Cut(args[0], "-")
+	if !ok {
+		fmt.Printf("Invalid range: %s\n", args[0])
+		return
+	}
+	start, err := d.parseValue(startText)
+	if err != nil {
+		fmt.Printf("Invalid address: %v\n", err)
+		return
+	}
+	end, err := d.parseValue(endText)
+	if err != nil {
+		fmt.Printf("Invalid address: %v\n", err)
+		return
+	}
+	if start > end {
+		fmt.Printf("Invalid range: $%04X is after $%04X\n", start, end)
+		return
+	}
+	value, err := d.parseByte(args[1])
+	if err != nil {
+		fmt.Printf("Invalid byte: %v\n", err)
+		return
+	}
+	if err := d.checkRange(start, int(end)-int(start)+1); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for addr := int(start); addr <= int(end); addr++ {
+		d.cpu.Write(uint16(addr), value)
+	}
+	fmt.Printf("Filled $%04X-$%04X with $%02X\n", start, end, value)
+}
+
+// load copies the contents of a file into memory, starting at an address
+func (d *Debugger) load(args []string) {
+	if len(args) != 2 {
+		fmt.Println("Usage: load <file> <address>")
+		return
+	}
+
+	addr, err := d.parseValue(args[1])
+	if err != nil {
+		fmt.Printf("Invalid address: %v\n", err)
+		return
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := d.checkRange(addr, len(data)); err != nil {
+		fmt.Printf("%s: %v\n", args[0], err)
+		return
+	}
+
+	for i, b := range data {
+		d.cpu.Write(addr+uint16(i), b)
+	}
+	fmt.Printf("Loaded %s (%d bytes) at $%04X-$%04X\n", args[0], len(data), addr, int(addr)+len(data)-1)
+}
+
+// checkRange checks that size bytes from addr are all in memory, so that
+// writing them neither wraps around nor goes past the end
+func (d *Debugger) checkRange(addr uint16, size int) error {
+	if end := int(addr) + size; end > d.cpu.MemorySize() {
+		return fmt.Errorf("$%04X-$%04X is outside the %d bytes of memory", addr, end-1, d.cpu.MemorySize())
+	}
+	return nil
+}
+
+// parseByte parses a byte value: a label or a hex number up to $FF
+func (d *Debugger) parseByte(s string) (byte, error) {
+	value, err := d.parseValue(s)
 	if err != nil {
 		return 0, err
 	}
+	if value > 0xFF {
+		return 0, fmt.Errorf("%s is more than $FF", s)
+	}
+	return byte(value), nil
+}
+
+// parseValue parses a label or a hex number with an optional $ or 0x prefix.
+// A label wins over a number spelled the same; a prefix makes it a number.
+func (d *Debugger) parseValue(s string) (uint16, error) {
+	if value, ok := d.symbols[s]; ok {
+		return value, nil
+	}
+	value, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(s, "$"), "0x"), 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%s is neither a label nor a hex number", s)
+	}
 	return uint16(value), nil
 }
 
@@ -0,0 +1,97 @@
+package debugger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+func newTestDebugger(memorySize int) (*Debugger, cpu.ICPU) {
+	processor := cpu.NewIntel8008(memorySize, 0)
+	return New(processor), processor
+}
+
+func TestParseValue(t *testing.T) {
+	d, _ := newTestDebugger(65536)
+	d.SetSymbols(map[string]uint16{"add": 0x8010, "loop": 0x8020})
+
+	tests := []struct {
+		text string
+		want uint16
+	}{
+		{"10", 0x10},
+		{"$10", 0x10},
+		{"0x10", 0x10},
+		{"loop", 0x8020},
+		{"add", 0x8010},  // The label wins over the hex number ADD
+		{"$add", 0x0ADD}, // A prefix makes it a number
+	}
+	for _, test := range tests {
+		got, err := d.parseValue(test.text)
+		if err != nil || got != test.want {
+			t.Errorf("parseValue(%q) = $%04X, %v; want $%04X", test.text, got, err, test.want)
+		}
+	}
+
+	for _, text := range []string{"", "missing", "$10000"} {
+		if _, err := d.parseValue(text); err == nil {
+			t.Errorf("parseValue(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+func TestHandleSet(t *testing.T) {
+	d, processor := newTestDebugger(65536)
+	d.SetSymbols(map[string]uint16{"start": 0x8100})
+
+	d.handleSet([]string{"A=$10"})
+	d.handleSet([]string{"b", "=", "2F"})
+	d.handleSet([]string{"pc=start"})
+	d.handleSet([]string{"flags.C=1"})
+	d.handleSet([]string{"C=100"})     // Does not fit in 8 bits
+	d.handleSet([]string{"flags.Z=2"}) // Flags are 0 or 1
+	d.handleSet([]string{"Q=1"})       // No such register
+
+	for name, want := range map[string]uint16{"A": 0x10, "B": 0x2F, "C": 0, "PC": 0x8100} {
+		if got, _ := processor.GetRegister(name); got != want {
+			t.Errorf("register %s = $%X, want $%X", name, got, want)
+		}
+	}
+	if carry, _ := processor.GetFlag("C"); !carry {
+		t.Error("flag C is clear, want set")
+	}
+	if zero, _ := processor.GetFlag("Z"); zero {
+		t.Error("flag Z is set by an invalid value")
+	}
+}
+
+func TestWritesStayInMemory(t *testing.T) {
+	d, processor := newTestDebugger(1024)
+	file := filepath.Join(t.TempDir(), "patch.bin")
+	if err := os.WriteFile(file, []byte{1, 2, 3, 4}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// None of these fit and none may panic or write anything
+	d.poke([]string{"8000", "01"})
+	d.poke([]string{"3FF", "01", "02"})
+	d.poke([]string{"FFFF", "01", "02"})
+	d.fill([]string{"3F0-400", "AA"})
+	d.load([]string{file, "3FE"})
+	for addr := uint16(0x3F0); addr < 0x400; addr++ {
+		if value := processor.Read(addr); value != 0 {
+			t.Fatalf("$%04X = $%02X after rejected writes", addr, value)
+		}
+	}
+
+	d.poke([]string{"3FE", "01", "02"})
+	d.fill([]string{"10-13", "AA"})
+	d.load([]string{file, "20"})
+	for addr, want := range map[uint16]byte{0x3FE: 1, 0x3FF: 2, 0x10: 0xAA, 0x13: 0xAA, 0x20: 1, 0x23: 4} {
+		if got := processor.Read(addr); got != want {
+			t.Errorf("$%04X = $%02X, want $%02X", addr, got, want)
+		}
+	}
+}
@@ -1,27 +1,72 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lukasz-gorgol/g8b/src/asm"
 	"github.com/lukasz-gorgol/g8b/src/cpu"
 	"github.com/lukasz-gorgol/g8b/src/debugger"
+	"github.com/lukasz-gorgol/g8b/src/image"
+	"github.com/lukasz-gorgol/g8b/src/machine"
+	"github.com/lukasz-gorgol/g8b/src/trace"
 )
 
 // Config represents the emulator configuration
 type Config struct {
-	Binary     string `json:"binary"`                // Path to the binary file
-	StartAddr  string `json:"start_addr,omitempty"`  // Start address as hex string (e.g., "0x8000")
-	MemorySize uint   `json:"memory_size,omitempty"` // Memory size in bytes (default: 65536)
-	DumpAddrs  string `json:"dump_addrs,omitempty"`  // Memory addresses to dump
-	CPUType    string `json:"cpu,omitempty"`         // CPU type (default: 8008)
-	CPUSpeed   uint   `json:"speed,omitempty"`       // CPU speed in Hz (default: 1000000 for 1MHz)
-	Verbose    bool   `json:"verbose,omitempty"`     // Enable verbose output
+	Binary     string `json:"binary"`                 // Path to the binary file
+	StartAddr  string `json:"start_addr,omitempty"`   // Start address as hex string (e.g., "0x8000"); relocates g8b images, sets the entry of hex files
+	MemorySize uint   `json:"memory_size,omitempty"`  // Memory size in bytes (default: 65536)
+	DumpAddrs  string `json:"dump_addrs,omitempty"`   // Memory addresses to dump
+	CPUType    string `json:"cpu,omitempty"`          // CPU type (default: 8008)
+	CPUSpeed   uint   `json:"speed,omitempty"`        // CPU speed in Hz (default: 1000000 for 1MHz)
+	Verbose    bool   `json:"verbose,omitempty"`      // Enable verbose output
+	Syntax     string `json:"syntax,omitempty"`       // Mnemonic dialect used by the debugger's disassembly
+	Symbols    string `json:"symbols,omitempty"`      // Assembler listing whose labels the debugger accepts
+	Timeout    string `json:"timeout,omitempty"`      // Stop after this much wall-clock time (e.g., "5s")
+	MaxCycles  int    `json:"max_cycles,omitempty"`   // Stop after this many cycles
+	JSON       bool   `json:"json,omitempty"`         // Print the result as JSON instead of text
+	Quiet      bool   `json:"quiet,omitempty"`        // Suppress banners and statistics
+	Trace      string `json:"trace,omitempty"`        // Write an execution trace to this file
+	TraceFmt   string `json:"trace_format,omitempty"` // Trace format: text, jsonl or binary (default: from file extension)
+	TraceRange string `json:"trace_range,omitempty"`  // Trace only instructions in these address ranges
+	TraceSkip  int    `json:"trace_skip,omitempty"`   // Skip this many instructions before tracing
+	TraceCount int    `json:"trace_count,omitempty"`  // Stop tracing after this many instructions
+	CPM        bool   `json:"cpm,omitempty"`          // Run the binary as a CP/M .COM program
+	Serial     int    `json:"serial,omitempty"`       // Baud rate of an 8085 serial line on stdin and stdout
+	Pins       bool   `json:"pins,omitempty"`         // Print changes of output pins such as the 1802's Q
+}
+
+// quiet suppresses the informational output printed by info
+var quiet bool
+
+// jsonOutput reserves standard output for the JSON result, so errors go to standard error
+var jsonOutput bool
+
+// info prints informational output unless it is suppressed
+func info(format string, args ...interface{}) {
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// fatalf reports an error that prevents emulation and exits
+func fatalf(format string, args ...interface{}) {
+	out := os.Stdout
+	if jsonOutput {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, "🆘 "+format+"\n", args...)
+	os.Exit(exitError)
 }
 
 func main() {
@@ -30,10 +75,24 @@ func main() {
 	startAddr := flag.String("s", "0x8000", "Start address for program loading and PC initialization (hex string)")
 	memorySize := flag.Uint("m", 65536, "Memory size in bytes")
 	dumpAddrs := flag.String("d", "", "Memory addresses to dump")
-	cpuType := flag.String("cpu", "8008", "CPU type (default: 8008)")
-	cpuSpeed := flag.Uint("speed", 1000000, "CPU speed in Hz (default: 1000000 for 1MHz)")
+	cpuType := flag.String("cpu", "8008", "CPU type: 8008, 8080, 8085, 6502, 6800, 1802, z80, 4004 or 4040 (default: 8008)")
+	cpuSpeed := flag.Uint("speed", 1000000, "CPU speed in Hz; 0 runs as fast as possible (default: 1000000 for 1MHz)")
 	debug := flag.Bool("debug", false, "Run in debug mode")
 	verbose := flag.Bool("v", false, "Enable verbose output (show PC, registers, and flags)")
+	syntax := flag.String("syntax", "", "Mnemonic dialect for disassembly: 8008 or 8080 (default: 8008)")
+	symbols := flag.String("symbols", "", "Assembler listing (-l) whose labels the debugger accepts as addresses")
+	timeout := flag.String("timeout", "", "Stop after this much time, e.g. 5s (exit code 2)")
+	maxCycles := flag.Int("max-cycles", 0, "Stop after this many cycles (exit code 2)")
+	jsonFlag := flag.Bool("json", false, "Print the result (stop reason, registers, flags, memory dump) as JSON")
+	quietFlag := flag.Bool("quiet", false, "Suppress banners, configuration and statistics")
+	traceFile := flag.String("trace", "", "Write an execution trace to this file")
+	traceFormat := flag.String("trace-format", "", "Trace format: text, jsonl or binary (default: from file extension)")
+	traceRange := flag.String("trace-range", "", "Trace only instructions in these address ranges, e.g. 0x8000-0x80FF")
+	traceSkip := flag.Int("trace-skip", 0, "Skip this many instructions before tracing")
+	traceCount := flag.Int("trace-count", 0, "Stop tracing after this many instructions")
+	cpm := flag.Bool("cpm", false, "Run the binary as a CP/M .COM program at $0100 with a minimal BDOS (8080)")
+	serial := flag.Int("serial", 0, "Connect the 8085's SID and SOD to stdin and stdout at this baud rate")
+	pins := flag.Bool("pins", false, "Print changes of output pins such as the 1802's Q")
 	flag.Parse()
 
 	// Parse command-line arguments
@@ -45,40 +104,50 @@ func main() {
 		fmt.Println("  -s <addr>    Start address (hex string, e.g., 0x8000)")
 		fmt.Println("  -m <size>    Memory size in bytes (default: 65536)")
 		fmt.Println("  -d <addrs>   Memory addresses to dump")
-		fmt.Println("  -cpu <type>  CPU type (default: 8008)")
-		fmt.Println("  -speed <hz>  CPU speed in Hz (default: 1000000 for 1MHz)")
+		fmt.Println("  -cpu <type>  CPU type: 8008, 8080, 8085, 6502, 6800, 1802, z80, 4004 or 4040 (default: 8008)")
+		fmt.Println("  -speed <hz>  CPU speed in Hz, 0 for unlimited (default: 1000000 for 1MHz)")
 		fmt.Println("  -debug       Run in debug mode")
+		fmt.Println("  -syntax <s>  Mnemonic dialect for disassembly: 8008 or 8080")
+		fmt.Println("  -symbols <file> Assembler listing whose labels the debugger accepts")
+		fmt.Println("  -timeout <d> Stop after this much time, e.g. 5s")
+		fmt.Println("  -max-cycles <n> Stop after this many cycles")
+		fmt.Println("  -json        Print the result as JSON")
+		fmt.Println("  -quiet       Suppress banners and statistics")
+		fmt.Println("  -trace <file> Write an execution trace (see also -trace-format, -trace-range,")
+		fmt.Println("               -trace-skip, -trace-count)")
+		fmt.Println("  -cpm         Run a CP/M .COM program with a minimal BDOS (8080)")
+		fmt.Println("  -serial <baud> Connect the 8085's SID and SOD to stdin and stdout")
+		fmt.Println("  -pins        Print changes of output pins such as the 1802's Q")
 		fmt.Println("  -v           Enable verbose output")
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
-	fmt.Println("✅ All systems go! Emulator starting...")
-
 	var config Config
 
 	// Load configuration from file if specified
 	if *configFile != "" {
 		file, err := os.Open(*configFile)
 		if err != nil {
-			fmt.Printf("🆘 Error opening config file: %v\n", err)
-			os.Exit(1)
+			fatalf("Error opening config file: %v", err)
 		}
 		defer file.Close()
 
 		decoder := json.NewDecoder(file)
 		if err := decoder.Decode(&config); err != nil {
-			fmt.Printf("🆘 Error parsing config file: %v\n", err)
-			os.Exit(1)
+			fatalf("Error parsing config file: %v", err)
 		}
 	} else {
-		// If no config file is provided, use command line arguments
-		if flag.NArg() < 1 {
-			fmt.Println("🆘 Error: Binary file required")
-			flag.Usage()
-			os.Exit(1)
+		// Only an explicit -s overrides the addresses recorded in an image
+		startAddrSet := false
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "s" {
+				startAddrSet = true
+			}
+		})
+		if !startAddrSet {
+			*startAddr = ""
 		}
 
-		args := flag.Args()
 		config = Config{
 			Binary:     args[0],
 			StartAddr:  *startAddr,
@@ -87,9 +156,39 @@ func main() {
 			CPUType:    *cpuType, // Use the command line CPU type
 			CPUSpeed:   *cpuSpeed,
 			Verbose:    *verbose, // Use command line verbose flag
+			Syntax:     *syntax,
+			Symbols:    *symbols,
+			Timeout:    *timeout,
+			MaxCycles:  *maxCycles,
+			Trace:      *traceFile,
+			TraceFmt:   *traceFormat,
+			TraceRange: *traceRange,
+			TraceSkip:  *traceSkip,
+			TraceCount: *traceCount,
+			CPM:        *cpm,
+			Serial:     *serial,
+			Pins:       *pins,
 		}
 	}
 
+	// Output switches given on the command line apply to config files too
+	config.JSON = config.JSON || *jsonFlag
+	config.Quiet = config.Quiet || *quietFlag || config.JSON
+	jsonOutput = config.JSON
+	quiet = config.Quiet
+	if config.JSON && *debug {
+		fatalf("-json cannot be used with -debug")
+	}
+	if config.Serial == 0 {
+		config.Serial = *serial
+	}
+	config.Pins = config.Pins || *pins
+	if config.Serial != 0 && *debug {
+		fatalf("-serial cannot be used with -debug, which reads commands from stdin")
+	}
+
+	info("✅ All systems go! Emulator starting...\n")
+
 	// Set default CPU type if not specified in config file
 	if config.CPUType == "" {
 		config.CPUType = *cpuType // Use command line CPU type as default
@@ -98,12 +197,14 @@ func main() {
 		}
 	}
 
-	// Set default CPU speed if not specified
+	// Set default CPU speed if not specified; -speed 0 runs as fast as possible
 	if config.CPUSpeed == 0 {
 		config.CPUSpeed = *cpuSpeed // Use command line CPU speed as default
-		if config.CPUSpeed == 0 {
-			config.CPUSpeed = 1000000 // Fallback to 1MHz if still 0
-		}
+	}
+
+	// Set default memory size if not specified in config file
+	if config.MemorySize == 0 {
+		config.MemorySize = 65536
 	}
 
 	// Set verbose flag if not specified in config file
@@ -111,60 +212,180 @@ func main() {
 		config.Verbose = *verbose // Use command line verbose flag as default
 	}
 
+	// Set limits if not specified in config file
+	if config.Timeout == "" {
+		config.Timeout = *timeout
+	}
+	if config.MaxCycles == 0 {
+		config.MaxCycles = *maxCycles
+	}
+
 	// Display current configuration
-	fmt.Println("\n📋 Current Configuration:")
-	fmt.Printf("  Binary:      %s\n", config.Binary)
-	fmt.Printf("  Start Addr:  %s\n", config.StartAddr)
-	fmt.Printf("  Memory Size: %d bytes\n", config.MemorySize)
-	fmt.Printf("  CPU Type:    %s\n", config.CPUType)
-	fmt.Printf("  CPU Speed:   %d Hz\n", config.CPUSpeed)
+	info("\n📋 Current Configuration:\n")
+	info("  Binary:      %s\n", config.Binary)
+	defaultStart := defaultStartAddress(config.CPUType)
+	if config.StartAddr != "" {
+		info("  Start Addr:  %s\n", config.StartAddr)
+	} else {
+		info("  Start Addr:  from program (0x%04X for flat binaries)\n", defaultStart)
+	}
+	info("  Memory Size: %d bytes\n", config.MemorySize)
+	info("  CPU Type:    %s\n", config.CPUType)
+	if config.CPUSpeed > 0 {
+		info("  CPU Speed:   %d Hz\n", config.CPUSpeed)
+	} else {
+		info("  CPU Speed:   unlimited\n")
+	}
 	if config.DumpAddrs != "" {
-		fmt.Printf("  Dump Addrs:  %s\n", config.DumpAddrs)
+		info("  Dump Addrs:  %s\n", config.DumpAddrs)
+	}
+	info("  Verbose:     %v\n", config.Verbose)
+	if config.Syntax != "" {
+		info("  Syntax:      %s\n", config.Syntax)
+	}
+	if config.Symbols != "" {
+		info("  Symbols:     %s\n", config.Symbols)
 	}
-	fmt.Printf("  Verbose:     %v\n", config.Verbose)
-	fmt.Println()
+	if config.Timeout != "" {
+		info("  Timeout:     %s\n", config.Timeout)
+	}
+	if config.MaxCycles != 0 {
+		info("  Max Cycles:  %d\n", config.MaxCycles)
+	}
+	if config.Trace != "" {
+		info("  Trace:       %s\n", config.Trace)
+	}
+	if config.CPM {
+		info("  CP/M:        true\n")
+	}
+	if config.Serial != 0 {
+		info("  Serial:      %d baud\n", config.Serial)
+	}
+	if config.Pins {
+		info("  Pins:        shown as they change\n")
+	}
+	info("\n")
 
 	// Parse start address
-	startAddress, err := parseHexAddr(config.StartAddr, 0x8000)
+	startAddress, err := parseHexAddr(config.StartAddr, defaultStart)
 	if err != nil {
-		fmt.Printf("🆘 Error parsing start address: %v\n", err)
-		os.Exit(1)
+		fatalf("Error parsing start address: %v", err)
 	}
 
-	// Create CPU instance
-	var processor cpu.ICPU
-	switch config.CPUType {
-	case "8008":
-		processor = cpu.NewIntel8008(int(config.MemorySize), config.CPUSpeed)
-	default:
-		fmt.Printf("🆘 Unsupported CPU type: %s\n", config.CPUType)
-		fmt.Println("  Available CPU types: 8008")
-		os.Exit(1)
+	// Parse limits and the memory dump before running, so mistakes show up at once
+	var timeLimit time.Duration
+	if config.Timeout != "" {
+		if timeLimit, err = time.ParseDuration(config.Timeout); err != nil {
+			fatalf("Error parsing timeout: %v", err)
+		}
 	}
-
-	// Set verbose mode on CPU if enabled
-	if config.Verbose {
-		processor.SetVerbose(true)
+	var dumpAddresses []uint16
+	if config.DumpAddrs != "" {
+		if dumpAddresses, err = parseAddressSpec(config.DumpAddrs, config.MemorySize); err != nil {
+			fatalf("Error parsing address specification: %v", err)
+		}
 	}
 
-	// Load program
-	program, err := os.ReadFile(config.Binary)
+	// Build the machine
+	m, err := machine.NewMachine(machine.Config{
+		CPU:        config.CPUType,
+		MemorySize: int(config.MemorySize),
+		Speed:      config.CPUSpeed,
+		Verbose:    config.Verbose,
+		MaxCycles:  config.MaxCycles,
+	})
 	if err != nil {
-		fmt.Printf("🆘 Error reading binary file: %v\n", err)
-		os.Exit(1)
+		fatalf("%v", err)
+	}
+	processor := m.CPU()
+
+	if config.CPM {
+		// CP/M programs are flat binaries that start at $0100 and print through the BDOS
+		data, err := os.ReadFile(config.Binary)
+		if err != nil {
+			fatalf("Error reading binary file: %v", err)
+		}
+		console := os.Stdout
+		if config.JSON {
+			console = os.Stderr
+		}
+		if err := m.LoadCPM(data, console); err != nil {
+			fatalf("%v", err)
+		}
+		info("✅ CP/M program loaded successfully: %s (%d bytes, entry $%04X)\n",
+			config.Binary, len(data), machine.CPMLoadAddress)
+	} else {
+		// Load program; flat binaries are placed at the start address
+		program, format, err := image.Load(config.Binary, startAddress)
+		if err != nil {
+			fatalf("Error reading binary file: %v", err)
+		}
+
+		// A start address given for a g8b image moves the program there; hex
+		// files carry no relocations, so it only changes where execution starts
+		if config.StartAddr != "" {
+			switch format {
+			case "g8b":
+				if err := program.Relocate(startAddress); err != nil {
+					fatalf("Error relocating program: %v", err)
+				}
+			case "ihex", "srec":
+				program.Entry = startAddress
+			}
+		}
+		info("✅ Binary loaded successfully: %s (%d bytes, %s format, entry $%04X)\n",
+			config.Binary, program.Size(), format, program.Entry)
+
+		// Copy program to memory
+		if err := m.Load(program); err != nil {
+			fatalf("%v", err)
+		}
 	}
-	fmt.Printf("✅ Binary loaded successfully: %s (%d bytes)\n", config.Binary, len(program))
 
-	// Copy program to memory
-	for i, b := range program {
-		processor.Write(uint16(startAddress)+uint16(i), b)
+	// Bit-banged serial I/O is timed by the CPU clock the program is written for
+	if config.Serial != 0 {
+		if config.CPUSpeed == 0 {
+			fatalf("-serial needs a CPU speed to time its bits")
+		}
+		console := os.Stdout
+		if config.JSON {
+			console = os.Stderr
+		}
+		if err := m.AttachSerial(os.Stdin, console, int(config.CPUSpeed)/config.Serial); err != nil {
+			fatalf("%v", err)
+		}
 	}
-	processor.SetPC(uint16(startAddress))
 
+	// Output pins such as the 1802's Q are printed as the program drives them
+	if config.Pins {
+		out := os.Stdout
+		if config.JSON {
+			out = os.Stderr
+		}
+		if err := m.WatchPins(pinPrinter{out}); err != nil {
+			fatalf("%v", err)
+		}
+	}
+
+	// Record an execution trace if requested
+	closeTrace := func() {}
+	if config.Trace != "" {
+		closeTrace = startTrace(config, processor)
+	}
+
+	exitCode := exitHalted
 	if *debug {
 		// Run in debug mode
-		fmt.Println("\n▶️🔍 Entering debug mode...")
+		info("\n▶️🔍 Entering debug mode...\n")
 		dbg := debugger.New(processor)
+		if config.Syntax != "" {
+			if err := dbg.SetSyntax(config.Syntax); err != nil {
+				fatalf("%v", err)
+			}
+		}
+		if config.Symbols != "" {
+			dbg.SetSymbols(readSymbols(config.Symbols))
+		}
 
 		startTime := time.Now()
 		dbg.Run()
@@ -173,43 +394,147 @@ func main() {
 		// Calculate execution statistics
 		duration := endTime.Sub(startTime)
 
-		fmt.Println("⏹️ Emulation finished.")
-		fmt.Printf("  Execution completed in %v\n", duration)
-		fmt.Printf("  Total cycles:  %d\n", processor.GetCycles())
+		info("⏹️ Emulation finished.\n")
+		info("  Execution completed in %v\n", duration)
+		info("  Total cycles:  %d\n", processor.GetCycles())
 
 	} else {
 		// Run in normal mode
-		fmt.Println("\n▶️  Executing program...")
+		info("\n▶️  Executing program...\n")
+
+		// Ctrl-C stops the program but still reports statistics and the memory dump
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		if timeLimit > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeLimit)
+			defer cancel()
+		}
+		runErr := m.Run(ctx)
+		stop()
+		reason, code := stopReason(runErr)
+		exitCode = code
+		if errors.Is(runErr, context.DeadlineExceeded) {
+			runErr = fmt.Errorf("time limit of %v reached", timeLimit)
+		} else if errors.Is(runErr, context.Canceled) {
+			runErr = errors.New("interrupted")
+		}
+
+		if config.JSON {
+			closeTrace()
+			result := newResult(m, reason, code, runErr, dumpAddresses)
+			if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+				fatalf("Error writing result: %v", err)
+			}
+			os.Exit(exitCode)
+		}
 
-		processor.Run()
+		// Errors are reported even in quiet mode
+		if runErr != nil {
+			fmt.Printf("🆘 %v\n", runErr)
+		}
 
 		// Calculate execution statistics
 		duration := processor.GetElapsedTime()
 		cyclesPerSecond := float64(processor.GetCycles()) / duration.Seconds()
 
-		fmt.Println("⏹️  Emulation finished.")
-		fmt.Printf("  Execution completed in %v\n", duration)
-		fmt.Printf("  Total cycles:  %d\n", processor.GetCycles())
-		fmt.Printf("  Average speed: %.2f Hz (%.2f%% of target)\n",
-			cyclesPerSecond,
-			(cyclesPerSecond/float64(config.CPUSpeed))*100)
+		if runErr != nil {
+			info("⏹️  Emulation stopped at $%04X.\n", processor.GetPC())
+		} else {
+			info("⏹️  Emulation finished.\n")
+		}
+		info("  Execution completed in %v\n", duration)
+		info("  Total cycles:  %d\n", processor.GetCycles())
+		if config.CPUSpeed > 0 {
+			info("  Average speed: %.2f Hz (%.2f%% of target)\n",
+				cyclesPerSecond,
+				(cyclesPerSecond/float64(config.CPUSpeed))*100)
+		} else {
+			info("  Average speed: %.2f Hz\n", cyclesPerSecond)
+		}
 	}
 
+	closeTrace()
+
 	// Dump specified memory addresses
-	if config.DumpAddrs != "" {
-		addresses, err := parseAddressSpec(config.DumpAddrs)
+	if len(dumpAddresses) > 0 {
+		info("\n📝 Memory dump:\n")
+		for _, addr := range dumpAddresses {
+			fmt.Printf("  $%04X: $%02X\n", addr, processor.Read(addr))
+		}
+	}
+
+	info("\n✅ Emulator exiting...\n")
+	os.Exit(exitCode)
+}
+
+// startTrace attaches a trace writer to the CPU and returns a function that finishes the trace
+func startTrace(config Config, processor cpu.ICPU) func() {
+	format := config.TraceFmt
+	if format == "" {
+		format = trace.FormatForPath(config.Trace)
+	}
+	filter := trace.Filter{Skip: config.TraceSkip, Count: config.TraceCount}
+	if config.TraceRange != "" {
+		ranges, err := trace.ParseRanges(config.TraceRange)
 		if err != nil {
-			fmt.Printf("🆘 Error parsing address specification: %v\n", err)
-			os.Exit(1)
+			fatalf("Error parsing trace range: %v", err)
 		}
+		filter.Ranges = ranges
+	}
+	syntaxes := cpu.Syntaxes(processor)
+	syntax := syntaxes[0]
+	if config.Syntax != "" {
+		var err error
+		if syntax, err = cpu.FindSyntax(syntaxes, config.Syntax); err != nil {
+			fatalf("%v", err)
+		}
+	}
 
-		fmt.Println("\n📝 Memory dump:")
-		for _, addr := range addresses {
-			fmt.Printf("  $%04X: $%02X\n", addr, processor.Read(addr))
+	file, err := os.Create(config.Trace)
+	if err != nil {
+		fatalf("Error creating trace file: %v", err)
+	}
+	writer, err := trace.NewWriter(file, format, filter, syntax, processor.GetInstructions(), processor.GetPrefixTables())
+	if err != nil {
+		fatalf("%v", err)
+	}
+	processor.SetTracer(writer)
+
+	return func() {
+		processor.SetTracer(nil)
+		err := writer.Close()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fatalf("Error writing trace file: %v", err)
 		}
+		info("📄 Trace written to %s (%d instructions, %s format)\n", config.Trace, writer.Written(), format)
 	}
+}
 
-	fmt.Println("\n✅ Emulator exiting...")
+// readSymbols reads the labels from the symbol table of an assembler listing
+func readSymbols(path string) map[string]uint16 {
+	file, err := os.Open(path)
+	if err != nil {
+		fatalf("Error opening symbols: %v", err)
+	}
+	defer file.Close()
+
+	symbols, err := asm.ReadListingSymbols(file)
+	if err != nil {
+		fatalf("Error reading symbols from %s: %v", path, err)
+	}
+	return symbols
+}
+
+// defaultStartAddress returns where flat binaries are loaded when no start
+// address is given: 0x8000, or 0 for the 4004 and 4040, which start in ROM at 0
+func defaultStartAddress(cpuType string) uint16 {
+	if cpuType == "4004" || cpuType == "4040" {
+		return 0
+	}
+	return 0x8000
 }
 
 // parseHexAddr parses a hex address string
@@ -225,8 +550,9 @@ func parseHexAddr(addr string, defaultAddr uint16) (uint16, error) {
 	return uint16(value), nil
 }
 
-// parseAddressSpec parses a memory address specification
-func parseAddressSpec(spec string) ([]uint16, error) {
+// parseAddressSpec parses a memory address specification; every address must
+// be below the memory size
+func parseAddressSpec(spec string, memorySize uint) ([]uint16, error) {
 	var addresses []uint16
 	parts := strings.Split(spec, ",")
 
@@ -251,9 +577,13 @@ func parseAddressSpec(spec string) ([]uint16, error) {
 			if start > end {
 				return nil, fmt.Errorf("🆘 invalid range: start > end")
 			}
+			if uint(end) >= memorySize {
+				return nil, fmt.Errorf("range %s ends outside the %d bytes of memory", part, memorySize)
+			}
 
-			for addr := start; addr <= end; addr++ {
-				addresses = append(addresses, addr)
+			// An int counter, so that a range ending at $FFFF ends
+			for addr := int(start); addr <= int(end); addr++ {
+				addresses = append(addresses, uint16(addr))
 			}
 		} else {
 			// Handle single address
@@ -261,9 +591,26 @@ func parseAddressSpec(spec string) ([]uint16, error) {
 			if err != nil {
 				return nil, err
 			}
+			if uint(addr) >= memorySize {
+				return nil, fmt.Errorf("address %s is outside the %d bytes of memory", part, memorySize)
+			}
 			addresses = append(addresses, addr)
 		}
 	}
 
 	return addresses, nil
 }
+
+// pinPrinter prints the output pin changes of a running program, such as an
+// 1802 blinking Q
+type pinPrinter struct {
+	out io.Writer
+}
+
+func (p pinPrinter) PinChanged(name string, high bool, cycle int) {
+	level := 0
+	if high {
+		level = 1
+	}
+	fmt.Fprintf(p.out, "📍 %s=%d at cycle %d\n", name, level, cycle)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+	"github.com/lukasz-gorgol/g8b/src/machine"
+)
+
+// Exit codes tell scripts why emulation stopped
+const (
+	exitHalted        = 0   // The program executed a halt instruction
+	exitError         = 1   // Bad options, or a program that could not be loaded
+	exitTimeout       = 2   // -timeout or -max-cycles was reached
+	exitUnknownOpcode = 3   // The CPU fetched an opcode outside its instruction set
+	exitFault         = 4   // An instruction could not be executed, e.g. a memory access outside memory
+	exitInterrupted   = 130 // Stopped with Ctrl-C
+)
+
+// Result is the report printed by -json
+type Result struct {
+	StopReason     string            `json:"stop_reason"`     // halt, timeout, unknown_opcode, fault or interrupted
+	Error          string            `json:"error,omitempty"` // Error that stopped the program
+	ExitCode       int               `json:"exit_code"`
+	CPU            string            `json:"cpu"`
+	PC             uint16            `json:"pc"`
+	SP             uint16            `json:"sp"`
+	Registers      map[string]uint16 `json:"registers"`
+	Flags          map[string]bool   `json:"flags"`
+	Cycles         int               `json:"cycles"`
+	ElapsedSeconds float64           `json:"elapsed_seconds"`
+	Memory         []MemoryByte      `json:"memory,omitempty"` // Addresses requested with -d
+}
+
+// MemoryByte is one dumped memory location
+type MemoryByte struct {
+	Addr  uint16 `json:"addr"`
+	Value byte   `json:"value"`
+}
+
+// stopReason classifies the error Run returned
+func stopReason(err error) (string, int) {
+	var unknownOpcode *cpu.UnknownOpcodeError
+	switch {
+	case err == nil:
+		return "halt", exitHalted
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, machine.ErrCycleLimit):
+		return "timeout", exitTimeout
+	case errors.Is(err, context.Canceled):
+		return "interrupted", exitInterrupted
+	case errors.As(err, &unknownOpcode):
+		return "unknown_opcode", exitUnknownOpcode
+	}
+	// cpu.FaultError, or anything else that stopped an instruction
+	return "fault", exitFault
+}
+
+// newResult collects the final machine state for -json
+func newResult(m *machine.Machine, reason string, code int, err error, dump []uint16) Result {
+	state := m.State()
+	result := Result{
+		StopReason:     reason,
+		ExitCode:       code,
+		CPU:            state.CPU,
+		PC:             state.PC,
+		SP:             state.SP,
+		Registers:      state.Registers,
+		Flags:          state.Flags,
+		Cycles:         state.Cycles,
+		ElapsedSeconds: m.CPU().GetElapsedTime().Seconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	for _, addr := range dump {
+		result.Memory = append(result.Memory, MemoryByte{Addr: addr, Value: m.Read(addr)})
+	}
+	return result
+}
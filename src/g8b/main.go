@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// command is a g8b subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int // Returns the exit code
+}
+
+var commands = []command{
+	{"test", "Run assembly-level unit tests", runTests},
+	{"tracediff", "Compare two execution traces and report the first divergence", runTraceDiff},
+}
+
+// stringList is a flag value that collects repeated string flags
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return
+	}
+	fmt.Fprintf(os.Stderr, "g8b: unknown command %q\n", name)
+	printUsage()
+	os.Exit(2)
+}
+
+// printUsage lists the commands
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: g8b <command> [options] [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun 'g8b <command> -h' for the options of a command.")
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/lukasz-gorgol/g8b/src/asmtest"
+)
+
+// testFileExt is the extension of test files found in directories
+const testFileExt = ".g8t"
+
+// runTests runs the test files or directories given in args and reports like go test
+func runTests(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	verbose := flags.Bool("v", false, "Print every test as it runs, not only failures")
+	run := flags.String("run", "", "Run only tests whose name matches this regular expression")
+	junit := flags.String("junit", "", "Write a JUnit XML report to this file")
+	maxSteps := flags.Int("steps", asmtest.DefaultMaxSteps, "Instructions a call may execute before the test fails")
+	var includePaths stringList
+	flags.Var(&includePaths, "I", "Add a directory to the INCLUDE/INCBIN search path (repeatable)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: g8b test [options] [file.g8t | directory]...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := asmtest.Options{MaxSteps: *maxSteps, IncludePaths: includePaths}
+	if *run != "" {
+		match, err := regexp.Compile(*run)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "g8b test: invalid -run pattern: %v\n", err)
+			return 2
+		}
+		opts.Match = match
+	}
+
+	files, err := findTestFiles(flags.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "g8b test: %v\n", err)
+		return 2
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "g8b test: no %s files found\n", testFileExt)
+		return 2
+	}
+
+	var results []*asmtest.SuiteResult
+	failed := false
+	for _, file := range files {
+		var result *asmtest.SuiteResult
+		suite, err := asmtest.ParseFile(file)
+		if err != nil {
+			result = &asmtest.SuiteResult{File: file, Err: err}
+		} else {
+			result = asmtest.Run(suite, opts)
+		}
+		printSuiteResult(result, *verbose)
+		results = append(results, result)
+		failed = failed || result.Failed()
+	}
+
+	if *junit != "" {
+		out, err := os.Create(*junit)
+		if err == nil {
+			err = asmtest.WriteJUnit(out, results)
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "g8b test: writing JUnit report: %v\n", err)
+			return 2
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// findTestFiles expands directories to the test files in them; no arguments means the current directory
+func findTestFiles(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*"+testFileExt))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// printSuiteResult reports a test file in the format of go test
+func printSuiteResult(result *asmtest.SuiteResult, verbose bool) {
+	if result.Err != nil {
+		for _, diag := range result.Diagnostics {
+			fmt.Println(diag)
+		}
+		fmt.Println(result.Err)
+		fmt.Printf("FAIL\t%s [setup failed]\n", result.File)
+		return
+	}
+
+	for _, test := range result.Results {
+		if verbose {
+			fmt.Printf("=== RUN   %s\n", test.Name)
+		}
+		status := "PASS"
+		if test.Failed() {
+			status = "FAIL"
+		}
+		if verbose || test.Failed() {
+			fmt.Printf("--- %s: %s (%.2fs)\n", status, test.Name, test.Duration.Seconds())
+		}
+		for _, failure := range test.Failures {
+			fmt.Printf("    %s\n", failure)
+		}
+	}
+
+	if result.Failed() {
+		fmt.Println("FAIL")
+		fmt.Printf("FAIL\t%s\t%.3fs\n", result.File, result.Duration.Seconds())
+		return
+	}
+	if verbose {
+		fmt.Println("PASS")
+	}
+	if len(result.Results) == 0 {
+		fmt.Printf("ok  \t%s\t%.3fs [no tests to run]\n", result.File, result.Duration.Seconds())
+		return
+	}
+	fmt.Printf("ok  \t%s\t%.3fs\n", result.File, result.Duration.Seconds())
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/trace"
+)
+
+// runTraceDiff compares two execution traces and reports the first divergence.
+// It exits 0 when the traces match, 1 when they diverge and 2 on errors.
+func runTraceDiff(args []string) int {
+	flags := flag.NewFlagSet("tracediff", flag.ContinueOnError)
+	formatA := flags.String("format-a", "", "Format of the first trace (default: from its extension)")
+	formatB := flags.String("format-b", "", "Format of the second trace (default: from its extension)")
+	context := flags.Int("context", 5, "Matching instructions to show before the divergence")
+	sync := flags.String("sync", "", "Start comparing each trace at its first instruction at this address (hex)")
+	cycles := flags.Bool("cycles", false, "Compare cycle counts too")
+	var ignore stringList
+	flags.Var(&ignore, "ignore", "Register or flag (flags.C) not to compare (repeatable, comma-separated)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: g8b tracediff [options] <trace-a> <trace-b>")
+		fmt.Fprintln(os.Stderr, "\nFormats: text, jsonl, binary; verbose (emulator -v output);")
+		fmt.Fprintln(os.Stderr, "regex:PATTERN and regex-before:PATTERN for other emulators' logs, with")
+		fmt.Fprintln(os.Stderr, "named groups pc, cycle, bytes, asm, f_<flag> and <register>.")
+		fmt.Fprintln(os.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	opts := trace.DiffOptions{Context: *context, Cycles: *cycles, Ignore: make(map[string]bool)}
+	if *sync != "" {
+		pc, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(*sync, "0x"), "$"), 16, 16)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "g8b tracediff: invalid -sync address %q\n", *sync)
+			return 2
+		}
+		opts.Sync, opts.SyncPC = true, uint16(pc)
+	}
+	for _, names := range ignore {
+		for _, name := range strings.Split(names, ",") {
+			opts.Ignore[strings.ToUpper(strings.TrimSpace(name))] = true
+		}
+	}
+
+	pathA, pathB := flags.Arg(0), flags.Arg(1)
+	readerA, closeA, err := openTrace(pathA, *formatA)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "g8b tracediff: %v\n", err)
+		return 2
+	}
+	defer closeA()
+	readerB, closeB, err := openTrace(pathB, *formatB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "g8b tracediff: %v\n", err)
+		return 2
+	}
+	defer closeB()
+
+	result, err := trace.Diff(readerA, readerB, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "g8b tracediff: %v\n", err)
+		return 2
+	}
+	if result.Divergence == nil {
+		fmt.Printf("Traces match: %d instructions compared\n", result.Compared)
+		return 0
+	}
+
+	pair := result.Divergence
+	fmt.Printf("Traces diverge at instruction %d (%d matched)\n", pair.Index, pair.Index-1)
+	if len(result.Context) > 0 {
+		fmt.Println()
+		for _, matched := range result.Context {
+			fmt.Printf("  %8d  %s\n", matched.Index, describeStep(matched.A))
+		}
+	}
+	fmt.Println()
+	fmt.Printf("> %8d  a: %s\n", pair.Index, describeDivergentStep(pathA, pair.A))
+	fmt.Printf("  %8s  b: %s\n", "", describeDivergentStep(pathB, pair.B))
+	fmt.Println()
+	for _, difference := range result.Differences {
+		fmt.Printf("  %s\n", difference)
+	}
+	return 1
+}
+
+// openTrace opens a trace file with a reader for its format
+func openTrace(path, format string) (trace.Reader, func(), error) {
+	if format == "" {
+		format = trace.FormatForPath(path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := trace.NewReader(file, format)
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return reader, func() { file.Close() }, nil
+}
+
+// describeStep formats the address and instruction of a step
+func describeStep(step *trace.Step) string {
+	instruction := step.Disassembly
+	if instruction == "" {
+		instruction = trace.FormatBytes(step.Bytes)
+	}
+	return fmt.Sprintf("$%04X  %s", step.PC, instruction)
+}
+
+// describeDivergentStep formats a diverging step with where it is in its file
+func describeDivergentStep(path string, step *trace.Step) string {
+	if step == nil {
+		return fmt.Sprintf("(end of trace)  %s", path)
+	}
+	location := fmt.Sprintf("%s:%d", path, step.Line)
+	if step.HasCycle {
+		location += fmt.Sprintf(", cycle %d", step.Cycle)
+	}
+	return fmt.Sprintf("%-24s  %s", describeStep(step), location)
+}
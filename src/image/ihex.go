@@ -0,0 +1,169 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Intel HEX record types
+const (
+	ihexData            = 0x00
+	ihexEOF             = 0x01
+	ihexExtendedSegment = 0x02
+	ihexStartSegment    = 0x03
+	ihexExtendedLinear  = 0x04
+	ihexStartLinear     = 0x05
+)
+
+// ihexBytesPerRecord is the number of data bytes written per record
+const ihexBytesPerRecord = 16
+
+// maxImageAddress is the highest address an image can occupy
+const maxImageAddress = 0xFFFF
+
+// WriteIntelHex writes the image as Intel HEX with a start segment address record for the entry point
+func WriteIntelHex(w io.Writer, img *Image) error {
+	bw := bufio.NewWriter(w)
+	for _, segment := range img.Segments {
+		for offset := 0; offset < len(segment.Data); offset += ihexBytesPerRecord {
+			end := offset + ihexBytesPerRecord
+			if end > len(segment.Data) {
+				end = len(segment.Data)
+			}
+			writeIntelHexRecord(bw, uint16(int(segment.Addr)+offset), ihexData, segment.Data[offset:end])
+		}
+	}
+
+	// CS:IP of 0000:entry, understood by 8- and 16-bit tools alike
+	writeIntelHexRecord(bw, 0, ihexStartSegment, []byte{0, 0, byte(img.Entry >> 8), byte(img.Entry)})
+	writeIntelHexRecord(bw, 0, ihexEOF, nil)
+	return bw.Flush()
+}
+
+// writeIntelHexRecord writes one ":LLAAAATT<data>CC" line
+func writeIntelHexRecord(w io.Writer, addr uint16, recordType byte, data []byte) {
+	record := append([]byte{byte(len(data)), byte(addr >> 8), byte(addr), recordType}, data...)
+	var sum byte
+	for _, b := range record {
+		sum += b
+	}
+	record = append(record, -sum)
+	fmt.Fprintf(w, ":%s\n", strings.ToUpper(hex.EncodeToString(record)))
+}
+
+// IsIntelHex reports whether data looks like an Intel HEX file
+func IsIntelHex(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte(":"))
+}
+
+// ReadIntelHex parses an Intel HEX file; without a start address record the entry is the lowest address
+func ReadIntelHex(data []byte) (*Image, error) {
+	img := &Image{}
+	var base uint32 // Upper address bits from extended address records
+	hasEntry := false
+	builder := &segmentBuilder{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, ":") {
+			return nil, fmt.Errorf("line %d: missing ':' record mark", lineNum)
+		}
+		record, err := hex.DecodeString(line[1:])
+		if err != nil || len(record) < 5 || len(record) != int(record[0])+5 {
+			return nil, fmt.Errorf("line %d: malformed record", lineNum)
+		}
+		var sum byte
+		for _, b := range record {
+			sum += b
+		}
+		if sum != 0 {
+			return nil, fmt.Errorf("line %d: checksum mismatch", lineNum)
+		}
+
+		addr := uint32(record[1])<<8 | uint32(record[2])
+		payload := record[4 : len(record)-1]
+		switch record[3] {
+		case ihexData:
+			full := base + addr
+			if full+uint32(len(payload))-1 > maxImageAddress && len(payload) > 0 {
+				return nil, fmt.Errorf("line %d: address $%X is beyond 64K", lineNum, full)
+			}
+			builder.add(uint16(full), payload)
+		case ihexEOF:
+			img.Segments = builder.segments
+			if !hasEntry && len(img.Segments) > 0 {
+				img.Entry = img.lowestAddress()
+			}
+			return img, img.Sort()
+		case ihexExtendedSegment:
+			if len(payload) != 2 {
+				return nil, fmt.Errorf("line %d: malformed extended segment address", lineNum)
+			}
+			base = (uint32(payload[0])<<8 | uint32(payload[1])) << 4
+		case ihexExtendedLinear:
+			if len(payload) != 2 {
+				return nil, fmt.Errorf("line %d: malformed extended linear address", lineNum)
+			}
+			base = (uint32(payload[0])<<8 | uint32(payload[1])) << 16
+		case ihexStartSegment, ihexStartLinear:
+			if len(payload) != 4 {
+				return nil, fmt.Errorf("line %d: malformed start address", lineNum)
+			}
+			var entry uint32
+			if record[3] == ihexStartSegment {
+				entry = (uint32(payload[0])<<8|uint32(payload[1]))<<4 + (uint32(payload[2])<<8 | uint32(payload[3]))
+			} else {
+				entry = uint32(payload[0])<<24 | uint32(payload[1])<<16 | uint32(payload[2])<<8 | uint32(payload[3])
+			}
+			if entry > maxImageAddress {
+				return nil, fmt.Errorf("line %d: start address $%X is beyond 64K", lineNum, entry)
+			}
+			img.Entry = uint16(entry)
+			hasEntry = true
+		default:
+			return nil, fmt.Errorf("line %d: unknown record type %02X", lineNum, record[3])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("missing end of file record")
+}
+
+// segmentBuilder merges consecutive data records into segments
+type segmentBuilder struct {
+	segments []Segment
+}
+
+// add appends data at addr, extending the last segment when the data follows it directly
+func (b *segmentBuilder) add(addr uint16, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	if n := len(b.segments); n > 0 && b.segments[n-1].End() == int(addr) {
+		b.segments[n-1].Data = append(b.segments[n-1].Data, data...)
+		return
+	}
+	b.segments = append(b.segments, Segment{Addr: addr, Data: append([]byte{}, data...)})
+}
+
+// lowestAddress returns the lowest segment address
+func (img *Image) lowestAddress() uint16 {
+	lowest := img.Segments[0].Addr
+	for _, segment := range img.Segments {
+		if segment.Addr < lowest {
+			lowest = segment.Addr
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,247 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Magic identifies a g8b program image
+var Magic = []byte{'G', '8', 'B', 0x1A}
+
+// Version is the g8b image format version written by this package
+const Version = 1
+
+const (
+	flagRelocatable = 0x01 // Image can be moved to another load address
+	flagBigEndian   = 0x02 // Relocated words are stored high byte first
+)
+
+// Segment is a contiguous block of bytes loaded at a fixed address
+type Segment struct {
+	Addr uint16 // Load address of the first byte
+	Data []byte // Segment contents
+}
+
+// End returns the address just past the last byte of the segment
+func (s Segment) End() int {
+	return int(s.Addr) + len(s.Data)
+}
+
+// Image is a program together with the addresses it is loaded at
+type Image struct {
+	Segments    []Segment // Memory contents, in ascending address order
+	Entry       uint16    // Address execution starts at
+	Relocations []uint16  // Addresses of 16-bit words holding program addresses
+	Relocatable bool      // Whether the image can be moved with Relocate
+	BigEndian   bool      // Whether the relocated words are stored high byte first, as on the 6800
+}
+
+// FromBinary wraps a flat binary loaded at addr in an image
+func FromBinary(data []byte, addr uint16) *Image {
+	return &Image{
+		Segments: []Segment{{Addr: addr, Data: data}},
+		Entry:    addr,
+	}
+}
+
+// Size returns the total number of bytes in all segments
+func (img *Image) Size() int {
+	size := 0
+	for _, segment := range img.Segments {
+		size += len(segment.Data)
+	}
+	return size
+}
+
+// Flatten returns the image as one block starting at the lowest segment address; gaps are zero-filled
+func (img *Image) Flatten() (uint16, []byte) {
+	if len(img.Segments) == 0 {
+		return img.Entry, nil
+	}
+	base := img.Segments[0].Addr
+	end := 0
+	for _, segment := range img.Segments {
+		if segment.Addr < base {
+			base = segment.Addr
+		}
+		if segment.End() > end {
+			end = segment.End()
+		}
+	}
+
+	data := make([]byte, end-int(base))
+	for _, segment := range img.Segments {
+		copy(data[int(segment.Addr)-int(base):], segment.Data)
+	}
+	return base, data
+}
+
+// Relocate moves the image so that its entry point is at entry, patching every relocation
+func (img *Image) Relocate(entry uint16) error {
+	if entry == img.Entry {
+		return nil
+	}
+	if !img.Relocatable {
+		return fmt.Errorf("image assembled for $%04X is not relocatable", img.Entry)
+	}
+
+	delta := int(entry) - int(img.Entry)
+	for _, segment := range img.Segments {
+		if int(segment.Addr)+delta < 0 || segment.End()+delta > 0x10000 {
+			return fmt.Errorf("segment at $%04X does not fit in memory when moved to $%04X", segment.Addr, entry)
+		}
+	}
+
+	// Patch the words that hold program addresses, then move the segments
+	var order binary.ByteOrder = binary.LittleEndian
+	if img.BigEndian {
+		order = binary.BigEndian
+	}
+	for _, addr := range img.Relocations {
+		segment := img.segmentAt(addr, 2)
+		if segment == nil {
+			return fmt.Errorf("relocation at $%04X is outside the image", addr)
+		}
+		offset := int(addr) - int(segment.Addr)
+		value := int(order.Uint16(segment.Data[offset:])) + delta
+		order.PutUint16(segment.Data[offset:], uint16(value))
+	}
+	for i := range img.Segments {
+		img.Segments[i].Addr = uint16(int(img.Segments[i].Addr) + delta)
+	}
+	for i := range img.Relocations {
+		img.Relocations[i] = uint16(int(img.Relocations[i]) + delta)
+	}
+	img.Entry = entry
+	return nil
+}
+
+// segmentAt returns the segment holding size bytes at addr
+func (img *Image) segmentAt(addr uint16, size int) *Segment {
+	for i := range img.Segments {
+		segment := &img.Segments[i]
+		if addr >= segment.Addr && int(addr)+size <= segment.End() {
+			return segment
+		}
+	}
+	return nil
+}
+
+// Sort orders the segments by address and checks that they do not overlap
+func (img *Image) Sort() error {
+	sort.SliceStable(img.Segments, func(i, j int) bool {
+		return img.Segments[i].Addr < img.Segments[j].Addr
+	})
+	for i := 1; i < len(img.Segments); i++ {
+		if int(img.Segments[i].Addr) < img.Segments[i-1].End() {
+			return fmt.Errorf("segments at $%04X and $%04X overlap", img.Segments[i-1].Addr, img.Segments[i].Addr)
+		}
+	}
+	return nil
+}
+
+// Write writes the image in g8b format.
+//
+// The format is little-endian, whatever the byte order of the program: the
+// 4-byte magic, a version byte, a flags byte, the entry address, the segment
+// count and the relocation count (16 bits each), then each segment as address,
+// length and data, then each relocation address.
+func Write(w io.Writer, img *Image) error {
+	var buf bytes.Buffer
+	buf.Write(Magic)
+	buf.WriteByte(Version)
+	var flags byte
+	if img.Relocatable {
+		flags |= flagRelocatable
+	}
+	if img.BigEndian {
+		flags |= flagBigEndian
+	}
+	buf.WriteByte(flags)
+
+	header := []uint16{img.Entry, uint16(len(img.Segments)), uint16(len(img.Relocations))}
+	binary.Write(&buf, binary.LittleEndian, header)
+	for _, segment := range img.Segments {
+		if len(segment.Data) > 0xFFFF {
+			return fmt.Errorf("segment at $%04X is too large", segment.Addr)
+		}
+		binary.Write(&buf, binary.LittleEndian, []uint16{segment.Addr, uint16(len(segment.Data))})
+		buf.Write(segment.Data)
+	}
+	binary.Write(&buf, binary.LittleEndian, img.Relocations)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Read parses an image in g8b format
+func Read(data []byte) (*Image, error) {
+	if !IsImage(data) {
+		return nil, fmt.Errorf("not a g8b image")
+	}
+	r := bytes.NewReader(data[len(Magic):])
+
+	version, _ := r.ReadByte()
+	if version != Version {
+		return nil, fmt.Errorf("unsupported g8b image version %d", version)
+	}
+	flags, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("truncated g8b image")
+	}
+
+	var header [3]uint16
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("truncated g8b image")
+	}
+	img := &Image{Entry: header[0], Relocatable: flags&flagRelocatable != 0, BigEndian: flags&flagBigEndian != 0}
+
+	for i := 0; i < int(header[1]); i++ {
+		var segment [2]uint16
+		if err := binary.Read(r, binary.LittleEndian, &segment); err != nil {
+			return nil, fmt.Errorf("truncated g8b image")
+		}
+		contents := make([]byte, segment[1])
+		if _, err := io.ReadFull(r, contents); err != nil {
+			return nil, fmt.Errorf("truncated g8b image")
+		}
+		img.Segments = append(img.Segments, Segment{Addr: segment[0], Data: contents})
+	}
+
+	img.Relocations = make([]uint16, header[2])
+	if err := binary.Read(r, binary.LittleEndian, img.Relocations); err != nil {
+		return nil, fmt.Errorf("truncated g8b image")
+	}
+	return img, nil
+}
+
+// IsImage reports whether data starts with the g8b magic
+func IsImage(data []byte) bool {
+	return bytes.HasPrefix(data, Magic)
+}
+
+// Load reads a program file and returns it with the name of its format.
+// g8b images, Intel HEX and S-record files keep their addresses; anything
+// else is a flat binary loaded at addr.
+func Load(path string, addr uint16) (*Image, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	switch {
+	case IsImage(data):
+		img, err := Read(data)
+		return img, "g8b", err
+	case IsIntelHex(data):
+		img, err := ReadIntelHex(data)
+		return img, "ihex", err
+	case IsSRecord(data):
+		img, err := ReadSRecord(data)
+		return img, "srec", err
+	}
+	return FromBinary(data, addr), "bin", nil
+}
@@ -0,0 +1,139 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// srecBytesPerRecord is the number of data bytes written per S1 record
+const srecBytesPerRecord = 16
+
+// WriteSRecord writes the image as Motorola S-records: an S0 header, S1 data, an S5 count and an S9 entry point
+func WriteSRecord(w io.Writer, img *Image, header string) error {
+	bw := bufio.NewWriter(w)
+	writeSRecord(bw, '0', 0, []byte(header))
+
+	count := 0
+	for _, segment := range img.Segments {
+		for offset := 0; offset < len(segment.Data); offset += srecBytesPerRecord {
+			end := offset + srecBytesPerRecord
+			if end > len(segment.Data) {
+				end = len(segment.Data)
+			}
+			writeSRecord(bw, '1', uint32(int(segment.Addr)+offset), segment.Data[offset:end])
+			count++
+		}
+	}
+	if count <= 0xFFFF {
+		writeSRecord(bw, '5', uint32(count), nil)
+	}
+	writeSRecord(bw, '9', uint32(img.Entry), nil)
+	return bw.Flush()
+}
+
+// srecAddressSize returns the number of address bytes used by an S-record type
+func srecAddressSize(recordType byte) int {
+	switch recordType {
+	case '0', '1', '5', '9':
+		return 2
+	case '2', '6', '8':
+		return 3
+	case '3', '7':
+		return 4
+	}
+	return 0
+}
+
+// writeSRecord writes one "StLLAAAA<data>CC" line with a 16-bit address
+func writeSRecord(w io.Writer, recordType byte, addr uint32, data []byte) {
+	size := srecAddressSize(recordType)
+	record := []byte{byte(size + len(data) + 1)}
+	for i := size - 1; i >= 0; i-- {
+		record = append(record, byte(addr>>(8*i)))
+	}
+	record = append(record, data...)
+	var sum byte
+	for _, b := range record {
+		sum += b
+	}
+	record = append(record, ^sum)
+	fmt.Fprintf(w, "S%c%s\n", recordType, strings.ToUpper(hex.EncodeToString(record)))
+}
+
+// IsSRecord reports whether data looks like a Motorola S-record file
+func IsSRecord(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) >= 2 && data[0] == 'S' && data[1] >= '0' && data[1] <= '9'
+}
+
+// ReadSRecord parses a Motorola S-record file; without a start record the entry is the lowest address
+func ReadSRecord(data []byte) (*Image, error) {
+	img := &Image{}
+	hasEntry := false
+	builder := &segmentBuilder{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if len(line) < 4 || line[0] != 'S' {
+			return nil, fmt.Errorf("line %d: malformed record", lineNum)
+		}
+		recordType := line[1]
+		record, err := hex.DecodeString(line[2:])
+		if err != nil || len(record) < 1 || len(record) != int(record[0])+1 {
+			return nil, fmt.Errorf("line %d: malformed record", lineNum)
+		}
+		var sum byte
+		for _, b := range record[:len(record)-1] {
+			sum += b
+		}
+		if ^sum != record[len(record)-1] {
+			return nil, fmt.Errorf("line %d: checksum mismatch", lineNum)
+		}
+
+		size := srecAddressSize(recordType)
+		if size == 0 {
+			return nil, fmt.Errorf("line %d: unknown record type S%c", lineNum, recordType)
+		}
+		if len(record) < size+2 {
+			return nil, fmt.Errorf("line %d: malformed record", lineNum)
+		}
+		var addr uint32
+		for _, b := range record[1 : 1+size] {
+			addr = addr<<8 | uint32(b)
+		}
+		payload := record[1+size : len(record)-1]
+
+		switch recordType {
+		case '1', '2', '3':
+			if len(payload) > 0 && addr+uint32(len(payload))-1 > maxImageAddress {
+				return nil, fmt.Errorf("line %d: address $%X is beyond 64K", lineNum, addr)
+			}
+			builder.add(uint16(addr), payload)
+		case '7', '8', '9':
+			if addr > maxImageAddress {
+				return nil, fmt.Errorf("line %d: start address $%X is beyond 64K", lineNum, addr)
+			}
+			img.Entry = uint16(addr)
+			hasEntry = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	img.Segments = builder.segments
+	if !hasEntry && len(img.Segments) > 0 {
+		img.Entry = img.lowestAddress()
+	}
+	return img, img.Sort()
+}
@@ -0,0 +1,87 @@
+// Command isagen generates an instruction table, the forms of its mnemonic
+// dialects and a reference page from an instruction set definition.
+//
+// A definition is a text file. Lines starting with # are comments; a line
+// starting with ## starts a section, and the comment lines right after it
+// describe the section. The header names the CPU, the Go variables and the
+// dialects:
+//
+//	cpu Intel 8008                 Name used in the reference page
+//	table Intel8008Instructions    Go map of the instructions, map[byte]Instruction
+//	forms intel8008Forms           Go map of the forms by dialect, map[string]map[byte]Form
+//	syntax 8008 8080               Dialect names, one template column each
+//
+// A field is a group of opcode bits with named values. Each value line holds
+// the bits, the value's name in every dialect and a text for descriptions:
+//
+//	field cond 3
+//	0 | FC | NC | if flag Carry is false
+//	end
+//
+// Every other line defines instructions. Its columns are the opcode pattern,
+// a template for each dialect, the addressing mode, the cycle count, the flags
+// the instruction changes and a description template:
+//
+//	01 c:cond 000 | J{c} | J{c} | Absolute | 11 | | Jump to memory address {c.text}
+//
+// The pattern lists the opcode bits from bit 7 down: 0 and 1 are fixed bits,
+// x is a bit that can be either, "c:cond" is a slot holding any value of the
+// field cond, "p:5" a slot holding any 5-bit number, and a slot name given
+// again, as in "11 r:reg r", repeats the value of the slot. The line defines
+// one instruction for every combination of slot values. In templates, {c} is
+// the value's name in the template's dialect (or the number of a number
+// slot), {c.text} the value's text, itself a template, and {c.bin} the value
+// in binary. A template is written as in source: the mnemonic, then the fixed
+// operands separated by commas. A data byte or address is not written; the
+// addressing mode adds it, and the instruction size follows from the mode.
+//
+// A line may define opcodes that an earlier line defined too; the later line
+// wins, so special cases follow the general rule.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	goOutput := flag.String("go", "", "Write the Go tables to this file")
+	docOutput := flag.String("doc", "", "Write the reference page (Markdown) to this file")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-go file.go] [-doc file.md] definition.isa\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	def, err := parseFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "isagen: %v\n", err)
+		os.Exit(1)
+	}
+	if *goOutput != "" {
+		if err := writeFile(*goOutput, def.goSource); err != nil {
+			fmt.Fprintf(os.Stderr, "isagen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *docOutput != "" {
+		if err := writeFile(*docOutput, def.reference); err != nil {
+			fmt.Fprintf(os.Stderr, "isagen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// writeFile writes the output of generate to path
+func writeFile(path string, generate func() ([]byte, error)) error {
+	data, err := generate()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// goSource writes the instruction table and the forms of every dialect
+func (def *definition) goSource() ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by isagen from %s; DO NOT EDIT.\n\n", def.source)
+	fmt.Fprintf(&out, "package cpu\n\n")
+
+	fmt.Fprintf(&out, "// %s is the %s instruction set\n", def.table, def.cpu)
+	fmt.Fprintf(&out, "var %s = map[byte]Instruction{\n", def.table)
+	for _, s := range def.sections {
+		if s.title != "" {
+			fmt.Fprintf(&out, "\n\t// %s\n", s.title)
+			for _, note := range s.notes {
+				fmt.Fprintf(&out, "\t// %s\n", note)
+			}
+		}
+		for _, l := range s.lines {
+			if len(l.instructions) == 0 {
+				continue
+			}
+			out.WriteString("\n")
+			for _, in := range l.sortedOpcodes() {
+				fmt.Fprintf(&out, "\t0x%02X: {0x%02X, %q, %s, %d, %d, %q},\n",
+					in.opcode, in.opcode, in.forms[0].mnemonic, in.mode, in.size, in.cycles, in.description)
+			}
+		}
+	}
+	fmt.Fprintf(&out, "}\n\n")
+
+	fmt.Fprintf(&out, "// %s holds the written form of every opcode, keyed by dialect name\n", def.forms)
+	fmt.Fprintf(&out, "var %s = map[string]map[byte]Form{\n", def.forms)
+	for i, syntax := range def.syntaxes {
+		fmt.Fprintf(&out, "\t%q: {\n", syntax)
+		for _, in := range def.sortedOpcodes() {
+			f := in.forms[i]
+			operands := "nil"
+			if len(f.operands) > 0 {
+				quoted := make([]string, len(f.operands))
+				for j, operand := range f.operands {
+					quoted[j] = fmt.Sprintf("%q", operand)
+				}
+				operands = "[]string{" + strings.Join(quoted, ", ") + "}"
+			}
+			fmt.Fprintf(&out, "\t\t0x%02X: {%q, %s},\n", in.opcode, f.mnemonic, operands)
+		}
+		fmt.Fprintf(&out, "\t},\n")
+	}
+	fmt.Fprintf(&out, "}\n")
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %v", err)
+	}
+	return source, nil
+}
+
+// reference writes a Markdown page with every opcode
+func (def *definition) reference() ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# %s Instruction Set\n\n", def.cpu)
+	fmt.Fprintf(&out, "<!-- Generated by isagen from src/cpu/%s; do not edit. -->\n\n", def.source)
+	fmt.Fprintf(&out, "Every opcode with its form in each mnemonic dialect. Data bytes and addresses follow the\n")
+	fmt.Fprintf(&out, "opcode as the addressing mode says. Flags lists the flags the instruction changes.\n")
+
+	header := []string{"Opcode"}
+	for _, syntax := range def.syntaxes {
+		header = append(header, "`-syntax "+syntax+"`")
+	}
+	header = append(header, "Mode", "Bytes", "Cycles", "Flags", "Description")
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	for _, s := range def.sections {
+		if s.title != "" {
+			fmt.Fprintf(&out, "\n## %s\n", s.title)
+		}
+		if len(s.notes) > 0 {
+			fmt.Fprintf(&out, "\n%s\n", strings.Join(s.notes, " "))
+		}
+		fmt.Fprintf(&out, "\n| %s |\n| %s |\n", strings.Join(header, " | "), strings.Join(separator, " | "))
+		for _, l := range s.lines {
+			for _, in := range l.sortedOpcodes() {
+				row := []string{fmt.Sprintf("`%02X`", in.opcode)}
+				for _, f := range in.forms {
+					row = append(row, "`"+f.String()+"`")
+				}
+				row = append(row, in.mode, fmt.Sprint(in.size), fmt.Sprint(in.cycles), in.flags, in.description)
+				fmt.Fprintf(&out, "| %s |\n", strings.Join(row, " | "))
+			}
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// sortedOpcodes returns every instruction in opcode order
+func (def *definition) sortedOpcodes() []*instruction {
+	sorted := make([]*instruction, 0, len(def.opcodes))
+	for _, in := range def.opcodes {
+		sorted = append(sorted, in)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].opcode < sorted[j].opcode })
+	return sorted
+}
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// operandBytes is the number of bytes after the opcode in each addressing mode
+var operandBytes = map[string]int{
+	"Implied":         0,
+	"Accumulator":     0,
+	"Immediate":       1,
+	"Immediate16":     2,
+	"Absolute":        2,
+	"AbsoluteX":       2,
+	"AbsoluteY":       2,
+	"ZeroPage":        1,
+	"ZeroPageX":       1,
+	"ZeroPageY":       1,
+	"Indirect":        2,
+	"IndexedIndirect": 1,
+	"IndirectIndexed": 1,
+	"IndexedOffset":   1,
+	"Relative":        1,
+	"Page":            1,
+	"OperandPage":     1,
+	"Absolute12":      1,
+}
+
+// definition is a parsed instruction set definition
+type definition struct {
+	source   string // Base name of the definition file
+	cpu      string
+	table    string
+	forms    string
+	syntaxes []string
+	fields   map[string]*field
+	sections []*section
+	opcodes  map[byte]*instruction // Final definition of every opcode
+}
+
+// field is a group of opcode bits with named values
+type field struct {
+	name   string
+	bits   int
+	values []fieldValue
+}
+
+// fieldValue is one value of a field
+type fieldValue struct {
+	bits  int
+	names []string // Name in each dialect
+	text  string
+}
+
+// section is a group of instruction lines under a ## heading
+type section struct {
+	title string
+	notes []string
+	lines []*line
+}
+
+// line is an instruction line and the instructions it defines
+type line struct {
+	number       int
+	instructions []*instruction
+}
+
+// instruction is one opcode as defined by a line
+type instruction struct {
+	line        *line
+	opcode      byte
+	forms       []form // Form in each dialect
+	mode        string
+	size        int
+	cycles      int
+	flags       string
+	description string
+}
+
+// form is an instruction as written in a dialect
+type form struct {
+	mnemonic string
+	operands []string
+}
+
+func (f form) String() string {
+	if len(f.operands) == 0 {
+		return f.mnemonic
+	}
+	return f.mnemonic + " " + strings.Join(f.operands, ",")
+}
+
+// slot is a part of an opcode pattern
+type slot struct {
+	name   string
+	field  *field // nil for a number slot
+	bits   int
+	repeat bool   // Takes the value of an earlier slot of the same name
+	fixed  string // Fixed bits, when name is empty; x is either bit
+}
+
+// binding is the value a slot has in one instruction
+type binding struct {
+	slot  *slot
+	value int
+	field *fieldValue // nil for a number slot
+}
+
+// parseFile reads a definition and expands its instructions
+func parseFile(path string) (*definition, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	def := &definition{
+		source:  filepath.Base(path),
+		fields:  make(map[string]*field),
+		opcodes: make(map[byte]*instruction),
+	}
+	var current *field
+	var notes *section // Section whose notes are being read
+	scanner := bufio.NewScanner(file)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("%s:%d: %s", path, number, fmt.Sprintf(format, args...))
+		}
+
+		switch {
+		case strings.HasPrefix(text, "##"):
+			notes = &section{title: strings.TrimSpace(text[2:])}
+			def.sections = append(def.sections, notes)
+			continue
+		case strings.HasPrefix(text, "#"):
+			if notes != nil {
+				notes.notes = append(notes.notes, strings.TrimSpace(text[1:]))
+			}
+			continue
+		}
+		notes = nil
+		if text == "" {
+			continue
+		}
+
+		if current != nil {
+			if text == "end" {
+				current = nil
+				continue
+			}
+			value, err := def.parseValue(current, text)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			current.values = append(current.values, value)
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(text, " ")
+		rest = strings.TrimSpace(rest)
+		switch keyword {
+		case "cpu":
+			def.cpu = rest
+		case "table":
+			def.table = rest
+		case "forms":
+			def.forms = rest
+		case "syntax":
+			def.syntaxes = strings.Fields(rest)
+		case "field":
+			name, width, _ := strings.Cut(rest, " ")
+			bits, err := strconv.Atoi(strings.TrimSpace(width))
+			if err != nil || bits < 1 || bits > 8 {
+				return nil, fail("field %s needs a width of 1-8 bits", name)
+			}
+			current = &field{name: name, bits: bits}
+			def.fields[name] = current
+		default:
+			if err := def.parseLine(text, number); err != nil {
+				return nil, fail("%v", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	switch {
+	case current != nil:
+		return nil, fmt.Errorf("%s: field %s has no end", path, current.name)
+	case def.table == "" || def.forms == "" || len(def.syntaxes) == 0:
+		return nil, fmt.Errorf("%s: table, forms and syntax must be given", path)
+	}
+	return def, nil
+}
+
+// parseValue parses a value line of a field
+func (def *definition) parseValue(f *field, text string) (fieldValue, error) {
+	columns := splitColumns(text)
+	if len(columns) != len(def.syntaxes)+2 {
+		return fieldValue{}, fmt.Errorf("field value needs bits, %d names and a text", len(def.syntaxes))
+	}
+	bits, err := strconv.Atoi(columns[0])
+	if err != nil || bits < 0 || bits >= 1<<f.bits {
+		return fieldValue{}, fmt.Errorf("invalid value %q for the %d-bit field %s", columns[0], f.bits, f.name)
+	}
+	return fieldValue{bits: bits, names: columns[1 : len(columns)-1], text: columns[len(columns)-1]}, nil
+}
+
+// parseLine parses an instruction line and defines its instructions
+func (def *definition) parseLine(text string, number int) error {
+	columns := splitColumns(text)
+	if len(columns) != len(def.syntaxes)+5 {
+		return fmt.Errorf("instruction needs a pattern, %d templates, mode, cycles, flags and description", len(def.syntaxes))
+	}
+	slots, err := def.parsePattern(columns[0])
+	if err != nil {
+		return err
+	}
+	templates := columns[1 : 1+len(def.syntaxes)]
+	rest := columns[1+len(def.syntaxes):]
+	mode, flags, description := rest[0], rest[2], rest[3]
+	operands, ok := operandBytes[mode]
+	if !ok {
+		return fmt.Errorf("unknown addressing mode %s", mode)
+	}
+	cycles, err := strconv.Atoi(rest[1])
+	if err != nil {
+		return fmt.Errorf("invalid cycle count %q", rest[1])
+	}
+
+	if len(def.sections) == 0 {
+		def.sections = append(def.sections, &section{})
+	}
+	current := def.sections[len(def.sections)-1]
+	l := &line{number: number}
+	current.lines = append(current.lines, l)
+
+	return expand(slots, nil, func(bindings []binding) error {
+		opcode := 0
+		for _, b := range bindings {
+			opcode = opcode<<b.slot.bits | b.value
+		}
+		in := &instruction{
+			line:   l,
+			opcode: byte(opcode),
+			mode:   mode,
+			size:   1 + operands,
+			cycles: cycles,
+			flags:  flags,
+		}
+		for i, template := range templates {
+			written, err := render(template, bindings, i)
+			if err != nil {
+				return err
+			}
+			in.forms = append(in.forms, parseForm(written))
+		}
+		if in.description, err = render(description, bindings, 0); err != nil {
+			return err
+		}
+		// A later line replaces an earlier definition of the opcode
+		if earlier, ok := def.opcodes[in.opcode]; ok {
+			earlier.line.remove(earlier)
+		}
+		def.opcodes[in.opcode] = in
+		l.instructions = append(l.instructions, in)
+		return nil
+	})
+}
+
+// remove drops an instruction that a later line redefined
+func (l *line) remove(in *instruction) {
+	for i, other := range l.instructions {
+		if other == in {
+			l.instructions = append(l.instructions[:i], l.instructions[i+1:]...)
+			return
+		}
+	}
+}
+
+// parsePattern parses an opcode pattern into its slots
+func (def *definition) parsePattern(pattern string) ([]*slot, error) {
+	var slots []*slot
+	named := make(map[string]*slot)
+	width := 0
+	for _, token := range strings.Fields(pattern) {
+		var s *slot
+		switch name, kind, hasKind := strings.Cut(token, ":"); {
+		case strings.Trim(token, "01x") == "":
+			for _, bit := range token {
+				// Each x is a slot of its own, so it takes both values
+				s = &slot{fixed: string(bit), bits: 1}
+				slots = append(slots, s)
+			}
+			width += len(token)
+			continue
+		case !hasKind:
+			earlier, ok := named[name]
+			if !ok {
+				return nil, fmt.Errorf("slot %s is repeated before it is defined", name)
+			}
+			s = &slot{name: name, field: earlier.field, bits: earlier.bits, repeat: true}
+		default:
+			if _, ok := named[name]; ok {
+				return nil, fmt.Errorf("slot %s is defined twice", name)
+			}
+			if f, ok := def.fields[kind]; ok {
+				s = &slot{name: name, field: f, bits: f.bits}
+			} else if bits, err := strconv.Atoi(kind); err == nil && bits > 0 {
+				s = &slot{name: name, bits: bits}
+			} else {
+				return nil, fmt.Errorf("unknown field %s", kind)
+			}
+			named[name] = s
+		}
+		slots = append(slots, s)
+		width += s.bits
+	}
+	if width != 8 {
+		return nil, fmt.Errorf("pattern %q has %d bits, not 8", pattern, width)
+	}
+	return slots, nil
+}
+
+// expand calls emit with every combination of slot values
+func expand(slots []*slot, bindings []binding, emit func([]binding) error) error {
+	if len(slots) == 0 {
+		return emit(bindings)
+	}
+	s, rest := slots[0], slots[1:]
+	next := func(b binding) error {
+		return expand(rest, append(bindings, b), emit)
+	}
+	switch {
+	case s.fixed == "x":
+		for value := 0; value < 2; value++ {
+			if err := next(binding{slot: s, value: value}); err != nil {
+				return err
+			}
+		}
+	case s.fixed != "":
+		return next(binding{slot: s, value: int(s.fixed[0] - '0')})
+	case s.repeat:
+		for _, b := range bindings {
+			if b.slot.name == s.name {
+				return next(binding{slot: s, value: b.value, field: b.field})
+			}
+		}
+	case s.field != nil:
+		for i := range s.field.values {
+			value := &s.field.values[i]
+			if err := next(binding{slot: s, value: value.bits, field: value}); err != nil {
+				return err
+			}
+		}
+	default:
+		for value := 0; value < 1<<s.bits; value++ {
+			if err := next(binding{slot: s, value: value}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)(?:\.(\w+))?\}`)
+
+// render fills in a template with the slot values of an instruction; names
+// are taken from the given dialect
+func render(template string, bindings []binding, syntax int) (string, error) {
+	var err error
+	text := placeholder.ReplaceAllStringFunc(template, func(match string) string {
+		parts := placeholder.FindStringSubmatch(match)
+		name, attribute := parts[1], parts[2]
+		var found *binding
+		for i := range bindings {
+			if bindings[i].slot.name == name {
+				found = &bindings[i]
+				break
+			}
+		}
+		if found == nil {
+			err = fmt.Errorf("template %q uses unknown slot %s", template, name)
+			return match
+		}
+
+		switch {
+		case attribute == "bin":
+			return fmt.Sprintf("%0*b", found.slot.bits, found.value)
+		case attribute == "text" && found.field != nil:
+			var text string
+			text, err = render(found.field.text, bindings, syntax)
+			return text
+		case attribute == "" && found.field != nil:
+			return found.field.names[syntax]
+		case attribute == "":
+			return strconv.Itoa(found.value)
+		}
+		err = fmt.Errorf("template %q uses unknown attribute %s of slot %s", template, attribute, name)
+		return match
+	})
+	return text, err
+}
+
+// parseForm splits a written instruction into its mnemonic and operands
+func parseForm(written string) form {
+	mnemonic, operands, _ := strings.Cut(written, " ")
+	f := form{mnemonic: mnemonic}
+	if operands = strings.TrimSpace(operands); operands != "" {
+		for _, operand := range strings.Split(operands, ",") {
+			f.operands = append(f.operands, strings.TrimSpace(operand))
+		}
+	}
+	return f
+}
+
+// splitColumns splits a line at the | separators
+func splitColumns(text string) []string {
+	columns := strings.Split(text, "|")
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+	return columns
+}
+
+// sortedOpcodes returns the instructions of a line in opcode order
+func (l *line) sortedOpcodes() []*instruction {
+	sorted := append([]*instruction(nil), l.instructions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].opcode < sorted[j].opcode })
+	return sorted
+}
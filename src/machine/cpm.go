@@ -0,0 +1,86 @@
+package machine
+
+import (
+	"fmt"
+	"io"
+)
+
+// CPMLoadAddress is where CP/M loads .COM programs and starts them
+const CPMLoadAddress = 0x0100
+
+// cpmBDOSPort is the I/O port the BDOS stub uses to hand calls to the machine
+const cpmBDOSPort = 0xFF
+
+// cpmCPUs lists the CPU types that can run CP/M programs
+var cpmCPUs = map[string]bool{"8080": true, "8085": true, "z80": true}
+
+// LoadCPM loads a CP/M .COM program with a minimal BDOS, enough for CPU
+// exercisers and other programs that only print to the console:
+//
+//	$0000      HLT; a warm boot (JMP 0) ends the program
+//	$0005      JMP to the BDOS; the address at $0006 is the top of usable memory
+//	BDOS       OUT $FF / RET in the last page of memory
+//
+// The BDOS supports function 0 (system reset), 2 (console output), 9 (print
+// a string ending in '$') and 11 (console status, never ready). Console output
+// goes to console.
+func (m *Machine) LoadCPM(program []byte, console io.Writer) error {
+	if !cpmCPUs[m.config.CPU] {
+		return fmt.Errorf("CP/M programs need an 8080-compatible CPU, not %s", m.config.CPU)
+	}
+	bdos := m.config.MemorySize - 0x100
+	if CPMLoadAddress+len(program) > bdos {
+		return fmt.Errorf("program of %d bytes does not fit below the BDOS at $%04X", len(program), bdos)
+	}
+	if err := m.Attach(&cpmBDOS{machine: m, console: console}, cpmBDOSPort); err != nil {
+		return err
+	}
+
+	m.Write(0x0000, 0x76) // HLT
+	m.Write(0x0005, 0xC3) // JMP bdos
+	m.Write(0x0006, byte(bdos))
+	m.Write(0x0007, byte(bdos>>8))
+	m.Write(uint16(bdos), 0xD3) // OUT cpmBDOSPort
+	m.Write(uint16(bdos+1), cpmBDOSPort)
+	m.Write(uint16(bdos+2), 0xC9) // RET
+	for i, b := range program {
+		m.Write(CPMLoadAddress+uint16(i), b)
+	}
+	m.cpu.SetPC(CPMLoadAddress)
+	m.halted = false
+	return nil
+}
+
+// cpmBDOS carries out the BDOS calls the stub passes to its port. The function
+// number is in register C and its argument in E or DE.
+type cpmBDOS struct {
+	machine *Machine
+	console io.Writer
+}
+
+func (b *cpmBDOS) In(port byte) byte {
+	return 0
+}
+
+func (b *cpmBDOS) Out(port byte, value byte) {
+	registers := b.machine.State().Registers
+	switch registers["C"] {
+	case 0: // System reset
+		b.machine.cpu.SetPC(0x0000)
+	case 2: // Console output
+		b.console.Write([]byte{byte(registers["E"])})
+	case 9: // Print string
+		addr := registers["D"]<<8 | registers["E"]
+		var text []byte
+		for i := 0; i < b.machine.config.MemorySize; i++ {
+			ch := b.machine.Read(addr + uint16(i))
+			if ch == '$' {
+				break
+			}
+			text = append(text, ch)
+		}
+		b.console.Write(text)
+	case 11: // Console status
+		b.machine.SetRegister("A", 0)
+	}
+}
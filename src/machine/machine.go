@@ -0,0 +1,266 @@
+// Package machine assembles a CPU, memory and I/O devices into a system that
+// programs and tools can load, run and inspect without the emulator binary.
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+	"github.com/lukasz-gorgol/g8b/src/image"
+)
+
+// contextCheckInterval is how many instructions Run executes between checks for cancellation
+const contextCheckInterval = 1024
+
+// ErrCycleLimit is returned by Run when the program used up Config.MaxCycles
+var ErrCycleLimit = errors.New("cycle limit reached")
+
+// Config describes the system to build
+type Config struct {
+	CPU        string // CPU type (default: 8008)
+	MemorySize int    // Memory size in bytes (default: 65536)
+	Speed      uint   // CPU speed in Hz; 0 runs as fast as possible
+	Verbose    bool   // Print every executed instruction
+	MaxCycles  int    // Run stops with ErrCycleLimit after this many cycles; 0 means no limit
+}
+
+// Device is a peripheral attached to I/O ports
+type Device interface {
+	In(port byte) byte
+	Out(port byte, value byte)
+}
+
+// Machine is a CPU with its memory and the devices on its I/O ports
+type Machine struct {
+	config  Config
+	cpu     cpu.ICPU
+	devices [256]Device // Device attached to each port
+	serial  *serialLine // 8085 serial lines connected by AttachSerial
+	halted  bool
+}
+
+// State is a snapshot of the CPU
+type State struct {
+	CPU       string            // CPU name
+	PC        uint16            // Program counter
+	SP        uint16            // Stack pointer
+	Registers map[string]uint16 // Registers other than PC and SP by name, see cpu.ICPU.Registers
+	Flags     map[string]bool   // Flags by name, see cpu.ICPU.Flags
+	Cycles    int               // Cycles executed so far
+	Halted    bool              // Whether the CPU executed a halt instruction
+}
+
+// NewMachine builds a system from the configuration
+func NewMachine(cfg Config) (*Machine, error) {
+	if cfg.CPU == "" {
+		cfg.CPU = "8008"
+	}
+	if cfg.MemorySize == 0 {
+		cfg.MemorySize = 65536
+	}
+	if cfg.MemorySize < 0 || cfg.MemorySize > 65536 {
+		return nil, fmt.Errorf("memory size %d is outside 1-65536 bytes", cfg.MemorySize)
+	}
+
+	var processor cpu.ICPU
+	switch cfg.CPU {
+	case "8008":
+		processor = cpu.NewIntel8008(cfg.MemorySize, cfg.Speed)
+	case "8080":
+		processor = cpu.NewIntel8080(cfg.MemorySize, cfg.Speed)
+	case "8085":
+		processor = cpu.NewIntel8085(cfg.MemorySize, cfg.Speed)
+	case "6502":
+		processor = cpu.NewMOS6502(cfg.MemorySize, cfg.Speed)
+	case "6800":
+		processor = cpu.NewMotorola6800(cfg.MemorySize, cfg.Speed)
+	case "1802":
+		processor = cpu.NewRCA1802(cfg.MemorySize, cfg.Speed)
+	case "z80":
+		processor = cpu.NewZ80(cfg.MemorySize, cfg.Speed)
+	case "4004":
+		// Memory is the program ROM; data RAM is inside the CPU
+		cfg.MemorySize = min(cfg.MemorySize, cpu.Intel4004ROMSize)
+		processor = cpu.NewIntel4004(cfg.MemorySize, cfg.Speed)
+	case "4040":
+		cfg.MemorySize = min(cfg.MemorySize, cpu.Intel4040ROMSize)
+		processor = cpu.NewIntel4040(cfg.MemorySize, cfg.Speed)
+	default:
+		return nil, fmt.Errorf("unsupported CPU type: %s (available: 8008, 8080, 8085, 6502, 6800, 1802, z80, 4004, 4040)", cfg.CPU)
+	}
+	processor.SetVerbose(cfg.Verbose)
+
+	m := &Machine{config: cfg, cpu: processor}
+	processor.SetIOBus(m)
+	return m, nil
+}
+
+// CPU returns the machine's processor
+func (m *Machine) CPU() cpu.ICPU {
+	return m.cpu
+}
+
+// Attach connects a device to the given I/O ports
+func (m *Machine) Attach(device Device, ports ...byte) error {
+	for _, port := range ports {
+		if m.devices[port] != nil {
+			return fmt.Errorf("port %d already has a device attached", port)
+		}
+	}
+	for _, port := range ports {
+		m.devices[port] = device
+	}
+	return nil
+}
+
+// In reads from the device on a port; unattached ports read 0
+func (m *Machine) In(port byte) byte {
+	if device := m.devices[port]; device != nil {
+		return device.In(port)
+	}
+	return 0
+}
+
+// Out writes to the device on a port; writes to unattached ports are ignored
+func (m *Machine) Out(port byte, value byte) {
+	if device := m.devices[port]; device != nil {
+		device.Out(port, value)
+	}
+}
+
+// SetPin sets the level of one of the CPU's input pins, such as the 8085's
+// TRAP and RST 7.5. Devices call it from In and Out, or programs between
+// steps, to request interrupts. An interrupt the CPU will serve ends a halt,
+// so Step and Run continue with the interrupt routine.
+func (m *Machine) SetPin(name string, high bool) error {
+	pins, ok := m.cpu.(cpu.Pins)
+	if !ok {
+		return fmt.Errorf("the %s has no input pins", m.cpu.GetName())
+	}
+	if err := pins.SetPin(name, high); err != nil {
+		return err
+	}
+	if interrupter, ok := m.cpu.(cpu.Interrupter); ok && interrupter.InterruptPending() {
+		m.halted = false
+	}
+	return nil
+}
+
+// WatchPins tells listener when the program changes one of the CPU's output
+// pins, such as the 1802's Q
+func (m *Machine) WatchPins(listener cpu.PinListener) error {
+	pins, ok := m.cpu.(cpu.OutputPins)
+	if !ok {
+		return fmt.Errorf("the %s has no output pins", m.cpu.GetName())
+	}
+	pins.WatchPins(listener)
+	return nil
+}
+
+// Load copies a program into memory and sets the program counter to its entry point
+func (m *Machine) Load(program *image.Image) error {
+	for _, segment := range program.Segments {
+		if segment.End() > m.config.MemorySize {
+			return fmt.Errorf("program segment $%04X-$%04X does not fit in %d bytes of memory",
+				segment.Addr, segment.End()-1, m.config.MemorySize)
+		}
+	}
+	for _, segment := range program.Segments {
+		for i, b := range segment.Data {
+			m.cpu.Write(segment.Addr+uint16(i), b)
+		}
+	}
+	m.cpu.SetPC(program.Entry)
+	m.halted = false
+	return nil
+}
+
+// Read reads a byte from memory
+func (m *Machine) Read(addr uint16) byte {
+	return m.cpu.Read(addr)
+}
+
+// Write writes a byte to memory
+func (m *Machine) Write(addr uint16, value byte) {
+	m.cpu.Write(addr, value)
+}
+
+// Step executes one instruction. It returns cpu.ErrHalted once the CPU has halted.
+func (m *Machine) Step() error {
+	if m.halted {
+		return cpu.ErrHalted
+	}
+	err := m.cpu.ExecuteInstruction()
+	if errors.Is(err, cpu.ErrHalted) {
+		m.halted = true
+	}
+	return err
+}
+
+// Run executes instructions until the CPU halts, an instruction fails, the
+// cycle limit is reached or ctx is done. It returns nil when the program
+// halted and ctx.Err() when it was stopped; calling Run again resumes execution.
+func (m *Machine) Run(ctx context.Context) error {
+	m.cpu.Start()
+	defer m.cpu.Stop()
+	if m.serial != nil {
+		// Write a byte whose last bits went out after the last change of SOD
+		defer func() { m.serial.flush(m.cpu.GetCycles()) }()
+	}
+
+	for i := 0; ; i++ {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if err := m.Step(); err != nil {
+			if errors.Is(err, cpu.ErrHalted) {
+				return nil
+			}
+			return err
+		}
+		if m.config.MaxCycles > 0 && m.cpu.GetCycles() >= m.config.MaxCycles {
+			return ErrCycleLimit
+		}
+	}
+}
+
+// SetRegister sets a register by name: PC, SP or one of the names in State.Registers
+func (m *Machine) SetRegister(name string, value uint16) error {
+	if strings.EqualFold(name, "SP") {
+		m.cpu.SetSP(value)
+		return nil
+	}
+	return m.cpu.SetRegister(name, value)
+}
+
+// SetFlag sets a flag by one of the names in State.Flags
+func (m *Machine) SetFlag(name string, value bool) error {
+	return m.cpu.SetFlag(name, value)
+}
+
+// State returns a snapshot of the CPU registers and flags
+func (m *Machine) State() State {
+	state := State{
+		CPU:       m.cpu.GetName(),
+		PC:        m.cpu.GetPC(),
+		SP:        m.cpu.GetSP(),
+		Registers: make(map[string]uint16),
+		Flags:     make(map[string]bool),
+		Cycles:    m.cpu.GetCycles(),
+		Halted:    m.halted,
+	}
+	for _, register := range m.cpu.Registers() {
+		if register.Name != "SP" {
+			state.Registers[register.Name], _ = m.cpu.GetRegister(register.Name)
+		}
+	}
+	for _, flag := range m.cpu.Flags() {
+		state.Flags[flag.Name], _ = m.cpu.GetFlag(flag.Name)
+	}
+	return state
+}
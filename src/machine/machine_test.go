@@ -0,0 +1,262 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+	"github.com/lukasz-gorgol/g8b/src/image"
+)
+
+func TestInterruptEndsHalt(t *testing.T) {
+	m, err := NewMachine(Config{CPU: "8085"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	program := image.FromBinary([]byte{0x76}, 0x8000) // HLT
+	if err := m.Load(program); err != nil {
+		t.Fatal(err)
+	}
+	m.Write(cpu.Intel8085TrapAddress, 0x3E) // MVI A,$42
+	m.Write(cpu.Intel8085TrapAddress+1, 0x42)
+	m.Write(cpu.Intel8085TrapAddress+2, 0x76) // HLT
+
+	if err := m.Step(); !errors.Is(err, cpu.ErrHalted) {
+		t.Fatalf("HLT returned %v, want ErrHalted", err)
+	}
+
+	// RST 7.5 is masked after reset, so it does not end the halt
+	if err := m.SetPin("RST7.5", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Step(); !errors.Is(err, cpu.ErrHalted) {
+		t.Fatalf("masked RST 7.5 ended the halt: %v", err)
+	}
+
+	if err := m.SetPin("TRAP", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Step(); err != nil {
+		t.Fatalf("TRAP did not end the halt: %v", err)
+	}
+	if a, _ := m.CPU().GetRegister("A"); a != 0x42 {
+		t.Errorf("A = $%02X after the TRAP routine's MVI, want $42", a)
+	}
+	if ret := m.CPU().Pull16(); ret != 0x8001 {
+		t.Errorf("TRAP saved $%04X, want the address after HLT, $8001", ret)
+	}
+}
+
+func TestInterruptEndsIdle(t *testing.T) {
+	m, err := NewMachine(Config{CPU: "1802"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// R0 runs IDL at 0; the interrupt routine at R1 loads $42 into D and idles again
+	program := image.FromBinary([]byte{0x00, 0x00, 0xF8, 0x42, 0x00}, 0)
+	if err := m.Load(program); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetRegister("R1", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Step(); !errors.Is(err, cpu.ErrHalted) {
+		t.Fatalf("IDL returned %v, want ErrHalted", err)
+	}
+	if err := m.SetPin("INT", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Step(); err != nil {
+		t.Fatalf("INT did not end IDL: %v", err)
+	}
+	if d, _ := m.CPU().GetRegister("D"); d != 0x42 {
+		t.Errorf("D = $%02X after the interrupt routine's LDI, want $42", d)
+	}
+}
+
+func TestZ80InterruptEndsHalt(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    byte // Second byte of IM n: $46, $56 or $5E
+		pin     string
+		data    byte   // Byte on the bus for IM 0 and IM 2
+		handler uint16 // Where the interrupt routine starts
+	}{
+		{"IM 0", 0x46, "INT", 0xCF, 0x0008}, // RST 08H
+		{"IM 1", 0x56, "INT", 0xFF, cpu.Z80IM1Address},
+		{"IM 2", 0x5E, "INT", 0xFE, 0x9000}, // Vector at $90FE
+		{"NMI", 0x46, "NMI", 0xFF, cpu.Z80NMIAddress},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			m, err := NewMachine(Config{CPU: "z80"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// IM n, EI, HALT
+			if err := m.Load(image.FromBinary([]byte{0xED, test.mode, 0xFB, 0x76}, 0x8000)); err != nil {
+				t.Fatal(err)
+			}
+			// The routine loads $42 into A
+			m.Write(test.handler, 0x3E)
+			m.Write(test.handler+1, 0x42)
+			m.Write(0x90FE, 0x00)
+			m.Write(0x90FF, 0x90)
+			if err := m.SetRegister("I", 0x90); err != nil {
+				t.Fatal(err)
+			}
+			m.CPU().(*cpu.Z80).InterruptData = test.data
+
+			for i := 0; i < 2; i++ {
+				if err := m.Step(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := m.Step(); !errors.Is(err, cpu.ErrHalted) {
+				t.Fatalf("HALT returned %v, want ErrHalted", err)
+			}
+
+			if err := m.SetPin(test.pin, true); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Step(); err != nil {
+				t.Fatalf("%s did not end the halt: %v", test.pin, err)
+			}
+			if a, _ := m.CPU().GetRegister("A"); a != 0x42 {
+				t.Errorf("A = $%02X after the routine at $%04X, want $42", a, test.handler)
+			}
+			if ret := m.CPU().Pull16(); ret != 0x8004 {
+				t.Errorf("%s saved $%04X, want the address after HALT, $8004", test.pin, ret)
+			}
+		})
+	}
+}
+
+func TestIRQEndsWAI(t *testing.T) {
+	m, err := NewMachine(Config{CPU: "6800"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// CLI, WAI; the IRQ routine at $9000 loads $42 into A
+	if err := m.Load(image.FromBinary([]byte{0x0E, 0x3E}, 0x8000)); err != nil {
+		t.Fatal(err)
+	}
+	m.Write(cpu.Motorola6800IRQVector, 0x90)
+	m.Write(cpu.Motorola6800IRQVector+1, 0x00)
+	m.Write(0x9000, 0x86)
+	m.Write(0x9001, 0x42)
+	if err := m.SetRegister("SP", 0x01FF); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Step(); !errors.Is(err, cpu.ErrHalted) {
+		t.Fatalf("WAI returned %v, want ErrHalted", err)
+	}
+	if err := m.SetPin("IRQ", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Step(); err != nil {
+		t.Fatalf("IRQ did not end WAI: %v", err)
+	}
+	if a, _ := m.CPU().GetRegister("A"); a != 0x42 {
+		t.Errorf("A = $%02X after the IRQ routine's LDAA, want $42", a)
+	}
+	if masked, _ := m.CPU().GetFlag("I"); !masked {
+		t.Error("the interrupt mask is clear in the IRQ routine, want set")
+	}
+	// WAI pushed the registers once: PC, X, A, B and the CCR
+	if sp, _ := m.CPU().GetRegister("SP"); sp != 0x01FF-7 {
+		t.Errorf("SP = $%04X, want $%04X", sp, 0x01FF-7)
+	}
+}
+
+func TestMOS6502TakesIRQFromPin(t *testing.T) {
+	m, err := NewMachine(Config{CPU: "6502"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SEI, CLI, NOP; the IRQ routine at $9000 loads $42 into A
+	if err := m.Load(image.FromBinary([]byte{0x78, 0x58, 0xEA}, 0x8000)); err != nil {
+		t.Fatal(err)
+	}
+	m.Write(cpu.MOS6502IRQVector, 0x00)
+	m.Write(cpu.MOS6502IRQVector+1, 0x90)
+	m.Write(0x9000, 0xA9)
+	m.Write(0x9001, 0x42)
+
+	if err := m.Step(); err != nil { // SEI
+		t.Fatal(err)
+	}
+	if err := m.SetPin("IRQ", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Step(); err != nil { // CLI; IRQ is still masked before it
+		t.Fatal(err)
+	}
+	if pc := m.CPU().GetPC(); pc != 0x8002 {
+		t.Fatalf("PC = $%04X after CLI, want $8002: IRQ was taken while masked", pc)
+	}
+	if err := m.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := m.CPU().GetRegister("A"); a != 0x42 {
+		t.Errorf("A = $%02X after the IRQ routine's LDA, want $42", a)
+	}
+}
+
+// TestCPUExercisers runs CP/M CPU exercisers from the files named by
+// environment variables, as in
+//
+//	G8B_8080EXM=/path/to/8080EXM.COM go test -run Exercisers -timeout 0 ./src/machine
+//
+// The exercisers are not part of the repository, so each one is skipped when
+// its variable is unset. A full run takes billions of cycles.
+func TestCPUExercisers(t *testing.T) {
+	exercisers := []struct {
+		env string
+		cpu string
+	}{
+		{"G8B_8080EXM", "8080"},
+		{"G8B_8080PRE", "8080"},
+		{"G8B_ZEXDOC", "z80"},
+	}
+	for _, exerciser := range exercisers {
+		exerciser := exerciser
+		t.Run(exerciser.env, func(t *testing.T) {
+			path := os.Getenv(exerciser.env)
+			if path == "" {
+				t.Skipf("%s is not set", exerciser.env)
+			}
+			program, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := NewMachine(Config{CPU: exerciser.cpu})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var console strings.Builder
+			if err := m.LoadCPM(program, &console); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("%v\n%s", err, console.String())
+			}
+
+			// Every test prints OK or ERROR, and the exerciser ends with "Tests complete"
+			output := console.String()
+			t.Log(output)
+			if strings.Contains(output, "ERROR") || !strings.Contains(output, "complete") {
+				t.Errorf("%s reported errors or did not finish:\n%s", path, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package machine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// AttachSerial connects the 8085's serial lines to host streams as an
+// asynchronous line with 8 data bits, no parity and 1 stop bit, the format
+// bit-banged serial routines use. Bytes sent on SOD are written to out and
+// bytes read from in are sent on SID. A bit lasts cyclesPerBit CPU cycles,
+// the CPU clock divided by the baud rate the program is written for.
+//
+// Reading SID when no byte is being sent starts the next byte, so a program
+// that polls SID for a start bit waits until in has one.
+func (m *Machine) AttachSerial(in io.Reader, out io.Writer, cyclesPerBit int) error {
+	c, ok := m.cpu.(*cpu.Intel8085)
+	if !ok {
+		return fmt.Errorf("serial lines need an 8085, not %s", m.config.CPU)
+	}
+	if cyclesPerBit <= 0 {
+		return fmt.Errorf("serial bit time of %d cycles is not positive", cyclesPerBit)
+	}
+	m.serial = &serialLine{bitCycles: cyclesPerBit, in: bufio.NewReader(in), out: out}
+	c.Serial = m.serial
+	return nil
+}
+
+// serialLine decodes the bytes a program sends on SOD and clocks the bytes it
+// receives onto SID. Both directions are timed in CPU cycles.
+type serialLine struct {
+	bitCycles int
+	in        *bufio.Reader
+	out       io.Writer
+
+	// Sending: SOD is sampled in the middle of each data bit
+	level   bool // SOD level; the line is idle when high
+	sending bool // A start bit was seen and data bits are being sampled
+	start   int  // Cycle at which the start bit began
+	bits    int  // Data bits sampled so far
+	data    byte
+
+	// Receiving: SID follows the bits of the byte being received
+	receiving bool
+	rxStart   int // Cycle at which the start bit began
+	rxData    byte
+	eof       bool // in is exhausted; SID stays idle
+}
+
+// SOD samples the data bits that passed at the old level and starts a byte on
+// the falling edge of a start bit
+func (s *serialLine) SOD(cycle int, level bool) {
+	s.flush(cycle)
+	if !s.sending && s.level && !level {
+		s.sending, s.start, s.bits, s.data = true, cycle, 0, 0
+	}
+	s.level = level
+}
+
+// flush samples the data bits whose middle is before cycle and writes the
+// byte once all eight are in
+func (s *serialLine) flush(cycle int) {
+	for s.sending && s.start+s.bitCycles*(2*s.bits+3)/2 < cycle {
+		if s.level {
+			s.data |= 1 << s.bits
+		}
+		s.bits++
+		if s.bits == 8 {
+			s.out.Write([]byte{s.data})
+			s.sending = false
+		}
+	}
+}
+
+// SID returns the level of the bit being received at cycle: the start bit,
+// eight data bits from the lowest, then the stop bit. Between bytes the line is
+// idle (high) until in has another byte.
+func (s *serialLine) SID(cycle int) bool {
+	if s.receiving && cycle-s.rxStart >= 10*s.bitCycles {
+		s.receiving = false
+	}
+	if !s.receiving {
+		if s.eof {
+			return true
+		}
+		b, err := s.in.ReadByte()
+		if err != nil {
+			s.eof = true
+			return true
+		}
+		s.receiving, s.rxStart, s.rxData = true, cycle, b
+	}
+	switch bit := (cycle - s.rxStart) / s.bitCycles; {
+	case bit == 0:
+		return false
+	case bit <= 8:
+		return s.rxData>>(bit-1)&1 != 0
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package trace
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Adapters read execution logs written by other tools. A pattern is matched
+// against each line and its named groups give the step:
+//
+//	pc       Instruction address (hex)
+//	cycle    Cycle count before the instruction (decimal)
+//	bytes    Instruction bytes (hex, spaces allowed)
+//	asm      Disassembly
+//	f_NAME   Flag NAME, set when the text is 1
+//	NAME     Register NAME (hex)
+//
+// Lines that do not match are skipped. Most emulators log the state before
+// each instruction rather than after it; such logs are read with a
+// "regex-before:" spec, which takes the state after an instruction from the
+// line of the next one.
+var adapterPresets = map[string]string{
+	// Output of the emulator's -v option
+	"verbose": `regex-before:^PC: (?P<pc>[0-9A-F]{4}), OP: (?P<bytes>[0-9A-F]{2}), MN: (?P<asm>\w+), ` +
+		`A:(?P<A>[0-9A-F]{2}) B:(?P<B>[0-9A-F]{2}) C:(?P<C>[0-9A-F]{2}) D:(?P<D>[0-9A-F]{2}) ` +
+		`E:(?P<E>[0-9A-F]{2}) H:(?P<H>[0-9A-F]{2}) L:(?P<L>[0-9A-F]{2}) \| ` +
+		`Flags\(CZSP\): (?P<f_C>[01])(?P<f_Z>[01])(?P<f_S>[01])(?P<f_P>[01])`,
+}
+
+// NewAdapter reads a foreign log. The spec is the name of a preset
+// ("verbose"), "regex:PATTERN" for logs of the state after each instruction,
+// or "regex-before:PATTERN" for logs of the state before it.
+func NewAdapter(r io.Reader, spec string) (Reader, error) {
+	if preset, ok := adapterPresets[spec]; ok {
+		spec = preset
+	}
+	kind, pattern, ok := strings.Cut(spec, ":")
+	if !ok || (kind != "regex" && kind != "regex-before") {
+		return nil, fmt.Errorf("unknown trace format %q (available: text, jsonl, binary, verbose, regex:PATTERN, regex-before:PATTERN)", spec)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid adapter pattern: %v", err)
+	}
+	if re.SubexpIndex("pc") < 0 {
+		return nil, fmt.Errorf("adapter pattern has no (?P<pc>...) group")
+	}
+	return &adapterReader{scanner: newLineScanner(r), pattern: re, before: kind == "regex-before"}, nil
+}
+
+// adapterReader reads steps from lines matched by a pattern
+type adapterReader struct {
+	scanner *bufio.Scanner
+	pattern *regexp.Regexp
+	before  bool  // Lines show the state before their instruction
+	pending *Step // Before-state logs: the step waiting for the next line's state
+	done    bool
+	line    int
+}
+
+func (r *adapterReader) Next() (*Step, error) {
+	if !r.before {
+		return r.read()
+	}
+
+	if r.pending == nil && !r.done {
+		step, err := r.read()
+		if err == io.EOF {
+			r.done = true
+		} else if err != nil {
+			return nil, err
+		}
+		r.pending = step
+	}
+	if r.pending == nil {
+		return nil, io.EOF
+	}
+
+	step := r.pending
+	next, err := r.read()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	r.pending = next
+	if next != nil {
+		step.Registers, step.Flags = next.Registers, next.Flags
+	} else {
+		// The state after the last instruction is not in the log
+		r.done = true
+		step.Registers, step.Flags = nil, nil
+	}
+	return step, nil
+}
+
+// read parses the next matching line
+func (r *adapterReader) read() (*Step, error) {
+	for r.scanner.Scan() {
+		r.line++
+		m := r.pattern.FindStringSubmatch(r.scanner.Text())
+		if m == nil {
+			continue
+		}
+		step := &Step{Line: r.line, Registers: make(map[string]uint16), Flags: make(map[string]bool)}
+		for i, name := range r.pattern.SubexpNames() {
+			if name == "" || i >= len(m) {
+				continue
+			}
+			if err := r.field(step, name, strings.TrimSpace(m[i])); err != nil {
+				return nil, fmt.Errorf("line %d: %v", r.line, err)
+			}
+		}
+		return step, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// field sets the part of a step captured by a named group
+func (r *adapterReader) field(step *Step, name, text string) error {
+	switch {
+	case name == "pc":
+		pc, err := parseAddr(text)
+		if err != nil {
+			return err
+		}
+		step.PC = pc
+	case name == "cycle":
+		cycle, err := strconv.Atoi(text)
+		if err != nil {
+			return fmt.Errorf("invalid cycle %q", text)
+		}
+		step.Cycle, step.HasCycle = cycle, true
+	case name == "bytes":
+		digits := strings.Join(strings.Fields(text), "")
+		for i := 0; i+2 <= len(digits); i += 2 {
+			b, err := strconv.ParseUint(digits[i:i+2], 16, 8)
+			if err != nil {
+				return fmt.Errorf("invalid bytes %q", text)
+			}
+			step.Bytes = append(step.Bytes, byte(b))
+		}
+	case name == "asm":
+		step.Disassembly = text
+	case strings.HasPrefix(name, "f_"):
+		step.Flags[strings.ToUpper(name[2:])] = text == "1"
+	default:
+		value, err := parseAddr(text)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s", text, name)
+		}
+		step.Registers[strings.ToUpper(name)] = value
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+)
+
+// binaryMagic starts a binary trace; the last byte is the format version
+var binaryMagic = []byte{'G', '8', 'B', 'T', 1}
+
+// Entry tags of the binary format
+const (
+	binaryName        = 'N' // Defines a register or flag name: index, length, name
+	binaryInstruction = 'I' // An instruction record
+)
+
+// binaryEncoder writes compact records. Register and flag names are written
+// once, as 'N' entries, and records refer to them by index:
+//
+//	'I' cycle(uvarint) pc(2) nbytes(1) bytes
+//	    nregs(1) {index(1) value(uvarint)} nflags(1) {index(1) set(1)}
+//	    nmem(1) {addr(2) value(1) write(1)}
+//
+// Multi-byte fields are little-endian. Disassembly is not stored.
+type binaryEncoder struct {
+	names map[string]byte // Index of each name written so far
+	buf   []byte
+}
+
+func newBinaryEncoder() *binaryEncoder {
+	return &binaryEncoder{names: make(map[string]byte)}
+}
+
+// nameIndex returns the index of a name, writing its definition the first time
+func (e *binaryEncoder) nameIndex(w *bufio.Writer, name string) (byte, error) {
+	if index, ok := e.names[name]; ok {
+		return index, nil
+	}
+	if len(e.names) == 256 || len(name) > 255 {
+		return 0, fmt.Errorf("too many or too long register names for a binary trace")
+	}
+	index := byte(len(e.names))
+	e.names[name] = index
+	w.WriteByte(binaryName)
+	w.WriteByte(index)
+	w.WriteByte(byte(len(name)))
+	_, err := w.WriteString(name)
+	return index, err
+}
+
+func (e *binaryEncoder) encode(w *bufio.Writer, record *Record) error {
+	if len(record.Bytes) > 255 || len(record.Memory) > 255 {
+		return fmt.Errorf("instruction at $%04X is too large for a binary trace", record.PC)
+	}
+
+	// Names must be defined before the record that uses them
+	regIndexes := make([]byte, len(record.Registers))
+	for i, register := range record.Registers {
+		index, err := e.nameIndex(w, register.Name)
+		if err != nil {
+			return err
+		}
+		regIndexes[i] = index
+	}
+	flagIndexes := make([]byte, len(record.Flags))
+	for i, flag := range record.Flags {
+		index, err := e.nameIndex(w, flag.Name)
+		if err != nil {
+			return err
+		}
+		flagIndexes[i] = index
+	}
+
+	buf := append(e.buf[:0], binaryInstruction)
+	buf = binary.AppendUvarint(buf, uint64(record.Cycle))
+	buf = binary.LittleEndian.AppendUint16(buf, record.PC)
+	buf = append(buf, byte(len(record.Bytes)))
+	buf = append(buf, record.Bytes...)
+	buf = append(buf, byte(len(record.Registers)))
+	for i, register := range record.Registers {
+		buf = append(buf, regIndexes[i])
+		buf = binary.AppendUvarint(buf, uint64(register.Value))
+	}
+	buf = append(buf, byte(len(record.Flags)))
+	for i, flag := range record.Flags {
+		buf = append(buf, flagIndexes[i], byte(boolToInt(flag.Set)))
+	}
+	buf = append(buf, byte(len(record.Memory)))
+	for _, access := range record.Memory {
+		buf = binary.LittleEndian.AppendUint16(buf, access.Addr)
+		buf = append(buf, access.Value, byte(boolToInt(access.Write)))
+	}
+	e.buf = buf
+
+	_, err := w.Write(buf)
+	return err
+}
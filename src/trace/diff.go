@@ -0,0 +1,229 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// DiffOptions control how two traces are aligned and compared
+type DiffOptions struct {
+	Context int             // Matching steps kept before a divergence
+	Sync    bool            // Skip steps in each trace until the first one at SyncPC
+	SyncPC  uint16          //
+	Cycles  bool            // Compare cycle counts as well
+	Ignore  map[string]bool // Registers and flags ("flags.C") not to compare, upper-case
+}
+
+// StepPair is a step of each trace at the same position
+type StepPair struct {
+	Index int // Position in the aligned traces, from 1
+	A, B  *Step
+}
+
+// DiffResult is the outcome of comparing two traces
+type DiffResult struct {
+	Compared    int        // Steps compared, including a diverging one
+	Divergence  *StepPair  // First differing steps; nil when the traces match. A or B is nil if that trace ended first.
+	Differences []string   // What differs at the divergence, e.g. "A: $03 vs $04"
+	Context     []StepPair // Matching steps before the divergence, oldest first
+}
+
+// Diff aligns two traces step by step and finds the first difference. Only
+// what both traces record is compared: registers, flags, instruction bytes
+// and memory accesses that one of them lacks are skipped.
+func Diff(a, b Reader, opts DiffOptions) (*DiffResult, error) {
+	if opts.Sync {
+		var err error
+		if a, err = syncTo(a, opts.SyncPC, "first"); err != nil {
+			return nil, err
+		}
+		if b, err = syncTo(b, opts.SyncPC, "second"); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &DiffResult{}
+	for {
+		stepA, err := next(a, "first")
+		if err != nil {
+			return nil, err
+		}
+		stepB, err := next(b, "second")
+		if err != nil {
+			return nil, err
+		}
+		if stepA == nil && stepB == nil {
+			return result, nil
+		}
+		result.Compared++
+		pair := StepPair{Index: result.Compared, A: stepA, B: stepB}
+
+		switch {
+		case stepA == nil:
+			result.Differences = []string{"the first trace ends here; the second continues"}
+		case stepB == nil:
+			result.Differences = []string{"the second trace ends here; the first continues"}
+		default:
+			result.Differences = compareSteps(stepA, stepB, opts)
+		}
+		if len(result.Differences) > 0 {
+			result.Divergence = &pair
+			return result, nil
+		}
+
+		if opts.Context > 0 {
+			if len(result.Context) == opts.Context {
+				result.Context = append(result.Context[:0], result.Context[1:]...)
+			}
+			result.Context = append(result.Context, pair)
+		}
+	}
+}
+
+// next reads a step, returning nil at the end of the trace
+func next(r Reader, which string) (*Step, error) {
+	step, err := r.Next()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s trace: %v", which, err)
+	}
+	return step, nil
+}
+
+// syncedReader returns a step read ahead before the rest of a trace
+type syncedReader struct {
+	first *Step
+	rest  Reader
+}
+
+func (r *syncedReader) Next() (*Step, error) {
+	if step := r.first; step != nil {
+		r.first = nil
+		return step, nil
+	}
+	return r.rest.Next()
+}
+
+// syncTo skips steps until the first one at pc
+func syncTo(r Reader, pc uint16, which string) (Reader, error) {
+	for {
+		step, err := next(r, which)
+		if err != nil {
+			return nil, err
+		}
+		if step == nil {
+			return nil, fmt.Errorf("%s trace never reaches $%04X", which, pc)
+		}
+		if step.PC == pc {
+			return &syncedReader{first: step, rest: r}, nil
+		}
+	}
+}
+
+// compareSteps lists the differences between two steps
+func compareSteps(a, b *Step, opts DiffOptions) []string {
+	var differences []string
+	if a.PC != b.PC {
+		differences = append(differences, fmt.Sprintf("PC: $%04X vs $%04X", a.PC, b.PC))
+	}
+	if a.Bytes != nil && b.Bytes != nil && !bytesPrefixEqual(a.Bytes, b.Bytes) {
+		differences = append(differences, fmt.Sprintf("bytes: %s vs %s", FormatBytes(a.Bytes), FormatBytes(b.Bytes)))
+	}
+	if opts.Cycles && a.HasCycle && b.HasCycle && a.Cycle != b.Cycle {
+		differences = append(differences, fmt.Sprintf("cycle: %d vs %d", a.Cycle, b.Cycle))
+	}
+
+	for _, name := range sortedKeys(a.Registers) {
+		valueB, ok := b.Registers[name]
+		if valueA := a.Registers[name]; ok && !opts.Ignore[name] && valueA != valueB {
+			differences = append(differences, fmt.Sprintf("%s: %s vs %s", name, formatValue(valueA), formatValue(valueB)))
+		}
+	}
+	for _, name := range sortedKeys(a.Flags) {
+		setB, ok := b.Flags[name]
+		if setA := a.Flags[name]; ok && !opts.Ignore["FLAGS."+name] && setA != setB {
+			differences = append(differences, fmt.Sprintf("flags.%s: %d vs %d", name, boolToInt(setA), boolToInt(setB)))
+		}
+	}
+
+	if a.HasMemory && b.HasMemory && !accessesEqual(a.Memory, b.Memory) {
+		differences = append(differences, fmt.Sprintf("memory: %s vs %s", FormatAccesses(a.Memory), FormatAccesses(b.Memory)))
+	}
+	return differences
+}
+
+// bytesPrefixEqual compares instruction bytes, allowing one log to show only the opcode
+func bytesPrefixEqual(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// accessesEqual compares memory accesses in order
+func accessesEqual(a, b []cpu.MemoryAccess) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatBytes formats instruction bytes as "46 20 80"
+func FormatBytes(code []byte) string {
+	var parts []string
+	for _, b := range code {
+		parts = append(parts, fmt.Sprintf("%02X", b))
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatAccesses formats memory accesses as in text traces, e.g. "W[$0200]=$05"
+func FormatAccesses(accesses []cpu.MemoryAccess) string {
+	if len(accesses) == 0 {
+		return "(none)"
+	}
+	var parts []string
+	for _, access := range accesses {
+		op := "R"
+		if access.Write {
+			op = "W"
+		}
+		parts = append(parts, fmt.Sprintf("%s[$%04X]=$%02X", op, access.Addr, access.Value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatValue formats a register value with two or four hex digits
+func formatValue(value uint16) string {
+	if value > 0xFF {
+		return fmt.Sprintf("$%04X", value)
+	}
+	return fmt.Sprintf("$%02X", value)
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
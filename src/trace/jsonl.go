@@ -0,0 +1,65 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// jsonRecord is a record as a JSON line
+type jsonRecord struct {
+	Cycle  int               `json:"cycle"`
+	PC     uint16            `json:"pc"`
+	Bytes  string            `json:"bytes"` // Instruction bytes as hex, e.g. "0E05"
+	Asm    string            `json:"asm"`
+	Regs   map[string]uint16 `json:"regs,omitempty"`
+	Flags  map[string]bool   `json:"flags,omitempty"`
+	Memory []jsonAccess      `json:"mem,omitempty"`
+}
+
+// jsonAccess is a memory access in a JSON record
+type jsonAccess struct {
+	Addr  uint16 `json:"addr"`
+	Value byte   `json:"value"`
+	Op    string `json:"op"` // "R" or "W"
+}
+
+// jsonlEncoder writes one JSON object per line
+type jsonlEncoder struct{}
+
+func (e *jsonlEncoder) encode(w *bufio.Writer, record *Record) error {
+	out := jsonRecord{
+		Cycle: record.Cycle,
+		PC:    record.PC,
+		Bytes: strings.ToUpper(hex.EncodeToString(record.Bytes)),
+		Asm:   record.Disassembly,
+	}
+	if len(record.Registers) > 0 {
+		out.Regs = make(map[string]uint16, len(record.Registers))
+		for _, register := range record.Registers {
+			out.Regs[register.Name] = register.Value
+		}
+	}
+	if len(record.Flags) > 0 {
+		out.Flags = make(map[string]bool, len(record.Flags))
+		for _, flag := range record.Flags {
+			out.Flags[flag.Name] = flag.Set
+		}
+	}
+	for _, access := range record.Memory {
+		op := "R"
+		if access.Write {
+			op = "W"
+		}
+		out.Memory = append(out.Memory, jsonAccess{Addr: access.Addr, Value: access.Value, Op: op})
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,343 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// Step is an instruction read back from a trace, with the full state after it
+type Step struct {
+	Line        int               // Line or record number in the trace file
+	Cycle       int               // Cycle count before the instruction, if HasCycle
+	HasCycle    bool              //
+	PC          uint16            // Address of the instruction
+	Bytes       []byte            // Instruction bytes; nil if the trace has none
+	Disassembly string            // Disassembly; empty if the trace has none
+	Registers   map[string]uint16 // Registers after the instruction, by upper-case name
+	Flags       map[string]bool   // Flags after the instruction, by upper-case name
+	Memory      []cpu.MemoryAccess
+	HasMemory   bool // Whether the trace records memory accesses
+}
+
+// Reader reads the steps of a trace in order; Next returns io.EOF at the end
+type Reader interface {
+	Next() (*Step, error)
+}
+
+// NewReader reads a trace in one of the formats written by Writer, or in a
+// foreign format through an adapter (see NewAdapter)
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatText:
+		return &textReader{scanner: newLineScanner(r), state: newState()}, nil
+	case FormatJSONL:
+		return &jsonlReader{scanner: newLineScanner(r), state: newState()}, nil
+	case FormatBinary:
+		return newBinaryReader(r)
+	}
+	return NewAdapter(r, format)
+}
+
+// newLineScanner returns a scanner that accepts long trace lines
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return scanner
+}
+
+// state accumulates the register and flag changes of delta-encoded traces
+type state struct {
+	registers map[string]uint16
+	flags     map[string]bool
+}
+
+func newState() *state {
+	return &state{registers: make(map[string]uint16), flags: make(map[string]bool)}
+}
+
+// apply records changes and fills the step with a copy of the full state
+func (s *state) apply(step *Step, registers map[string]uint16, flags map[string]bool) {
+	for name, value := range registers {
+		s.registers[strings.ToUpper(name)] = value
+	}
+	for name, set := range flags {
+		s.flags[strings.ToUpper(name)] = set
+	}
+	step.Registers = make(map[string]uint16, len(s.registers))
+	for name, value := range s.registers {
+		step.Registers[name] = value
+	}
+	step.Flags = make(map[string]bool, len(s.flags))
+	for name, set := range s.flags {
+		step.Flags[name] = set
+	}
+}
+
+// jsonlReader reads JSON line traces
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	state   *state
+	line    int
+}
+
+func (r *jsonlReader) Next() (*Step, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := bytes.TrimSpace(r.scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var record jsonRecord
+		if err := json.Unmarshal(text, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %v", r.line, err)
+		}
+		code, err := hex.DecodeString(record.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid bytes %q", r.line, record.Bytes)
+		}
+		step := &Step{
+			Line:        r.line,
+			Cycle:       record.Cycle,
+			HasCycle:    true,
+			PC:          record.PC,
+			Bytes:       code,
+			Disassembly: record.Asm,
+			HasMemory:   true,
+		}
+		for _, access := range record.Memory {
+			step.Memory = append(step.Memory, cpu.MemoryAccess{Addr: access.Addr, Value: access.Value, Write: access.Op == "W"})
+		}
+		r.state.apply(step, record.Regs, record.Flags)
+		return step, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// textDelta matches the change and access fields at the end of a text trace line
+var textDelta = regexp.MustCompile(`^(?:flags\.(\w+)=([01])|([RW])\[\$([0-9A-F]{4})\]=\$([0-9A-F]{2})|(\w+)=\$([0-9A-F]+))$`)
+
+// textReader reads text traces
+type textReader struct {
+	scanner *bufio.Scanner
+	state   *state
+	line    int
+}
+
+func (r *textReader) Next() (*Step, error) {
+	for r.scanner.Scan() {
+		r.line++
+		fields := strings.Fields(r.scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || !strings.HasPrefix(fields[1], "$") {
+			return nil, fmt.Errorf("line %d: not a text trace line", r.line)
+		}
+		cycle, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid cycle %q", r.line, fields[0])
+		}
+		pc, err := strconv.ParseUint(fields[1][1:], 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid address %q", r.line, fields[1])
+		}
+		step := &Step{Line: r.line, Cycle: cycle, HasCycle: true, PC: uint16(pc), HasMemory: true}
+
+		// Instruction bytes are two-digit hex fields
+		rest := fields[2:]
+		for len(rest) > 0 && len(rest[0]) == 2 {
+			b, err := strconv.ParseUint(rest[0], 16, 8)
+			if err != nil {
+				break
+			}
+			step.Bytes = append(step.Bytes, byte(b))
+			rest = rest[1:]
+		}
+
+		// Changes and accesses follow the disassembly
+		end := len(rest)
+		for end > 0 && textDelta.MatchString(rest[end-1]) {
+			end--
+		}
+		step.Disassembly = strings.Join(rest[:end], " ")
+		registers := make(map[string]uint16)
+		flags := make(map[string]bool)
+		for _, field := range rest[end:] {
+			m := textDelta.FindStringSubmatch(field)
+			switch {
+			case m[1] != "":
+				flags[m[1]] = m[2] == "1"
+			case m[3] != "":
+				addr, _ := strconv.ParseUint(m[4], 16, 16)
+				value, _ := strconv.ParseUint(m[5], 16, 8)
+				step.Memory = append(step.Memory, cpu.MemoryAccess{Addr: uint16(addr), Value: byte(value), Write: m[3] == "W"})
+			default:
+				value, _ := strconv.ParseUint(m[7], 16, 16)
+				registers[m[6]] = uint16(value)
+			}
+		}
+		r.state.apply(step, registers, flags)
+		return step, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// binaryReader reads binary traces
+type binaryReader struct {
+	in     *bufio.Reader
+	names  map[byte]string
+	state  *state
+	record int
+}
+
+func newBinaryReader(r io.Reader) (*binaryReader, error) {
+	in := bufio.NewReader(r)
+	magic := make([]byte, len(binaryMagic))
+	if _, err := io.ReadFull(in, magic); err != nil || !bytes.Equal(magic[:4], binaryMagic[:4]) {
+		return nil, fmt.Errorf("not a binary trace")
+	}
+	if magic[4] != binaryMagic[4] {
+		return nil, fmt.Errorf("unsupported binary trace version %d", magic[4])
+	}
+	return &binaryReader{in: in, names: make(map[byte]string), state: newState()}, nil
+}
+
+func (r *binaryReader) Next() (*Step, error) {
+	for {
+		tag, err := r.in.ReadByte()
+		if err != nil {
+			return nil, err // io.EOF at the end of the trace
+		}
+		switch tag {
+		case binaryName:
+			var header [2]byte
+			if _, err := io.ReadFull(r.in, header[:]); err != nil {
+				return nil, r.truncated(err)
+			}
+			name := make([]byte, header[1])
+			if _, err := io.ReadFull(r.in, name); err != nil {
+				return nil, r.truncated(err)
+			}
+			r.names[header[0]] = string(name)
+		case binaryInstruction:
+			r.record++
+			step, err := r.readInstruction()
+			if err != nil {
+				return nil, r.truncated(err)
+			}
+			return step, nil
+		default:
+			return nil, fmt.Errorf("record %d: unknown entry tag $%02X", r.record+1, tag)
+		}
+	}
+}
+
+// readInstruction reads an instruction entry after its tag
+func (r *binaryReader) readInstruction() (*Step, error) {
+	cycle, err := binary.ReadUvarint(r.in)
+	if err != nil {
+		return nil, err
+	}
+	var pc uint16
+	if err := binary.Read(r.in, binary.LittleEndian, &pc); err != nil {
+		return nil, err
+	}
+	step := &Step{Line: r.record, Cycle: int(cycle), HasCycle: true, PC: pc, HasMemory: true}
+
+	count, err := r.in.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	step.Bytes = make([]byte, count)
+	if _, err := io.ReadFull(r.in, step.Bytes); err != nil {
+		return nil, err
+	}
+
+	registers := make(map[string]uint16)
+	if count, err = r.in.ReadByte(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(count); i++ {
+		name, err := r.name()
+		if err != nil {
+			return nil, err
+		}
+		value, err := binary.ReadUvarint(r.in)
+		if err != nil {
+			return nil, err
+		}
+		registers[name] = uint16(value)
+	}
+
+	flags := make(map[string]bool)
+	if count, err = r.in.ReadByte(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(count); i++ {
+		name, err := r.name()
+		if err != nil {
+			return nil, err
+		}
+		set, err := r.in.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		flags[name] = set != 0
+	}
+
+	if count, err = r.in.ReadByte(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(count); i++ {
+		var access [4]byte
+		if _, err := io.ReadFull(r.in, access[:]); err != nil {
+			return nil, err
+		}
+		step.Memory = append(step.Memory, cpu.MemoryAccess{
+			Addr:  binary.LittleEndian.Uint16(access[:2]),
+			Value: access[2],
+			Write: access[3] != 0,
+		})
+	}
+
+	r.state.apply(step, registers, flags)
+	return step, nil
+}
+
+// name reads a name index
+func (r *binaryReader) name() (string, error) {
+	index, err := r.in.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	name, ok := r.names[index]
+	if !ok {
+		return "", fmt.Errorf("undefined name index %d", index)
+	}
+	return name, nil
+}
+
+// truncated describes an error inside a record
+func (r *binaryReader) truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("record %d: trace is truncated", r.record)
+	}
+	return fmt.Errorf("record %d: %v", r.record, err)
+}
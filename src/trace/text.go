@@ -0,0 +1,44 @@
+package trace
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// textEncoder writes one aligned line per instruction:
+//
+//	1234  $8002  0E 05       LBI  #$05          B=$05 flags.Z=0 W[$0200]=$05
+type textEncoder struct {
+	line strings.Builder
+}
+
+func (e *textEncoder) encode(w *bufio.Writer, record *Record) error {
+	line := &e.line
+	line.Reset()
+
+	fmt.Fprintf(line, "%10d  $%04X  %-11s %-18s", record.Cycle, record.PC, FormatBytes(record.Bytes), record.Disassembly)
+	for _, register := range record.Registers {
+		fmt.Fprintf(line, " %s=$%02X", register.Name, register.Value)
+	}
+	for _, flag := range record.Flags {
+		fmt.Fprintf(line, " flags.%s=%d", flag.Name, boolToInt(flag.Set))
+	}
+	for _, access := range record.Memory {
+		op := "R"
+		if access.Write {
+			op = "W"
+		}
+		fmt.Fprintf(line, " %s[$%04X]=$%02X", op, access.Addr, access.Value)
+	}
+
+	_, err := w.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	return err
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,214 @@
+// Package trace writes instruction-level execution traces recorded by the CPU
+// tracer as text, JSON lines or a compact binary format, reads them back,
+// along with other emulators' logs, and compares them.
+package trace
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lukasz-gorgol/g8b/src/cpu"
+)
+
+// Trace formats
+const (
+	FormatText   = "text"   // One aligned line per instruction
+	FormatJSONL  = "jsonl"  // One JSON object per line
+	FormatBinary = "binary" // Compact binary records
+)
+
+// FormatForPath chooses a format from a file extension: .jsonl and .json
+// are JSON lines, .bin and .g8bt binary, anything else text
+func FormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".json":
+		return FormatJSONL
+	case ".bin", ".g8bt":
+		return FormatBinary
+	}
+	return FormatText
+}
+
+// Range is an inclusive range of instruction addresses
+type Range struct {
+	Start, End uint16
+}
+
+// ParseRanges parses address ranges such as "0x8000-0x80FF,0x9000"
+func ParseRanges(spec string) ([]Range, error) {
+	var ranges []Range
+	for _, part := range strings.Split(spec, ",") {
+		start, end, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := parseAddr(start)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = parseAddr(end); err != nil {
+				return nil, err
+			}
+		}
+		if first > last {
+			return nil, fmt.Errorf("invalid range %s: start > end", part)
+		}
+		ranges = append(ranges, Range{first, last})
+	}
+	return ranges, nil
+}
+
+// parseAddr parses a hex address with an optional 0x or $ prefix
+func parseAddr(s string) (uint16, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "0x"), "$")
+	value, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %q", s)
+	}
+	return uint16(value), nil
+}
+
+// Filter selects which instructions are written
+type Filter struct {
+	Ranges []Range // Write only instructions at these addresses; empty writes all
+	Skip   int     // Skip this many executed instructions before writing any
+	Count  int     // Stop after writing this many records; 0 means no limit
+}
+
+// Record is an instruction as written to a trace. Registers and Flags hold
+// only what changed since the previous record; the first record has all.
+type Record struct {
+	Cycle       int
+	PC          uint16
+	Bytes       []byte
+	Disassembly string
+	Registers   []cpu.RegisterValue
+	Flags       []cpu.FlagValue
+	Memory      []cpu.MemoryAccess
+}
+
+// encoder writes records in one format
+type encoder interface {
+	encode(w *bufio.Writer, record *Record) error
+}
+
+// Writer is a cpu.Tracer that writes the records it receives
+type Writer struct {
+	out          *bufio.Writer
+	encoder      encoder
+	filter       Filter
+	syntax       *cpu.Syntax
+	instructions map[byte]cpu.Instruction
+	prefixes     cpu.PrefixTables
+	executed     int               // Instructions seen
+	written      int               // Records written
+	registers    map[string]uint16 // Register values as of the last record written
+	flags        map[string]bool   // Flags as of the last record written
+	record       Record            // Reused record
+	err          error             // First write error
+}
+
+// NewWriter creates a tracer writing to w. Disassembly uses the given syntax
+// and instruction set with its prefix tables.
+func NewWriter(w io.Writer, format string, filter Filter, syntax *cpu.Syntax, instructions map[byte]cpu.Instruction, prefixes cpu.PrefixTables) (*Writer, error) {
+	writer := &Writer{
+		out:          bufio.NewWriter(w),
+		filter:       filter,
+		syntax:       syntax,
+		instructions: instructions,
+		prefixes:     prefixes,
+		registers:    make(map[string]uint16),
+		flags:        make(map[string]bool),
+	}
+	switch format {
+	case FormatText:
+		writer.encoder = &textEncoder{}
+	case FormatJSONL:
+		writer.encoder = &jsonlEncoder{}
+	case FormatBinary:
+		writer.encoder = newBinaryEncoder()
+		if _, err := writer.out.Write(binaryMagic); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown trace format %q (available: text, jsonl, binary)", format)
+	}
+	return writer, nil
+}
+
+// Trace writes a record for an executed instruction if it passes the filter
+func (w *Writer) Trace(executed *cpu.TraceRecord) {
+	w.executed++
+	if w.err != nil || w.executed <= w.filter.Skip || w.Done() || !w.inRanges(executed.PC) {
+		return
+	}
+
+	record := &w.record
+	record.Cycle = executed.Cycle
+	record.PC = executed.PC
+	record.Bytes = executed.Bytes
+	record.Disassembly = w.disassemble(executed)
+	record.Memory = executed.Memory
+	record.Registers = record.Registers[:0]
+	for _, register := range executed.Registers {
+		if previous, ok := w.registers[register.Name]; !ok || previous != register.Value {
+			record.Registers = append(record.Registers, register)
+			w.registers[register.Name] = register.Value
+		}
+	}
+	record.Flags = record.Flags[:0]
+	for _, flag := range executed.Flags {
+		if previous, ok := w.flags[flag.Name]; !ok || previous != flag.Set {
+			record.Flags = append(record.Flags, flag)
+			w.flags[flag.Name] = flag.Set
+		}
+	}
+
+	w.err = w.encoder.encode(w.out, record)
+	w.written++
+}
+
+// Done reports whether the writer has written as many records as the filter allows
+func (w *Writer) Done() bool {
+	return w.filter.Count > 0 && w.written >= w.filter.Count
+}
+
+// Written returns the number of records written
+func (w *Writer) Written() int {
+	return w.written
+}
+
+// Close flushes the trace and returns the first error that occurred while writing
+func (w *Writer) Close() error {
+	if err := w.out.Flush(); w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// inRanges reports whether an instruction address passes the range filter
+func (w *Writer) inRanges(pc uint16) bool {
+	if len(w.filter.Ranges) == 0 {
+		return true
+	}
+	for _, r := range w.filter.Ranges {
+		if pc >= r.Start && pc <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// disassemble disassembles an instruction from the bytes in its record
+func (w *Writer) disassemble(record *cpu.TraceRecord) string {
+	text, _ := cpu.Disassemble(w.syntax, w.instructions, w.prefixes, func(addr uint16) byte {
+		if i := int(addr - record.PC); i < len(record.Bytes) {
+			return record.Bytes[i]
+		}
+		return 0
+	}, record.PC)
+	return text
+}